@@ -3,6 +3,7 @@ package client
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -17,6 +18,10 @@ import (
 	"k8s.io/client-go/util/homedir"
 )
 
+// ErrNotFound is returned when the server has no route for a request, such as a route
+// which was added in a later version of the server
+var ErrNotFound = errors.New("unknown error, status code: 404")
+
 // Client represents the client for the Porter API
 type Client struct {
 	BaseURL        string
@@ -209,6 +214,10 @@ func (c *Client) sendRequest(req *http.Request, v interface{}, useCookie bool) (
 			return &errRes, nil
 		}
 
+		if res.StatusCode == http.StatusNotFound {
+			return nil, ErrNotFound
+		}
+
 		return nil, fmt.Errorf("unknown error, status code: %d", res.StatusCode)
 	}
 
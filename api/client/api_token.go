@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// CreateAPIToken creates a new API token for a project
+func (c *Client) CreateAPIToken(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateAPITokenRequest,
+) (*types.CreateAPITokenResponse, error) {
+	resp := &types.CreateAPITokenResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListAPITokens lists the API tokens for a project
+func (c *Client) ListAPITokens(
+	ctx context.Context,
+	projectID uint,
+) (*types.ListAPITokensResponse, error) {
+	resp := &types.ListAPITokensResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RevokeAPIToken revokes an API token in a project, so that it can no longer
+// be used to authenticate
+func (c *Client) RevokeAPIToken(
+	ctx context.Context,
+	projectID uint,
+	tokenID string,
+) (*types.RevokeAPITokenResponse, error) {
+	resp := &types.RevokeAPITokenResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token/%s/revoke",
+			projectID,
+			tokenID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// GetApplyManifest gets the releases deployed by the last apply of a porter.yaml file
+func (c *Client) GetApplyManifest(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.GetApplyManifestRequest,
+) (*types.GetApplyManifestResponse, error) {
+	resp := &types.GetApplyManifestResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/apply_manifest",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateApplyManifest replaces the releases deployed by a porter.yaml file
+func (c *Client) UpdateApplyManifest(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.UpdateApplyManifestRequest,
+) (*types.UpdateApplyManifestResponse, error) {
+	resp := &types.UpdateApplyManifestResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/apply_manifest",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ListAuditEvents lists the audit events for a project, most recent first
+func (c *Client) ListAuditEvents(
+	ctx context.Context,
+	projectID uint,
+	req *types.ListAuditEventsRequest,
+) (*types.ListAuditEventsResponse, error) {
+	resp := &types.ListAuditEventsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/audit_events",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -107,3 +107,68 @@ func (c *Client) UpgradeRelease(
 		},
 	)
 }
+
+// CanaryRolloutRelease starts a canary rollout of a web release with new values. The
+// progress of the rollout is reported as release steps.
+func (c *Client) CanaryRolloutRelease(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.CanaryRolloutRequest,
+) error {
+	return c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/canary",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		nil,
+	)
+}
+
+// DryRunUpgradeRelease renders an upgrade of a release with new values or chart version
+// without applying it, and returns the changes that the upgrade would make
+func (c *Client) DryRunUpgradeRelease(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.DryRunUpgradeReleaseRequest,
+) (*types.DryRunUpgradeReleaseResponse, error) {
+	resp := &types.DryRunUpgradeReleaseResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/upgrade/dry_run",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// PromoteRelease upgrades the same-named release in a target namespace or cluster to the
+// chart, values and image tag of a release, creating it if it does not exist
+func (c *Client) PromoteRelease(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.PromoteReleaseRequest,
+) (*types.PromoteReleaseResponse, error) {
+	resp := &types.PromoteReleaseResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/promote",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ListEnvGroups lists the env groups in a namespace
+func (c *Client) ListEnvGroups(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+) (*types.ListConfigMapsResponse, error) {
+	resp := &types.ListConfigMapsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/configmap/list",
+			projectID, clusterID,
+			namespace,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetEnvGroup gets the config map of an env group. Secret variables have the value
+// PORTERSECRET_<name>; use GetEnvGroupSecrets to read their values.
+func (c *Client) GetEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.GetConfigMapRequest,
+) (*types.GetConfigMapResponse, error) {
+	resp := &types.GetConfigMapResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/configmap",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetEnvGroupSecrets gets the values of the secret variables of an env group
+func (c *Client) GetEnvGroupSecrets(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.GetConfigMapSecretsRequest,
+) (*types.GetConfigMapSecretsResponse, error) {
+	resp := &types.GetConfigMapSecretsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/configmap/secrets",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CreateEnvGroup creates a new env group
+func (c *Client) CreateEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.CreateConfigMapRequest,
+) (*types.CreateConfigMapResponse, error) {
+	resp := &types.CreateConfigMapResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/configmap/create",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateEnvGroup sets variables of an env group. Variables with an empty value are
+// removed, and variables which are not part of the request are left unchanged.
+func (c *Client) UpdateEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.UpdateConfigMapRequest,
+) (*types.UpdateConfigMapResponse, error) {
+	resp := &types.UpdateConfigMapResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/configmap/update",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RenameEnvGroup renames an env group
+func (c *Client) RenameEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.RenameConfigMapRequest,
+) (*types.RenameConfigMapResponse, error) {
+	resp := &types.RenameConfigMapResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/configmap/rename",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteEnvGroup deletes an env group along with its linked secret
+func (c *Client) DeleteEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/configmap/delete?name=%s",
+			projectID, clusterID,
+			namespace, url.QueryEscape(name),
+		),
+		nil,
+		nil,
+	)
+}
@@ -90,6 +90,44 @@ func (c *Client) GetRelease(
 	return resp, err
 }
 
+// DeleteRelease uninstalls a release
+func (c *Client) DeleteRelease(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0",
+			projectID, clusterID,
+			namespace, name,
+		),
+		nil,
+		nil,
+	)
+}
+
+// ExportRelease exports a release as a porter.yaml resource group
+func (c *Client) ExportRelease(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) (*types.ExportReleaseResponse, error) {
+	resp := &types.ExportReleaseResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/export",
+			projectID, clusterID,
+			namespace, name,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
 func (c *Client) GetJobs(
 	ctx context.Context,
 	projectID, clusterID uint,
@@ -112,6 +150,113 @@ func (c *Client) GetJobs(
 	return *resp, err
 }
 
+// ListJobRuns lists the stored runs of a job release, most recent first
+func (c *Client) ListJobRuns(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	req *types.ListJobRunsRequest,
+) (*types.ListJobRunsResponse, error) {
+	resp := &types.ListJobRunsResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/jobs/runs",
+			projectID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetJobRun gets a stored run of a job release, including the end of its logs
+func (c *Client) GetJobRun(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	runID uint,
+) (*types.GetJobRunResponse, error) {
+	resp := &types.GetJobRunResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/jobs/runs/%d",
+			projectID, clusterID,
+			namespace, name,
+			runID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RunJob triggers a manual run of a job release, and returns the created job
+func (c *Client) RunJob(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	req *types.RunJobRequest,
+) (*v1.Job, error) {
+	resp := &v1.Job{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/jobs/run",
+			projectID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// SuspendCronJob suspends or resumes the schedule of a cron job release
+func (c *Client) SuspendCronJob(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	req *types.SuspendCronJobRequest,
+) error {
+	return c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/jobs/suspend",
+			projectID, clusterID,
+			namespace, name,
+		),
+		req,
+		nil,
+	)
+}
+
+// ListReleases lists the latest revision of each release in a namespace
+func (c *Client) ListReleases(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.ListReleasesRequest,
+) (types.ListReleasesResponse, error) {
+	resp := make(types.ListReleasesResponse, 0)
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		&resp,
+	)
+
+	return resp, err
+}
+
 // GetK8sAllPods gets all pods for a given release
 func (c *Client) GetK8sAllPods(
 	ctx context.Context,
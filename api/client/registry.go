@@ -201,3 +201,128 @@ func (c *Client) CreateRepository(
 		nil,
 	)
 }
+
+// ListRegistryRetentionPolicies lists the retention policies of a registry
+func (c *Client) ListRegistryRetentionPolicies(
+	ctx context.Context,
+	projectID, regID uint,
+) (*types.ListRegistryRetentionPoliciesResponse, error) {
+	resp := &types.ListRegistryRetentionPoliciesResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/retention_policies",
+			projectID,
+			regID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CreateRegistryRetentionPolicy creates a retention policy for a registry
+func (c *Client) CreateRegistryRetentionPolicy(
+	ctx context.Context,
+	projectID, regID uint,
+	req *types.CreateRegistryRetentionPolicyRequest,
+) (*types.CreateRegistryRetentionPolicyResponse, error) {
+	resp := &types.CreateRegistryRetentionPolicyResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/retention_policies",
+			projectID,
+			regID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// GetRegistryRetentionPolicy gets a retention policy of a registry, with the report of
+// the last time it was enforced
+func (c *Client) GetRegistryRetentionPolicy(
+	ctx context.Context,
+	projectID, regID, policyID uint,
+) (*types.RegistryRetentionPolicy, error) {
+	resp := &types.RegistryRetentionPolicy{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/retention_policies/%d",
+			projectID,
+			regID,
+			policyID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateRegistryRetentionPolicy updates the rules of a retention policy
+func (c *Client) UpdateRegistryRetentionPolicy(
+	ctx context.Context,
+	projectID, regID, policyID uint,
+	req *types.UpdateRegistryRetentionPolicyRequest,
+) (*types.UpdateRegistryRetentionPolicyResponse, error) {
+	resp := &types.UpdateRegistryRetentionPolicyResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/retention_policies/%d",
+			projectID,
+			regID,
+			policyID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteRegistryRetentionPolicy deletes a retention policy of a registry
+func (c *Client) DeleteRegistryRetentionPolicy(
+	ctx context.Context,
+	projectID, regID, policyID uint,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/retention_policies/%d",
+			projectID,
+			regID,
+			policyID,
+		),
+		nil,
+		nil,
+	)
+}
+
+// RunRegistryRetentionPolicy enforces a retention policy immediately, and returns the
+// images which were deleted, or which would be deleted for a dry run
+func (c *Client) RunRegistryRetentionPolicy(
+	ctx context.Context,
+	projectID, regID, policyID uint,
+	req *types.RunRegistryRetentionPolicyRequest,
+) (*types.RunRegistryRetentionPolicyResponse, error) {
+	resp := &types.RunRegistryRetentionPolicyResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/registries/%d/retention_policies/%d/run",
+			projectID,
+			regID,
+			policyID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
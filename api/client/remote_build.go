@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// remoteBuildChunkSize is the size of the binary messages which the build context is
+// uploaded with
+const remoteBuildChunkSize = 512 * 1024
+
+// CreateRemoteBuild creates a build which runs as a job in the cluster
+func (c *Client) CreateRemoteBuild(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.CreateRemoteBuildRequest,
+) (*types.CreateRemoteBuildResponse, error) {
+	resp := &types.CreateRemoteBuildResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/remote_builds",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// StreamRemoteBuild uploads the build context of a remote build, which must be a gzipped
+// tar, and writes the logs of the build to logs until the build finishes. An error is
+// returned if the build fails.
+func (c *Client) StreamRemoteBuild(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	buildContext io.Reader,
+	logs io.Writer,
+) error {
+	baseURL, err := url.Parse(c.BaseURL)
+
+	if err != nil {
+		return err
+	}
+
+	wsURL := *baseURL
+	wsURL.Path = strings.TrimSuffix(baseURL.Path, "/") + fmt.Sprintf(
+		"/projects/%d/clusters/%d/namespaces/%s/remote_builds/%s/stream",
+		projectID, clusterID,
+		namespace, name,
+	)
+
+	if baseURL.Scheme == "https" {
+		wsURL.Scheme = "wss"
+	} else {
+		wsURL.Scheme = "ws"
+	}
+
+	// the server only accepts websockets from its own origin
+	header := http.Header{}
+	header.Set("Origin", fmt.Sprintf("%s://%s", baseURL.Scheme, baseURL.Host))
+
+	if c.Token != "" {
+		header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	} else if cookie, _ := c.getCookie(); cookie != nil {
+		header.Set("Cookie", cookie.String())
+	}
+
+	conn, res, err := websocket.DefaultDialer.DialContext(ctx, wsURL.String(), header)
+
+	if err != nil {
+		if res != nil {
+			return fmt.Errorf("could not connect to remote build: %v (status code %d)", err, res.StatusCode)
+		}
+
+		return fmt.Errorf("could not connect to remote build: %v", err)
+	}
+
+	defer conn.Close()
+
+	uploadErr := make(chan error, 1)
+
+	go func() {
+		uploadErr <- uploadBuildContext(conn, buildContext)
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+
+		if err != nil {
+			select {
+			case uErr := <-uploadErr:
+				if uErr != nil {
+					return uErr
+				}
+			default:
+			}
+
+			return fmt.Errorf("remote build connection closed: %v", err)
+		}
+
+		msg := &types.RemoteBuildMessage{}
+
+		if err := json.Unmarshal(data, msg); err != nil || msg.Type == "" {
+			// errors of the server are sent as an external error
+			extErr := &types.ExternalError{}
+
+			if err := json.Unmarshal(data, extErr); err == nil && extErr.Error != "" {
+				return fmt.Errorf("%s", extErr.Error)
+			}
+
+			return fmt.Errorf("unexpected message from remote build: %s", string(data))
+		}
+
+		switch msg.Type {
+		case types.RemoteBuildMessageLog:
+			fmt.Fprintln(logs, msg.Log)
+		case types.RemoteBuildMessageStatus:
+			if msg.Status != types.RemoteBuildStatusSucceeded {
+				if msg.Error != "" {
+					return fmt.Errorf("remote build failed: %s", msg.Error)
+				}
+
+				return fmt.Errorf("remote build failed")
+			}
+
+			return nil
+		}
+	}
+}
+
+func uploadBuildContext(conn *websocket.Conn, buildContext io.Reader) error {
+	buf := make([]byte, remoteBuildChunkSize)
+
+	for {
+		n, err := buildContext.Read(buf)
+
+		if n > 0 {
+			if wErr := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); wErr != nil {
+				return fmt.Errorf("could not upload build context: %v", wErr)
+			}
+		}
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("could not read build context: %v", err)
+		}
+	}
+
+	// an empty message marks the end of the build context
+	if err := conn.WriteMessage(websocket.BinaryMessage, []byte{}); err != nil {
+		return fmt.Errorf("could not upload build context: %v", err)
+	}
+
+	return nil
+}
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/sessions"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/porter-dev/porter/internal/models"
 )
 
 // AuthNFactory generates a middleware handler `AuthN`
@@ -29,14 +31,33 @@ func NewAuthNFactory(
 // NewAuthenticated creates a new instance of `AuthN` that implements the http.Handler
 // interface.
 func (f *AuthNFactory) NewAuthenticated(next http.Handler) http.Handler {
-	return &AuthN{next, f.config, false}
+	return &AuthN{next, f.config, false, false}
 }
 
 // NewAuthenticatedWithRedirect creates a new instance of `AuthN` that implements the http.Handler
 // interface. This handler redirects the user to login if the user is not attached, and stores a
 // redirect URI in the session, if the session exists.
 func (f *AuthNFactory) NewAuthenticatedWithRedirect(next http.Handler) http.Handler {
-	return &AuthN{next, f.config, true}
+	return &AuthN{next, f.config, true, false}
+}
+
+// NewAuthenticatedForEndpoint returns a middleware that creates a new instance of `AuthN` for
+// the endpoint. API tokens with a policy are only accepted if the endpoint is project-scoped,
+// since the policy of the token is enforced by the project-scoped policy middleware.
+func (f *AuthNFactory) NewAuthenticatedForEndpoint(
+	endpointMeta *types.APIRequestMetadata,
+) func(next http.Handler) http.Handler {
+	allowPolicyTokens := false
+
+	for _, scope := range endpointMeta.Scopes {
+		if scope == types.ProjectScope {
+			allowPolicyTokens = true
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return &AuthN{next, f.config, endpointMeta.ShouldRedirect, allowPolicyTokens}
+	}
 }
 
 // AuthN implements the authentication middleware
@@ -44,6 +65,9 @@ type AuthN struct {
 	next     http.Handler
 	config   *config.Config
 	redirect bool
+
+	// allowPolicyTokens is true if API tokens with a policy can be used for the request
+	allowPolicyTokens bool
 }
 
 // ServeHTTP attaches an authenticated subject to the request context,
@@ -54,7 +78,7 @@ type AuthN struct {
 // user, we attach that user to the context.
 func (authn *AuthN) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// first check for a bearer token
-	tok, err := authn.getTokenFromRequest(r)
+	tok, apiToken, err := authn.getTokenFromRequest(r)
 
 	// if the error is not an invalid auth error, the token was invalid, and we throw error
 	// forbidden. If the error was an invalid auth error, we look for a cookie.
@@ -62,7 +86,7 @@ func (authn *AuthN) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		authn.sendForbiddenError(err, w, r)
 		return
 	} else if err == nil && tok != nil {
-		authn.nextWithToken(w, r, tok)
+		authn.nextWithToken(w, r, tok, apiToken)
 		return
 	}
 
@@ -121,10 +145,30 @@ func (authn *AuthN) handleForbiddenForSession(
 }
 
 // nextWithToken calls the next handler with either the service account or user corresponding
-// to the token set in context.
-func (authn *AuthN) nextWithToken(w http.ResponseWriter, r *http.Request, tok *token.Token) {
+// to the token set in context. If the token is a stored API token, the stored token is
+// also set in context with key `types.APITokenCtxKey`, so that its policy can be enforced.
+func (authn *AuthN) nextWithToken(w http.ResponseWriter, r *http.Request, tok *token.Token, apiToken *models.APIToken) {
 	// TODO: add section to get service account for server-side token
 
+	if apiToken != nil && !authn.allowPolicyTokens {
+		tokenPolicy, err := apiToken.GetPolicy()
+
+		if err != nil {
+			apierrors.HandleAPIError(authn.config, w, r, apierrors.NewErrInternal(err), true)
+			return
+		}
+
+		if len(tokenPolicy) != 0 {
+			authn.sendForbiddenError(errPolicyToken, w, r)
+			return
+		}
+	}
+
+	if apiToken != nil {
+		ctx := context.WithValue(r.Context(), types.APITokenCtxKey, apiToken)
+		r = r.Clone(ctx)
+	}
+
 	// for now, we just use nextWithUser using the `iby` field for the token
 	authn.nextWithUserID(w, r, tok.IBy)
 }
@@ -158,15 +202,25 @@ func (authn *AuthN) sendForbiddenError(err error, w http.ResponseWriter, r *http
 
 var errInvalidToken = fmt.Errorf("authorization header exists, but token is not valid")
 var errInvalidAuthHeader = fmt.Errorf("invalid authorization header in request")
+var errRevokedToken = fmt.Errorf("token has been revoked")
+var errExpiredToken = fmt.Errorf("token has expired")
+var errPolicyToken = fmt.Errorf("api tokens with a policy can only be used for project-scoped endpoints")
+var errLegacyToken = fmt.Errorf("api tokens issued before api tokens could be revoked are no longer accepted, create a new token with \"porter token create\"")
+
+// lastUsedInterval is how often the last used time of an API token is recorded
+const lastUsedInterval = time.Minute
 
 // getTokenFromRequest finds an `Authorization` header of the form `Bearer <token>`,
-// and returns a valid token if it exists.
-func (authn *AuthN) getTokenFromRequest(r *http.Request) (*token.Token, error) {
+// and returns a valid token if it exists. If the token references a stored API token,
+// the stored token is returned as well, and the token is rejected if it has been
+// revoked or has expired. API tokens which do not reference a stored token cannot be
+// revoked, so they are only accepted until the configured legacy token cutoff.
+func (authn *AuthN) getTokenFromRequest(r *http.Request) (*token.Token, *models.APIToken, error) {
 	reqToken := r.Header.Get("Authorization")
 	splitToken := strings.Split(reqToken, "Bearer")
 
 	if len(splitToken) != 2 {
-		return nil, errInvalidAuthHeader
+		return nil, nil, errInvalidAuthHeader
 	}
 
 	reqToken = strings.TrimSpace(splitToken[1])
@@ -174,8 +228,36 @@ func (authn *AuthN) getTokenFromRequest(r *http.Request) (*token.Token, error) {
 	tok, err := token.GetTokenFromEncoded(reqToken, authn.config.TokenConf)
 
 	if err != nil {
-		return nil, errInvalidToken
+		return nil, nil, errInvalidToken
+	}
+
+	if tok.TokenID == "" {
+		if tok.SubKind == token.API && !time.Now().Before(authn.config.TokenConf.LegacyAPITokenCutoff) {
+			return nil, nil, errLegacyToken
+		}
+
+		return tok, nil, nil
+	}
+
+	apiToken, err := authn.config.Repo.APIToken().ReadAPIToken(tok.ProjectID, tok.TokenID)
+
+	if err != nil {
+		return nil, nil, errInvalidToken
+	}
+
+	if apiToken.Revoked {
+		return nil, nil, errRevokedToken
+	}
+
+	if apiToken.IsExpired() {
+		return nil, nil, errExpiredToken
+	}
+
+	// the last used time is only recorded once per interval, and failing to record it
+	// should not block the request
+	if now := time.Now(); apiToken.LastUsed == nil || now.Sub(*apiToken.LastUsed) >= lastUsedInterval {
+		authn.config.Repo.APIToken().UpdateAPITokenLastUsed(apiToken, now)
 	}
 
-	return tok, nil
+	return tok, apiToken, nil
 }
@@ -5,11 +5,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/authn"
 	"github.com/porter-dev/porter/api/server/shared/apitest"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -198,6 +200,232 @@ func TestAuthBadSessionUserIDType(t *testing.T) {
 	assertForbiddenError(t, next, rr)
 }
 
+func TestAuthenticatedUserWithStoredToken(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+
+	req, err := http.NewRequest("GET", "/auth-endpoint", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	user := apitest.CreateTestUser(t, config, true)
+	tokenStr := createStoredAPIToken(t, config, user.ID, &models.APIToken{})
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	handler.ServeHTTP(rr, req)
+
+	assertNextHandlerCalled(t, next, rr, user)
+
+	apiToken, err := config.Repo.APIToken().ReadAPIToken(1, "teststoredtoken")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, apiToken.LastUsed, "last used time should be set")
+}
+
+func TestAuthenticatedUserWithRecentlyUsedToken(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+
+	req, err := http.NewRequest("GET", "/auth-endpoint", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	user := apitest.CreateTestUser(t, config, true)
+	lastUsed := time.Now().Add(-10 * time.Second)
+	tokenStr := createStoredAPIToken(t, config, user.ID, &models.APIToken{
+		LastUsed: &lastUsed,
+	})
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	handler.ServeHTTP(rr, req)
+
+	assertNextHandlerCalled(t, next, rr, user)
+
+	apiToken, err := config.Repo.APIToken().ReadAPIToken(1, "teststoredtoken")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, apiToken.LastUsed.Equal(lastUsed), "last used time should not be recorded again within a minute")
+}
+
+func TestUnauthenticatedUserWithRevokedToken(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+
+	req, err := http.NewRequest("GET", "/auth-endpoint", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	user := apitest.CreateTestUser(t, config, true)
+	tokenStr := createStoredAPIToken(t, config, user.ID, &models.APIToken{
+		Revoked: true,
+	})
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	handler.ServeHTTP(rr, req)
+
+	assertForbiddenError(t, next, rr)
+}
+
+func TestUnauthenticatedUserWithExpiredToken(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+
+	req, err := http.NewRequest("GET", "/auth-endpoint", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	user := apitest.CreateTestUser(t, config, true)
+	expiry := time.Now().Add(-1 * time.Hour)
+	tokenStr := createStoredAPIToken(t, config, user.ID, &models.APIToken{
+		Expiry: &expiry,
+	})
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	handler.ServeHTTP(rr, req)
+
+	assertForbiddenError(t, next, rr)
+}
+
+func TestAuthenticatedUserWithLegacyTokenBeforeCutoff(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+	config.TokenConf.LegacyAPITokenCutoff = time.Now().Add(time.Hour)
+
+	req, err := http.NewRequest("GET", "/auth-endpoint", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	user := apitest.CreateTestUser(t, config, true)
+	tokenStr := createLegacyAPIToken(t, config, user.ID)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	handler.ServeHTTP(rr, req)
+
+	assertNextHandlerCalled(t, next, rr, user)
+}
+
+func TestUnauthenticatedUserWithLegacyTokenAfterCutoff(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+	config.TokenConf.LegacyAPITokenCutoff = time.Now().Add(-time.Hour)
+
+	req, err := http.NewRequest("GET", "/auth-endpoint", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+
+	user := apitest.CreateTestUser(t, config, true)
+	tokenStr := createLegacyAPIToken(t, config, user.ID)
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	handler.ServeHTTP(rr, req)
+
+	assertForbiddenError(t, next, rr)
+}
+
+func TestPolicyTokenOnlyForProjectScopedEndpoints(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+
+	tokenStr := createStoredAPIToken(t, config, user.ID, &models.APIToken{
+		PolicyBytes: []byte(`[{"scope":"project","verbs":["get","list"]}]`),
+	})
+
+	factory := authn.NewAuthNFactory(config)
+
+	for _, tc := range []struct {
+		scopes    []types.PermissionScope
+		expCalled bool
+	}{
+		{[]types.PermissionScope{types.UserScope}, false},
+		{[]types.PermissionScope{types.UserScope, types.ProjectScope}, true},
+	} {
+		req, err := http.NewRequest("DELETE", "/auth-endpoint", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+		rr := httptest.NewRecorder()
+		next := &testHandler{}
+
+		factory.NewAuthenticatedForEndpoint(&types.APIRequestMetadata{Scopes: tc.scopes})(next).ServeHTTP(rr, req)
+
+		if tc.expCalled {
+			assertNextHandlerCalled(t, next, rr, user)
+		} else {
+			assertForbiddenError(t, next, rr)
+		}
+	}
+}
+
+func createStoredAPIToken(t *testing.T, config *config.Config, userID uint, apiToken *models.APIToken) string {
+	apiToken.UniqueID = "teststoredtoken"
+	apiToken.ProjectID = 1
+	apiToken.CreatedByUserID = userID
+
+	if _, err := config.Repo.APIToken().CreateAPIToken(apiToken); err != nil {
+		t.Fatal(err)
+	}
+
+	issToken, err := token.GetStoredTokenForAPI(userID, 1, apiToken.UniqueID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := issToken.EncodeToken(config.TokenConf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+// createLegacyAPIToken returns an API token which does not reference a stored token, like
+// the tokens issued before API tokens were stored
+func createLegacyAPIToken(t *testing.T, config *config.Config, userID uint) string {
+	issToken, err := token.GetTokenForAPI(userID, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := issToken.EncodeToken(config.TokenConf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
 type testHandler struct {
 	WasCalled bool
 	User      *models.User
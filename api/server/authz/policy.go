@@ -73,12 +73,82 @@ func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// if the request was authenticated with a stored API token, the token must be issued
+	// for this project, and its attached policy must also permit the action
+	if apiToken, ok := r.Context().Value(types.APITokenCtxKey).(*models.APIToken); ok && apiToken != nil {
+		if reqErr := checkAPITokenAccess(apiToken, projID, reqScopes); reqErr != nil {
+			apierrors.HandleAPIError(h.config, w, r, reqErr, true)
+			return
+		}
+	}
+
 	// add the set of resource ids to the request context
 	ctx := NewRequestScopeCtx(r.Context(), reqScopes)
 	r = r.Clone(ctx)
 	h.next.ServeHTTP(w, r)
 }
 
+func checkAPITokenAccess(
+	apiToken *models.APIToken,
+	projID uint,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+) apierrors.RequestError {
+	if apiToken.ProjectID != projID {
+		return apierrors.NewErrForbidden(
+			fmt.Errorf("api token %s was not issued for project %d", apiToken.UniqueID, projID),
+		)
+	}
+
+	tokenPolicy, err := apiToken.GetPolicy()
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	// tokens without a policy have the same permissions as the user who created them
+	if len(tokenPolicy) == 0 {
+		return nil
+	}
+
+	if !policy.HasScopeAccess(tokenPolicy, reqScopes) {
+		return apierrors.NewErrForbidden(
+			fmt.Errorf("policy for api token %s forbids action in project %d", apiToken.UniqueID, projID),
+		)
+	}
+
+	return nil
+}
+
+// CheckScopeAccess checks that the policies of the user and API token of a request permit
+// an action on resources which are not part of the request URL, such as the target
+// cluster of a release promotion
+func CheckScopeAccess(
+	config *config.Config,
+	r *http.Request,
+	projID uint,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+) apierrors.RequestError {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	loader := policy.NewCustomRolePolicyDocumentLoader(config.Repo.Project(), config.Repo.CustomRole())
+
+	policyDocs, reqErr := loader.LoadPolicyDocuments(user.ID, projID)
+
+	if reqErr != nil {
+		return reqErr
+	}
+
+	if !policy.HasScopeAccess(policyDocs, reqScopes) {
+		return apierrors.NewErrForbidden(fmt.Errorf("policy forbids action for user %d in project %d", user.ID, projID))
+	}
+
+	if apiToken, ok := r.Context().Value(types.APITokenCtxKey).(*models.APIToken); ok && apiToken != nil {
+		return checkAPITokenAccess(apiToken, projID, reqScopes)
+	}
+
+	return nil
+}
+
 func NewRequestScopeCtx(ctx context.Context, reqScopes map[types.PermissionScope]*types.RequestAction) context.Context {
 	return context.WithValue(ctx, types.RequestScopeCtxKey, reqScopes)
 }
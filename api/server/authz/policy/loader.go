@@ -5,6 +5,7 @@ import (
 
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
 )
@@ -36,6 +37,63 @@ func (b *BasicPolicyDocumentLoader) LoadPolicyDocuments(
 		return nil, apierrors.NewErrInternal(err)
 	}
 
+	return loadBuiltinRolePolicy(role, userID, projectID)
+}
+
+// CustomRolePolicyDocumentLoader loads policy documents for the built-in role kinds, and
+// additionally loads the policy of a custom role when the role kind is "custom"
+type CustomRolePolicyDocumentLoader struct {
+	projRepo       repository.ProjectRepository
+	customRoleRepo repository.CustomRoleRepository
+}
+
+func NewCustomRolePolicyDocumentLoader(
+	projRepo repository.ProjectRepository,
+	customRoleRepo repository.CustomRoleRepository,
+) *CustomRolePolicyDocumentLoader {
+	return &CustomRolePolicyDocumentLoader{projRepo, customRoleRepo}
+}
+
+func (c *CustomRolePolicyDocumentLoader) LoadPolicyDocuments(
+	userID, projectID uint,
+) ([]*types.PolicyDocument, apierrors.RequestError) {
+	role, err := c.projRepo.ReadProjectRole(projectID, userID)
+
+	if err != nil && err == gorm.ErrRecordNotFound {
+		return nil, apierrors.NewErrForbidden(
+			fmt.Errorf("user %d does not have a role in project %d", userID, projectID),
+		)
+	} else if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	if role.Kind != types.RoleCustom {
+		return loadBuiltinRolePolicy(role, userID, projectID)
+	}
+
+	customRole, err := c.customRoleRepo.ReadCustomRole(projectID, role.CustomRoleID)
+
+	if err != nil && err == gorm.ErrRecordNotFound {
+		return nil, apierrors.NewErrForbidden(
+			fmt.Errorf("custom role %d not found for user %d, project %d", role.CustomRoleID, userID, projectID),
+		)
+	} else if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	policy, err := customRole.GetPolicy()
+
+	if err != nil {
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return policy, nil
+}
+
+func loadBuiltinRolePolicy(
+	role *models.Role,
+	userID, projectID uint,
+) ([]*types.PolicyDocument, apierrors.RequestError) {
 	// load role based on role kind
 	switch role.Kind {
 	case types.RoleAdmin:
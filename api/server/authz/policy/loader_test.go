@@ -1,6 +1,7 @@
 package policy_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -189,3 +190,168 @@ func TestErrorCannotQuery(t *testing.T) {
 		"status is not status internal",
 	)
 }
+
+func TestCustomRolePolicyDocumentLoader(t *testing.T) {
+	projRepo := test.NewProjectRepository(true)
+	customRoleRepo := test.NewCustomRoleRepository(true)
+	loader := policy.NewCustomRolePolicyDocumentLoader(projRepo, customRoleRepo)
+
+	project, err := projRepo.CreateProject(&models.Project{
+		Name: "test-project",
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	policyBytes, err := json.Marshal(testStagingDeployerPolicy)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	customRole, err := customRoleRepo.CreateCustomRole(&models.CustomRole{
+		ProjectID:   project.ID,
+		Name:        "staging-deployer",
+		PolicyBytes: policyBytes,
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = projRepo.CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:       1,
+			ProjectID:    project.ID,
+			Kind:         types.RoleCustom,
+			CustomRoleID: customRole.ID,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	docs, reqErr := loader.LoadPolicyDocuments(1, project.ID)
+
+	if reqErr != nil {
+		t.Fatalf("%v", reqErr)
+	}
+
+	if diff := deep.Equal(testStagingDeployerPolicy, docs); diff != nil {
+		t.Errorf("policy documents not equal:")
+		t.Error(diff)
+	}
+
+	// built-in roles should still load the built-in policies
+	_, err = projRepo.CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:    2,
+			ProjectID: project.ID,
+			Kind:      types.RoleViewer,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	docs, reqErr = loader.LoadPolicyDocuments(2, project.ID)
+
+	if reqErr != nil {
+		t.Fatalf("%v", reqErr)
+	}
+
+	if diff := deep.Equal(policy.ViewerPolicy, docs); diff != nil {
+		t.Errorf("policy documents not equal:")
+		t.Error(diff)
+	}
+}
+
+func TestCustomRolePolicyDocumentLoaderMissingRole(t *testing.T) {
+	assert := assert.New(t)
+
+	projRepo := test.NewProjectRepository(true)
+	loader := policy.NewCustomRolePolicyDocumentLoader(projRepo, test.NewCustomRoleRepository(true))
+
+	project, err := projRepo.CreateProject(&models.Project{
+		Name: "test-project",
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = projRepo.CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:       1,
+			ProjectID:    project.ID,
+			Kind:         types.RoleCustom,
+			CustomRoleID: 5,
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, reqErr := loader.LoadPolicyDocuments(1, project.ID)
+
+	if reqErr == nil {
+		t.Fatalf("Expected forbidden error for missing custom role")
+	}
+
+	assert.Equal(
+		http.StatusForbidden,
+		reqErr.GetStatusCode(),
+		"status is not status forbidden",
+	)
+}
+
+// testStagingDeployerPolicy allows a user to deploy to the "staging" namespace, but only
+// read the "production" namespace
+var testStagingDeployerPolicy = []*types.PolicyDocument{
+	{
+		Scope: types.ProjectScope,
+		Verbs: types.ReadVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadVerbGroup(),
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.NamespaceScope: {
+						Scope: types.NamespaceScope,
+						Verbs: types.ReadWriteVerbGroup(),
+						Resources: []types.NameOrUInt{
+							{
+								Name: "staging",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Scope: types.ProjectScope,
+		Verbs: types.ReadVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadVerbGroup(),
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.NamespaceScope: {
+						Scope: types.NamespaceScope,
+						Verbs: types.ReadVerbGroup(),
+						Resources: []types.NameOrUInt{
+							{
+								Name: "production",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
@@ -1,15 +1,81 @@
 package policy
 
 import (
+	"fmt"
+
 	"github.com/porter-dev/porter/api/types"
 )
 
+// ValidatePolicy checks that each policy document is valid for the scope heirarchy of
+// the current API server, and that each document only uses known verbs.
+func ValidatePolicy(policy []*types.PolicyDocument) error {
+	if len(policy) == 0 {
+		return fmt.Errorf("policy must contain at least one document")
+	}
+
+	for i, policyDoc := range policy {
+		if policyDoc == nil {
+			return fmt.Errorf("policy document %d is empty", i)
+		}
+
+		isValid, _ := populateAndVerifyPolicyDocument(
+			policyDoc,
+			types.ScopeHeirarchy,
+			types.ProjectScope,
+			types.ReadWriteVerbGroup(),
+			map[types.PermissionScope]*types.RequestAction{},
+			nil,
+		)
+
+		if !isValid {
+			return fmt.Errorf("policy document %d does not match the scope heirarchy", i)
+		}
+
+		if err := validateVerbs(policyDoc); err != nil {
+			return fmt.Errorf("policy document %d is invalid: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateVerbs(policyDoc *types.PolicyDocument) error {
+	allowed := make(map[types.APIVerb]bool)
+
+	for _, verb := range types.ReadWriteVerbGroup() {
+		allowed[verb] = true
+	}
+
+	for _, verb := range policyDoc.Verbs {
+		if !allowed[verb] {
+			return fmt.Errorf("unknown verb %s for scope %s", verb, policyDoc.Scope)
+		}
+	}
+
+	for _, child := range policyDoc.Children {
+		if child == nil {
+			continue
+		}
+
+		if err := validateVerbs(child); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // HasScopeAccess checks that a user can perform an action (`verb`) against a specific
-// resource (`resource+scope`) according to a `policy`.
+// resource (`resource+scope`) according to a `policy`. The resources of every requested
+// scope must be allowed, but the verb is only checked against the most specific scope of
+// the request, so that a document can grant write access to a namespace while only
+// granting read access to its parent cluster and project.
 func HasScopeAccess(
 	policy []*types.PolicyDocument,
 	reqScopes map[types.PermissionScope]*types.RequestAction,
 ) bool {
+	leafScope := getLeafScope(reqScopes)
+
 	// iterate through policy documents until a match is found
 	for _, policyDoc := range policy {
 		// check that policy document is valid for current API server
@@ -35,8 +101,8 @@ func HasScopeAccess(
 				}
 			}
 
-			// for the matching scope, make sure it matches the allowed verbs
-			if !isVerbAllowed(matchDoc, reqScopes[matchScope].Verb) {
+			// for the most specific scope, make sure it matches the allowed verbs
+			if matchScope == leafScope && !isVerbAllowed(matchDoc, reqScopes[matchScope].Verb) {
 				isValid = false
 			}
 		}
@@ -49,6 +115,31 @@ func HasScopeAccess(
 	return false
 }
 
+// getLeafScope returns the requested scope which is deepest in the scope heirarchy
+func getLeafScope(reqScopes map[types.PermissionScope]*types.RequestAction) types.PermissionScope {
+	depths := make(map[types.PermissionScope]int)
+	populateScopeDepths(types.ScopeHeirarchy, 0, depths)
+
+	var leafScope types.PermissionScope
+	leafDepth := -1
+
+	for scope := range reqScopes {
+		if depth, ok := depths[scope]; ok && depth > leafDepth {
+			leafScope = scope
+			leafDepth = depth
+		}
+	}
+
+	return leafScope
+}
+
+func populateScopeDepths(tree types.ScopeTree, depth int, depths map[types.PermissionScope]int) {
+	for scope, subTree := range tree {
+		depths[scope] = depth
+		populateScopeDepths(subTree, depth+1, depths)
+	}
+}
+
 func isResourceAllowed(
 	matchDoc *types.PolicyDocument,
 	resource types.NameOrUInt,
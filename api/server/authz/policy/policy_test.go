@@ -164,6 +164,94 @@ var hasScopeAccessTests = []testHasScopeAccess{
 		},
 		expRes: false,
 	},
+	{
+		description: "staging deployer can update the staging namespace",
+		policy:      testStagingDeployerPolicy,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.ClusterScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					Name: "staging",
+				},
+			},
+		},
+		expRes: true,
+	},
+	{
+		description: "staging deployer can read the production namespace",
+		policy:      testStagingDeployerPolicy,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbGet,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.ClusterScope: {
+				Verb: types.APIVerbGet,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbGet,
+				Resource: types.NameOrUInt{
+					Name: "production",
+				},
+			},
+		},
+		expRes: true,
+	},
+	{
+		description: "staging deployer cannot update the production namespace",
+		policy:      testStagingDeployerPolicy,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.ClusterScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					Name: "production",
+				},
+			},
+		},
+		expRes: false,
+	},
+	{
+		description: "staging deployer cannot update the project",
+		policy:      testStagingDeployerPolicy,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+		},
+		expRes: false,
+	},
 }
 
 func TestHasScopeAccess(t *testing.T) {
@@ -179,6 +267,58 @@ func TestHasScopeAccess(t *testing.T) {
 	}
 }
 
+type testValidatePolicy struct {
+	description string
+	policy      []*types.PolicyDocument
+	expErr      bool
+}
+
+var validatePolicyTests = []testValidatePolicy{
+	{
+		description: "admin policy is valid",
+		policy:      policy.AdminPolicy,
+	},
+	{
+		description: "namespace-specific policy is valid",
+		policy:      testPolicyNamespaceSpecific,
+	},
+	{
+		description: "empty policy is invalid",
+		policy:      []*types.PolicyDocument{},
+		expErr:      true,
+	},
+	{
+		description: "cluster above project is invalid",
+		policy:      testInvalidPolicyDocument,
+		expErr:      true,
+	},
+	{
+		description: "release as child of cluster is invalid",
+		policy:      testInvalidPolicyDocumentNested,
+		expErr:      true,
+	},
+	{
+		description: "unknown verb is invalid",
+		policy: []*types.PolicyDocument{
+			{
+				Scope: types.ProjectScope,
+				Verbs: []types.APIVerb{"deploy"},
+			},
+		},
+		expErr: true,
+	},
+}
+
+func TestValidatePolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, test := range validatePolicyTests {
+		err := policy.ValidatePolicy(test.policy)
+
+		assert.Equal(test.expErr, err != nil, test.description)
+	}
+}
+
 func BenchmarkSimpleHasScopeAccess(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		res := policy.HasScopeAccess(
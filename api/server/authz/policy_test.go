@@ -1,6 +1,8 @@
 package authz_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -164,6 +166,51 @@ func TestPolicyMiddlewareInvalidPermissions(t *testing.T) {
 	apitest.AssertResponseForbidden(t, rr)
 }
 
+func TestPolicyMiddlewareAPITokenPolicyForbids(t *testing.T) {
+	config, handler, next := loadHandlers(t, types.APIRequestMetadata{
+		Verb:   types.APIVerbCreate,
+		Method: types.HTTPVerbPost,
+		Scopes: []types.PermissionScope{
+			types.ProjectScope,
+			types.ClusterScope,
+		},
+	}, false, false)
+
+	user := apitest.CreateTestUser(t, config, true)
+	_, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policyBytes, err := json.Marshal(policy.ViewerPolicy)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/clusters/1", nil)
+
+	req = apitest.WithURLParams(t, req, map[string]string{
+		"project_id": "1",
+		"cluster_id": "1",
+	})
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = req.WithContext(context.WithValue(req.Context(), types.APITokenCtxKey, &models.APIToken{
+		UniqueID:    "readonly",
+		ProjectID:   1,
+		PolicyBytes: policyBytes,
+	}))
+
+	handler.ServeHTTP(rr, req)
+
+	assert.False(t, next.WasCalled, "next handler should not have been called")
+	apitest.AssertResponseForbidden(t, rr)
+}
+
 func TestPolicyMiddlewareFailInvalidLoader(t *testing.T) {
 	config, handler, next := loadHandlers(t, types.APIRequestMetadata{
 		Verb:   types.APIVerbCreate,
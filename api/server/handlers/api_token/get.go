@@ -0,0 +1,74 @@
+package api_token
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type APITokenGetHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewAPITokenGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *APITokenGetHandler {
+	return &APITokenGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *APITokenGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	apiToken, ok := readAPIToken(p, w, r, proj.ID)
+
+	if !ok {
+		return
+	}
+
+	res := types.GetAPITokenResponse(*apiToken.ToAPITokenType())
+
+	p.WriteResult(w, r, res)
+}
+
+// readAPIToken reads the api token referenced by the `api_token_id` url parameter,
+// writing an error and returning false if the token cannot be read
+func readAPIToken(
+	p handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+	projectID uint,
+) (*models.APIToken, bool) {
+	tokenID, reqErr := requestutils.GetURLParamString(r, types.URLParamTokenID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return nil, false
+	}
+
+	apiToken, err := p.Repo().APIToken().ReadAPIToken(projectID, tokenID)
+
+	if err == gorm.ErrRecordNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("api token %s not found in project %d", tokenID, projectID),
+			http.StatusNotFound,
+		))
+
+		return nil, false
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, false
+	}
+
+	return apiToken, true
+}
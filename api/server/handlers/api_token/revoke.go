@@ -0,0 +1,48 @@
+package api_token
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type APITokenRevokeHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewAPITokenRevokeHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *APITokenRevokeHandler {
+	return &APITokenRevokeHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *APITokenRevokeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	apiToken, ok := readAPIToken(p, w, r, proj.ID)
+
+	if !ok {
+		return
+	}
+
+	apiToken.Revoked = true
+
+	apiToken, err := p.Repo().APIToken().UpdateAPIToken(apiToken)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := types.RevokeAPITokenResponse(*apiToken.ToAPITokenType())
+
+	p.WriteResult(w, r, res)
+}
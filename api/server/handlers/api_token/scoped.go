@@ -0,0 +1,115 @@
+package api_token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/random"
+	"gorm.io/gorm"
+)
+
+// CreateClusterScopedToken creates a stored API token for a single cluster of a project,
+// which is issued to GitHub Actions, preview environments and the Porter agent instead of
+// a token with the full permissions of the user. The token cannot access other clusters or
+// the settings of the project, and is listed with the API tokens of the project so that it
+// can be revoked. Since nothing rotates the token after it has been issued, it does not
+// expire, and should instead be revoked with RevokeScopedToken when the resource it was
+// issued for is deleted. The stored token and the encoded token are returned.
+func CreateClusterScopedToken(
+	config *config.Config,
+	userID, projectID, clusterID uint,
+	name string,
+) (*models.APIToken, string, error) {
+	policyBytes, err := json.Marshal(getClusterScopedPolicy(clusterID))
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	uid, err := random.StringWithCharset(16, "")
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(name) > 255 {
+		name = name[:255]
+	}
+
+	apiToken, err := config.Repo.APIToken().CreateAPIToken(&models.APIToken{
+		UniqueID:        uid,
+		ProjectID:       projectID,
+		CreatedByUserID: userID,
+		Name:            name,
+		PolicyBytes:     policyBytes,
+	})
+
+	if err != nil {
+		return nil, "", fmt.Errorf("could not create api token: %v", err)
+	}
+
+	jwt, err := token.GetStoredTokenForAPI(userID, projectID, apiToken.UniqueID)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	encoded, err := jwt.EncodeToken(config.TokenConf)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return apiToken, encoded, nil
+}
+
+// RevokeScopedToken revokes a token which was created with CreateClusterScopedToken. A
+// token which no longer exists is ignored.
+func RevokeScopedToken(config *config.Config, projectID uint, uid string) error {
+	if uid == "" {
+		return nil
+	}
+
+	apiToken, err := config.Repo.APIToken().ReadAPIToken(projectID, uid)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if apiToken.Revoked {
+		return nil
+	}
+
+	apiToken.Revoked = true
+
+	_, err = config.Repo.APIToken().UpdateAPIToken(apiToken)
+
+	return err
+}
+
+func getClusterScopedPolicy(clusterID uint) types.Policy {
+	return types.Policy{
+		{
+			Scope: types.ProjectScope,
+			Verbs: types.ReadWriteVerbGroup(),
+			Children: map[types.PermissionScope]*types.PolicyDocument{
+				types.ClusterScope: {
+					Scope:     types.ClusterScope,
+					Resources: []types.NameOrUInt{{UInt: clusterID}},
+					Verbs:     types.ReadWriteVerbGroup(),
+				},
+				types.SettingsScope: {
+					Scope: types.SettingsScope,
+					Verbs: []types.APIVerb{},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package api_token_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers/api_token"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateClusterScopedToken(t *testing.T) {
+	config := apitest.LoadConfig(t)
+
+	_, encoded, err := api_token.CreateClusterScopedToken(config, 1, 1, 2, "porter-agent")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok, err := token.GetTokenFromEncoded(encoded, config.TokenConf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apiToken, err := config.Repo.APIToken().ReadAPIToken(1, tok.TokenID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "porter-agent", apiToken.Name)
+	assert.Nil(t, apiToken.Expiry, "token should not expire, since it is not rotated")
+
+	tokenPolicy, err := apiToken.GetPolicy()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, policy.HasScopeAccess(tokenPolicy, map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope:   {Verb: types.APIVerbUpdate, Resource: types.NameOrUInt{UInt: 1}},
+		types.ClusterScope:   {Verb: types.APIVerbUpdate, Resource: types.NameOrUInt{UInt: 2}},
+		types.NamespaceScope: {Verb: types.APIVerbUpdate, Resource: types.NameOrUInt{Name: "default"}},
+	}), "token should write to its cluster")
+
+	assert.False(t, policy.HasScopeAccess(tokenPolicy, map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope: {Verb: types.APIVerbGet, Resource: types.NameOrUInt{UInt: 1}},
+		types.ClusterScope: {Verb: types.APIVerbGet, Resource: types.NameOrUInt{UInt: 3}},
+	}), "token should not read other clusters")
+
+	assert.False(t, policy.HasScopeAccess(tokenPolicy, map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope:  {Verb: types.APIVerbGet, Resource: types.NameOrUInt{UInt: 1}},
+		types.SettingsScope: {Verb: types.APIVerbCreate, Resource: types.NameOrUInt{UInt: 1}},
+	}), "token should not create api tokens")
+}
+
+func TestRevokeScopedToken(t *testing.T) {
+	config := apitest.LoadConfig(t)
+
+	apiToken, _, err := api_token.CreateClusterScopedToken(config, 1, 1, 2, "github-actions-porter-dev/porter-web")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := api_token.RevokeScopedToken(config, 1, apiToken.UniqueID); err != nil {
+		t.Fatal(err)
+	}
+
+	apiToken, err = config.Repo.APIToken().ReadAPIToken(1, apiToken.UniqueID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, apiToken.Revoked, "token should be revoked")
+
+	// revoking a token which does not exist is a no-op
+	assert.NoError(t, api_token.RevokeScopedToken(config, 1, "missing"))
+	assert.NoError(t, api_token.RevokeScopedToken(config, 1, ""))
+}
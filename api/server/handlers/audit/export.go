@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// exportBatchSize is the number of audit events read from the database at a time
+const exportBatchSize = 500
+
+type ExportAuditEventsHandler struct {
+	handlers.PorterHandlerReader
+}
+
+func NewExportAuditEventsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+) *ExportAuditEventsHandler {
+	return &ExportAuditEventsHandler{
+		PorterHandlerReader: handlers.NewDefaultPorterHandler(config, decoderValidator, nil),
+	}
+}
+
+// ServeHTTP writes all audit events matching the filters as JSON lines, most
+// recent first. The limit and skip options are ignored.
+func (c *ExportAuditEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ListAuditEventsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	wroteHeader := false
+	encoder := json.NewEncoder(w)
+
+	// events are read in batches which start after the last event of the previous batch,
+	// so that events created during the export do not shift the batches
+	var lastEvent *models.AuditEvent
+
+	for {
+		auditEvents, err := c.Repo().AuditEvent().ListAuditEventsBefore(proj.ID, request, lastEvent, exportBatchSize)
+
+		if err != nil {
+			// if events were already written, the status code can no longer be changed
+			if wroteHeader {
+				c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+			} else {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			}
+
+			return
+		}
+
+		if !wroteHeader {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.Header().Set("Content-Disposition", "attachment; filename=audit_events.jsonl")
+			w.WriteHeader(http.StatusOK)
+			wroteHeader = true
+		}
+
+		for _, auditEvent := range auditEvents {
+			if err := encoder.Encode(auditEvent.ToAuditEventType()); err != nil {
+				c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+		}
+
+		if len(auditEvents) < exportBatchSize {
+			return
+		}
+
+		lastEvent = auditEvents[len(auditEvents)-1]
+	}
+}
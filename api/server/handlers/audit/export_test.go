@@ -0,0 +1,87 @@
+package audit_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/audit"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestExportAuditEventsInBatches(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// more events than fit in a single batch of the export
+	numEvents := 1201
+
+	for i := 0; i < numEvents; i++ {
+		_, err := config.Repo.AuditEvent().CreateAuditEvent(&models.AuditEvent{
+			ProjectID: proj.ID,
+			UserID:    user.ID,
+			Verb:      string(types.APIVerbCreate),
+			Path:      "/api/projects/1/a",
+			Status:    201,
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbGet),
+		"/api/projects/1/audit_events/export",
+		nil,
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	handler := audit.NewExportAuditEventsHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	scanner := bufio.NewScanner(rr.Body)
+	seen := make(map[uint]bool)
+	var lastID uint
+
+	for scanner.Scan() {
+		event := &types.AuditEvent{}
+
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			t.Fatal(err)
+		}
+
+		if seen[event.ID] {
+			t.Fatalf("event %d was exported more than once", event.ID)
+		}
+
+		if lastID != 0 && event.ID >= lastID {
+			t.Fatalf("expected events to be exported most recent first, got %d after %d", event.ID, lastID)
+		}
+
+		seen[event.ID] = true
+		lastID = event.ID
+	}
+
+	if len(seen) != numEvents {
+		t.Errorf("expected %d exported events, got %d", numEvents, len(seen))
+	}
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListAuditEventsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListAuditEventsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListAuditEventsHandler {
+	return &ListAuditEventsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListAuditEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ListAuditEventsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Limit == 0 {
+		request.Limit = types.DefaultAuditEventsLimit
+	}
+
+	auditEvents, count, err := c.Repo().AuditEvent().ListAuditEventsByProjectID(proj.ID, request)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	resp := &types.ListAuditEventsResponse{
+		Count:       count,
+		Limit:       request.Limit,
+		Skip:        request.Skip,
+		AuditEvents: []*types.AuditEvent{},
+	}
+
+	for _, auditEvent := range auditEvents {
+		resp.AuditEvents = append(resp.AuditEvents, auditEvent.ToAuditEventType())
+	}
+
+	c.WriteResult(w, r, resp)
+}
@@ -0,0 +1,64 @@
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/audit"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestListAuditEventsSuccessful(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := []*models.AuditEvent{
+		{ProjectID: proj.ID, UserID: user.ID, Verb: string(types.APIVerbCreate), Path: "/api/projects/1/a", Status: 201},
+		{ProjectID: proj.ID + 1, UserID: user.ID, Verb: string(types.APIVerbCreate), Path: "/api/projects/2/a", Status: 201},
+		{ProjectID: proj.ID, UserID: user.ID, Verb: string(types.APIVerbDelete), Path: "/api/projects/1/b", Status: 200},
+	}
+
+	for _, event := range events {
+		if _, err := config.Repo.AuditEvent().CreateAuditEvent(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbGet),
+		"/api/projects/1/audit_events?limit=1",
+		nil,
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	handler := audit.NewListAuditEventsHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config),
+		shared.NewDefaultResultWriter(config),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	expResp := &types.ListAuditEventsResponse{
+		Count:       2,
+		Limit:       1,
+		AuditEvents: []*types.AuditEvent{events[2].ToAuditEventType()},
+	}
+
+	gotResp := &types.ListAuditEventsResponse{}
+
+	apitest.AssertResponseExpected(t, rr, expResp, gotResp)
+}
@@ -6,11 +6,11 @@ import (
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/api_token"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
-	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/helm/loader"
 	"github.com/porter-dev/porter/internal/models"
@@ -58,15 +58,14 @@ func (c *InstallAgentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// add api token to values
-	jwt, err := token.GetTokenForAPI(user.ID, proj.ID)
-
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
-	}
-
-	encoded, err := jwt.EncodeToken(c.Config().TokenConf)
+	// add an api token which can only access this cluster to values
+	_, encoded, err := api_token.CreateClusterScopedToken(
+		c.Config(),
+		user.ID,
+		proj.ID,
+		cluster.ID,
+		fmt.Sprintf("porter-agent-cluster-%d", cluster.ID),
+	)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
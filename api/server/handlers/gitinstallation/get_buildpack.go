@@ -1,10 +1,7 @@
 package gitinstallation
 
 import (
-	"context"
-	"fmt"
 	"net/http"
-	"sync"
 
 	"github.com/google/go-github/v41/github"
 	"github.com/porter-dev/porter/api/server/authz"
@@ -16,24 +13,6 @@ import (
 	"github.com/porter-dev/porter/internal/integrations/buildpacks"
 )
 
-func initBuilderInfo() map[string]*buildpacks.BuilderInfo {
-	builders := make(map[string]*buildpacks.BuilderInfo)
-	builders[buildpacks.PaketoBuilder] = &buildpacks.BuilderInfo{
-		Name: "Paketo",
-		Builders: []string{
-			"paketobuildpacks/builder:full",
-		},
-	}
-	builders[buildpacks.HerokuBuilder] = &buildpacks.BuilderInfo{
-		Name: "Heroku",
-		Builders: []string{
-			"heroku/buildpacks:20",
-			"heroku/buildpacks:18",
-		},
-	}
-	return builders
-}
-
 type GithubGetBuildpackHandler struct {
 	handlers.PorterHandlerReadWriter
 	authz.KubernetesAgentGetter
@@ -79,12 +58,9 @@ func (c *GithubGetBuildpackHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 
 	repoContentOptions := github.RepositoryContentGetOptions{}
 	repoContentOptions.Ref = branch
-	_, directoryContents, _, err := client.Repositories.GetContents(
-		context.Background(),
-		owner,
-		name,
-		request.Dir,
-		&repoContentOptions,
+
+	builders, err := buildpacks.DetectBuilders(
+		buildpacks.NewGithubFS(client, owner, name, request.Dir, repoContentOptions),
 	)
 
 	if err != nil {
@@ -92,41 +68,5 @@ func (c *GithubGetBuildpackHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	builderInfoMap := initBuilderInfo()
-	var wg sync.WaitGroup
-	wg.Add(len(buildpacks.Runtimes))
-	for i := range buildpacks.Runtimes {
-		go func(idx int) {
-			defer func() {
-				if rec := recover(); rec != nil {
-					c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("panic detected in runtime detection")))
-					return
-				}
-			}()
-			buildpacks.Runtimes[idx].Detect(
-				client, directoryContents, owner, name, request.Dir, repoContentOptions,
-				builderInfoMap[buildpacks.PaketoBuilder], builderInfoMap[buildpacks.HerokuBuilder],
-			)
-			wg.Done()
-		}(i)
-	}
-	wg.Wait()
-
-	// FIXME: add Java buildpacks
-	builderInfoMap[buildpacks.PaketoBuilder].Others = append(builderInfoMap[buildpacks.PaketoBuilder].Others,
-		buildpacks.BuildpackInfo{
-			Name:      "Java",
-			Buildpack: "gcr.io/paketo-buildpacks/java",
-		})
-	builderInfoMap[buildpacks.HerokuBuilder].Others = append(builderInfoMap[buildpacks.HerokuBuilder].Others,
-		buildpacks.BuildpackInfo{
-			Name:      "Java",
-			Buildpack: "heroku/java",
-		})
-
-	var builders []*buildpacks.BuilderInfo
-	for _, v := range builderInfoMap {
-		builders = append(builders, v)
-	}
 	c.WriteResult(w, r, builders)
 }
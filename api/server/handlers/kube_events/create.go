@@ -15,9 +15,10 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/helm/grapher"
-	"github.com/porter-dev/porter/internal/integrations/slack"
+	"github.com/porter-dev/porter/internal/jobrun"
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
 	"gorm.io/gorm"
 )
 
@@ -90,6 +91,14 @@ func (c *CreateKubeEventHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 
 	w.WriteHeader(http.StatusCreated)
 
+	// events for job pods are used to keep the stored run of the job up to date, since
+	// the job may be deleted before its history is viewed
+	if strings.ToLower(request.OwnerType) == "job" && request.OwnerName != "" {
+		if err := recordJobRun(c, r, cluster, request); err != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
+
 	if strings.ToLower(string(request.EventType)) == "critical" &&
 		strings.ToLower(request.ResourceType) == "pod" &&
 		request.Message != "Unable to determine the root cause of the error" {
@@ -108,6 +117,36 @@ func (c *CreateKubeEventHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+func recordJobRun(
+	c *CreateKubeEventHandler,
+	r *http.Request,
+	cluster *models.Cluster,
+	event *types.CreateKubeEventRequest,
+) error {
+	agent, err := c.GetAgent(r, cluster, event.Namespace)
+
+	if err != nil {
+		return err
+	}
+
+	job, err := agent.GetJob(grapher.Object{
+		Kind:      "Job",
+		Name:      event.OwnerName,
+		Namespace: event.Namespace,
+	})
+
+	// the job may already have been deleted, in which case it was recorded earlier
+	if errors.Is(err, kubernetes.IsNotFoundError) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	_, err = jobrun.Record(c.Repo(), agent, cluster.ProjectID, cluster.ID, job)
+
+	return err
+}
+
 func mapKubeEventToMessage(event *types.CreateKubeEventRequest) string {
 	if strings.HasSuffix(event.Reason, "RunContainerError") {
 		if strings.Contains(event.Message, "exec:") {
@@ -136,7 +175,8 @@ func notifyPodCrashing(
 	// attempt to get a matching Porter release to get the notification configuration
 	var conf *models.NotificationConfig
 	var notifConfig *types.NotificationConfig
-	var notifyOpts *slack.NotifyOpts
+	var notifyOpts *notifier.Event
+	var releaseID uint
 	var matchedRel *models.Release
 	var err error
 
@@ -182,7 +222,8 @@ func notifyPodCrashing(
 			}
 		}
 
-		notifyOpts = &slack.NotifyOpts{
+		notifyOpts = &notifier.Event{
+			Type:        types.NotificationEventJobFailure,
 			ProjectID:   cluster.ProjectID,
 			ClusterID:   cluster.ID,
 			ClusterName: cluster.Name,
@@ -245,7 +286,10 @@ func notifyPodCrashing(
 			notifConfig = conf.ToNotificationConfigType()
 		}
 
-		notifyOpts = &slack.NotifyOpts{
+		releaseID = matchedRel.ID
+
+		notifyOpts = &notifier.Event{
+			Type:        types.NotificationEventPodCrash,
 			ProjectID:   cluster.ProjectID,
 			ClusterID:   cluster.ID,
 			ClusterName: cluster.Name,
@@ -263,12 +307,17 @@ func notifyPodCrashing(
 		}
 	}
 
-	slackInts, _ := config.Repo.SlackIntegration().ListSlackIntegrationsByProjectID(project.ID)
+	projNotifier, err := notifier.NewProjectNotifier(config.NotificationRegistry, config.Repo, &notifier.ProjectNotifierOpts{
+		ProjectID: project.ID,
+		ReleaseID: releaseID,
+		Config:    notifConfig,
+	})
 
-	notifier := slack.NewSlackNotifier(notifConfig, slackInts...)
-	notifyOpts.Status = slack.StatusPodCrashed
+	if err != nil {
+		return err
+	}
 
-	err = notifier.Notify(notifyOpts)
+	err = projNotifier.Notify(notifyOpts)
 
 	if err != nil {
 		return err
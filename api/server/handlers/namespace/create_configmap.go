@@ -1,7 +1,6 @@
 package namespace
 
 import (
-	"fmt"
 	"net/http"
 
 	v1 "k8s.io/api/core/v1"
@@ -12,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/models"
 )
@@ -39,9 +39,15 @@ func (c *CreateConfigMapHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	namespace := r.Context().Value(types.NamespaceScope).(string)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
+	if err := envgroup.ValidateSecretRefs(cluster.ProjectID, request.SecretReferences); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
 	agent, err := c.GetAgent(r, cluster, "")
 
 	if err != nil {
@@ -65,6 +71,25 @@ func (c *CreateConfigMapHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		ConfigMap: configMap,
 	}
 
+	if len(request.SecretReferences) > 0 {
+		res.SecretStatus, err = syncEnvGroupSecrets(c.Config(), agent, cluster, namespace, request.Name, request.SecretReferences)
+
+		if err == nil {
+			res.ConfigMap, err = agent.GetConfigMap(request.Name, namespace)
+		}
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	_, err = envgroup.RecordVersion(c.Repo(), agent, cluster, namespace, request.Name, user.Email)
+
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+
 	c.WriteResult(w, r, res)
 }
 
@@ -78,7 +103,7 @@ func createConfigMap(agent *kubernetes.Agent, input types.ConfigMapInput) (*v1.C
 
 	// add all secret env variables to configmap with value PORTERSECRET_${configmap_name}
 	for key := range input.SecretVariables {
-		input.Variables[key] = fmt.Sprintf("PORTERSECRET_%s", input.Name)
+		input.Variables[key] = envgroup.SecretReference(input.Name)
 	}
 
 	return agent.CreateConfigMap(input.Name, input.Namespace, input.Variables)
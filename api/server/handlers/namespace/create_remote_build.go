@@ -0,0 +1,145 @@
+package namespace
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/remotebuild"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type CreateRemoteBuildHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewCreateRemoteBuildHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateRemoteBuildHandler {
+	return &CreateRemoteBuildHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *CreateRemoteBuildHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.CreateRemoteBuildRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	regs, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	reg := getImageRepoRegistry(regs, request.ImageRepoURI)
+
+	if reg == nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("image repository %s does not belong to a registry linked to this project", request.ImageRepoURI),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the build pushes with the same credentials which releases pull with
+	secrets, err := agent.CreateImagePullSecrets(
+		c.Repo(),
+		namespace,
+		map[string]*models.Registry{reg.URL: reg},
+		c.Config().DOConf,
+	)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	name, err := remotebuild.GetJobName(request.ImageRepoURI)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	job, err := remotebuild.GetJobTemplate(&remotebuild.BuildOpts{
+		Name:               name,
+		Namespace:          namespace,
+		Method:             request.Method,
+		ImageRepo:          request.ImageRepoURI,
+		Tag:                request.Tag,
+		Dockerfile:         request.Dockerfile,
+		Builder:            request.Builder,
+		Buildpacks:         request.Buildpacks,
+		Env:                request.Env,
+		DockerConfigSecret: secrets[reg.URL],
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if _, err := agent.CreateJob(namespace, job); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.CreateRemoteBuildResponse{
+		Name: name,
+	})
+}
+
+// getImageRepoRegistry returns the registry which an image repository belongs to, by
+// comparing the image repository against the URL of each registry
+func getImageRepoRegistry(regs []*models.Registry, imageRepo string) *models.Registry {
+	for _, reg := range regs {
+		regURL := strings.TrimSuffix(reg.URL, "/")
+
+		if i := strings.Index(regURL, "://"); i != -1 {
+			regURL = regURL[i+3:]
+		}
+
+		if regURL == "" {
+			continue
+		}
+
+		if imageRepo == regURL || strings.HasPrefix(imageRepo, regURL+"/") {
+			return reg
+		}
+
+		// Docker Hub registries are stored as index.docker.io, while images are
+		// usually referred to as docker.io
+		if hubPath := strings.TrimPrefix(regURL, "index."); hubPath != regURL &&
+			strings.HasPrefix(regURL, "index.docker.io") &&
+			(imageRepo == hubPath || strings.HasPrefix(imageRepo, hubPath+"/")) {
+			return reg
+		}
+	}
+
+	return nil
+}
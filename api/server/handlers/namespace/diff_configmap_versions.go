@@ -0,0 +1,89 @@
+package namespace
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type DiffConfigMapVersionsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewDiffConfigMapVersionsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DiffConfigMapVersionsHandler {
+	return &DiffConfigMapVersionsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *DiffConfigMapVersionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.DiffEnvGroupVersionsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	from, ok := c.readVersion(w, r, cluster, namespace, request.Name, request.From)
+
+	if !ok {
+		return
+	}
+
+	to, ok := c.readVersion(w, r, cluster, namespace, request.Name, request.To)
+
+	if !ok {
+		return
+	}
+
+	changes, err := envgroup.Diff(from, to)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.DiffEnvGroupVersionsResponse{
+		From:    from.Version,
+		To:      to.Version,
+		Changes: changes,
+	})
+}
+
+func (c *DiffConfigMapVersionsHandler) readVersion(
+	w http.ResponseWriter,
+	r *http.Request,
+	cluster *models.Cluster,
+	namespace, name string,
+	version uint,
+) (*models.EnvGroupVersion, bool) {
+	res, err := c.Repo().EnvGroupVersion().ReadEnvGroupVersion(cluster.ProjectID, cluster.ID, namespace, name, version)
+
+	if err == gorm.ErrRecordNotFound {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("version %d of env group %s not found", version, name),
+			http.StatusNotFound,
+		))
+
+		return nil, false
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, false
+	}
+
+	return res, true
+}
@@ -0,0 +1,65 @@
+package namespace
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type GetApplyManifestHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewGetApplyManifestHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetApplyManifestHandler {
+	return &GetApplyManifestHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP returns the releases deployed by the last apply of a porter.yaml file. A
+// porter.yaml file which was never applied returns a manifest without releases.
+func (c *GetApplyManifestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.GetApplyManifestRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	manifest, err := c.Repo().ApplyManifest().ReadApplyManifest(cluster.ProjectID, cluster.ID, namespace, request.Name)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.WriteResult(w, r, &types.GetApplyManifestResponse{
+			Name:      request.Name,
+			Namespace: namespace,
+			Resources: []*types.ApplyManifestResource{},
+		})
+
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := manifest.ToApplyManifestType()
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, (*types.GetApplyManifestResponse)(res))
+}
@@ -0,0 +1,52 @@
+package namespace
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type GetConfigMapSecretStatusHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewGetConfigMapSecretStatusHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetConfigMapSecretStatusHandler {
+	return &GetConfigMapSecretStatusHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *GetConfigMapSecretStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.GetEnvGroupSecretStatusRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	refs, err := c.Repo().EnvGroupSecretRef().ListEnvGroupSecretRefs(cluster.ProjectID, cluster.ID, namespace, request.Name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.GetEnvGroupSecretStatusResponse, 0)
+
+	for _, ref := range refs {
+		res = append(res, ref.ToEnvGroupSecretStatusType())
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,74 @@
+package namespace
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type LinkConfigMapReleaseHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewLinkConfigMapReleaseHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *LinkConfigMapReleaseHandler {
+	return &LinkConfigMapReleaseHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *LinkConfigMapReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.LinkEnvGroupReleaseRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	policy := request.RedeployPolicy
+
+	if policy == "" {
+		policy = types.EnvGroupRedeployPolicyNone
+	}
+
+	// either create a new link or update the policy of the current one
+	link, err := c.Repo().EnvGroupRelease().ReadEnvGroupRelease(
+		cluster.ProjectID,
+		cluster.ID,
+		namespace,
+		request.Name,
+		request.ReleaseName,
+	)
+
+	if err == gorm.ErrRecordNotFound {
+		link, err = c.Repo().EnvGroupRelease().CreateEnvGroupRelease(&models.EnvGroupRelease{
+			ProjectID:      cluster.ProjectID,
+			ClusterID:      cluster.ID,
+			Namespace:      namespace,
+			EnvGroupName:   request.Name,
+			ReleaseName:    request.ReleaseName,
+			RedeployPolicy: policy,
+		})
+	} else if err == nil {
+		link.RedeployPolicy = policy
+		link, err = c.Repo().EnvGroupRelease().UpdateEnvGroupRelease(link)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, link.ToEnvGroupReleaseType())
+}
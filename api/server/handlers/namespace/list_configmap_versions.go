@@ -0,0 +1,67 @@
+package namespace
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListConfigMapVersionsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListConfigMapVersionsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListConfigMapVersionsHandler {
+	return &ListConfigMapVersionsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListConfigMapVersionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.ListEnvGroupVersionsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	versions, err := c.Repo().EnvGroupVersion().ListEnvGroupVersions(cluster.ProjectID, cluster.ID, namespace, request.Name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListEnvGroupVersionsResponse, 0)
+
+	// versions are listed from newest to oldest, so the previous version of each version
+	// is the next one in the list
+	for i, version := range versions {
+		var previous *models.EnvGroupVersion
+
+		if i+1 < len(versions) {
+			previous = versions[i+1]
+		}
+
+		versionType, err := version.ToEnvGroupVersionType(previous)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		res = append(res, versionType)
+	}
+
+	c.WriteResult(w, r, res)
+}
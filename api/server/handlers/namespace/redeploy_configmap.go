@@ -0,0 +1,140 @@
+package namespace
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type RedeployConfigMapHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewRedeployConfigMapHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RedeployConfigMapHandler {
+	return &RedeployConfigMapHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *RedeployConfigMapHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.RedeployEnvGroupRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	current, err := c.Repo().EnvGroupVersion().ReadLatestEnvGroupVersion(cluster.ProjectID, cluster.ID, namespace, request.Name)
+
+	if err == gorm.ErrRecordNotFound {
+		// the env group has not been changed since versions were stored, so record its
+		// current state to merge into the releases
+		current, err = envgroup.RecordVersion(c.Repo(), helmAgent.K8sAgent, cluster, namespace, request.Name, "")
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	links := make([]*models.EnvGroupRelease, 0)
+
+	if len(request.ReleaseNames) == 0 {
+		links, err = envgroup.DetectReleases(c.Repo(), helmAgent, cluster, namespace, request.Name)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	} else {
+		for _, releaseName := range request.ReleaseNames {
+			links = append(links, &models.EnvGroupRelease{ReleaseName: releaseName})
+		}
+	}
+
+	// the strategy of the request overrides the redeploy policy of each release
+	for _, link := range links {
+		link.RedeployPolicy = request.Strategy
+	}
+
+	res, err := redeployEnvGroup(c.Config(), helmAgent, cluster, links, nil, current)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, types.RedeployEnvGroupResponse(res))
+}
+
+// redeployTimeout is how long a request waits for releases to be redeployed. Releases
+// which take longer are redeployed in the background.
+const redeployTimeout = 20 * time.Second
+
+// redeployEnvGroup redeploys the given releases after an env group was updated from the
+// previous to the current version
+func redeployEnvGroup(
+	config *config.Config,
+	helmAgent *helm.Agent,
+	cluster *models.Cluster,
+	links []*models.EnvGroupRelease,
+	previous, current *models.EnvGroupVersion,
+) ([]*types.EnvGroupRedeployResult, error) {
+	registries, err := config.Repo.Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return envgroup.Redeploy(&envgroup.RedeployConfig{
+		Repo:       config.Repo,
+		Cluster:    cluster,
+		HelmAgent:  helmAgent,
+		Registries: registries,
+		DOConf:     config.DOConf,
+		Timeout:    redeployTimeout,
+		Logger:     config.Logger,
+	}, links, previous, current), nil
+}
+
+// redeployUpdatedEnvGroup redeploys the releases which consume an env group according
+// to their redeploy policies
+func redeployUpdatedEnvGroup(
+	config *config.Config,
+	helmAgent *helm.Agent,
+	cluster *models.Cluster,
+	previous, current *models.EnvGroupVersion,
+) ([]*types.EnvGroupRedeployResult, error) {
+	links, err := envgroup.DetectReleases(config.Repo, helmAgent, cluster, current.Namespace, current.Name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return redeployEnvGroup(config, helmAgent, cluster, links, previous, current)
+}
@@ -0,0 +1,105 @@
+package namespace
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type RollbackConfigMapHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewRollbackConfigMapHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RollbackConfigMapHandler {
+	return &RollbackConfigMapHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *RollbackConfigMapHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.RollbackEnvGroupRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	target, err := c.Repo().EnvGroupVersion().ReadEnvGroupVersion(
+		cluster.ProjectID,
+		cluster.ID,
+		namespace,
+		request.Name,
+		request.Version,
+	)
+
+	if err == gorm.ErrRecordNotFound {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("version %d of env group %s not found", request.Version, request.Name),
+			http.StatusNotFound,
+		))
+
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	previous, err := c.Repo().EnvGroupVersion().ReadLatestEnvGroupVersion(cluster.ProjectID, cluster.ID, namespace, request.Name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	configMap, version, err := envgroup.Rollback(c.Repo(), helmAgent.K8sAgent, cluster, target, user.Email)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	versionType, err := version.ToEnvGroupVersionType(previous)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.RollbackEnvGroupResponse{
+		ConfigMap: configMap,
+		Version:   versionType,
+	}
+
+	res.Redeploys, err = redeployUpdatedEnvGroup(c.Config(), helmAgent, cluster, previous, version)
+
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+
+	c.WriteResult(w, r, res)
+}
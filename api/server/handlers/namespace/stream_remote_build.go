@@ -0,0 +1,204 @@
+package namespace
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/server/shared/websocket"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/remotebuild"
+	"github.com/porter-dev/porter/internal/models"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// StreamRemoteBuildHandler uploads the build context of a remote build to the job of the
+// build, and streams the logs and the final status of the build back to the client.
+//
+// The client sends the build context as a gzipped tar over binary messages, followed by
+// an empty message once the whole build context has been sent.
+type StreamRemoteBuildHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewStreamRemoteBuildHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *StreamRemoteBuildHandler {
+	return &StreamRemoteBuildHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *StreamRemoteBuildHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	safeRW := r.Context().Value(types.RequestCtxWebsocketKey).(*websocket.WebsocketSafeReadWriter)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamRemoteBuildName)
+
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	job, err := agent.GetJob(grapher.Object{
+		Kind:      "Job",
+		Name:      name,
+		Namespace: namespace,
+	})
+
+	if errors.Is(err, kubernetes.IsNotFoundError) || (err == nil && job.Labels[remotebuild.LabelKey] != "true") {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("remote build %s/%s was not found", namespace, name),
+			http.StatusNotFound,
+		))
+
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	contextReader, contextWriter := io.Pipe()
+
+	go func() {
+		uploaded := false
+
+		for {
+			_, data, err := safeRW.ReadMessage()
+
+			if err != nil {
+				contextWriter.CloseWithError(fmt.Errorf("build context upload was interrupted: %v", err))
+				return
+			}
+
+			// after the upload, keep reading to handle the closing handshake
+			if uploaded {
+				continue
+			}
+
+			if len(data) == 0 {
+				uploaded = true
+				contextWriter.Close()
+				continue
+			}
+
+			if _, err := contextWriter.Write(data); err != nil {
+				// the build container stopped reading, which is reported by the attach
+				uploaded = true
+			}
+		}
+	}()
+
+	pod, err := agent.WaitForJobPod(namespace, name, remotebuild.ContextContainerName, 5*time.Minute)
+
+	if err != nil {
+		contextReader.Close()
+		c.writeStatus(safeRW, nil, err)
+		return
+	}
+
+	err = agent.AttachToContainer(namespace, pod.Name, remotebuild.ContextContainerName, contextReader)
+	contextReader.Close()
+
+	if err != nil {
+		c.writeStatus(safeRW, nil, fmt.Errorf("could not upload build context: %v", err))
+		return
+	}
+
+	logWriter, logDone := newRemoteBuildLogWriter(safeRW)
+
+	err = agent.FollowJobLogs(namespace, name, remotebuild.BuildContainerName, 10*time.Minute, logWriter)
+	logWriter.Close()
+	<-logDone
+
+	if err != nil {
+		c.writeStatus(safeRW, nil, err)
+		return
+	}
+
+	job, err = agent.WaitForJobCompletion(namespace, name, time.Hour)
+
+	c.writeStatus(safeRW, job, err)
+}
+
+func (c *StreamRemoteBuildHandler) writeStatus(
+	safeRW *websocket.WebsocketSafeReadWriter,
+	job *batchv1.Job,
+	err error,
+) {
+	msg := &types.RemoteBuildMessage{
+		Type:   types.RemoteBuildMessageStatus,
+		Status: types.RemoteBuildStatusFailed,
+	}
+
+	if err != nil {
+		msg.Error = err.Error()
+	} else {
+		for _, cond := range job.Status.Conditions {
+			if cond.Type == batchv1.JobComplete {
+				msg.Status = types.RemoteBuildStatusSucceeded
+			} else if cond.Type == batchv1.JobFailed {
+				msg.Error = cond.Message
+			}
+		}
+	}
+
+	data, err := json.Marshal(msg)
+
+	if err != nil {
+		return
+	}
+
+	safeRW.Write(data)
+}
+
+// newRemoteBuildLogWriter returns a writer which sends each line written to it as a log
+// message, and a channel which is closed once all lines have been sent
+func newRemoteBuildLogWriter(safeRW *websocket.WebsocketSafeReadWriter) (*io.PipeWriter, <-chan struct{}) {
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+		for scanner.Scan() {
+			data, err := json.Marshal(&types.RemoteBuildMessage{
+				Type: types.RemoteBuildMessageLog,
+				Log:  scanner.Text(),
+			})
+
+			if err != nil {
+				continue
+			}
+
+			safeRW.Write(data)
+		}
+
+		// unblock the writer if scanning stopped early on a line which is too long
+		io.Copy(io.Discard, reader)
+	}()
+
+	return writer, done
+}
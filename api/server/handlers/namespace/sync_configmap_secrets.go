@@ -0,0 +1,88 @@
+package namespace
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type SyncConfigMapSecretsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewSyncConfigMapSecretsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SyncConfigMapSecretsHandler {
+	return &SyncConfigMapSecretsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *SyncConfigMapSecretsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.SyncEnvGroupSecretsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := syncEnvGroupSecrets(c.Config(), agent, cluster, namespace, request.Name, nil)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, types.SyncEnvGroupSecretsResponse(res))
+}
+
+// syncEnvGroupSecrets saves the given secret references of an env group, and syncs the
+// values of all secret references of the env group from the external secret stores
+func syncEnvGroupSecrets(
+	config *config.Config,
+	agent *kubernetes.Agent,
+	cluster *models.Cluster,
+	namespace, name string,
+	refs map[string]*types.EnvGroupSecretReference,
+) ([]*types.EnvGroupSecretStatus, error) {
+	if err := envgroup.SaveSecretRefs(config.Repo, cluster, namespace, name, refs); err != nil {
+		return nil, err
+	}
+
+	resolver := envgroup.NewSecretResolver(config.Repo, config.SecretVault)
+
+	synced, err := envgroup.SyncSecretRefs(config.Repo, resolver, agent, cluster, namespace, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*types.EnvGroupSecretStatus, 0)
+
+	for _, ref := range synced {
+		res = append(res, ref.ToEnvGroupSecretStatusType())
+	}
+
+	return res, nil
+}
@@ -0,0 +1,114 @@
+package namespace
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type UnlinkConfigMapReleaseHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewUnlinkConfigMapReleaseHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UnlinkConfigMapReleaseHandler {
+	return &UnlinkConfigMapReleaseHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *UnlinkConfigMapReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.UnlinkEnvGroupReleaseRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	link, err := c.Repo().EnvGroupRelease().ReadEnvGroupRelease(
+		cluster.ProjectID,
+		cluster.ID,
+		namespace,
+		request.Name,
+		request.ReleaseName,
+	)
+
+	if err == gorm.ErrRecordNotFound {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("release %s is not linked to env group %s", request.ReleaseName, request.Name),
+			http.StatusNotFound,
+		))
+
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	rel, err := helmAgent.GetRelease(request.ReleaseName, 0, false)
+
+	if err == nil {
+		// releases which reference the secrets of the env group are linked again whenever the
+		// linked releases are listed, so they cannot be unlinked
+		if envgroup.ReferencesSecrets(rel.Config, request.Name) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("release %s references secrets of env group %s and cannot be unlinked", request.ReleaseName, request.Name),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		// the env group is no longer recorded as synced, so that the release is not linked again
+		if envgroup.RemoveSyncedEnvGroup(rel.Config, request.Name) {
+			registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+			if err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+
+			_, err = helmAgent.UpgradeReleaseByValues(&helm.UpgradeReleaseConfig{
+				Name:       rel.Name,
+				Cluster:    cluster,
+				Repo:       c.Repo(),
+				Registries: registries,
+				Values:     rel.Config,
+			}, c.Config().DOConf)
+
+			if err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+		}
+	}
+
+	if err := c.Repo().EnvGroupRelease().DeleteEnvGroupRelease(link); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+}
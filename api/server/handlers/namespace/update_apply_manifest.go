@@ -0,0 +1,83 @@
+package namespace
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type UpdateApplyManifestHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdateApplyManifestHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateApplyManifestHandler {
+	return &UpdateApplyManifestHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *UpdateApplyManifestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.UpdateApplyManifestRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	manifest, err := c.Repo().ApplyManifest().ReadApplyManifest(cluster.ProjectID, cluster.ID, namespace, request.Name)
+	isNew := errors.Is(err, gorm.ErrRecordNotFound)
+
+	if isNew {
+		manifest = &models.ApplyManifest{
+			ProjectID: cluster.ProjectID,
+			ClusterID: cluster.ID,
+			Namespace: namespace,
+			Name:      request.Name,
+		}
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if request.Resources == nil {
+		request.Resources = []*types.ApplyManifestResource{}
+	}
+
+	if err := manifest.SetResources(request.Resources); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if isNew {
+		manifest, err = c.Repo().ApplyManifest().CreateApplyManifest(manifest)
+	} else {
+		manifest, err = c.Repo().ApplyManifest().UpdateApplyManifest(manifest)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := manifest.ToApplyManifestType()
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, (*types.UpdateApplyManifestResponse)(res))
+}
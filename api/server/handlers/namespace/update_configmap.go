@@ -1,7 +1,6 @@
 package namespace
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/authz"
@@ -10,6 +9,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -36,9 +36,15 @@ func (c *UpdateConfigMapHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	namespace := r.Context().Value(types.NamespaceScope).(string)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
+	if err := envgroup.ValidateSecretRefs(cluster.ProjectID, request.SecretReferences); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
 	agent, err := c.GetAgent(r, cluster, "")
 
 	if err != nil {
@@ -46,6 +52,49 @@ func (c *UpdateConfigMapHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// env groups created before versions were stored get their current state recorded,
+	// so that this update can be rolled back
+	if err := envgroup.RecordInitialVersion(c.Repo(), agent, cluster, namespace, request.Name); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	previous, err := c.Repo().EnvGroupVersion().ReadLatestEnvGroupVersion(cluster.ProjectID, cluster.ID, namespace, request.Name)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if request.Variables == nil {
+		request.Variables = make(map[string]string)
+	}
+
+	if request.SecretVariables == nil {
+		request.SecretVariables = make(map[string]string)
+	}
+
+	// secret variables which are set directly no longer reference an external secret store
+	literalKeys := make([]string, 0)
+
+	for key := range request.SecretVariables {
+		literalKeys = append(literalKeys, key)
+	}
+
+	if len(literalKeys) > 0 {
+		if err := envgroup.DeleteSecretRefs(c.Repo(), cluster, namespace, request.Name, literalKeys...); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	// removed secret references also remove the variable
+	for key, ref := range request.SecretReferences {
+		if _, found := request.SecretVariables[key]; ref == nil && !found {
+			request.SecretVariables[key] = ""
+		}
+	}
+
 	secretData := encodeSecrets(request.SecretVariables)
 
 	// create secret first
@@ -62,15 +111,51 @@ func (c *UpdateConfigMapHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		if _, found := request.Variables[key]; val == "" && !found {
 			request.Variables[key] = ""
 		} else if val != "" {
-			request.Variables[key] = fmt.Sprintf("PORTERSECRET_%s", request.Name)
+			request.Variables[key] = envgroup.SecretReference(request.Name)
 		}
 	}
 
 	configMap, err := agent.UpdateConfigMap(request.Name, namespace, request.Variables)
 
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	res := types.UpdateConfigMapResponse{
 		ConfigMap: configMap,
 	}
 
+	if len(request.SecretReferences) > 0 {
+		res.SecretStatus, err = syncEnvGroupSecrets(c.Config(), agent, cluster, namespace, request.Name, request.SecretReferences)
+
+		if err == nil {
+			res.ConfigMap, err = agent.GetConfigMap(request.Name, namespace)
+		}
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	current, err := envgroup.RecordVersion(c.Repo(), agent, cluster, namespace, request.Name, user.Email)
+
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		c.WriteResult(w, r, res)
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, namespace)
+
+	if err == nil {
+		res.Redeploys, err = redeployUpdatedEnvGroup(c.Config(), helmAgent, cluster, previous, current)
+	}
+
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+
 	c.WriteResult(w, r, res)
 }
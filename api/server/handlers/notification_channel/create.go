@@ -0,0 +1,88 @@
+package notification_channel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type NotificationChannelCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewNotificationChannelCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *NotificationChannelCreateHandler {
+	return &NotificationChannelCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *NotificationChannelCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateNotificationChannelRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	releaseID, ok := getReleaseID(p, w, r)
+
+	if !ok {
+		return
+	}
+
+	conf := &types.NotificationChannelConfig{
+		URL:    request.URL,
+		Secret: request.Secret,
+		SMTP:   request.SMTP,
+	}
+
+	confBytes, err := json.Marshal(conf)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	channel := &models.NotificationChannel{
+		ProjectID: proj.ID,
+		ReleaseID: releaseID,
+		Name:      request.Name,
+		Kind:      request.Kind,
+		Config:    confBytes,
+	}
+
+	channel.SetEvents(request.Events)
+
+	// construct the notifier to validate the kind-specific configuration
+	if _, err := p.Config().NotificationRegistry.NewNotifier(channel, conf); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("invalid notification channel: %w", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	channel, err = p.Repo().NotificationChannel().CreateNotificationChannel(channel)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := types.CreateNotificationChannelResponse(*channel.ToNotificationChannelType())
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,101 @@
+package notification_channel_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/notification_channel"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestCreateNotificationChannelSuccessful(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/projects/1/notification_channels",
+		&types.CreateNotificationChannelRequest{
+			Name:   "alerts",
+			Kind:   types.NotificationChannelDiscord,
+			Events: []types.NotificationEventType{types.NotificationEventDeployFailure},
+			URL:    "https://discord.com/api/webhooks/1/abc",
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	handler := notification_channel.NewNotificationChannelCreateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config),
+		shared.NewDefaultResultWriter(config),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	channel, err := config.Repo.NotificationChannel().ReadNotificationChannel(proj.ID, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the channel config is stored, but never returned
+	expResp := types.CreateNotificationChannelResponse(*channel.ToNotificationChannelType())
+	gotResp := &types.CreateNotificationChannelResponse{}
+
+	apitest.AssertResponseExpected(t, rr, &expResp, gotResp)
+
+	if !channel.Routes(types.NotificationEventDeployFailure) || channel.Routes(types.NotificationEventDeploySuccess) {
+		t.Errorf("incorrect routing for events %s", channel.Events)
+	}
+}
+
+func TestCreateNotificationChannelMissingConfig(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/projects/1/notification_channels",
+		&types.CreateNotificationChannelRequest{
+			Name: "email",
+			Kind: types.NotificationChannelSMTP,
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	handler := notification_channel.NewNotificationChannelCreateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config),
+		shared.NewDefaultResultWriter(config),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	apitest.AssertResponseError(t, rr, http.StatusBadRequest, &types.ExternalError{
+		Error: "invalid notification channel: smtp notification channels require a host and at least one recipient",
+	})
+}
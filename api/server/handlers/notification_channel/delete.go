@@ -0,0 +1,58 @@
+package notification_channel
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type NotificationChannelDeleteHandler struct {
+	handlers.PorterHandler
+}
+
+func NewNotificationChannelDeleteHandler(
+	config *config.Config,
+) *NotificationChannelDeleteHandler {
+	return &NotificationChannelDeleteHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *NotificationChannelDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	channelID, reqErr := requestutils.GetURLParamUint(r, types.URLParamNotificationChannelID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	channel, err := p.Repo().NotificationChannel().ReadNotificationChannel(proj.ID, channelID)
+
+	if err == gorm.ErrRecordNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("notification channel %d not found in project %d", channelID, proj.ID),
+			http.StatusNotFound,
+		))
+
+		return
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := p.Repo().NotificationChannel().DeleteNotificationChannel(channel); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,56 @@
+package notification_channel
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type NotificationChannelListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewNotificationChannelListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *NotificationChannelListHandler {
+	return &NotificationChannelListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *NotificationChannelListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	releaseID, ok := getReleaseID(p, w, r)
+
+	if !ok {
+		return
+	}
+
+	channels, err := p.Repo().NotificationChannel().ListNotificationChannelsByProjectID(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListNotificationChannelsResponse, 0)
+
+	for _, channel := range channels {
+		// when listing the channels of a release, only project-wide channels and the
+		// channels of that release are returned
+		if releaseID != 0 && channel.ReleaseID != 0 && channel.ReleaseID != releaseID {
+			continue
+		}
+
+		res = append(res, channel.ToNotificationChannelType())
+	}
+
+	p.WriteResult(w, r, res)
+}
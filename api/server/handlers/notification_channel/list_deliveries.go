@@ -0,0 +1,68 @@
+package notification_channel
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type NotificationChannelListDeliveriesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewNotificationChannelListDeliveriesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *NotificationChannelListDeliveriesHandler {
+	return &NotificationChannelListDeliveriesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *NotificationChannelListDeliveriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	channelID, reqErr := requestutils.GetURLParamUint(r, types.URLParamNotificationChannelID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	channel, err := p.Repo().NotificationChannel().ReadNotificationChannel(proj.ID, channelID)
+
+	if err == gorm.ErrRecordNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("notification channel %d not found in project %d", channelID, proj.ID),
+			http.StatusNotFound,
+		))
+
+		return
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	deliveries, err := p.Repo().WebhookDelivery().ListWebhookDeliveriesByChannelID(proj.ID, channel.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListWebhookDeliveriesResponse, 0, len(deliveries))
+
+	for _, delivery := range deliveries {
+		res = append(res, delivery.ToWebhookDeliveryType())
+	}
+
+	p.WriteResult(w, r, res)
+}
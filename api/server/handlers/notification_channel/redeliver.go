@@ -0,0 +1,120 @@
+package notification_channel
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"gorm.io/gorm"
+)
+
+type WebhookRedeliverHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewWebhookRedeliverHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *WebhookRedeliverHandler {
+	return &WebhookRedeliverHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP sends a stored delivery to its webhook again, with the same delivery id and
+// payload. Deliveries which are being attempted cannot be redelivered, and return a
+// conflict. The delivery is returned before it is attempted, and the attempt is recorded
+// on the delivery whether or not it succeeds.
+func (p *WebhookRedeliverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	channelID, reqErr := requestutils.GetURLParamUint(r, types.URLParamNotificationChannelID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	deliveryID, reqErr := requestutils.GetURLParamUint(r, types.URLParamWebhookDeliveryID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	channel, err := p.Repo().NotificationChannel().ReadNotificationChannel(proj.ID, channelID)
+
+	if err == gorm.ErrRecordNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("notification channel %d not found in project %d", channelID, proj.ID),
+			http.StatusNotFound,
+		))
+
+		return
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if channel.Kind != types.NotificationChannelWebhook {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("notification channel %d is not a webhook", channelID),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	delivery, err := p.Repo().WebhookDelivery().ReadWebhookDelivery(proj.ID, deliveryID)
+
+	if (err == nil && delivery.NotificationChannelID != channel.ID) || err == gorm.ErrRecordNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("delivery %d not found for notification channel %d", deliveryID, channelID),
+			http.StatusNotFound,
+		))
+
+		return
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	conf, err := channel.GetConfig()
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the delivery is claimed, so that it is not sent again while it is being retried or
+	// redelivered
+	claimed, err := webhook.Claim(p.Repo(), delivery)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if !claimed {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("delivery %d is already being attempted", deliveryID),
+			http.StatusConflict,
+		))
+
+		return
+	}
+
+	res := types.RedeliverWebhookResponse(*delivery.ToWebhookDeliveryType())
+
+	// the delivery is attempted in the background, so that a slow endpoint does not block
+	// the request. The attempt is recorded on the delivery, which can be listed to get
+	// its result.
+	go webhook.NewDeliverer(p.Repo(), conf).Attempt(delivery)
+
+	p.WriteResult(w, r, res)
+}
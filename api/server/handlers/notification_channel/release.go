@@ -0,0 +1,39 @@
+package notification_channel
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// getReleaseID returns the id of the release referenced by the `name` url parameter,
+// or 0 if the request is not scoped to a release. It writes an error and returns false
+// if the release cannot be read.
+func getReleaseID(p handlers.PorterHandler, w http.ResponseWriter, r *http.Request) (uint, bool) {
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+
+	if reqErr != nil {
+		// project-scoped requests do not have a release name
+		return 0, true
+	}
+
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	namespace, _ := r.Context().Value(types.NamespaceScope).(string)
+
+	release, err := p.Repo().Release().ReadRelease(cluster.ID, name, namespace)
+
+	if err == gorm.ErrRecordNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		return 0, false
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return 0, false
+	}
+
+	return release.ID, true
+}
@@ -0,0 +1,65 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type CustomRoleDeleteHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewCustomRoleDeleteHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CustomRoleDeleteHandler {
+	return &CustomRoleDeleteHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *CustomRoleDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	customRole, ok := readCustomRole(p, w, r, proj.ID)
+
+	if !ok {
+		return
+	}
+
+	// a custom role cannot be deleted while collaborators are still assigned to it,
+	// since those collaborators would lose access to the project
+	roles, err := p.Repo().Project().ListProjectRoles(proj.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, role := range roles {
+		if role.Kind == types.RoleCustom && role.CustomRoleID == customRole.ID {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("custom role %s is still assigned to user %d", customRole.Name, role.UserID),
+				http.StatusPreconditionFailed,
+			))
+
+			return
+		}
+	}
+
+	customRole, err = p.Repo().CustomRole().DeleteCustomRole(customRole)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, customRole.ToCustomRoleType())
+}
@@ -0,0 +1,72 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type CustomRoleGetHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewCustomRoleGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *CustomRoleGetHandler {
+	return &CustomRoleGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *CustomRoleGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	customRole, ok := readCustomRole(p, w, r, proj.ID)
+
+	if !ok {
+		return
+	}
+
+	p.WriteResult(w, r, types.GetCustomRoleResponse(*customRole.ToCustomRoleType()))
+}
+
+// readCustomRole reads the custom role referenced by the `custom_role_id` url parameter,
+// writing an error and returning false if the role cannot be read
+func readCustomRole(
+	p handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+	projectID uint,
+) (*models.CustomRole, bool) {
+	roleID, reqErr := requestutils.GetURLParamUint(r, types.URLParamCustomRoleID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return nil, false
+	}
+
+	customRole, err := p.Repo().CustomRole().ReadCustomRole(projectID, roleID)
+
+	if err == gorm.ErrRecordNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("custom role %d not found in project %d", roleID, projectID),
+			http.StatusNotFound,
+		))
+
+		return nil, false
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, false
+	}
+
+	return customRole, true
+}
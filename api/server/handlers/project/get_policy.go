@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/authz/policy"
-	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -30,12 +29,13 @@ func (p *ProjectGetPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 	user, _ := r.Context().Value(types.UserScope).(*models.User)
 	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 
-	policyDocLoader := policy.NewBasicPolicyDocumentLoader(p.Config().Repo.Project())
+	policyDocLoader := policy.NewCustomRolePolicyDocumentLoader(p.Repo().Project(), p.Repo().CustomRole())
 
-	policyDocs, err := policyDocLoader.LoadPolicyDocuments(user.ID, proj.ID)
+	policyDocs, reqErr := policyDocLoader.LoadPolicyDocuments(user.ID, proj.ID)
 
-	if err != nil {
-		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
 	}
 
 	var res types.GetProjectPolicyResponse = policyDocs
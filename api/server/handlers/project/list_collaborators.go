@@ -54,11 +54,12 @@ func (p *CollaboratorsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 
 	for _, user := range users {
 		res = append(res, &types.Collaborator{
-			ID:        roleMap[user.ID].ID,
-			Kind:      string(roleMap[user.ID].Kind),
-			UserID:    roleMap[user.ID].UserID,
-			Email:     user.Email,
-			ProjectID: roleMap[user.ID].ProjectID,
+			ID:           roleMap[user.ID].ID,
+			Kind:         string(roleMap[user.ID].Kind),
+			CustomRoleID: roleMap[user.ID].CustomRoleID,
+			UserID:       roleMap[user.ID].UserID,
+			Email:        user.Email,
+			ProjectID:    roleMap[user.ID].ProjectID,
 		})
 	}
 
@@ -0,0 +1,89 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type CustomRoleUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCustomRoleUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CustomRoleUpdateHandler {
+	return &CustomRoleUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *CustomRoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	customRole, ok := readCustomRole(p, w, r, proj.ID)
+
+	if !ok {
+		return
+	}
+
+	request := &types.UpdateCustomRoleRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Name != "" && request.Name != customRole.Name {
+		_, err := p.Repo().CustomRole().ReadCustomRoleByName(proj.ID, request.Name)
+
+		if err == nil {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("custom role %s already exists in project %d", request.Name, proj.ID),
+				http.StatusConflict,
+			))
+
+			return
+		} else if err != gorm.ErrRecordNotFound {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		customRole.Name = request.Name
+	}
+
+	if len(request.Policy) > 0 {
+		if err := policy.ValidatePolicy(request.Policy); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		policyBytes, err := json.Marshal(request.Policy)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		customRole.PolicyBytes = policyBytes
+	}
+
+	customRole, err := p.Repo().CustomRole().UpdateCustomRole(customRole)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, types.UpdateCustomRoleResponse(*customRole.ToCustomRoleType()))
+}
@@ -1,6 +1,7 @@
 package project
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -9,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
 )
 
 type RoleUpdateHandler struct {
@@ -43,7 +45,36 @@ func (p *RoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	role.Kind = types.RoleKind(request.Kind)
+	switch kind := types.RoleKind(request.Kind); kind {
+	case types.RoleAdmin, types.RoleDeveloper, types.RoleViewer:
+		role.Kind = kind
+		role.CustomRoleID = 0
+	case types.RoleCustom:
+		// custom roles must reference a custom role defined in this project
+		_, err := p.Repo().CustomRole().ReadCustomRole(proj.ID, request.CustomRoleID)
+
+		if err == gorm.ErrRecordNotFound {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("custom role %d not found in project %d", request.CustomRoleID, proj.ID),
+				http.StatusBadRequest,
+			))
+
+			return
+		} else if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		role.Kind = kind
+		role.CustomRoleID = request.CustomRoleID
+	default:
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("unsupported role kind %s", request.Kind),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
 
 	role, err = p.Repo().Project().UpdateProjectRole(proj.ID, role)
 
@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+type RegistryCreateRetentionPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewRegistryCreateRetentionPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryCreateRetentionPolicyHandler {
+	return &RegistryCreateRetentionPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *RegistryCreateRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	request := &types.CreateRegistryRetentionPolicyRequest{}
+
+	ok := p.DecodeAndValidate(w, r, request)
+
+	if !ok {
+		return
+	}
+
+	if reg.AWSIntegrationID == 0 && reg.GCPIntegrationID == 0 && reg.DOIntegrationID == 0 {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			registry.ErrManifestsNotSupported,
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	policy := &models.RegistryRetentionPolicy{
+		ProjectID:               reg.ProjectID,
+		RegistryID:              reg.ID,
+		RepositoryName:          strings.Trim(request.RepositoryName, "/"),
+		KeepLastTags:            request.KeepLastTags,
+		DeleteUntaggedAfterDays: request.DeleteUntaggedAfterDays,
+		ProtectLiveReleases:     request.ProtectLiveReleases == nil || *request.ProtectLiveReleases,
+		DryRun:                  request.DryRun == nil || *request.DryRun,
+	}
+
+	if err := validateRetentionPolicy(policy); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	policy, err := p.Repo().RegistryRetentionPolicy().CreateRegistryRetentionPolicy(policy)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, policy.ToRegistryRetentionPolicyType())
+}
+
+// validateRetentionPolicy checks that a retention policy deletes images with at least
+// one of its rules
+func validateRetentionPolicy(policy *models.RegistryRetentionPolicy) error {
+	if policy.KeepLastTags == 0 && policy.DeleteUntaggedAfterDays == 0 {
+		return fmt.Errorf("invalid retention policy: keep_last_tags or delete_untagged_after_days must be set")
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package registry
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+)
+
+type RegistryDeleteRetentionPolicyHandler struct {
+	handlers.PorterHandler
+}
+
+func NewRegistryDeleteRetentionPolicyHandler(
+	config *config.Config,
+) *RegistryDeleteRetentionPolicyHandler {
+	return &RegistryDeleteRetentionPolicyHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *RegistryDeleteRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	policy, ok := readRetentionPolicy(p, w, r)
+
+	if !ok {
+		return
+	}
+
+	if err := p.Repo().RegistryRetentionPolicy().DeleteRegistryRetentionPolicy(policy); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
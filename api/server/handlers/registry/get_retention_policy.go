@@ -0,0 +1,82 @@
+package registry
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type RegistryGetRetentionPolicyHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewRegistryGetRetentionPolicyHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RegistryGetRetentionPolicyHandler {
+	return &RegistryGetRetentionPolicyHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *RegistryGetRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	policy, ok := readRetentionPolicy(p, w, r)
+
+	if !ok {
+		return
+	}
+
+	res := policy.ToRegistryRetentionPolicyType()
+
+	report, err := policy.GetLastReport()
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res.LastReport = report
+
+	p.WriteResult(w, r, res)
+}
+
+// readRetentionPolicy reads the retention policy in the URL of the request, and writes
+// an error if it is not a policy of the registry in scope
+func readRetentionPolicy(
+	p handlers.PorterHandler,
+	w http.ResponseWriter,
+	r *http.Request,
+) (*models.RegistryRetentionPolicy, bool) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	policyID, reqErr := requestutils.GetURLParamUint(r, types.URLParamRetentionPolicyID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return nil, false
+	}
+
+	policy, err := p.Repo().RegistryRetentionPolicy().ReadRegistryRetentionPolicy(reg.ProjectID, reg.ID, policyID)
+
+	if err == gorm.ErrRecordNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("retention policy %d not found in registry %d", policyID, reg.ID),
+			http.StatusNotFound,
+		))
+
+		return nil, false
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return nil, false
+	}
+
+	return policy, true
+}
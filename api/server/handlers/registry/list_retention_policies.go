@@ -0,0 +1,44 @@
+package registry
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type RegistryListRetentionPoliciesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewRegistryListRetentionPoliciesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RegistryListRetentionPoliciesHandler {
+	return &RegistryListRetentionPoliciesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *RegistryListRetentionPoliciesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reg, _ := r.Context().Value(types.RegistryScope).(*models.Registry)
+
+	policies, err := p.Repo().RegistryRetentionPolicy().ListRegistryRetentionPolicies(reg.ProjectID, reg.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListRegistryRetentionPoliciesResponse, 0, len(policies))
+
+	for _, policy := range policies {
+		res = append(res, policy.ToRegistryRetentionPolicyType())
+	}
+
+	p.WriteResult(w, r, res)
+}
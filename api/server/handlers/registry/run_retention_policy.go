@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/registry/retention"
+)
+
+// RegistryRunRetentionPolicyHandler enforces a retention policy immediately. Unlike the
+// scheduled runs, images are deleted even if the policy is a dry run, unless the request
+// is a dry run, so that a policy can be enforced once its report has been reviewed.
+type RegistryRunRetentionPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewRegistryRunRetentionPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryRunRetentionPolicyHandler {
+	return &RegistryRunRetentionPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *RegistryRunRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	policy, ok := readRetentionPolicy(p, w, r)
+
+	if !ok {
+		return
+	}
+
+	request := &types.RunRegistryRetentionPolicyRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	enforcer := &retention.Enforcer{
+		Repo:   p.Repo(),
+		DOConf: p.Config().DOConf,
+		Logger: p.Config().Logger,
+	}
+
+	report, err := enforcer.Enforce(policy, request.DryRun)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, report)
+}
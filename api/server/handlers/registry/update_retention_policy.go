@@ -0,0 +1,69 @@
+package registry
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+)
+
+type RegistryUpdateRetentionPolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewRegistryUpdateRetentionPolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RegistryUpdateRetentionPolicyHandler {
+	return &RegistryUpdateRetentionPolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *RegistryUpdateRetentionPolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	policy, ok := readRetentionPolicy(p, w, r)
+
+	if !ok {
+		return
+	}
+
+	request := &types.UpdateRegistryRetentionPolicyRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.KeepLastTags != nil {
+		policy.KeepLastTags = *request.KeepLastTags
+	}
+
+	if request.DeleteUntaggedAfterDays != nil {
+		policy.DeleteUntaggedAfterDays = *request.DeleteUntaggedAfterDays
+	}
+
+	if request.ProtectLiveReleases != nil {
+		policy.ProtectLiveReleases = *request.ProtectLiveReleases
+	}
+
+	if request.DryRun != nil {
+		policy.DryRun = *request.DryRun
+	}
+
+	if err := validateRetentionPolicy(policy); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	policy, err := p.Repo().RegistryRetentionPolicy().UpdateRegistryRetentionPolicy(policy)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, policy.ToRegistryRetentionPolicyType())
+}
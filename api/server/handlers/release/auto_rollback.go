@@ -0,0 +1,110 @@
+package release
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// watchForAutoRollback waits in the background for the controllers of an upgraded
+// release to become ready, if automatic rollbacks are enabled for the release. If the
+// controllers are not ready within the configured window, the release is rolled back
+// to the last revision which was deployed successfully, and a notification is sent
+// with the reason. Since the upgrade has already succeeded, errors are only logged.
+func watchForAutoRollback(
+	config *config.Config,
+	helmAgent *helm.Agent,
+	cluster *models.Cluster,
+	rel *models.Release,
+	helmRelease *release.Release,
+	projNotifier notifier.Notifier,
+	notifyOpts notifier.Event,
+) {
+	if rel == nil || rel.AutoRollbackConfig == 0 || helmRelease.Version <= 1 {
+		return
+	}
+
+	rollbackConf, err := config.Repo.AutoRollbackConfig().ReadAutoRollbackConfig(rel.AutoRollbackConfig)
+
+	if err != nil {
+		config.Logger.Error().Err(err).
+			Str("release", helmRelease.Name).
+			Str("namespace", helmRelease.Namespace).
+			Uint("cluster_id", cluster.ID).
+			Msg("could not read auto rollback config, so the release is not watched")
+
+		return
+	}
+
+	if !rollbackConf.Enabled {
+		return
+	}
+
+	go func() {
+		readyErr := helmAgent.WaitForReleaseReady(helmRelease, rollbackConf.GetWindow())
+
+		if readyErr == nil {
+			return
+		}
+
+		// if the release was upgraded again while waiting, the newer revision is left as-is
+		latest, err := helmAgent.GetRelease(helmRelease.Name, 0, false)
+
+		if err != nil || latest.Version != helmRelease.Version {
+			return
+		}
+
+		notifyOpts.Version = helmRelease.Version
+
+		history, err := helmAgent.GetReleaseHistory(helmRelease.Name)
+		prevVersion, found := getLastGoodRevision(history, helmRelease.Version)
+
+		if err != nil {
+			notifyOpts.Type = types.NotificationEventDeployFailure
+			notifyOpts.Info = fmt.Sprintf("%v. Automatic rollback failed, since the release history could not be read: %v", readyErr, err)
+		} else if !found {
+			notifyOpts.Type = types.NotificationEventDeployFailure
+			notifyOpts.Info = fmt.Sprintf("%v. No previous version was deployed successfully, so the release was not rolled back.", readyErr)
+		} else if err := helmAgent.RollbackRelease(helmRelease.Name, prevVersion); err != nil {
+			notifyOpts.Type = types.NotificationEventDeployFailure
+			notifyOpts.Info = fmt.Sprintf("%v. Automatic rollback to version %d failed: %v", readyErr, prevVersion, err)
+		} else {
+			notifyOpts.Type = types.NotificationEventDeployRolledBack
+			notifyOpts.Info = fmt.Sprintf("%v. Rolled back to version %d.", readyErr, prevVersion)
+		}
+
+		config.Logger.Warn().
+			Str("release", helmRelease.Name).
+			Str("namespace", helmRelease.Namespace).
+			Uint("cluster_id", cluster.ID).
+			Msg(notifyOpts.Info)
+
+		if !cluster.NotificationsDisabled {
+			projNotifier.Notify(&notifyOpts)
+		}
+	}()
+}
+
+// getLastGoodRevision returns the most recent revision before the given version which
+// was deployed successfully. Revisions which failed or never finished deploying are
+// skipped.
+func getLastGoodRevision(history []*release.Release, version int) (int, bool) {
+	res := 0
+
+	for _, rev := range history {
+		if rev.Version >= version || rev.Version <= res || rev.Info == nil {
+			continue
+		}
+
+		if status := rev.Info.Status; status == release.StatusDeployed || status == release.StatusSuperseded {
+			res = rev.Version
+		}
+	}
+
+	return res, res != 0
+}
@@ -0,0 +1,191 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/canary"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type CanaryRolloutHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewCanaryRolloutHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CanaryRolloutHandler {
+	return &CanaryRolloutHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP starts a canary rollout of a web release in the background. The progress
+// of the rollout is reported as release steps, and its state is stored so that the
+// canary release is removed if the rollout is interrupted.
+func (c *CanaryRolloutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+
+	request := &types.CanaryRolloutRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if helmRelease.Chart == nil || helmRelease.Chart.Name() != "web" {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("canary rollouts are only supported for web releases"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	canary.SetDefaults(request)
+
+	if err := canary.ValidateSteps(request.Steps); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	values, err := chartutil.ReadValues([]byte(request.Values))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("Values could not be parsed: %v", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	rel, err := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("release %s is not managed by Porter", helmRelease.Name),
+				http.StatusNotFound,
+			))
+
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	metrics, err := canary.NewPrometheusQuerier(helmAgent.K8sAgent.Clientset)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	// only a single rollout can run at a time, since the canary release name is fixed
+	if reqErr := checkNoCanaryRollout(c.Config(), cluster, helmRelease.Name, helmRelease.Namespace); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if _, err := helmAgent.GetRelease(canary.GetCanaryName(helmRelease.Name), 0, false); err == nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("canary release %s already exists", canary.GetCanaryName(helmRelease.Name)),
+			http.StatusConflict,
+		))
+
+		return
+	}
+
+	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	state, err := c.Repo().CanaryRollout().CreateCanaryRollout(&models.CanaryRollout{
+		ProjectID:           cluster.ProjectID,
+		ClusterID:           cluster.ID,
+		Namespace:           helmRelease.Namespace,
+		ReleaseName:         helmRelease.Name,
+		Status:              types.CanaryRolloutStatusInProgress,
+		StepIntervalSeconds: request.StepIntervalSeconds,
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	rollout := &canary.Rollout{
+		HelmAgent:  helmAgent,
+		Metrics:    metrics,
+		Reporter:   canary.NewReleaseStepReporter(c.Repo(), rel),
+		Release:    helmRelease,
+		Values:     values,
+		Cluster:    cluster,
+		Repo:       c.Repo(),
+		Registries: registries,
+		DOAuth:     c.Config().DOConf,
+		Opts:       request,
+		State:      state,
+	}
+
+	// the rollout outlives the request, so its result is logged and stored rather than
+	// written to the response
+	go func() {
+		if err := rollout.Run(); err != nil {
+			c.Config().Logger.Error().Err(err).
+				Uint("cluster_id", cluster.ID).
+				Str("namespace", helmRelease.Namespace).
+				Str("release", helmRelease.Name).
+				Msg("canary rollout failed")
+		}
+	}()
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// checkNoCanaryRollout returns a conflict error if a canary rollout of the release is in
+// progress, since the rollout promotes its own values when it completes
+func checkNoCanaryRollout(
+	config *config.Config,
+	cluster *models.Cluster,
+	name, namespace string,
+) apierrors.RequestError {
+	_, err := config.Repo.CanaryRollout().ReadInProgressCanaryRollout(cluster.ID, namespace, name)
+
+	if err == nil {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("a canary rollout is in progress for %s", name),
+			http.StatusConflict,
+		)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return apierrors.NewErrInternal(err)
+	}
+
+	return nil
+}
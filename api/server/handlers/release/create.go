@@ -8,12 +8,12 @@ import (
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/api_token"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/analytics"
-	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/helm/loader"
 	"github.com/porter-dev/porter/internal/integrations/ci/actions"
@@ -102,6 +102,12 @@ func (c *CreateReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	helmRelease, err := helmAgent.InstallChart(conf, c.Config().DOConf)
 
 	if err != nil {
+		event := getReleaseEvent(c.Config(), cluster, request.Name, namespace)
+		event.Type = types.NotificationEventDeployFailure
+		event.Info = err.Error()
+
+		notifyReleaseEvent(c.Config(), cluster, nil, event)
+
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
 			fmt.Errorf("error installing a new chart: %s", err.Error()),
 			http.StatusBadRequest,
@@ -117,6 +123,12 @@ func (c *CreateReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	event := getReleaseEvent(c.Config(), cluster, helmRelease.Name, helmRelease.Namespace)
+	event.Type = types.NotificationEventReleaseCreated
+	event.Version = helmRelease.Version
+
+	notifyReleaseEvent(c.Config(), cluster, release, event)
+
 	if request.GithubActionConfig != nil {
 		_, _, err := createGitAction(
 			c.Config(),
@@ -232,17 +244,25 @@ func createGitAction(
 		return nil, nil, fmt.Errorf("invalid formatting of repo name")
 	}
 
-	// generate porter jwt token
-	jwt, err := token.GetTokenForAPI(userID, projectID)
+	// generate a porter token which can only access the cluster of the release. A dry run
+	// only renders the workflow, so no token is issued for it.
+	var apiToken *models.APIToken
+	var encoded string
 
-	if err != nil {
-		return nil, nil, err
-	}
+	if release != nil {
+		var err error
 
-	encoded, err := jwt.EncodeToken(config.TokenConf)
+		apiToken, encoded, err = api_token.CreateClusterScopedToken(
+			config,
+			userID,
+			projectID,
+			clusterID,
+			fmt.Sprintf("github-actions-%s-%s", request.GitRepo, name),
+		)
 
-	if err != nil {
-		return nil, nil, err
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	// create the commit in the git repo
@@ -295,6 +315,7 @@ func createGitAction(
 		FolderPath:     request.FolderPath,
 		IsInstallation: true,
 		Version:        "v0.1.0",
+		APITokenID:     apiToken.UniqueID,
 	})
 
 	if err != nil {
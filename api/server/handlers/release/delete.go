@@ -5,6 +5,7 @@ import (
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/api_token"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
@@ -50,6 +51,12 @@ func (c *DeleteReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 
 	rel, releaseErr := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
 
+	event := getReleaseEvent(c.Config(), cluster, helmRelease.Name, helmRelease.Namespace)
+	event.Type = types.NotificationEventReleaseDeleted
+	event.Version = helmRelease.Version
+
+	notifyReleaseEvent(c.Config(), cluster, rel, event)
+
 	// update the github actions env if the release exists and is built from source
 	if cName := helmRelease.Chart.Metadata.Name; cName == "job" || cName == "web" || cName == "worker" {
 		if releaseErr == nil && rel != nil {
@@ -79,6 +86,14 @@ func (c *DeleteReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 					c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 					return
 				}
+
+				// revoke the token issued to the action
+				err = api_token.RevokeScopedToken(c.Config(), cluster.ProjectID, gitAction.APITokenID)
+
+				if err != nil {
+					c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+					return
+				}
 			}
 		}
 	}
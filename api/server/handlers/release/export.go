@@ -0,0 +1,87 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/export"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type ExportReleaseHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewExportReleaseHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ExportReleaseHandler {
+	return &ExportReleaseHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP exports a release as a porter.yaml resource group, so that releases which
+// were created in the dashboard can be managed with `porter apply`
+func (c *ExportReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	opts := &export.ReleaseOpts{
+		Release: helmRelease,
+	}
+
+	rel, err := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
+
+	if err == nil {
+		if rel.GitActionConfig != nil {
+			opts.GitActionConfig = rel.GitActionConfig.ToGitActionConfigType()
+		}
+
+		if rel.BuildConfig != 0 {
+			bc, err := c.Repo().BuildConfig().GetBuildConfig(rel.BuildConfig)
+
+			if err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+
+			opts.BuildConfig = bc.ToBuildConfigType()
+		}
+	} else if err != gorm.ErrRecordNotFound {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if helmRelease.Chart != nil && helmRelease.Chart.Metadata != nil {
+		cache := c.Config().URLCache
+		chartRepoURL, foundFirst := cache.GetURL(helmRelease.Chart.Metadata.Name)
+
+		if !foundFirst {
+			cache.Update()
+
+			chartRepoURL, _ = cache.GetURL(helmRelease.Chart.Metadata.Name)
+		}
+
+		opts.RepoURL = chartRepoURL
+		opts.IsApplication = chartRepoURL != "" && chartRepoURL == c.Config().ServerConf.DefaultApplicationHelmRepoURL
+	}
+
+	resource, err := export.Resource(opts)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	c.WriteResult(w, r, &types.ExportReleaseResponse{
+		Version:   export.ResourceGroupVersion,
+		Resources: []*types.PorterYAMLResource{resource},
+	})
+}
@@ -0,0 +1,65 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type GetAutoRollbackHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewGetAutoRollbackHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetAutoRollbackHandler {
+	return &GetAutoRollbackHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *GetAutoRollbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+
+	release, err := c.Repo().Release().ReadRelease(cluster.ID, name, namespace)
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.GetAutoRollbackConfigResponse{
+		AutoRollbackConfig: &types.AutoRollbackConfig{
+			Enabled:       false,
+			WindowSeconds: models.DefaultAutoRollbackWindowSeconds,
+		},
+	}
+
+	if release.AutoRollbackConfig != 0 {
+		rollbackConfig, err := c.Repo().AutoRollbackConfig().ReadAutoRollbackConfig(release.AutoRollbackConfig)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		res.AutoRollbackConfig = rollbackConfig.ToAutoRollbackConfigType()
+	}
+
+	c.WriteResult(w, r, res)
+}
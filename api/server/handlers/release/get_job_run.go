@@ -0,0 +1,61 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type GetJobRunHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewGetJobRunHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetJobRunHandler {
+	return &GetJobRunHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *GetJobRunHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	runID, reqErr := requestutils.GetURLParamUint(r, types.URLParamJobRunID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	run, err := c.Repo().JobRun().ReadJobRun(cluster.ProjectID, cluster.ID, runID)
+
+	if (err == nil && (run.Namespace != helmRelease.Namespace || run.ReleaseName != helmRelease.Name)) ||
+		errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("run %d not found for job %s", runID, helmRelease.Name),
+			http.StatusNotFound,
+		))
+
+		return
+	} else if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := types.GetJobRunResponse(*run.ToJobRunType(true))
+
+	c.WriteResult(w, r, res)
+}
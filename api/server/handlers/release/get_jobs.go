@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/jobrun"
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/models"
 	"helm.sh/helm/v3/pkg/release"
@@ -47,6 +48,12 @@ func (c *GetJobsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// record the jobs so that their history is kept after they are deleted. Failing to
+	// record the jobs should not prevent the live jobs from being returned.
+	if err := jobrun.RecordAll(c.Repo(), agent, cluster.ProjectID, cluster.ID, jobs); err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+
 	c.WriteResult(w, r, jobs)
 }
 
@@ -0,0 +1,76 @@
+package release
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// getReleaseNotifier returns the notifier for events of a release, which applies the
+// notification config of the release if it exists. The release may be nil if it was
+// not deployed through Porter.
+func getReleaseNotifier(config *config.Config, cluster *models.Cluster, rel *models.Release) (notifier.Notifier, error) {
+	opts := &notifier.ProjectNotifierOpts{
+		ProjectID: cluster.ProjectID,
+	}
+
+	if rel != nil {
+		opts.ReleaseID = rel.ID
+
+		if rel.NotificationConfig != 0 {
+			conf, err := config.Repo.NotificationConfig().ReadNotificationConfig(rel.NotificationConfig)
+
+			if err != nil {
+				return nil, err
+			}
+
+			opts.Config = conf.ToNotificationConfigType()
+		}
+	}
+
+	return notifier.NewProjectNotifier(config.NotificationRegistry, config.Repo, opts)
+}
+
+// getReleaseEvent returns an event for a release, without the event type set
+func getReleaseEvent(config *config.Config, cluster *models.Cluster, name, namespace string) *notifier.Event {
+	return &notifier.Event{
+		ProjectID:   cluster.ProjectID,
+		ClusterID:   cluster.ID,
+		ClusterName: cluster.Name,
+		Name:        name,
+		Namespace:   namespace,
+		URL: fmt.Sprintf(
+			"%s/applications/%s/%s/%s?project_id=%d",
+			config.ServerConf.ServerURL,
+			url.PathEscape(cluster.Name),
+			namespace,
+			name,
+			cluster.ProjectID,
+		),
+	}
+}
+
+// notifyReleaseEvent sends an event for a release, unless notifications are disabled
+// for the cluster. Notification failures are logged, but do not fail the request.
+func notifyReleaseEvent(config *config.Config, cluster *models.Cluster, rel *models.Release, event *notifier.Event) {
+	if cluster.NotificationsDisabled {
+		return
+	}
+
+	releaseNotifier, err := getReleaseNotifier(config, cluster, rel)
+
+	if err == nil {
+		err = releaseNotifier.Notify(event)
+	}
+
+	if err != nil {
+		config.Logger.Warn().Err(err).
+			Str("event", string(event.Type)).
+			Str("release", event.Name).
+			Str("namespace", event.Namespace).
+			Msg("could not send release notification")
+	}
+}
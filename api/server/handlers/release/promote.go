@@ -0,0 +1,241 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/promote"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+type PromoteReleaseHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewPromoteReleaseHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *PromoteReleaseHandler {
+	return &PromoteReleaseHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP upgrades the same-named release in the target namespace to the chart and
+// values of this release, or installs it if it does not exist. The chart is taken from
+// the stored release, so the target runs the exact chart version of the source.
+func (c *PromoteReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+
+	request := &types.PromoteReleaseRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	targetCluster := cluster
+
+	if request.TargetClusterID != 0 && request.TargetClusterID != cluster.ID {
+		var err error
+
+		targetCluster, err = c.Repo().Cluster().ReadCluster(cluster.ProjectID, request.TargetClusterID)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("target cluster %d not found in project %d", request.TargetClusterID, cluster.ProjectID),
+					http.StatusNotFound,
+				))
+
+				return
+			}
+
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	if targetCluster.ID == cluster.ID && request.TargetNamespace == helmRelease.Namespace {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("cannot promote release %s to its own namespace", helmRelease.Name),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	// the policy middleware only checks the source release, so the target release is
+	// checked separately
+	reqErr := authz.CheckScopeAccess(c.Config(), r, cluster.ProjectID, map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope: {
+			Verb:     types.APIVerbUpdate,
+			Resource: types.NameOrUInt{UInt: cluster.ProjectID},
+		},
+		types.ClusterScope: {
+			Verb:     types.APIVerbUpdate,
+			Resource: types.NameOrUInt{UInt: targetCluster.ID},
+		},
+		types.NamespaceScope: {
+			Verb:     types.APIVerbUpdate,
+			Resource: types.NameOrUInt{Name: request.TargetNamespace},
+		},
+		types.ReleaseScope: {
+			Verb:     types.APIVerbUpdate,
+			Resource: types.NameOrUInt{Name: helmRelease.Name},
+		},
+	})
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	overrides, err := chartutil.ReadValues([]byte(request.Values))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("Values could not be parsed: %v", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	values := promote.Values(helmRelease.Config, &promote.Options{
+		ImageTag:  request.ImageTag,
+		Overrides: overrides,
+		EnvGroups: request.EnvGroups,
+	})
+
+	// a release with a canary rollout in progress is only updated by the rollout
+	if reqErr := checkNoCanaryRollout(c.Config(), targetCluster, helmRelease.Name, request.TargetNamespace); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	targetAgent, err := c.GetHelmAgent(r, targetCluster, request.TargetNamespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// secrets of env groups are injected from the linked secrets in the target namespace,
+	// so the env groups must exist before the release is deployed
+	for _, name := range envgroup.ReferencedEnvGroups(values) {
+		if _, err := targetAgent.K8sAgent.GetConfigMap(name, request.TargetNamespace); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("env group %s not found in namespace %s of the target cluster", name, request.TargetNamespace),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
+	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.PromoteReleaseResponse{
+		Name:      helmRelease.Name,
+		ClusterID: targetCluster.ID,
+		Namespace: request.TargetNamespace,
+		ImageTag:  promote.ImageTag(values),
+	}
+
+	if helmRelease.Chart != nil && helmRelease.Chart.Metadata != nil {
+		res.Chart = helmRelease.Chart.Metadata.Name
+		res.ChartVersion = helmRelease.Chart.Metadata.Version
+	}
+
+	var targetRelease *release.Release
+
+	_, err = targetAgent.GetRelease(helmRelease.Name, 0, false)
+
+	switch {
+	case err == nil:
+		targetRelease, err = targetAgent.UpgradeReleaseByValues(&helm.UpgradeReleaseConfig{
+			Name:       helmRelease.Name,
+			Values:     values,
+			Cluster:    targetCluster,
+			Repo:       c.Repo(),
+			Registries: registries,
+			Chart:      helmRelease.Chart,
+		}, c.Config().DOConf)
+	case errors.Is(err, driver.ErrReleaseNotFound):
+		res.Created = true
+
+		targetRelease, err = targetAgent.InstallChart(&helm.InstallChartConfig{
+			Chart:      helmRelease.Chart,
+			Name:       helmRelease.Name,
+			Namespace:  request.TargetNamespace,
+			Values:     values,
+			Cluster:    targetCluster,
+			Repo:       c.Repo(),
+			Registries: registries,
+		}, c.Config().DOConf)
+	default:
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err != nil {
+		event := getReleaseEvent(c.Config(), targetCluster, helmRelease.Name, request.TargetNamespace)
+		event.Type = types.NotificationEventDeployFailure
+		event.Info = err.Error()
+
+		notifyReleaseEvent(c.Config(), targetCluster, nil, event)
+
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("error promoting release: %s", err.Error()),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	res.Version = targetRelease.Version
+
+	// only releases with an image are tracked by Porter, matching release creation
+	var rel *models.Release
+
+	if res.Created && res.ImageTag != "" {
+		rel, err = createReleaseFromHelmRelease(c.Config(), targetCluster.ProjectID, targetCluster.ID, targetRelease)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	} else if !res.Created {
+		rel, _ = c.Repo().Release().ReadRelease(targetCluster.ID, targetRelease.Name, targetRelease.Namespace)
+	}
+
+	event := getReleaseEvent(c.Config(), targetCluster, targetRelease.Name, targetRelease.Namespace)
+	event.Type = types.NotificationEventDeploySuccess
+	event.Version = targetRelease.Version
+
+	notifyReleaseEvent(c.Config(), targetCluster, rel, event)
+
+	c.WriteResult(w, r, res)
+}
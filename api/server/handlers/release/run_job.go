@@ -0,0 +1,153 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/jobrun"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"helm.sh/helm/v3/pkg/release"
+
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+type RunJobHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewRunJobHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RunJobHandler {
+	return &RunJobHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *RunJobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.RunJobRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	template, err := getJobTemplate(agent, helmRelease)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if template == nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("release %s does not contain a job or cron job", helmRelease.Name),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	suffix, err := repository.GenerateRandomBytes(3)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// job names are used as pod labels, which are limited to 63 characters
+	jobName := helmRelease.Name
+
+	if len(jobName) > 52 {
+		jobName = strings.TrimSuffix(jobName[:52], "-")
+	}
+
+	labels := make(map[string]string)
+
+	for _, label := range getJobLabels(helmRelease) {
+		labels[label.Key] = label.Val
+	}
+
+	job := kubernetes.NewJobFromTemplate(helmRelease.Namespace, template, &kubernetes.JobRunOpts{
+		Name:   fmt.Sprintf("%s-run-%s", jobName, suffix),
+		Labels: labels,
+		Annotations: map[string]string{
+			jobrun.TriggeredByAnnotation: user.Email,
+		},
+		Command: request.Command,
+		Env:     request.Env,
+	})
+
+	job, err = agent.CreateJob(helmRelease.Namespace, job)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if _, err := jobrun.Record(c.Repo(), agent, cluster.ProjectID, cluster.ID, job); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, job)
+}
+
+// getJobTemplate returns the job template of the cron job or job in a release. If the
+// release does not contain either, a nil template is returned.
+func getJobTemplate(agent *kubernetes.Agent, helmRelease *release.Release) (*batchv1.JobTemplateSpec, error) {
+	yamlArr := grapher.ImportMultiDocYAML([]byte(helmRelease.Manifest))
+	controllers := grapher.ParseControllers(yamlArr)
+
+	for _, controller := range controllers {
+		controller.Namespace = helmRelease.Namespace
+
+		switch controller.Kind {
+		case "CronJob":
+			cronJob, err := agent.GetCronJob(controller)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &batchv1.JobTemplateSpec{
+				ObjectMeta: cronJob.Spec.JobTemplate.ObjectMeta,
+				Spec:       cronJob.Spec.JobTemplate.Spec,
+			}, nil
+		case "Job":
+			job, err := agent.GetJob(controller)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return &batchv1.JobTemplateSpec{
+				ObjectMeta: job.ObjectMeta,
+				Spec:       job.Spec,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
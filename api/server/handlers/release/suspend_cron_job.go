@@ -0,0 +1,80 @@
+package release
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type SuspendCronJobHandler struct {
+	handlers.PorterHandlerReader
+	authz.KubernetesAgentGetter
+}
+
+func NewSuspendCronJobHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+) *SuspendCronJobHandler {
+	return &SuspendCronJobHandler{
+		PorterHandlerReader:   handlers.NewDefaultPorterHandler(config, decoderValidator, nil),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP suspends or resumes the schedule of every cron job in a release. Since the
+// suspended state is not stored in the release values, the next upgrade of the release
+// may reset it.
+func (c *SuspendCronJobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.SuspendCronJobRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	yamlArr := grapher.ImportMultiDocYAML([]byte(helmRelease.Manifest))
+	controllers := grapher.ParseControllers(yamlArr)
+	numCronJobs := 0
+
+	for _, controller := range controllers {
+		if controller.Kind != "CronJob" {
+			continue
+		}
+
+		if _, err := agent.SetCronJobSuspend(helmRelease.Namespace, controller.Name, request.Suspend); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		numCronJobs++
+	}
+
+	if numCronJobs == 0 {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("release %s does not run on a schedule", helmRelease.Name),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
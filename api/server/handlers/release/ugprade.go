@@ -3,7 +3,6 @@ package release
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 
 	semver "github.com/Masterminds/semver/v3"
 
@@ -13,10 +12,12 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/helm/loader"
-	"github.com/porter-dev/porter/internal/integrations/slack"
 	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/release"
 )
 
@@ -45,6 +46,11 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
 
+	if reqErr := checkNoCanaryRollout(c.Config(), cluster, helmRelease.Name, helmRelease.Namespace); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	helmAgent, err := c.GetHelmAgent(r, cluster, "")
 
 	if err != nil {
@@ -74,91 +80,56 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 
 	// if the chart version is set, load a chart from the repo
 	if request.ChartVersion != "" {
-		cache := c.Config().URLCache
-		chartRepoURL, foundFirst := cache.GetURL(helmRelease.Chart.Metadata.Name)
-
-		if !foundFirst {
-			cache.Update()
-
-			var found bool
-
-			chartRepoURL, found = cache.GetURL(helmRelease.Chart.Metadata.Name)
-
-			if !found {
-				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-					fmt.Errorf("chart not found"),
-					http.StatusBadRequest,
-				))
+		chart, reqErr := loadUpgradeChart(c.Config(), helmRelease, request.ChartVersion)
 
-				return
-			}
+		if reqErr != nil {
+			c.HandleAPIError(w, r, reqErr)
+			return
 		}
 
-		chart, err := loader.LoadChartPublic(
-			chartRepoURL,
-			helmRelease.Chart.Metadata.Name,
-			request.ChartVersion,
-		)
+		conf.Chart = chart
+	}
 
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-				fmt.Errorf("chart not found"),
-				http.StatusBadRequest,
-			))
+	values, err := chartutil.ReadValues([]byte(request.Values))
 
-			return
-		}
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("values could not be parsed: %v", err),
+			http.StatusBadRequest,
+		))
 
-		conf.Chart = chart
+		return
 	}
 
-	newHelmRelease, upgradeErr := helmAgent.UpgradeRelease(conf, request.Values, c.Config().DOConf)
+	// clients only record the env groups which they merge into the values, so the env
+	// groups which the release already consumes are kept
+	envgroup.KeepSyncedEnvGroups(helmRelease.Config, values)
+
+	conf.Values = values
+
+	newHelmRelease, upgradeErr := helmAgent.UpgradeReleaseByValues(conf, c.Config().DOConf)
 
 	if upgradeErr == nil && newHelmRelease != nil {
 		helmRelease = newHelmRelease
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
-
 	rel, releaseErr := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
 
-	var notifConf *types.NotificationConfig
-	notifConf = nil
-	if rel != nil && rel.NotificationConfig != 0 {
-		conf, err := c.Repo().NotificationConfig().ReadNotificationConfig(rel.NotificationConfig)
+	projNotifier, err := getReleaseNotifier(c.Config(), cluster, rel)
 
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
-
-		notifConf = conf.ToNotificationConfigType()
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
 	}
 
-	notifier := slack.NewSlackNotifier(notifConf, slackInts...)
-
-	notifyOpts := &slack.NotifyOpts{
-		ProjectID:   cluster.ProjectID,
-		ClusterID:   cluster.ID,
-		ClusterName: cluster.Name,
-		Name:        helmRelease.Name,
-		Namespace:   helmRelease.Namespace,
-		URL: fmt.Sprintf(
-			"%s/applications/%s/%s/%s?project_id=%d",
-			c.Config().ServerConf.ServerURL,
-			url.PathEscape(cluster.Name),
-			helmRelease.Namespace,
-			helmRelease.Name,
-			cluster.ProjectID,
-		),
-	}
+	notifyOpts := getReleaseEvent(c.Config(), cluster, helmRelease.Name, helmRelease.Namespace)
 
 	if upgradeErr != nil {
-		notifyOpts.Status = slack.StatusHelmFailed
+		notifyOpts.Type = types.NotificationEventDeployFailure
 		notifyOpts.Info = upgradeErr.Error()
 
 		if !cluster.NotificationsDisabled {
-			notifier.Notify(notifyOpts)
+			projNotifier.Notify(notifyOpts)
 		}
 
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
@@ -170,11 +141,15 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	}
 
 	if helmRelease.Chart != nil && helmRelease.Chart.Metadata.Name != "job" {
-		notifyOpts.Status = slack.StatusHelmDeployed
+		notifyOpts.Type = types.NotificationEventDeploySuccess
 		notifyOpts.Version = helmRelease.Version
 
 		if !cluster.NotificationsDisabled {
-			notifier.Notify(notifyOpts)
+			projNotifier.Notify(notifyOpts)
+		}
+
+		if releaseErr == nil {
+			watchForAutoRollback(c.Config(), helmAgent, cluster, rel, helmRelease, projNotifier, *notifyOpts)
 		}
 	}
 
@@ -225,3 +200,44 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		}
 	}
 }
+
+// loadUpgradeChart loads the chart for a release at a specific version from the
+// public chart repositories
+func loadUpgradeChart(
+	config *config.Config,
+	helmRelease *release.Release,
+	version string,
+) (*chart.Chart, apierrors.RequestError) {
+	cache := config.URLCache
+	chartRepoURL, foundFirst := cache.GetURL(helmRelease.Chart.Metadata.Name)
+
+	if !foundFirst {
+		cache.Update()
+
+		var found bool
+
+		chartRepoURL, found = cache.GetURL(helmRelease.Chart.Metadata.Name)
+
+		if !found {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("chart not found"),
+				http.StatusBadRequest,
+			)
+		}
+	}
+
+	ch, err := loader.LoadChartPublic(
+		chartRepoURL,
+		helmRelease.Chart.Metadata.Name,
+		version,
+	)
+
+	if err != nil {
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("chart not found"),
+			http.StatusBadRequest,
+		)
+	}
+
+	return ch, nil
+}
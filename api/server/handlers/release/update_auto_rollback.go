@@ -0,0 +1,89 @@
+package release
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type UpdateAutoRollbackHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdateAutoRollbackHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateAutoRollbackHandler {
+	return &UpdateAutoRollbackHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *UpdateAutoRollbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	name, _ := requestutils.GetURLParamString(r, types.URLParamReleaseName)
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+
+	request := &types.UpdateAutoRollbackConfigRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	release, err := c.Repo().Release().ReadRelease(cluster.ID, name, namespace)
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	windowSeconds := request.WindowSeconds
+
+	if windowSeconds == 0 {
+		windowSeconds = models.DefaultAutoRollbackWindowSeconds
+	}
+
+	// either create a new auto-rollback config or update the current one
+	newConfig := &models.AutoRollbackConfig{
+		Enabled:       request.Enabled,
+		WindowSeconds: windowSeconds,
+	}
+
+	if release.AutoRollbackConfig == 0 {
+		newConfig, err = c.Repo().AutoRollbackConfig().CreateAutoRollbackConfig(newConfig)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		release.AutoRollbackConfig = newConfig.ID
+
+		release, err = c.Repo().Release().UpdateRelease(release)
+	} else {
+		newConfig.ID = release.AutoRollbackConfig
+		newConfig, err = c.Repo().AutoRollbackConfig().UpdateAutoRollbackConfig(newConfig)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.GetAutoRollbackConfigResponse{
+		AutoRollbackConfig: newConfig.ToAutoRollbackConfigType(),
+	})
+}
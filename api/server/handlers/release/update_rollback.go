@@ -51,7 +51,16 @@ func (c *RollbackReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 
 	err = helmAgent.RollbackRelease(helmRelease.Name, request.Revision)
 
+	rel, _ := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
+
+	event := getReleaseEvent(c.Config(), cluster, helmRelease.Name, helmRelease.Namespace)
+
 	if err != nil {
+		event.Type = types.NotificationEventDeployFailure
+		event.Info = fmt.Sprintf("error rolling back to revision %d: %s", request.Revision, err.Error())
+
+		notifyReleaseEvent(c.Config(), cluster, rel, event)
+
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
 			fmt.Errorf("error rolling back release: %s", err.Error()),
 			http.StatusBadRequest,
@@ -60,11 +69,14 @@ func (c *RollbackReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	event.Type = types.NotificationEventReleaseRolledBack
+	event.Info = fmt.Sprintf("Rolled back from version %d to revision %d.", helmRelease.Version, request.Revision)
+
+	notifyReleaseEvent(c.Config(), cluster, rel, event)
+
 	// update the github actions env if the release exists and is built from source
 	if cName := helmRelease.Chart.Metadata.Name; cName == "job" || cName == "web" || cName == "worker" {
-		rel, err := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
-
-		if err == nil && rel != nil {
+		if rel != nil {
 			err = updateReleaseRepo(c.Config(), rel, helmRelease)
 
 			if err != nil {
@@ -3,7 +3,6 @@ package release
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -14,7 +13,6 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/helm"
-	"github.com/porter-dev/porter/internal/integrations/slack"
 	"gorm.io/gorm"
 )
 
@@ -71,6 +69,11 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if reqErr := checkNoCanaryRollout(c.Config(), cluster, release.Name, release.Namespace); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	// in this case, we retrieve the agent by passing in the namespace field directly, since
 	// it cannot be detected from the URL
 	helmAgent, err := c.GetHelmAgent(r, cluster, release.Namespace)
@@ -133,47 +136,23 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Values:     rel.Config,
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(release.ProjectID)
-
-	var notifConf *types.NotificationConfig
-	notifConf = nil
-	if release != nil && release.NotificationConfig != 0 {
-		conf, err := c.Repo().NotificationConfig().ReadNotificationConfig(release.NotificationConfig)
-
-		if err != nil {
-			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
-		}
+	projNotifier, err := getReleaseNotifier(c.Config(), cluster, release)
 
-		notifConf = conf.ToNotificationConfigType()
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
 	}
 
-	notifier := slack.NewSlackNotifier(notifConf, slackInts...)
-
-	notifyOpts := &slack.NotifyOpts{
-		ProjectID:   release.ProjectID,
-		ClusterID:   cluster.ID,
-		ClusterName: cluster.Name,
-		Name:        rel.Name,
-		Namespace:   rel.Namespace,
-		URL: fmt.Sprintf(
-			"%s/applications/%s/%s/%s?project_id=%d",
-			c.Config().ServerConf.ServerURL,
-			url.PathEscape(cluster.Name),
-			release.Namespace,
-			rel.Name,
-			cluster.ProjectID,
-		),
-	}
+	notifyOpts := getReleaseEvent(c.Config(), cluster, rel.Name, rel.Namespace)
 
 	rel, err = helmAgent.UpgradeReleaseByValues(conf, c.Config().DOConf)
 
 	if err != nil {
-		notifyOpts.Status = slack.StatusHelmFailed
+		notifyOpts.Type = types.NotificationEventDeployFailure
 		notifyOpts.Info = err.Error()
 
 		if !cluster.NotificationsDisabled {
-			notifier.Notify(notifyOpts)
+			projNotifier.Notify(notifyOpts)
 		}
 
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
@@ -185,12 +164,14 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if rel.Chart != nil && rel.Chart.Metadata.Name != "job" {
-		notifyOpts.Status = slack.StatusHelmDeployed
+		notifyOpts.Type = types.NotificationEventDeploySuccess
 		notifyOpts.Version = rel.Version
 
 		if !cluster.NotificationsDisabled {
-			notifier.Notify(notifyOpts)
+			projNotifier.Notify(notifyOpts)
 		}
+
+		watchForAutoRollback(c.Config(), helmAgent, cluster, release, rel, projNotifier, *notifyOpts)
 	}
 
 	c.Config().AnalyticsClient.Track(analytics.ApplicationDeploymentWebhookTrack(&analytics.ApplicationDeploymentWebhookTrackOpts{
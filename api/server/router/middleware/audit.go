@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/audit"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// maxAuditBodySize is the maximum number of bytes of the request body that are read
+// to generate the request summary
+const maxAuditBodySize = 64 * 1024
+
+// AuditMiddleware records an audit event for every request to a mutating endpoint. It is
+// registered before the policy and scope middlewares, so that requests which they deny
+// are recorded as well.
+type AuditMiddleware struct {
+	config       *config.Config
+	endpointMeta *types.APIRequestMetadata
+}
+
+func NewAuditMiddleware(config *config.Config, endpointMeta *types.APIRequestMetadata) *AuditMiddleware {
+	return &AuditMiddleware{config, endpointMeta}
+}
+
+// auditEventKey is the context key of the audit event of a request, which is populated
+// with the scopes of the request by ScopesMiddleware
+type auditEventKey struct{}
+
+// ShouldAudit returns true if requests to the endpoint should be recorded in the
+// audit log, which is the case for all project-scoped endpoints that do not only read
+// data. Endpoints without a project scope are not recorded, since their events could
+// not be listed for any project.
+func ShouldAudit(endpointMeta *types.APIRequestMetadata) bool {
+	if endpointMeta.Verb == types.APIVerbGet || endpointMeta.Verb == types.APIVerbList {
+		return false
+	}
+
+	for _, scope := range endpointMeta.Scopes {
+		if scope == types.ProjectScope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (mw *AuditMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := &models.AuditEvent{
+			Verb:   string(mw.endpointMeta.Verb),
+			Method: r.Method,
+		}
+
+		if !mw.endpointMeta.IsWebsocket && r.Body != nil {
+			event.RequestSummary = mw.readSummary(r)
+		}
+
+		rw := newRequestLoggerResponseWriter(w)
+
+		next.ServeHTTP(rw, r.WithContext(context.WithValue(r.Context(), auditEventKey{}, event)))
+
+		// the route template is recorded instead of the request path, so that values
+		// in the path are only recorded through the scopes of the event
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			event.Path = rctx.RoutePattern()
+		}
+
+		event.Status = rw.statusCode
+
+		// if the request was denied before its scopes were read, the scopes are read from
+		// the url parameters instead, so that the event can be listed for the project
+		if event.ProjectID == 0 {
+			populateAuditEventParams(r, event)
+		}
+
+		if _, err := mw.config.Repo.AuditEvent().CreateAuditEvent(event); err != nil {
+			// failing to write the audit event should not change the response, which
+			// has already been written
+			apierrors.HandleAPIError(mw.config, w, r, apierrors.NewErrInternal(err), false)
+		}
+	})
+}
+
+// ScopesMiddleware populates the audit event of the request with the scopes which have
+// been read by the policy and scope middlewares, so it is registered after them
+func (mw *AuditMiddleware) ScopesMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if event, ok := r.Context().Value(auditEventKey{}).(*models.AuditEvent); ok {
+			populateAuditEventScopes(r, event)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readSummary reads the request body to generate a redacted summary, and resets
+// the request body so that it can be read by the handler
+func (mw *AuditMiddleware) readSummary(r *http.Request) []byte {
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxAuditBodySize))
+
+	// the remaining body, if any, is passed through to the handler unchanged
+	r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+	if err != nil || len(body) == maxAuditBodySize {
+		return nil
+	}
+
+	summary := audit.SummarizeRequestBody(body)
+
+	if summary == nil {
+		return nil
+	}
+
+	summaryBytes, err := json.Marshal(summary)
+
+	if err != nil {
+		return nil
+	}
+
+	return summaryBytes
+}
+
+func populateAuditEventParams(r *http.Request, event *models.AuditEvent) {
+	if user, ok := r.Context().Value(types.UserScope).(*models.User); ok && user != nil {
+		event.UserID = user.ID
+	}
+
+	if apiToken, ok := r.Context().Value(types.APITokenCtxKey).(*models.APIToken); ok && apiToken != nil {
+		event.APITokenID = apiToken.UniqueID
+	}
+
+	if projectID, err := strconv.ParseUint(chi.URLParam(r, string(types.URLParamProjectID)), 10, 64); err == nil {
+		event.ProjectID = uint(projectID)
+	}
+
+	if clusterID, err := strconv.ParseUint(chi.URLParam(r, string(types.URLParamClusterID)), 10, 64); err == nil {
+		event.ClusterID = uint(clusterID)
+	}
+}
+
+func populateAuditEventScopes(r *http.Request, event *models.AuditEvent) {
+	ctx := r.Context()
+
+	if user, ok := ctx.Value(types.UserScope).(*models.User); ok && user != nil {
+		event.UserID = user.ID
+	}
+
+	if apiToken, ok := ctx.Value(types.APITokenCtxKey).(*models.APIToken); ok && apiToken != nil {
+		event.APITokenID = apiToken.UniqueID
+	}
+
+	reqScopes, ok := ctx.Value(types.RequestScopeCtxKey).(map[types.PermissionScope]*types.RequestAction)
+
+	if !ok {
+		return
+	}
+
+	if scope, ok := reqScopes[types.ProjectScope]; ok {
+		event.ProjectID = scope.Resource.UInt
+	}
+
+	if scope, ok := reqScopes[types.ClusterScope]; ok {
+		event.ClusterID = scope.Resource.UInt
+	}
+
+	if scope, ok := reqScopes[types.NamespaceScope]; ok {
+		event.Namespace = scope.Resource.Name
+	}
+
+	if scope, ok := reqScopes[types.ReleaseScope]; ok {
+		event.ReleaseName = scope.Resource.Name
+	}
+}
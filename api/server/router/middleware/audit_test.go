@@ -0,0 +1,127 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/router/middleware"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// newAuditedRouter returns a router which audits and authorizes requests to update a
+// cluster in the same order as the API server
+func newAuditedRouter(config *config.Config) http.Handler {
+	endpointMeta := &types.APIRequestMetadata{
+		Verb:   types.APIVerbUpdate,
+		Method: types.HTTPVerbPost,
+		Scopes: []types.PermissionScope{
+			types.UserScope,
+			types.ProjectScope,
+			types.ClusterScope,
+		},
+	}
+
+	auditMw := middleware.NewAuditMiddleware(config, endpointMeta)
+	policyMw := authz.NewPolicyMiddleware(config, *endpointMeta, policy.NewBasicPolicyDocumentLoader(config.Repo.Project()))
+
+	r := chi.NewRouter()
+
+	r.With(auditMw.Middleware, policyMw.Middleware, auditMw.ScopesMiddleware).Post(
+		"/api/projects/{project_id}/clusters/{cluster_id}",
+		func(w http.ResponseWriter, r *http.Request) {},
+	)
+
+	return r
+}
+
+func TestAuditMiddlewareRecordsAllowedRequest(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+
+	_, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/clusters/2", nil)
+	req = apitest.WithAuthenticatedUser(t, req, user)
+
+	newAuditedRouter(config).ServeHTTP(rr, req)
+
+	events, _, err := config.Repo.AuditEvent().ListAuditEventsByProjectID(1, &types.ListAuditEventsRequest{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(events))
+	}
+
+	event := events[0]
+
+	if event.Status != http.StatusOK || event.UserID != user.ID || event.ClusterID != 2 {
+		t.Errorf("incorrect audit event: %+v", event)
+	}
+
+	if event.Path != "/api/projects/{project_id}/clusters/{cluster_id}" {
+		t.Errorf("expected the route template to be recorded, got %s", event.Path)
+	}
+}
+
+func TestAuditMiddlewareRecordsDeniedRequest(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	owner := apitest.CreateTestUser(t, config, true)
+
+	_, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, owner)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the user is not a collaborator of the project, so the request is denied
+	user, err := config.Repo.User().CreateUser(&models.User{
+		Email: "other@example.com",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/clusters/2", nil)
+	req = apitest.WithAuthenticatedUser(t, req, user)
+
+	newAuditedRouter(config).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+
+	events, _, err := config.Repo.AuditEvent().ListAuditEventsByProjectID(1, &types.ListAuditEventsRequest{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 audit event, got %d", len(events))
+	}
+
+	event := events[0]
+
+	if event.Status != http.StatusForbidden || event.UserID != user.ID || event.ClusterID != 2 {
+		t.Errorf("incorrect audit event: %+v", event)
+	}
+}
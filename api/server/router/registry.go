@@ -228,5 +228,175 @@ func getRegistryRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/registries/{registry_id}/retention_policies -> registry.NewRegistryListRetentionPoliciesHandler
+	listRetentionPoliciesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/retention_policies",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	listRetentionPoliciesHandler := registry.NewRegistryListRetentionPoliciesHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: listRetentionPoliciesEndpoint,
+		Handler:  listRetentionPoliciesHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/registries/{registry_id}/retention_policies -> registry.NewRegistryCreateRetentionPolicyHandler
+	createRetentionPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/retention_policies",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	createRetentionPolicyHandler := registry.NewRegistryCreateRetentionPolicyHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: createRetentionPolicyEndpoint,
+		Handler:  createRetentionPolicyHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/registries/{registry_id}/retention_policies/{retention_policy_id} -> registry.NewRegistryGetRetentionPolicyHandler
+	getRetentionPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/retention_policies/{retention_policy_id}",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	getRetentionPolicyHandler := registry.NewRegistryGetRetentionPolicyHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: getRetentionPolicyEndpoint,
+		Handler:  getRetentionPolicyHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/registries/{registry_id}/retention_policies/{retention_policy_id} -> registry.NewRegistryUpdateRetentionPolicyHandler
+	updateRetentionPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/retention_policies/{retention_policy_id}",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	updateRetentionPolicyHandler := registry.NewRegistryUpdateRetentionPolicyHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: updateRetentionPolicyEndpoint,
+		Handler:  updateRetentionPolicyHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/registries/{registry_id}/retention_policies/{retention_policy_id} -> registry.NewRegistryDeleteRetentionPolicyHandler
+	deleteRetentionPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/retention_policies/{retention_policy_id}",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	deleteRetentionPolicyHandler := registry.NewRegistryDeleteRetentionPolicyHandler(
+		config,
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: deleteRetentionPolicyEndpoint,
+		Handler:  deleteRetentionPolicyHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/registries/{registry_id}/retention_policies/{retention_policy_id}/run -> registry.NewRegistryRunRetentionPolicyHandler
+	runRetentionPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/retention_policies/{retention_policy_id}/run",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.RegistryScope,
+			},
+		},
+	)
+
+	runRetentionPolicyHandler := registry.NewRegistryRunRetentionPolicyHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &Route{
+		Endpoint: runRetentionPolicyEndpoint,
+		Handler:  runRetentionPolicyHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
@@ -36,6 +36,7 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 	projectIntegrationRegisterer := NewProjectIntegrationScopedRegisterer()
 	projectOAuthRegisterer := NewProjectOAuthScopedRegisterer()
 	slackIntegrationRegisterer := NewSlackIntegrationScopedRegisterer()
+	notificationChannelRegisterer := NewNotificationChannelScopedRegisterer()
 	projRegisterer := NewProjectScopedRegisterer(
 		clusterRegisterer,
 		registryRegisterer,
@@ -46,6 +47,7 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 		projectIntegrationRegisterer,
 		projectOAuthRegisterer,
 		slackIntegrationRegisterer,
+		notificationChannelRegisterer,
 	)
 
 	userRegisterer := NewUserScopedRegisterer(projRegisterer)
@@ -188,7 +190,7 @@ func registerRoutes(config *config.Config, routes []*Route) {
 	releaseFactory := authz.NewReleaseScopedFactory(config)
 
 	// Policy doc loader loads the policy documents for a specific project.
-	policyDocLoader := policy.NewBasicPolicyDocumentLoader(config.Repo.Project())
+	policyDocLoader := policy.NewCustomRolePolicyDocumentLoader(config.Repo.Project(), config.Repo.CustomRole())
 
 	// set up logging middleware to log information about the request
 	loggerMw := middleware.NewRequestLoggerMiddleware(config.Logger)
@@ -199,16 +201,25 @@ func registerRoutes(config *config.Config, routes []*Route) {
 	for _, route := range routes {
 		atomicGroup := route.Router.Group(nil)
 
+		var auditMw *middleware.AuditMiddleware
+
+		if middleware.ShouldAudit(route.Endpoint.Metadata) {
+			auditMw = middleware.NewAuditMiddleware(config, route.Endpoint.Metadata)
+		}
+
 		for _, scope := range route.Endpoint.Metadata.Scopes {
 			switch scope {
 			case types.UserScope:
-				// if the endpoint should redirect when authn fails, attach redirect handler
-				if route.Endpoint.Metadata.ShouldRedirect {
-					atomicGroup.Use(authNFactory.NewAuthenticatedWithRedirect)
-				} else {
-					atomicGroup.Use(authNFactory.NewAuthenticated)
-				}
+				// the authn handler redirects if the endpoint should redirect when authn fails, and
+				// rejects API tokens with a policy if the endpoint is not project-scoped
+				atomicGroup.Use(authNFactory.NewAuthenticatedForEndpoint(route.Endpoint.Metadata))
 			case types.ProjectScope:
+				// requests are audited before they are authorized, so that denied requests are
+				// recorded as well
+				if auditMw != nil {
+					atomicGroup.Use(auditMw.Middleware)
+				}
+
 				policyFactory := authz.NewPolicyMiddleware(config, *route.Endpoint.Metadata, policyDocLoader)
 
 				atomicGroup.Use(policyFactory.Middleware)
@@ -236,6 +247,10 @@ func registerRoutes(config *config.Config, routes []*Route) {
 			atomicGroup.Use(loggerMw.Middleware)
 		}
 
+		if auditMw != nil {
+			atomicGroup.Use(auditMw.ScopesMiddleware)
+		}
+
 		if route.Endpoint.Metadata.IsWebsocket {
 			atomicGroup.Use(websocketMw.Middleware)
 		}
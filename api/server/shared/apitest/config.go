@@ -11,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
 	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/notifier/channels"
 	"github.com/porter-dev/porter/internal/repository/test"
 )
 
@@ -45,20 +46,22 @@ func (t *TestConfigLoader) LoadConfig() (*config.Config, error) {
 	}
 
 	tokenConf := &token.TokenGeneratorConf{
-		TokenSecret: envConf.ServerConf.TokenGeneratorSecret,
+		TokenSecret:          envConf.ServerConf.TokenGeneratorSecret,
+		LegacyAPITokenCutoff: envConf.ServerConf.LegacyAPITokenCutoff,
 	}
 
 	notifier := NewFakeUserNotifier()
 
 	return &config.Config{
-		Logger:          l,
-		Repo:            repo,
-		Store:           store,
-		ServerConf:      envConf.ServerConf,
-		TokenConf:       tokenConf,
-		UserNotifier:    notifier,
-		AnalyticsClient: analytics.InitializeAnalyticsSegmentClient("", l),
-		BillingManager:  &billing.NoopBillingManager{},
+		Logger:               l,
+		Repo:                 repo,
+		Store:                store,
+		ServerConf:           envConf.ServerConf,
+		TokenConf:            tokenConf,
+		UserNotifier:         notifier,
+		NotificationRegistry: channels.NewDefaultRegistry(repo, l),
+		AnalyticsClient:      analytics.InitializeAnalyticsSegmentClient("", l),
+		BillingManager:       &billing.NoopBillingManager{},
 	}, nil
 }
 
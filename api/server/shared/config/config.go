@@ -8,6 +8,7 @@ import (
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
+	"github.com/porter-dev/porter/internal/envgroup"
 	"github.com/porter-dev/porter/internal/helm/urlcache"
 	"github.com/porter-dev/porter/internal/integrations/powerdns"
 	"github.com/porter-dev/porter/internal/kubernetes"
@@ -54,6 +55,10 @@ type Config struct {
 	// verification, etc)
 	UserNotifier notifier.UserNotifier
 
+	// NotificationRegistry creates the notifiers for project and release notification
+	// channels (Slack, webhooks, email, etc)
+	NotificationRegistry *notifier.Registry
+
 	// DOConf is the configuration for a DigitalOcean OAuth client
 	DOConf *oauth2.Config
 
@@ -97,6 +102,10 @@ type Config struct {
 	// CredentialBackend is the backend for credential storage, if external cred storage (like Vault)
 	// is used
 	CredentialBackend credentials.CredentialStorage
+
+	// SecretVault is the Vault server that env group secret references are read from,
+	// if the Porter instance is connected to Vault
+	SecretVault envgroup.VaultSecretReader
 }
 
 type ConfigLoader interface {
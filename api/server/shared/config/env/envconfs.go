@@ -58,6 +58,22 @@ type ServerConf struct {
 	SlackClientID     string `env:"SLACK_CLIENT_ID"`
 	SlackClientSecret string `env:"SLACK_CLIENT_SECRET"`
 
+	// EnvGroupSecretSyncInterval is how often env group secrets are synced from external
+	// secret stores. Syncing is disabled if it is 0.
+	EnvGroupSecretSyncInterval time.Duration `env:"ENV_GROUP_SECRET_SYNC_INTERVAL,default=5m"`
+
+	// LegacyAPITokenCutoff is when API tokens without a token id stop being accepted, in
+	// RFC 3339 format
+	LegacyAPITokenCutoff time.Time `env:"LEGACY_API_TOKEN_CUTOFF,default=2027-01-01T00:00:00Z"`
+
+	// RegistryRetentionInterval is how often the retention policies of registries are
+	// enforced. Enforcement on schedule is disabled if it is 0.
+	RegistryRetentionInterval time.Duration `env:"REGISTRY_RETENTION_INTERVAL,default=24h"`
+
+	// JobRunRecordInterval is how often the runs of the jobs of every cluster are
+	// recorded. Recording in the background is disabled if it is 0.
+	JobRunRecordInterval time.Duration `env:"JOB_RUN_RECORD_INTERVAL,default=5m"`
+
 	IronPlansAPIKey    string `env:"IRON_PLANS_API_KEY"`
 	IronPlansServerURL string `env:"IRON_PLANS_SERVER_URL"`
 	WhitelistedUsers   []uint `env:"WHITELISTED_USERS"`
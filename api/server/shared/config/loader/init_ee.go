@@ -41,10 +41,13 @@ func init() {
 	}
 
 	if InstanceEnvConf.DBConf.VaultAPIKey != "" && InstanceEnvConf.DBConf.VaultServerURL != "" && InstanceEnvConf.DBConf.VaultPrefix != "" {
-		InstanceCredentialBackend = vault.NewClient(
+		vaultClient := vault.NewClient(
 			InstanceEnvConf.DBConf.VaultServerURL,
 			InstanceEnvConf.DBConf.VaultAPIKey,
 			InstanceEnvConf.DBConf.VaultPrefix,
 		)
+
+		InstanceCredentialBackend = vaultClient
+		InstanceSecretVault = vaultClient
 	}
 }
@@ -16,11 +16,13 @@ import (
 	"github.com/porter-dev/porter/internal/auth/sessionstore"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
+	"github.com/porter-dev/porter/internal/envgroup"
 	"github.com/porter-dev/porter/internal/helm/urlcache"
 	"github.com/porter-dev/porter/internal/integrations/powerdns"
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/kubernetes/local"
 	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/channels"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
 	"github.com/porter-dev/porter/internal/oauth"
 	"github.com/porter-dev/porter/internal/repository/credentials"
@@ -35,6 +37,7 @@ var InstanceBillingManager billing.BillingManager
 var InstanceEnvConf *envloader.EnvConf
 var InstanceDB *pgorm.DB
 var InstanceCredentialBackend credentials.CredentialStorage
+var InstanceSecretVault envgroup.VaultSecretReader
 
 type EnvConfigLoader struct {
 	version string
@@ -68,6 +71,7 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		RedisConf:         envConf.RedisConf,
 		BillingManager:    InstanceBillingManager,
 		CredentialBackend: InstanceCredentialBackend,
+		SecretVault:       InstanceSecretVault,
 	}
 
 	res.Metadata = config.MetadataFromConf(envConf.ServerConf, e.version)
@@ -100,7 +104,8 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 	}
 
 	res.TokenConf = &token.TokenGeneratorConf{
-		TokenSecret: envConf.ServerConf.TokenGeneratorSecret,
+		TokenSecret:          envConf.ServerConf.TokenGeneratorSecret,
+		LegacyAPITokenCutoff: envConf.ServerConf.LegacyAPITokenCutoff,
 	}
 
 	res.UserNotifier = &notifier.EmptyUserNotifier{}
@@ -116,6 +121,8 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		})
 	}
 
+	res.NotificationRegistry = channels.NewDefaultRegistry(res.Repo, res.Logger)
+
 	res.Alerter = alerter.NoOpAlerter{}
 
 	if envConf.ServerConf.SentryDSN != "" {
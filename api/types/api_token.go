@@ -0,0 +1,47 @@
+package types
+
+import "time"
+
+const (
+	URLParamTokenID URLParam = "api_token_id"
+)
+
+// APITokenCtxKey is the context key for a stored API token which authenticated
+// the request, if one exists
+const APITokenCtxKey = "apitoken"
+
+type APIToken struct {
+	ID              string     `json:"id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ProjectID       uint       `json:"project_id"`
+	CreatedByUserID uint       `json:"created_by_user_id"`
+	Name            string     `json:"name"`
+	Expiry          *time.Time `json:"expiry,omitempty"`
+	LastUsed        *time.Time `json:"last_used,omitempty"`
+	Revoked         bool       `json:"revoked"`
+	Policy          Policy     `json:"policy,omitempty"`
+}
+
+type CreateAPITokenRequest struct {
+	Name string `json:"name" form:"required,max=255"`
+
+	// Expiry is an optional time after which the token is no longer valid
+	Expiry *time.Time `json:"expiry"`
+
+	// Policy optionally restricts the token further than the creating user's
+	// role. If empty, the token has the same permissions as the creating user.
+	Policy Policy `json:"policy"`
+}
+
+type CreateAPITokenResponse struct {
+	*APIToken
+
+	// Token is the encoded token, which is only returned on creation
+	Token string `json:"token"`
+}
+
+type ListAPITokensResponse []*APIToken
+
+type GetAPITokenResponse APIToken
+
+type RevokeAPITokenResponse APIToken
@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+// ApplyManifest is the set of releases which were deployed by the last apply of a
+// porter.yaml file
+type ApplyManifest struct {
+	ID        uint                     `json:"id"`
+	UpdatedAt time.Time                `json:"updated_at"`
+	Name      string                   `json:"name"`
+	Namespace string                   `json:"namespace"`
+	Resources []*ApplyManifestResource `json:"resources"`
+}
+
+// ApplyManifestResource is a release which was deployed by applying a porter.yaml file.
+// The release may be in another cluster or namespace than the manifest, if the target
+// of the resource sets one.
+type ApplyManifestResource struct {
+	Name      string `json:"name" form:"required"`
+	ClusterID uint   `json:"cluster_id" form:"required"`
+	Namespace string `json:"namespace" form:"required"`
+}
+
+type GetApplyManifestRequest struct {
+	Name string `schema:"name,required"`
+}
+
+type GetApplyManifestResponse ApplyManifest
+
+// UpdateApplyManifestRequest replaces the releases of an apply manifest, creating the
+// manifest if it does not exist
+type UpdateApplyManifestRequest struct {
+	Name      string                   `json:"name" form:"required"`
+	Resources []*ApplyManifestResource `json:"resources" form:"omitempty,dive,required"`
+}
+
+type UpdateApplyManifestResponse ApplyManifest
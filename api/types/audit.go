@@ -0,0 +1,59 @@
+package types
+
+import "time"
+
+// AuditEvent is a record of a mutating API call
+type AuditEvent struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ProjectID   uint   `json:"project_id"`
+	ClusterID   uint   `json:"cluster_id,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	ReleaseName string `json:"release_name,omitempty"`
+
+	// UserID is the user who performed the action. If the action was performed with
+	// a stored API token, APITokenID is the id of that token.
+	UserID     uint   `json:"user_id"`
+	APITokenID string `json:"api_token_id,omitempty"`
+
+	Verb   APIVerb `json:"verb"`
+	Method string  `json:"method"`
+
+	// Path is the route template of the request, without the values of its URL parameters
+	Path string `json:"path"`
+
+	// RequestSummary is the request body with sensitive and large values redacted
+	RequestSummary map[string]interface{} `json:"request_summary,omitempty"`
+
+	Status int `json:"status"`
+}
+
+// DefaultAuditEventsLimit is the number of audit events which are listed if no limit
+// is given
+const DefaultAuditEventsLimit = 50
+
+type ListAuditEventsRequest struct {
+	Limit int `schema:"limit"`
+	Skip  int `schema:"skip"`
+
+	UserID      uint    `schema:"user_id"`
+	APITokenID  string  `schema:"api_token_id"`
+	ClusterID   uint    `schema:"cluster_id"`
+	Namespace   string  `schema:"namespace"`
+	ReleaseName string  `schema:"release_name"`
+	Verb        APIVerb `schema:"verb"`
+
+	// StartTime and EndTime are unix timestamps which bound the creation time of
+	// the returned events
+	StartTime int64 `schema:"start_time"`
+	EndTime   int64 `schema:"end_time"`
+}
+
+type ListAuditEventsResponse struct {
+	Count int64 `json:"count"`
+	Limit int   `json:"limit"`
+	Skip  int   `json:"skip"`
+
+	AuditEvents []*AuditEvent `json:"audit_events"`
+}
@@ -0,0 +1,209 @@
+package types
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// EnvGroupVersion is a numbered snapshot of the variables of an env group, which is
+// stored every time the env group is changed. Secret values are never returned; each
+// secret variable maps to whether its value changed since the previous version instead.
+type EnvGroupVersion struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   uint   `json:"version"`
+
+	// Author is the email of the user who made the change, or empty if the version was
+	// recorded from an env group which existed before versions were stored
+	Author string `json:"author"`
+
+	Variables       map[string]string `json:"variables"`
+	SecretVariables map[string]bool   `json:"secret_variables"`
+}
+
+type ListEnvGroupVersionsRequest struct {
+	Name string `schema:"name,required"`
+}
+
+type ListEnvGroupVersionsResponse []*EnvGroupVersion
+
+type DiffEnvGroupVersionsRequest struct {
+	Name string `schema:"name,required"`
+	From uint   `schema:"from,required"`
+	To   uint   `schema:"to,required"`
+}
+
+// EnvGroupVariableDiff is the change to a single variable between two env group
+// versions. Values are only set for variables which are not secret.
+type EnvGroupVariableDiff struct {
+	Key    string            `json:"key"`
+	Secret bool              `json:"secret"`
+	Change ReleaseDiffChange `json:"change"`
+	Old    string            `json:"old,omitempty"`
+	New    string            `json:"new,omitempty"`
+}
+
+type DiffEnvGroupVersionsResponse struct {
+	From    uint                    `json:"from"`
+	To      uint                    `json:"to"`
+	Changes []*EnvGroupVariableDiff `json:"changes"`
+}
+
+type RollbackEnvGroupRequest struct {
+	Name    string `json:"name" form:"required"`
+	Version uint   `json:"version" form:"required"`
+}
+
+type RollbackEnvGroupResponse struct {
+	*v1.ConfigMap
+
+	// Version is the new version which was created by the rollback
+	Version *EnvGroupVersion `json:"version"`
+
+	// Redeploys contains the result of redeploying each release which consumes the
+	// env group
+	Redeploys []*EnvGroupRedeployResult `json:"redeploys"`
+}
+
+// EnvGroupRedeployPolicy determines what happens to a release which consumes an env
+// group when the env group is updated
+type EnvGroupRedeployPolicy string
+
+const (
+	// EnvGroupRedeployPolicyNone does not redeploy the release, but the release is still
+	// reported so that it can be redeployed manually
+	EnvGroupRedeployPolicyNone EnvGroupRedeployPolicy = "none"
+
+	// EnvGroupRedeployPolicyRestart performs a rolling restart of the controllers of the
+	// release, so that pods pick up new secret values
+	EnvGroupRedeployPolicyRestart EnvGroupRedeployPolicy = "restart"
+
+	// EnvGroupRedeployPolicyUpgrade upgrades the release with the new variables of the env
+	// group merged into its values
+	EnvGroupRedeployPolicyUpgrade EnvGroupRedeployPolicy = "upgrade"
+)
+
+// EnvGroupRelease is a release which consumes an env group
+type EnvGroupRelease struct {
+	ID             uint                   `json:"id"`
+	EnvGroupName   string                 `json:"env_group_name"`
+	Namespace      string                 `json:"namespace"`
+	ReleaseName    string                 `json:"release_name"`
+	RedeployPolicy EnvGroupRedeployPolicy `json:"redeploy_policy"`
+}
+
+type ListEnvGroupReleasesRequest struct {
+	Name string `schema:"name,required"`
+}
+
+type ListEnvGroupReleasesResponse []*EnvGroupRelease
+
+type LinkEnvGroupReleaseRequest struct {
+	Name        string `json:"name" form:"required"`
+	ReleaseName string `json:"release_name" form:"required"`
+
+	// RedeployPolicy defaults to "none"
+	RedeployPolicy EnvGroupRedeployPolicy `json:"redeploy_policy" form:"omitempty,oneof=none restart upgrade"`
+}
+
+type UnlinkEnvGroupReleaseRequest struct {
+	Name        string `schema:"name,required"`
+	ReleaseName string `schema:"release_name,required"`
+}
+
+type RedeployEnvGroupRequest struct {
+	Name     string                 `json:"name" form:"required"`
+	Strategy EnvGroupRedeployPolicy `json:"strategy" form:"required,oneof=restart upgrade"`
+
+	// ReleaseNames limits the redeploy to these releases. If empty, all releases which
+	// consume the env group are redeployed.
+	ReleaseNames []string `json:"release_names"`
+}
+
+type RedeployEnvGroupResponse []*EnvGroupRedeployResult
+
+type EnvGroupRedeployStatus string
+
+const (
+	EnvGroupRedeployStatusSucceeded EnvGroupRedeployStatus = "succeeded"
+	EnvGroupRedeployStatusFailed    EnvGroupRedeployStatus = "failed"
+
+	// EnvGroupRedeployStatusSkipped is set for releases with the "none" redeploy policy
+	EnvGroupRedeployStatusSkipped EnvGroupRedeployStatus = "skipped"
+
+	// EnvGroupRedeployStatusInProgress is set for releases which were still being
+	// redeployed when the response was sent. The redeploy continues in the background.
+	EnvGroupRedeployStatusInProgress EnvGroupRedeployStatus = "in_progress"
+)
+
+// EnvGroupRedeployResult is the result of redeploying a single release after an env
+// group was updated. Strategy is the strategy which was used, which is "upgrade" for
+// releases with the "restart" policy if variables which are not secret changed.
+type EnvGroupRedeployResult struct {
+	ReleaseName string                 `json:"release_name"`
+	Strategy    EnvGroupRedeployPolicy `json:"strategy"`
+	Status      EnvGroupRedeployStatus `json:"status"`
+	Error       string                 `json:"error,omitempty"`
+}
+
+// EnvGroupSecretBackend is an external secret store which secret variables of an env
+// group can reference
+type EnvGroupSecretBackend string
+
+const (
+	EnvGroupSecretBackendVault             EnvGroupSecretBackend = "vault"
+	EnvGroupSecretBackendAWSSecretsManager EnvGroupSecretBackend = "aws_secrets_manager"
+)
+
+// EnvGroupSecretReference points a secret variable of an env group at a value in an
+// external secret store. The value is synced into the linked secret of the env group.
+type EnvGroupSecretReference struct {
+	Backend EnvGroupSecretBackend `json:"backend" form:"required,oneof=vault aws_secrets_manager"`
+
+	// Path is the path of the secret in the KV secrets engine for Vault, relative to
+	// env_groups/<project_id>, or the name or ARN of the secret for AWS Secrets Manager
+	Path string `json:"path" form:"required"`
+
+	// Field is the key of the value within the secret. It is required for Vault, and
+	// optional for AWS Secrets Manager if the secret is stored as a plain string.
+	Field string `json:"field"`
+
+	// AWSIntegrationID is the AWS integration used to read from AWS Secrets Manager
+	AWSIntegrationID uint `json:"aws_integration_id"`
+}
+
+type EnvGroupSecretSyncStatus string
+
+const (
+	EnvGroupSecretSyncStatusPending EnvGroupSecretSyncStatus = "pending"
+	EnvGroupSecretSyncStatusSynced  EnvGroupSecretSyncStatus = "synced"
+	EnvGroupSecretSyncStatusFailed  EnvGroupSecretSyncStatus = "failed"
+)
+
+// EnvGroupSecretStatus is the sync status of a single secret variable which references
+// an external secret store
+type EnvGroupSecretStatus struct {
+	Key string `json:"key"`
+
+	*EnvGroupSecretReference
+
+	Status       EnvGroupSecretSyncStatus `json:"status"`
+	Error        string                   `json:"error,omitempty"`
+	LastSyncedAt *time.Time               `json:"last_synced_at,omitempty"`
+}
+
+type GetEnvGroupSecretStatusRequest struct {
+	Name string `schema:"name,required"`
+}
+
+type GetEnvGroupSecretStatusResponse []*EnvGroupSecretStatus
+
+type SyncEnvGroupSecretsRequest struct {
+	Name string `json:"name" form:"required"`
+}
+
+type SyncEnvGroupSecretsResponse []*EnvGroupSecretStatus
@@ -0,0 +1,66 @@
+package types
+
+import "time"
+
+const URLParamJobRunID URLParam = "job_run_id"
+
+type JobRunStatus string
+
+const (
+	JobRunPending   JobRunStatus = "pending"
+	JobRunRunning   JobRunStatus = "running"
+	JobRunSucceeded JobRunStatus = "succeeded"
+	JobRunFailed    JobRunStatus = "failed"
+)
+
+// IsFinished returns true if the run has completed, either successfully or not
+func (s JobRunStatus) IsFinished() bool {
+	return s == JobRunSucceeded || s == JobRunFailed
+}
+
+// JobRun is the stored record of a single run of a job release. Records are kept after
+// the Kubernetes job has been deleted.
+type JobRun struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ProjectID   uint   `json:"project_id"`
+	ClusterID   uint   `json:"cluster_id"`
+	Namespace   string `json:"namespace"`
+	ReleaseName string `json:"release_name"`
+
+	// JobName is the name of the Kubernetes job for this run
+	JobName string `json:"job_name"`
+
+	Status     JobRunStatus `json:"status"`
+	StartedAt  *time.Time   `json:"started_at,omitempty"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+
+	// ExitCode is the exit code of the job container, if the run has finished
+	ExitCode *int32 `json:"exit_code,omitempty"`
+
+	// TriggeredBy is the email of the user who triggered the run, or "schedule" or "deploy"
+	// if the run was started by the schedule or by a deploy of the release
+	TriggeredBy string `json:"triggered_by"`
+
+	ImageTag string `json:"image_tag"`
+
+	// LogTail is the end of the logs of the job container, which is captured when the run
+	// finishes. It is only returned when a single run is requested.
+	LogTail string `json:"log_tail,omitempty"`
+}
+
+type ListJobRunsRequest struct {
+	Limit int `schema:"limit"`
+	Skip  int `schema:"skip"`
+}
+
+type ListJobRunsResponse struct {
+	Count int64 `json:"count"`
+	Limit int   `json:"limit"`
+	Skip  int   `json:"skip"`
+
+	JobRuns []*JobRun `json:"job_runs"`
+}
+
+type GetJobRunResponse JobRun
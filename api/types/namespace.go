@@ -83,20 +83,38 @@ type CreateConfigMapRequest struct {
 	Name            string            `json:"name,required"`
 	Variables       map[string]string `json:"variables,required"`
 	SecretVariables map[string]string `json:"secret_variables,required"`
+
+	// SecretReferences are secret variables whose values are synced from an external
+	// secret store
+	SecretReferences map[string]*EnvGroupSecretReference `json:"secret_references,omitempty" form:"omitempty,dive,required"`
 }
 
 type CreateConfigMapResponse struct {
 	*v1.ConfigMap
+
+	// SecretStatus contains the sync status of each secret reference
+	SecretStatus []*EnvGroupSecretStatus `json:"secret_status,omitempty"`
 }
 
 type UpdateConfigMapRequest struct {
 	Name            string            `json:"name,required"`
 	Variables       map[string]string `json:"variables,required"`
 	SecretVariables map[string]string `json:"secret_variables,required"`
+
+	// SecretReferences are secret variables whose values are synced from an external
+	// secret store. A null reference removes the reference and the variable.
+	SecretReferences map[string]*EnvGroupSecretReference `json:"secret_references,omitempty" form:"omitempty,dive,omitempty"`
 }
 
 type UpdateConfigMapResponse struct {
 	*v1.ConfigMap
+
+	// SecretStatus contains the sync status of each secret reference
+	SecretStatus []*EnvGroupSecretStatus `json:"secret_status,omitempty"`
+
+	// Redeploys contains the result of redeploying each release which consumes the
+	// env group
+	Redeploys []*EnvGroupRedeployResult `json:"redeploys"`
 }
 
 type RenameConfigMapRequest struct {
@@ -108,6 +126,14 @@ type RenameConfigMapResponse struct {
 	*v1.ConfigMap
 }
 
+type GetConfigMapSecretsRequest struct {
+	Name string `schema:"name,required"`
+}
+
+type GetConfigMapSecretsResponse struct {
+	SecretVariables map[string]string `json:"secret_variables"`
+}
+
 type DeleteConfigMapRequest struct {
 	Name string `schema:"name,required"`
 }
@@ -0,0 +1,145 @@
+package types
+
+import "time"
+
+const (
+	URLParamNotificationChannelID URLParam = "notification_channel_id"
+	URLParamWebhookDeliveryID     URLParam = "webhook_delivery_id"
+)
+
+// NotificationEventType is the type of event that a notification is sent for
+type NotificationEventType string
+
+const (
+	NotificationEventDeploySuccess    NotificationEventType = "deploy_success"
+	NotificationEventDeployFailure    NotificationEventType = "deploy_failure"
+	NotificationEventDeployRolledBack NotificationEventType = "deploy_rolled_back"
+	NotificationEventPodCrash         NotificationEventType = "pod_crash"
+	NotificationEventJobFailure       NotificationEventType = "job_failure"
+	NotificationEventProvisioningDone NotificationEventType = "provisioning_done"
+
+	NotificationEventReleaseCreated    NotificationEventType = "release_created"
+	NotificationEventReleaseRolledBack NotificationEventType = "release_rolled_back"
+	NotificationEventReleaseDeleted    NotificationEventType = "release_deleted"
+)
+
+// IsFailure returns true if the event type is a failure, which is used to apply
+// the success/failure settings of a release notification config
+func (e NotificationEventType) IsFailure() bool {
+	switch e {
+	case NotificationEventDeployFailure,
+		NotificationEventDeployRolledBack,
+		NotificationEventPodCrash,
+		NotificationEventJobFailure:
+		return true
+	}
+
+	return false
+}
+
+// NotificationChannelKind is the driver that delivers notifications for a channel
+type NotificationChannelKind string
+
+const (
+	NotificationChannelSlack   NotificationChannelKind = "slack"
+	NotificationChannelWebhook NotificationChannelKind = "webhook"
+	NotificationChannelTeams   NotificationChannelKind = "teams"
+	NotificationChannelDiscord NotificationChannelKind = "discord"
+	NotificationChannelSMTP    NotificationChannelKind = "smtp"
+)
+
+// NotificationChannelConfig is the driver-specific configuration of a notification
+// channel. It is stored encrypted, and is never returned by the API.
+type NotificationChannelConfig struct {
+	// URL is the webhook URL for slack, webhook, teams and discord channels
+	URL string `json:"url,omitempty"`
+
+	// Secret is used to sign the payloads sent to webhook channels
+	Secret string `json:"secret,omitempty"`
+
+	SMTP *SMTPChannelConfig `json:"smtp,omitempty"`
+}
+
+type SMTPChannelConfig struct {
+	Host     string   `json:"host" form:"required"`
+	Port     uint     `json:"port" form:"required"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	From     string   `json:"from" form:"required,email"`
+	To       []string `json:"to" form:"required,min=1,dive,email"`
+}
+
+type NotificationChannel struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uint      `json:"project_id"`
+
+	// ReleaseID is the id of the release that this channel is restricted to. If 0,
+	// the channel receives events from the entire project.
+	ReleaseID uint `json:"release_id,omitempty"`
+
+	Name string                  `json:"name"`
+	Kind NotificationChannelKind `json:"kind"`
+
+	// Events is the list of event types routed to this channel. If empty, all events
+	// are routed to this channel.
+	Events []NotificationEventType `json:"events"`
+}
+
+type CreateNotificationChannelRequest struct {
+	Name   string                  `json:"name" form:"required,max=255"`
+	Kind   NotificationChannelKind `json:"kind" form:"required,oneof=slack webhook teams discord smtp"`
+	Events []NotificationEventType `json:"events" form:"omitempty,dive,oneof=deploy_success deploy_failure deploy_rolled_back pod_crash job_failure provisioning_done release_created release_rolled_back release_deleted"`
+
+	// URL is required for all kinds except smtp, and SMTP is required for smtp
+	URL    string             `json:"url" form:"omitempty,url"`
+	Secret string             `json:"secret"`
+	SMTP   *SMTPChannelConfig `json:"smtp"`
+}
+
+type CreateNotificationChannelResponse NotificationChannel
+
+type ListNotificationChannelsResponse []*NotificationChannel
+
+// WebhookDeliveryStatus is the status of the delivery of an event to a webhook channel
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending means that the delivery has not succeeded yet, but will be
+	// retried
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+type WebhookDeliveryAttempt struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// StatusCode is the status code of the response, or 0 if no response was received
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error,omitempty"`
+}
+
+type WebhookDelivery struct {
+	ID                    uint      `json:"id"`
+	CreatedAt             time.Time `json:"created_at"`
+	NotificationChannelID uint      `json:"notification_channel_id"`
+
+	// DeliveryID is the unique id of the delivery, which is sent in the X-Porter-Delivery
+	// header and is the same for every attempt
+	DeliveryID string                `json:"delivery_id"`
+	EventType  NotificationEventType `json:"event_type"`
+	Status     WebhookDeliveryStatus `json:"status"`
+
+	// Payload is the JSON body that is sent to the webhook
+	Payload string `json:"payload"`
+
+	// NextAttemptAt is when a pending delivery is retried next
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+
+	Attempts []*WebhookDeliveryAttempt `json:"attempts"`
+}
+
+type ListWebhookDeliveriesResponse []*WebhookDelivery
+
+type RedeliverWebhookResponse WebhookDelivery
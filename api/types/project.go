@@ -37,11 +37,12 @@ type GetProjectPolicyResponse []*PolicyDocument
 type ListProjectRolesResponse []RoleKind
 
 type Collaborator struct {
-	ID        uint   `json:"id"`
-	Kind      string `json:"kind"`
-	UserID    uint   `json:"user_id"`
-	Email     string `json:"email"`
-	ProjectID uint   `json:"project_id"`
+	ID           uint   `json:"id"`
+	Kind         string `json:"kind"`
+	CustomRoleID uint   `json:"custom_role_id,omitempty"`
+	UserID       uint   `json:"user_id"`
+	Email        string `json:"email"`
+	ProjectID    uint   `json:"project_id"`
 }
 
 type ListCollaboratorsResponse []*Collaborator
@@ -49,6 +50,9 @@ type ListCollaboratorsResponse []*Collaborator
 type UpdateRoleRequest struct {
 	UserID uint   `json:"user_id,required"`
 	Kind   string `json:"kind,required"`
+
+	// CustomRoleID is required when the kind is "custom"
+	CustomRoleID uint `json:"custom_role_id"`
 }
 
 type UpdateRoleResponse struct {
@@ -0,0 +1,122 @@
+package types
+
+import "time"
+
+const (
+	URLParamRetentionPolicyID URLParam = "retention_policy_id"
+)
+
+// RegistryRetentionPolicy decides which images are deleted from the repositories of a
+// registry. Images are deleted if any of the rules which are set select them, unless
+// they are kept by the policy.
+type RegistryRetentionPolicy struct {
+	ID         uint `json:"id"`
+	ProjectID  uint `json:"project_id"`
+	RegistryID uint `json:"registry_id"`
+
+	// RepositoryName restricts the policy to a single repository of the registry. If
+	// empty, the policy applies to every repository of the registry.
+	RepositoryName string `json:"repository_name,omitempty"`
+
+	// KeepLastTags deletes tagged images which are not among the most recently pushed
+	// tags. If 0, tagged images are not deleted.
+	KeepLastTags uint `json:"keep_last_tags,omitempty"`
+
+	// DeleteUntaggedAfterDays deletes untagged images which were pushed more than this
+	// number of days ago. If 0, untagged images are not deleted.
+	DeleteUntaggedAfterDays uint `json:"delete_untagged_after_days,omitempty"`
+
+	// ProtectLiveReleases keeps the images which the releases of the project are
+	// currently deployed with, and the images of the revisions they would be rolled
+	// back to
+	ProtectLiveReleases bool `json:"protect_live_releases"`
+
+	// DryRun only reports the images which would be deleted when the policy is
+	// enforced on schedule
+	DryRun bool `json:"dry_run"`
+
+	// LastRunAt is when the policy was last enforced, and is nil if it has never run
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+
+	// LastReport is the report of the last time the policy was enforced, which is only
+	// returned when a single policy is read
+	LastReport *RegistryRetentionReport `json:"last_report,omitempty"`
+}
+
+type CreateRegistryRetentionPolicyRequest struct {
+	RepositoryName          string `json:"repository_name"`
+	KeepLastTags            uint   `json:"keep_last_tags"`
+	DeleteUntaggedAfterDays uint   `json:"delete_untagged_after_days"`
+
+	// ProtectLiveReleases defaults to true
+	ProtectLiveReleases *bool `json:"protect_live_releases"`
+
+	// DryRun defaults to true, so that the reports of a new policy can be reviewed
+	// before any image is deleted
+	DryRun *bool `json:"dry_run"`
+}
+
+type CreateRegistryRetentionPolicyResponse RegistryRetentionPolicy
+
+type UpdateRegistryRetentionPolicyRequest struct {
+	KeepLastTags            *uint `json:"keep_last_tags"`
+	DeleteUntaggedAfterDays *uint `json:"delete_untagged_after_days"`
+	ProtectLiveReleases     *bool `json:"protect_live_releases"`
+	DryRun                  *bool `json:"dry_run"`
+}
+
+type UpdateRegistryRetentionPolicyResponse RegistryRetentionPolicy
+
+type ListRegistryRetentionPoliciesResponse []*RegistryRetentionPolicy
+
+type RunRegistryRetentionPolicyRequest struct {
+	// DryRun only reports the images which would be deleted, even if the policy is
+	// not a dry run
+	DryRun bool `json:"dry_run"`
+}
+
+type RunRegistryRetentionPolicyResponse RegistryRetentionReport
+
+// RegistryRetentionReport lists the images which a retention policy deleted, or would
+// delete for a dry run, in each repository it applies to
+type RegistryRetentionReport struct {
+	PolicyID     uint                         `json:"policy_id"`
+	DryRun       bool                         `json:"dry_run"`
+	EvaluatedAt  time.Time                    `json:"evaluated_at"`
+	Repositories []*RepositoryRetentionReport `json:"repositories"`
+}
+
+type RepositoryRetentionReport struct {
+	RepositoryName string `json:"repository_name"`
+
+	Kept    []*RetentionImage `json:"kept"`
+	Deleted []*RetentionImage `json:"deleted"`
+
+	// Error is set if the repository could not be evaluated, or if its images could
+	// not be deleted
+	Error string `json:"error,omitempty"`
+}
+
+// RetentionImage is a tag of an image, or an untagged image, with the reason that a
+// retention policy kept or deleted it
+type RetentionImage struct {
+	Tag      string               `json:"tag,omitempty"`
+	Digest   string               `json:"digest"`
+	PushedAt *time.Time           `json:"pushed_at,omitempty"`
+	Reason   RetentionImageReason `json:"reason"`
+}
+
+type RetentionImageReason string
+
+const (
+	RetentionReasonLiveRelease    RetentionImageReason = "live_release"
+	RetentionReasonRecentTag      RetentionImageReason = "recent_tag"
+	RetentionReasonNoTagRule      RetentionImageReason = "no_tag_rule"
+	RetentionReasonRecentUntagged RetentionImageReason = "recent_untagged"
+	RetentionReasonNoUntaggedRule RetentionImageReason = "no_untagged_rule"
+	RetentionReasonIndexChild     RetentionImageReason = "multi_platform_image"
+	RetentionReasonBuildCache     RetentionImageReason = "build_cache"
+	RetentionReasonUnknownPushed  RetentionImageReason = "unknown_push_time"
+	RetentionReasonOldTag         RetentionImageReason = "old_tag"
+	RetentionReasonOldUntagged    RetentionImageReason = "old_untagged"
+)
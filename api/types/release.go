@@ -62,6 +62,131 @@ type UpgradeReleaseRequest struct {
 	ChartVersion string `json:"version"`
 }
 
+type ReleaseDiffChange string
+
+const (
+	ReleaseDiffChangeAdded    ReleaseDiffChange = "added"
+	ReleaseDiffChangeRemoved  ReleaseDiffChange = "removed"
+	ReleaseDiffChangeModified ReleaseDiffChange = "modified"
+)
+
+// ResourceDiff is the change to a single Kubernetes resource in a release manifest
+type ResourceDiff struct {
+	Kind   string            `json:"kind"`
+	Name   string            `json:"name"`
+	Change ReleaseDiffChange `json:"change"`
+
+	// Diff is a unified diff between the current and the new resource manifest
+	Diff string `json:"diff"`
+}
+
+// ValueDiff is the change to a single value in a release's values, where Path is the
+// dot-separated path of the value
+type ValueDiff struct {
+	Path     string            `json:"path"`
+	Change   ReleaseDiffChange `json:"change"`
+	Previous interface{}       `json:"previous,omitempty"`
+	Next     interface{}       `json:"next,omitempty"`
+}
+
+// ReleaseDiff is the set of changes between two revisions of a release
+type ReleaseDiff struct {
+	Resources []*ResourceDiff `json:"resources"`
+	Values    []*ValueDiff    `json:"values"`
+}
+
+type DryRunUpgradeReleaseRequest UpgradeReleaseRequest
+
+type DryRunUpgradeReleaseResponse ReleaseDiff
+
+// CanaryRolloutRequest upgrades a web release by deploying the new values as a canary
+// release next to the current release, and shifting NGINX ingress traffic to it in steps.
+// After each step, the canary's error rate and latency are checked: if either is over
+// its threshold the canary is removed, otherwise the release is upgraded after the last
+// step. Canary rollouts are only started through this request: upgrades and deploy
+// webhooks always upgrade the release directly, and are rejected while a canary rollout
+// of the release is in progress.
+type CanaryRolloutRequest struct {
+	Values string `json:"values" form:"required"`
+
+	// Steps are the percentages of traffic sent to the canary at each step, in
+	// increasing order. Defaults to 10, 25, 50 and 100.
+	Steps []int `json:"steps" form:"omitempty,dive,min=1,max=100"`
+
+	// StepIntervalSeconds is how long each step runs before the canary's metrics are
+	// checked. Defaults to 300.
+	StepIntervalSeconds uint `json:"step_interval_seconds"`
+
+	// MaxErrorRate is the maximum percentage of 5xx responses from the canary.
+	// Defaults to 1.
+	MaxErrorRate float64 `json:"max_error_rate" form:"omitempty,min=0,max=100"`
+
+	// MaxLatency is the maximum average latency of the canary, in seconds. Defaults
+	// to 1.
+	MaxLatency float64 `json:"max_latency" form:"omitempty,min=0"`
+}
+
+type CanaryRolloutStatus string
+
+const (
+	CanaryRolloutStatusInProgress CanaryRolloutStatus = "in_progress"
+	CanaryRolloutStatusSucceeded  CanaryRolloutStatus = "succeeded"
+	CanaryRolloutStatusFailed     CanaryRolloutStatus = "failed"
+)
+
+// PromoteReleaseRequest upgrades the release with the same name in a target namespace,
+// optionally in another cluster of the project, to the chart, values and image tag of
+// this release. The target release is created if it does not exist.
+type PromoteReleaseRequest struct {
+	// TargetClusterID is the cluster of the target release. Defaults to the cluster of
+	// this release.
+	TargetClusterID uint `json:"target_cluster_id"`
+
+	TargetNamespace string `json:"target_namespace" form:"required"`
+
+	// ImageTag replaces the image tag of this release, if set
+	ImageTag string `json:"image_tag"`
+
+	// Values are merged on top of the values of this release
+	Values string `json:"values"`
+
+	// EnvGroups maps the env groups referenced by this release to env groups in the
+	// target namespace. Env groups which are not mapped keep their name, and must exist
+	// in the target namespace.
+	EnvGroups map[string]string `json:"env_groups" form:"omitempty,dive,required"`
+}
+
+type PromoteReleaseResponse struct {
+	Name         string `json:"name"`
+	ClusterID    uint   `json:"cluster_id"`
+	Namespace    string `json:"namespace"`
+	Version      int    `json:"version"`
+	Chart        string `json:"chart"`
+	ChartVersion string `json:"chart_version"`
+	ImageTag     string `json:"image_tag"`
+
+	// Created is true if the target release did not exist before the promotion
+	Created bool `json:"created"`
+}
+
+// PorterYAMLResource is a resource of a porter.yaml file, which is applied with
+// `porter apply`
+type PorterYAMLResource struct {
+	Name      string                 `json:"name"`
+	Driver    string                 `json:"driver,omitempty"`
+	Source    map[string]interface{} `json:"source,omitempty"`
+	Target    map[string]interface{} `json:"target,omitempty"`
+	Config    map[string]interface{} `json:"config,omitempty"`
+	DependsOn []string               `json:"depends_on,omitempty"`
+}
+
+// ExportReleaseResponse is a porter.yaml resource group which creates the release with
+// `porter apply`
+type ExportReleaseResponse struct {
+	Version   string                `json:"version"`
+	Resources []*PorterYAMLResource `json:"resources"`
+}
+
 type UpdateImageBatchRequest struct {
 	ImageRepoURI string `json:"image_repo_uri" form:"required"`
 	Tag          string `json:"tag" form:"required"`
@@ -72,6 +197,21 @@ type GetJobsStatusResponse struct {
 	StartTime *metav1.Time `json:"start_time,omitempty"`
 }
 
+// RunJobRequest triggers a manual run of a job release, using the job template of the
+// release with optional overrides
+type RunJobRequest struct {
+	// Command overrides the command of the job container, if set
+	Command []string `json:"command"`
+
+	// Env is added to the environment of the job container, and overrides any existing
+	// variables with the same name
+	Env map[string]string `json:"env"`
+}
+
+type SuspendCronJobRequest struct {
+	Suspend bool `json:"suspend"`
+}
+
 const URLParamToken URLParam = "token"
 
 type WebhookRequest struct {
@@ -124,6 +264,24 @@ type GetNotificationConfigResponse struct {
 	*NotificationConfig
 }
 
+// AutoRollbackConfig is the policy for rolling back a release when its controllers
+// do not become ready after an upgrade
+type AutoRollbackConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// WindowSeconds is how long Porter waits for the controllers to become ready
+	WindowSeconds uint `json:"window_seconds"`
+}
+
+type UpdateAutoRollbackConfigRequest struct {
+	Enabled       bool `json:"enabled"`
+	WindowSeconds uint `json:"window_seconds" form:"omitempty,min=30,max=3600"`
+}
+
+type GetAutoRollbackConfigResponse struct {
+	*AutoRollbackConfig
+}
+
 type DNSRecord struct {
 	ExternalURL string `json:"external_url"`
 
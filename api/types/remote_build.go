@@ -0,0 +1,43 @@
+package types
+
+const (
+	URLParamRemoteBuildName URLParam = "name"
+)
+
+// CreateRemoteBuildRequest creates a build which runs as a job in the cluster, and which
+// pushes the image to a registry linked to the project
+type CreateRemoteBuildRequest struct {
+	ImageRepoURI string `json:"image_repo_uri" form:"required"`
+	Tag          string `json:"tag" form:"required"`
+
+	// Method is either "docker" or "pack"
+	Method string `json:"method" form:"required,oneof=docker pack"`
+
+	// Dockerfile is the path of the Dockerfile relative to the build context
+	Dockerfile string   `json:"dockerfile"`
+	Builder    string   `json:"builder"`
+	Buildpacks []string `json:"buildpacks"`
+
+	Env map[string]string `json:"env"`
+}
+
+type CreateRemoteBuildResponse struct {
+	Name string `json:"name"`
+}
+
+const (
+	RemoteBuildMessageLog    = "log"
+	RemoteBuildMessageStatus = "status"
+
+	RemoteBuildStatusSucceeded = "succeeded"
+	RemoteBuildStatusFailed    = "failed"
+)
+
+// RemoteBuildMessage is sent over the websocket of a remote build, either with a line of
+// the build logs or with the final status of the build
+type RemoteBuildMessage struct {
+	Type   string `json:"type"`
+	Log    string `json:"log,omitempty"`
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
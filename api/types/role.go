@@ -13,4 +13,39 @@ type Role struct {
 	Kind      RoleKind `json:"kind"`
 	UserID    uint     `json:"user_id"`
 	ProjectID uint     `json:"project_id"`
+
+	// CustomRoleID is the id of the custom role that is assigned, if the role
+	// kind is custom
+	CustomRoleID uint `json:"custom_role_id,omitempty"`
+}
+
+const (
+	URLParamCustomRoleID URLParam = "custom_role_id"
+)
+
+// CustomRole is a named set of policy documents defined for a project, which can
+// be assigned to collaborators
+type CustomRole struct {
+	ID        uint   `json:"id"`
+	ProjectID uint   `json:"project_id"`
+	Name      string `json:"name"`
+	Policy    Policy `json:"policy"`
+}
+
+type CreateCustomRoleRequest struct {
+	Name   string `json:"name" form:"required,max=255"`
+	Policy Policy `json:"policy" form:"required"`
 }
+
+type CreateCustomRoleResponse CustomRole
+
+type GetCustomRoleResponse CustomRole
+
+type ListCustomRolesResponse []*CustomRole
+
+type UpdateCustomRoleRequest struct {
+	Name   string `json:"name" form:"max=255"`
+	Policy Policy `json:"policy"`
+}
+
+type UpdateCustomRoleResponse CustomRole
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -17,6 +18,7 @@ import (
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/deploy"
+	"github.com/porter-dev/porter/internal/porteryaml"
 	"github.com/porter-dev/porter/internal/templater/utils"
 	"github.com/porter-dev/switchboard/pkg/drivers"
 	"github.com/porter-dev/switchboard/pkg/models"
@@ -55,9 +57,105 @@ applying a configuration:
   PORTER_SOURCE_REPO          The URL of the Helm charts registry
   PORTER_SOURCE_VERSION       The version of the Helm chart to use
   PORTER_TAG                  The Docker image tag to use (like the git commit hash)
+
+Besides releases, which are deployed by the default porter.deploy driver, porter.yaml can
+declare resources with the following drivers:
+  porter.env_group            Creates or updates the env group named after the resource, with
+                              the "variables" and "secretVariables" of its config. Outputs the
+                              "variables" of the env group, and a reference to the env group
+                              for each of the "secretVariables".
+  porter.job_run              Runs the job release named by "job" (the resource name by
+                              default), optionally overriding its "command" and "env", and waits
+                              up to "timeout" seconds for the run to complete. The apply fails
+                              if the run fails. Outputs the "jobName" and "status" of the run.
+
+For example, a migration job can be run before deploying a web service, using variables from
+an env group:
+
+  resources:
+  - name: shared-env
+    driver: porter.env_group
+    config:
+      variables:
+        LOG_LEVEL: info
+      secretVariables:
+        DATABASE_URL: postgres://...
+  - name: migrate
+    driver: porter.job_run
+    depends_on:
+    - shared-env
+    config:
+      command: ["python", "manage.py", "migrate"]
+  - name: web
+    depends_on:
+    - migrate
+    - shared-env
+    config:
+      values:
+        container:
+          env:
+            normal:
+              LOG_LEVEL: "{ .shared-env.variables.LOG_LEVEL }"
+
+Releases are built with the local Docker daemon by default. To build the image in the cluster
+instead, which does not require a Docker daemon, set "remote: true" in the build config:
+
+  config:
+    build:
+      method: docker
+      dockerfile: ./Dockerfile
+      remote: true
+
+Docker builds only reuse the final stage of the current image by default. To cache every stage
+of multi-stage builds in the image repository, which requires docker buildx, enable the cache in
+the build config. Builds on branches other than the default branch have a cache of their own,
+which falls back to the cache of the default branch. The cache can be exported to another "ref",
+and imported "from" additional refs:
+
+  config:
+    build:
+      method: docker
+      cache:
+        enabled: true
+        from:
+        - gcr.io/my-project/base:porter-cache
+
+Images are built for linux/amd64 by default. To build a multi-platform image, for example for
+arm64 node pools, list the platforms in the build config. Images of multiple platforms are pushed
+by the build, which requires docker buildx. Buildpack builds only support the platform of the Docker
+daemon, and remote builds run on the platform of the cluster's nodes:
+
+  config:
+    build:
+      method: docker
+      platforms:
+      - linux/amd64
+      - linux/arm64
+
+Each apply records the releases declared in porter.yaml. To uninstall releases which were
+deployed by an earlier apply but are no longer declared, pass the --prune flag. The releases
+to remove are listed and must be confirmed, unless the --yes flag is set:
+
+  %s
+
+porter.yaml is identified across applies by its git repository and its path within the
+repository. To prune releases of a porter.yaml file outside of a git repository, pass a name
+which identifies it with the --manifest-name flag.
+
+To only list the releases which would be removed, without applying the configuration, pass
+the --dry-run flag:
+
+  %s
+
+To check porter.yaml for errors without applying it, use "porter apply validate":
+
+  %s
 	`,
 		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter apply\":"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml --prune"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml --prune --dry-run"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter apply validate -f porter.yaml"),
 	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, apply)
@@ -68,13 +166,29 @@ applying a configuration:
 	},
 }
 
-var porterYAML string
+var (
+	porterYAML        string
+	applyPrune        bool
+	applyDryRun       bool
+	applyConfirm      bool
+	applyManifestName string
+)
 
 func init() {
 	rootCmd.AddCommand(applyCmd)
 
 	applyCmd.Flags().StringVarP(&porterYAML, "file", "f", "", "path to porter.yaml")
 	applyCmd.MarkFlagRequired("file")
+
+	applyCmd.Flags().BoolVar(&applyPrune, "prune", false, "uninstall releases which are no longer declared in porter.yaml")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "only list the releases which --prune would uninstall")
+	applyCmd.Flags().BoolVarP(&applyConfirm, "yes", "y", false, "uninstall pruned releases without confirmation")
+	applyCmd.Flags().StringVar(
+		&applyManifestName,
+		"manifest-name",
+		"",
+		"name which identifies porter.yaml across applies, instead of its git repository and path",
+	)
 }
 
 func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -88,22 +202,39 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []str
 		return err
 	}
 
-	basePath, err := os.Getwd()
+	if applyDryRun && !applyPrune {
+		return fmt.Errorf("--dry-run can only be used with --prune")
+	}
+
+	deplNamespace := os.Getenv("PORTER_NAMESPACE")
+
+	if deplNamespace == "" {
+		return fmt.Errorf("namespace must be set by PORTER_NAMESPACE")
+	}
+
+	manifest, err := newApplyManifest(client, resGroup, deplNamespace, applyPrune)
 
 	if err != nil {
 		return err
 	}
 
-	worker := worker.NewWorker()
-	worker.RegisterDriver("porter.deploy", NewPorterDriver)
-	worker.SetDefaultDriver("porter.deploy")
+	if applyDryRun {
+		manifest.printPruned()
+		return nil
+	}
 
-	deplNamespace := os.Getenv("PORTER_NAMESPACE")
+	basePath, err := os.Getwd()
 
-	if deplNamespace == "" {
-		return fmt.Errorf("namespace must be set by PORTER_NAMESPACE")
+	if err != nil {
+		return err
 	}
 
+	worker := worker.NewWorker()
+	worker.RegisterDriver(porteryaml.DeployDriver, NewPorterDriver)
+	worker.RegisterDriver(porteryaml.EnvGroupDriver, NewEnvGroupDriver)
+	worker.RegisterDriver(porteryaml.JobRunDriver, NewJobRunDriver)
+	worker.SetDefaultDriver(porteryaml.DeployDriver)
+
 	deploymentHook, err := NewDeploymentHook(client, resGroup, deplNamespace)
 
 	if err != nil {
@@ -112,9 +243,27 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []str
 
 	worker.RegisterHook("deployment", deploymentHook)
 
-	return worker.Apply(resGroup, &switchboardTypes.ApplyOpts{
+	err = worker.Apply(resGroup, &switchboardTypes.ApplyOpts{
 		BasePath: basePath,
 	})
+
+	if err != nil {
+		return err
+	}
+
+	var pruneErr error
+
+	if applyPrune {
+		pruneErr = manifest.prune(applyConfirm)
+	}
+
+	if manifest != nil {
+		if err := manifest.save(); err != nil {
+			return err
+		}
+	}
+
+	return pruneErr
 }
 
 type Source struct {
@@ -141,6 +290,19 @@ type ApplicationConfig struct {
 		Image      string
 		Builder    string
 		Buildpacks []string
+
+		// Remote builds the image in the cluster instead of with the local Docker daemon
+		Remote bool
+
+		// Cache configures a registry cache for Docker builds
+		Cache struct {
+			Enabled bool
+			Ref     string
+			From    []string
+		}
+
+		// Platforms are the platforms to build the image for
+		Platforms []string
 	}
 
 	Values map[string]interface{}
@@ -310,6 +472,25 @@ func (d *Driver) applyApplication(resource *models.Resource, client *api.Client,
 		LocalDockerfile: appConfig.Build.Dockerfile,
 		OverrideTag:     tag,
 		Method:          deploy.DeployBuildType(method),
+		RemoteBuild:     appConfig.Build.Remote,
+	}
+
+	sharedOpts.Platforms, err = getBuildPlatforms(client, appConfig.Build.Platforms)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if appConfig.Build.Cache.Enabled {
+		// builds with a registry cache authenticate with the Porter credential helper
+		if err := dockerConfig(nil, client, nil); err != nil {
+			return nil, err
+		}
+
+		sharedOpts.BuildCache = &deploy.BuildCacheOpts{
+			Ref:  appConfig.Build.Cache.Ref,
+			From: appConfig.Build.Cache.From,
+		}
 	}
 
 	if shouldCreate {
@@ -576,70 +757,86 @@ func (d *Driver) getSource(genericSource map[string]interface{}) error {
 }
 
 func (d *Driver) getTarget(genericTarget map[string]interface{}) error {
-	d.target = &Target{}
+	target, err := parseTarget(genericTarget)
+
+	if err != nil {
+		return err
+	}
+
+	d.target = target
+
+	return nil
+}
+
+// parseTarget reads the target of a resource, with environment variables taking precedence
+// over the values in porter.yaml
+func parseTarget(genericTarget map[string]interface{}) (*Target, error) {
+	target := &Target{}
 
 	// first read from env vars
 	if projectEnv := os.Getenv("PORTER_PROJECT"); projectEnv != "" {
 		project, err := strconv.Atoi(projectEnv)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		d.target.Project = uint(project)
+		target.Project = uint(project)
 	}
 
 	if clusterEnv := os.Getenv("PORTER_CLUSTER"); clusterEnv != "" {
 		cluster, err := strconv.Atoi(clusterEnv)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		d.target.Cluster = uint(cluster)
+		target.Cluster = uint(cluster)
 	}
 
-	d.target.Namespace = os.Getenv("PORTER_NAMESPACE")
+	target.Namespace = os.Getenv("PORTER_NAMESPACE")
 
 	// next, check for values in the YAML file
-	if d.target.Project == 0 {
+	if target.Project == 0 {
 		if project, ok := genericTarget["project"]; ok {
-			projectVal, ok := project.(uint)
-			if !ok {
-				return fmt.Errorf("project value must be an integer")
+			// numbers in porter.yaml are parsed as floats
+			projectVal, ok := project.(float64)
+			if !ok || projectVal <= 0 || projectVal != math.Trunc(projectVal) {
+				return nil, fmt.Errorf("project value must be an integer")
 			}
-			d.target.Project = projectVal
+			target.Project = uint(projectVal)
 		}
 	}
 
-	if d.target.Cluster == 0 {
+	if target.Cluster == 0 {
 		if cluster, ok := genericTarget["cluster"]; ok {
-			clusterVal, ok := cluster.(uint)
-			if !ok {
-				return fmt.Errorf("cluster value must be an integer")
+			// numbers in porter.yaml are parsed as floats
+			clusterVal, ok := cluster.(float64)
+			if !ok || clusterVal <= 0 || clusterVal != math.Trunc(clusterVal) {
+				return nil, fmt.Errorf("cluster value must be an integer")
 			}
-			d.target.Cluster = clusterVal
+			target.Cluster = uint(clusterVal)
 		}
 	}
 
-	if d.target.Namespace == "" {
+	if target.Namespace == "" {
 		if namespace, ok := genericTarget["namespace"]; ok {
 			namespaceVal, ok := namespace.(string)
 			if !ok {
-				return fmt.Errorf("invalid namespace provided")
+				return nil, fmt.Errorf("invalid namespace provided")
 			}
-			d.target.Namespace = namespaceVal
+			target.Namespace = namespaceVal
 		}
 	}
 
 	// lastly, just put in the defaults
-	if d.target.Project == 0 {
-		d.target.Project = config.Project
+	if target.Project == 0 {
+		target.Project = config.Project
 	}
-	if d.target.Cluster == 0 {
-		d.target.Cluster = config.Cluster
+	if target.Cluster == 0 {
+		target.Cluster = config.Cluster
 	}
-	if d.target.Namespace == "" {
-		d.target.Namespace = "default"
+	if target.Namespace == "" {
+		target.Namespace = "default"
 	}
 
-	return nil
+	return target, nil
 }
 
 func (d *Driver) getApplicationConfig(resource *models.Resource) (*ApplicationConfig, error) {
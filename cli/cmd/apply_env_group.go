@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+	"github.com/rs/zerolog"
+)
+
+// EnvGroupConfig is the config of a porter.env_group resource. The variables of the env
+// group are replaced by the declared variables on each apply.
+type EnvGroupConfig struct {
+	Variables       map[string]string
+	SecretVariables map[string]string
+}
+
+// EnvGroupDriver creates and updates env groups declared in porter.yaml. The output of
+// the driver contains the variables of the env group, and a reference for each secret
+// variable which can be used as a value in `container.env.normal` of a release.
+type EnvGroupDriver struct {
+	target      *Target
+	output      map[string]interface{}
+	lookupTable *map[string]drivers.Driver
+	logger      *zerolog.Logger
+}
+
+func NewEnvGroupDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	driver := &EnvGroupDriver{
+		lookupTable: opts.DriverLookupTable,
+		logger:      opts.Logger,
+		output:      make(map[string]interface{}),
+	}
+
+	target, err := parseTarget(resource.Target)
+
+	if err != nil {
+		return nil, err
+	}
+
+	driver.target = target
+
+	return driver, nil
+}
+
+func (d *EnvGroupDriver) ShouldApply(resource *models.Resource) bool {
+	return true
+}
+
+func (d *EnvGroupDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	client := GetAPIClient(config)
+
+	if resource.Name == "" {
+		return nil, fmt.Errorf("empty env group name")
+	}
+
+	envConfig, err := d.getConfig(resource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	listResp, err := client.ListEnvGroups(context.Background(), d.target.Project, d.target.Cluster, d.target.Namespace)
+
+	if err != nil {
+		return nil, err
+	}
+
+	exists := false
+
+	if listResp.ConfigMapList != nil {
+		for _, configMap := range listResp.ConfigMapList.Items {
+			if configMap.Name == resource.Name {
+				exists = true
+				break
+			}
+		}
+	}
+
+	if !exists {
+		color.New(color.FgGreen).Printf("Creating env group: %s\n", resource.Name)
+
+		_, err = client.CreateEnvGroup(
+			context.Background(),
+			d.target.Project,
+			d.target.Cluster,
+			d.target.Namespace,
+			&types.CreateConfigMapRequest{
+				Name:            resource.Name,
+				Variables:       copyStringMap(envConfig.Variables),
+				SecretVariables: copyStringMap(envConfig.SecretVariables),
+			},
+		)
+	} else {
+		color.New(color.FgGreen).Printf("Updating existing env group: %s\n", resource.Name)
+
+		err = d.update(resource.Name, envConfig)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.assignOutput(resource.Name, envConfig)
+
+	return resource, nil
+}
+
+// update replaces the variables of an existing env group with the declared variables.
+// Empty values remove variables, and variables which change between secret and not
+// secret are removed from the other kind.
+func (d *EnvGroupDriver) update(name string, envConfig *EnvGroupConfig) error {
+	client := GetAPIClient(config)
+
+	getResp, err := client.GetEnvGroup(
+		context.Background(),
+		d.target.Project,
+		d.target.Cluster,
+		d.target.Namespace,
+		&types.GetConfigMapRequest{
+			Name: name,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	variables := copyStringMap(envConfig.Variables)
+	secretVariables := copyStringMap(envConfig.SecretVariables)
+
+	if getResp.ConfigMap != nil {
+		for key := range getResp.ConfigMap.Data {
+			_, isVar := variables[key]
+			_, isSecret := secretVariables[key]
+
+			if !isVar && !isSecret {
+				variables[key] = ""
+				secretVariables[key] = ""
+			}
+		}
+	}
+
+	for key := range envConfig.Variables {
+		if _, ok := secretVariables[key]; !ok {
+			secretVariables[key] = ""
+		}
+	}
+
+	_, err = client.UpdateEnvGroup(
+		context.Background(),
+		d.target.Project,
+		d.target.Cluster,
+		d.target.Namespace,
+		&types.UpdateConfigMapRequest{
+			Name:            name,
+			Variables:       variables,
+			SecretVariables: secretVariables,
+		},
+	)
+
+	return err
+}
+
+func (d *EnvGroupDriver) assignOutput(name string, envConfig *EnvGroupConfig) {
+	variables := make(map[string]interface{})
+
+	for key, val := range envConfig.Variables {
+		variables[key] = val
+	}
+
+	// secret values are not part of the output: secrets are referenced instead, and
+	// injected from the env group at runtime
+	secretVariables := make(map[string]interface{})
+
+	for key := range envConfig.SecretVariables {
+		secretVariables[key] = fmt.Sprintf("PORTERSECRET_%s", name)
+	}
+
+	d.output = map[string]interface{}{
+		"name":            name,
+		"namespace":       d.target.Namespace,
+		"variables":       variables,
+		"secretVariables": secretVariables,
+	}
+}
+
+func (d *EnvGroupDriver) Output() (map[string]interface{}, error) {
+	return d.output, nil
+}
+
+func (d *EnvGroupDriver) getConfig(resource *models.Resource) (*EnvGroupConfig, error) {
+	populatedConf, err := drivers.ConstructConfig(&drivers.ConstructConfigOpts{
+		RawConf:      resource.Config,
+		LookupTable:  *d.lookupTable,
+		Dependencies: resource.Dependencies,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	envConfig := &EnvGroupConfig{}
+
+	// values in porter.yaml may be numbers or booleans, which are stored as strings
+	if err := mapstructure.WeakDecode(populatedConf, envConfig); err != nil {
+		return nil, err
+	}
+
+	if envConfig.Variables == nil {
+		envConfig.Variables = make(map[string]string)
+	}
+
+	if envConfig.SecretVariables == nil {
+		envConfig.SecretVariables = make(map[string]string)
+	}
+
+	for key := range envConfig.SecretVariables {
+		if _, ok := envConfig.Variables[key]; ok {
+			return nil, fmt.Errorf("variable %s of env group %s is both a variable and a secret variable", key, resource.Name)
+		}
+	}
+
+	return envConfig, nil
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	res := make(map[string]string, len(m))
+
+	for key, val := range m {
+		res[key] = val
+	}
+
+	return res
+}
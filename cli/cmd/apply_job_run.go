@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+	"github.com/rs/zerolog"
+	v1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const defaultJobRunTimeout = 300
+
+// JobRunConfig is the config of a porter.job_run resource
+type JobRunConfig struct {
+	// Job is the name of the job release to run, which defaults to the name of the resource
+	Job string
+
+	// Command and Env override the command and environment variables of the job template
+	Command []string
+	Env     map[string]string
+
+	// Timeout is the number of seconds to wait for the run to complete
+	Timeout int
+}
+
+// JobRunDriver triggers a run of a job release and waits for the run to complete, so
+// that porter.yaml can run steps such as migrations before deploying other resources.
+// The apply fails if the run fails.
+type JobRunDriver struct {
+	target      *Target
+	output      map[string]interface{}
+	lookupTable *map[string]drivers.Driver
+	logger      *zerolog.Logger
+}
+
+func NewJobRunDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	driver := &JobRunDriver{
+		lookupTable: opts.DriverLookupTable,
+		logger:      opts.Logger,
+		output:      make(map[string]interface{}),
+	}
+
+	target, err := parseTarget(resource.Target)
+
+	if err != nil {
+		return nil, err
+	}
+
+	driver.target = target
+
+	return driver, nil
+}
+
+func (d *JobRunDriver) ShouldApply(resource *models.Resource) bool {
+	return true
+}
+
+func (d *JobRunDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	client := GetAPIClient(config)
+
+	jobConfig, err := d.getConfig(resource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := client.RunJob(
+		context.Background(),
+		d.target.Project,
+		d.target.Cluster,
+		d.target.Namespace,
+		jobConfig.Job,
+		&types.RunJobRequest{
+			Command: jobConfig.Command,
+			Env:     jobConfig.Env,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	color.New(color.FgGreen).Printf("Started run %s of job %s\n", job.Name, jobConfig.Job)
+
+	d.output = map[string]interface{}{
+		"job":     jobConfig.Job,
+		"jobName": job.Name,
+		"status":  "running",
+	}
+
+	status, err := d.wait(jobConfig, job.Name)
+
+	d.output["status"] = status
+
+	if err != nil {
+		return nil, err
+	}
+
+	color.New(color.FgGreen).Printf("Job run %s succeeded\n", job.Name)
+
+	return resource, nil
+}
+
+// wait polls the jobs of the job release until the run completes, and returns the status
+// of the run along with an error if the run failed or timed out
+func (d *JobRunDriver) wait(jobConfig *JobRunConfig, jobName string) (string, error) {
+	client := GetAPIClient(config)
+
+	timeWait := time.Now().Add(time.Duration(jobConfig.Timeout) * time.Second)
+
+	for time.Now().Before(timeWait) {
+		jobs, err := client.GetJobs(context.Background(), d.target.Project, d.target.Cluster, d.target.Namespace, jobConfig.Job)
+
+		if err != nil {
+			return "unknown", err
+		}
+
+		for _, job := range jobs {
+			if job.Name != jobName {
+				continue
+			}
+
+			if status := getJobRunStatus(&job); status == "failed" {
+				return status, fmt.Errorf("job run %s of job %s failed", jobName, jobConfig.Job)
+			} else if status == "succeeded" {
+				return status, nil
+			}
+		}
+
+		time.Sleep(10 * time.Second)
+	}
+
+	return "timeout", fmt.Errorf("timed out waiting for job run %s of job %s", jobName, jobConfig.Job)
+}
+
+func (d *JobRunDriver) Output() (map[string]interface{}, error) {
+	return d.output, nil
+}
+
+func (d *JobRunDriver) getConfig(resource *models.Resource) (*JobRunConfig, error) {
+	populatedConf, err := drivers.ConstructConfig(&drivers.ConstructConfigOpts{
+		RawConf:      resource.Config,
+		LookupTable:  *d.lookupTable,
+		Dependencies: resource.Dependencies,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	jobConfig := &JobRunConfig{}
+
+	if err := mapstructure.WeakDecode(populatedConf, jobConfig); err != nil {
+		return nil, err
+	}
+
+	if jobConfig.Job == "" {
+		jobConfig.Job = resource.Name
+	}
+
+	if jobConfig.Timeout <= 0 {
+		jobConfig.Timeout = defaultJobRunTimeout
+	}
+
+	return jobConfig, nil
+}
+
+// getJobRunStatus returns "succeeded" or "failed" for completed job runs, and "running"
+// otherwise
+func getJobRunStatus(job *v1.Job) string {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		if cond.Type == v1.JobComplete {
+			return "succeeded"
+		} else if cond.Type == v1.JobFailed {
+			return "failed"
+		}
+	}
+
+	if job.Status.Failed > 0 {
+		return "failed"
+	}
+
+	if job.Status.Succeeded > 0 {
+		return "succeeded"
+	}
+
+	return "running"
+}
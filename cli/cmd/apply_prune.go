@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/gitutils"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/porter-dev/porter/internal/porteryaml"
+	switchboardTypes "github.com/porter-dev/switchboard/pkg/types"
+)
+
+// applyManifest tracks the releases deployed by a porter.yaml file across applies, so
+// that releases which are removed from the file can be pruned
+type applyManifest struct {
+	client    *api.Client
+	namespace string
+	name      string
+
+	// declared are the releases declared in porter.yaml
+	declared []*types.ApplyManifestResource
+
+	// pruned are the releases deployed by an earlier apply which are no longer declared
+	pruned []*types.ApplyManifestResource
+
+	// remaining are the pruned releases which were not uninstalled, and are kept in the
+	// manifest so that they are pruned by a later apply
+	remaining []*types.ApplyManifestResource
+}
+
+// newApplyManifest reads the releases deployed by the last apply of porter.yaml. If
+// porter.yaml cannot be identified across applies, or the Porter server does not record
+// applies, a nil manifest is returned so that the apply is not recorded, and an error is
+// returned if releases are pruned.
+func newApplyManifest(
+	client *api.Client,
+	resGroup *switchboardTypes.ResourceGroup,
+	namespace string,
+	prune bool,
+) (*applyManifest, error) {
+	name, err := getApplyManifestName()
+
+	if err != nil && prune {
+		return nil, fmt.Errorf("%v: set --manifest-name to prune releases", err)
+	} else if err != nil {
+		return nil, nil
+	}
+
+	res := &applyManifest{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+		declared:  make([]*types.ApplyManifestResource, 0),
+	}
+
+	for _, resource := range resGroup.Resources {
+		// only resources deployed by the porter.deploy driver are releases
+		if resource.Driver != "" && resource.Driver != porteryaml.DeployDriver {
+			continue
+		}
+
+		target, err := parseTarget(resource.Target)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid target for resource %s: %v", resource.Name, err)
+		}
+
+		res.declared = append(res.declared, &types.ApplyManifestResource{
+			Name:      resource.Name,
+			ClusterID: target.Cluster,
+			Namespace: target.Namespace,
+		})
+	}
+
+	previous, err := client.GetApplyManifest(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		&types.GetApplyManifestRequest{
+			Name: res.name,
+		},
+	)
+
+	if errors.Is(err, api.ErrNotFound) && !prune {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read the releases deployed by %s: %v", porterYAML, err)
+	}
+
+	declared := make(map[string]bool)
+
+	for _, resource := range res.declared {
+		declared[getApplyManifestKey(resource)] = true
+	}
+
+	for _, resource := range previous.Resources {
+		if !declared[getApplyManifestKey(resource)] {
+			res.pruned = append(res.pruned, resource)
+		}
+	}
+
+	res.remaining = res.pruned
+
+	return res, nil
+}
+
+// printPruned lists the releases which would be uninstalled by pruning
+func (m *applyManifest) printPruned() {
+	if len(m.pruned) == 0 {
+		color.New(color.FgGreen).Println("No releases would be removed")
+		return
+	}
+
+	fmt.Println("The following releases are no longer declared and would be removed:")
+
+	for _, resource := range m.pruned {
+		fmt.Printf("  - %s\n", formatApplyManifestResource(resource))
+	}
+}
+
+// prune uninstalls the releases which are no longer declared, after asking for
+// confirmation unless confirmed is set. Releases which fail to uninstall are kept in the
+// manifest, and an error is returned.
+func (m *applyManifest) prune(confirmed bool) error {
+	if len(m.pruned) == 0 {
+		return nil
+	}
+
+	fmt.Println("The following releases are no longer declared and will be removed:")
+
+	for _, resource := range m.pruned {
+		fmt.Printf("  - %s\n", formatApplyManifestResource(resource))
+	}
+
+	if !confirmed {
+		userResp, err := utils.PromptPlaintext(
+			fmt.Sprintf("Are you sure you'd like to uninstall these releases? %s ", color.New(color.FgCyan).Sprintf("[y/n]")),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if userResp := strings.ToLower(userResp); userResp != "y" && userResp != "yes" {
+			color.New(color.FgYellow).Println("Skipped removing releases")
+			return nil
+		}
+	}
+
+	remaining := make([]*types.ApplyManifestResource, 0)
+	failed := make([]string, 0)
+
+	for _, resource := range m.pruned {
+		_, err := m.client.GetRelease(context.Background(), config.Project, resource.ClusterID, resource.Namespace, resource.Name)
+
+		if err != nil {
+			color.New(color.FgYellow).Printf("Could not read release %s (%s): assuming it was already removed\n", resource.Name, err.Error())
+			continue
+		}
+
+		err = m.client.DeleteRelease(context.Background(), config.Project, resource.ClusterID, resource.Namespace, resource.Name)
+
+		if err != nil {
+			color.New(color.FgRed).Printf("Could not uninstall release %s: %s\n", resource.Name, err.Error())
+
+			remaining = append(remaining, resource)
+			failed = append(failed, resource.Name)
+
+			continue
+		}
+
+		color.New(color.FgGreen).Printf("Uninstalled release %s\n", formatApplyManifestResource(resource))
+	}
+
+	m.remaining = remaining
+
+	if len(failed) > 0 {
+		return fmt.Errorf("could not uninstall releases: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// save records the declared releases, along with the pruned releases which were not
+// uninstalled
+func (m *applyManifest) save() error {
+	resources := append(m.declared, m.remaining...)
+
+	_, err := m.client.UpdateApplyManifest(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		m.namespace,
+		&types.UpdateApplyManifestRequest{
+			Name:      m.name,
+			Resources: resources,
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("could not record the releases deployed by %s: %v", porterYAML, err)
+	}
+
+	return nil
+}
+
+// getApplyManifestName identifies the porter.yaml file within the namespace. The name is
+// set by the --manifest-name flag, or is the git repository of the file with the path of
+// the file within the repository. The repository is read from PORTER_REPO_OWNER and
+// PORTER_REPO_NAME when they are set, so that applies from GitHub Actions and from a
+// local clone of the repository share a name.
+func getApplyManifestName() (string, error) {
+	if applyManifestName != "" {
+		return applyManifestName, nil
+	}
+
+	filePath, err := filepath.Abs(porterYAML)
+
+	if err != nil {
+		return "", err
+	}
+
+	repoPath, repoErr := gitutils.GetRepoPath(filePath)
+
+	if repoOwner, repoName := os.Getenv("PORTER_REPO_OWNER"), os.Getenv("PORTER_REPO_NAME"); repoOwner != "" && repoName != "" {
+		if repoErr != nil {
+			repoPath = filepath.ToSlash(filepath.Clean(porterYAML))
+		}
+
+		return fmt.Sprintf("github.com/%s/%s/%s", repoOwner, repoName, repoPath), nil
+	}
+
+	if repoErr != nil {
+		return "", fmt.Errorf("%s cannot be identified across applies, since it is not in a git repository", porterYAML)
+	}
+
+	remoteURL, err := gitutils.GetRemoteURL(filepath.Dir(filePath))
+
+	if err != nil {
+		return "", fmt.Errorf("%s cannot be identified across applies: %v", porterYAML, err)
+	}
+
+	return fmt.Sprintf("%s/%s", remoteURL, repoPath), nil
+}
+
+func getApplyManifestKey(resource *types.ApplyManifestResource) string {
+	return fmt.Sprintf("%d/%s/%s", resource.ClusterID, resource.Namespace, resource.Name)
+}
+
+func formatApplyManifestResource(resource *types.ApplyManifestResource) string {
+	return fmt.Sprintf("%s (namespace %s, cluster %d)", resource.Name, resource.Namespace, resource.ClusterID)
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/porter-dev/porter/internal/helm/loader"
+	"github.com/porter-dev/porter/internal/porteryaml"
+	"github.com/spf13/cobra"
+	"k8s.io/helm/pkg/repo"
+)
+
+var validateSkipCharts bool
+
+var applyValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Checks a porter.yaml file for errors without applying it",
+	Long: fmt.Sprintf(`
+%s
+
+Checks a porter.yaml file for errors without applying it, and without contacting the cluster.
+For example:
+
+  %s
+
+The file is checked for unknown fields and drivers, invalid source, target and build fields, and
+"depends_on" entries which do not refer to a declared resource or which form a cycle. Values are
+checked against the form of the chart of each resource, which is downloaded from the chart
+repository. To skip downloading charts, pass the --skip-charts flag:
+
+  %s
+
+All errors are reported along with their line in porter.yaml, and this command exits with exit
+code 1 if any errors are found.
+	`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter apply validate\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter apply validate -f porter.yaml"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter apply validate -f porter.yaml --skip-charts"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := validatePorterYAML()
+
+		if err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "Error: %s\n", err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	applyCmd.AddCommand(applyValidateCmd)
+
+	applyValidateCmd.Flags().StringVarP(&porterYAML, "file", "f", "", "path to porter.yaml")
+	applyValidateCmd.MarkFlagRequired("file")
+
+	applyValidateCmd.Flags().BoolVar(&validateSkipCharts, "skip-charts", false, "do not check values against the forms of charts")
+}
+
+func validatePorterYAML() error {
+	fileBytes, err := ioutil.ReadFile(porterYAML)
+
+	if err != nil {
+		return err
+	}
+
+	opts := &porteryaml.ValidateOpts{}
+
+	if !validateSkipCharts {
+		opts.LoadChart = newValidateChartLoader().load
+	}
+
+	errs := porteryaml.Validate(fileBytes, opts)
+
+	if len(errs) == 0 {
+		color.New(color.FgGreen).Printf("%s is valid\n", porterYAML)
+		return nil
+	}
+
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", porterYAML, err.Error())
+	}
+
+	return fmt.Errorf("found %d errors in %s", len(errs), porterYAML)
+}
+
+// validateChartLoader loads charts from public chart repositories, caching the index of
+// each repository
+type validateChartLoader struct {
+	indexes map[string]*repo.IndexFile
+}
+
+func newValidateChartLoader() *validateChartLoader {
+	return &validateChartLoader{
+		indexes: make(map[string]*repo.IndexFile),
+	}
+}
+
+func (l *validateChartLoader) load(source *porteryaml.Source) (*porteryaml.Chart, error) {
+	version := source.Version
+
+	// an empty version loads the latest version of the chart
+	if version == "latest" {
+		version = ""
+	}
+
+	repoURLs := []string{source.Repo}
+
+	// like "porter apply", look for the chart in the application and addon repositories
+	// when no repository is set
+	if source.Repo == "" {
+		repoURLs = []string{porteryaml.ApplicationRepoURL, "https://chart-addons.getporter.dev"}
+	}
+
+	for _, repoURL := range repoURLs {
+		index, err := l.getIndex(repoURL)
+
+		if err != nil {
+			// charts are only used for additional checks, so repositories which cannot be
+			// reached are skipped
+			color.New(color.FgYellow).Fprintf(
+				os.Stderr, "Could not read chart repository %s (%s): skipping checks of values\n", repoURL, err.Error(),
+			)
+
+			return nil, nil
+		}
+
+		if _, err := index.Get(source.Name, version); err != nil {
+			continue
+		}
+
+		chart, err := loader.LoadChartPublic(repoURL, source.Name, version)
+
+		if err != nil {
+			return nil, err
+		}
+
+		res := &porteryaml.Chart{
+			IsApplication: repoURL == porteryaml.ApplicationRepoURL,
+		}
+
+		for _, file := range chart.Files {
+			if strings.Contains(file.Name, "form.yaml") {
+				res.Form = file.Data
+			}
+		}
+
+		return res, nil
+	}
+
+	if source.Repo == "" {
+		return nil, fmt.Errorf("chart %s does not exist in any repo", source.Name)
+	}
+
+	return nil, fmt.Errorf("chart %s does not exist in repo %s", source.Name, source.Repo)
+}
+
+func (l *validateChartLoader) getIndex(repoURL string) (*repo.IndexFile, error) {
+	if index, ok := l.indexes[repoURL]; ok {
+		return index, nil
+	}
+
+	index, err := loader.LoadRepoIndexPublic(repoURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	l.indexes[repoURL] = index
+
+	return index, nil
+}
@@ -44,6 +44,25 @@ default Docker registry. The path can be configured via the --path flag. For exa
 
   %s
 
+If a Docker daemon is not available, you can build the image in the cluster by passing the --remote
+flag. For example:
+
+  %s
+
+If the build directory does not contain a Dockerfile, or if you pass "--method pack", the image is built
+with Cloud Native Buildpacks. Porter detects the buildpacks of the application from the files in the
+build directory, such as package.json or pom.xml. For example:
+
+  %s
+
+Images are built for linux/amd64 by default. To build images for other platforms, such as the arm64
+nodes of AWS Graviton instances, pass the --platform flag. Images of multiple platforms are pushed to
+the image repository as a single multi-platform image, which requires docker buildx. Buildpack builds
+only support the platform of the Docker daemon, which must be linux/amd64 for the default Paketo and
+Heroku builders. Remote builds run on the platform of the cluster's nodes. For example:
+
+  %s
+
 To connect the application to Github, so that the application rebuilds and redeploys on each push
 to a Github branch, you can specify "--source github". If your local branch is set to track changes
 from an upstream remote branch, Porter will try to use the connected remote and remote branch as the
@@ -60,6 +79,9 @@ To deploy an application from a Docker registry, use "--source registry" and pas
 		color.New(color.FgGreen, color.Bold).Sprintf("porter create web --app example-app"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter create web --app example-app --values values.yaml"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter create web --app example-app --path ./path/to/app"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter create web --app example-app --remote"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter create web --app example-app --method pack"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter create web --app example-app --method docker --platform linux/amd64,linux/arm64"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter create web --app example-app --source github"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter create web --app example-app --source registry --image gcr.io/snowflake-12345/example-app:latest"),
 	),
@@ -155,6 +177,20 @@ func init() {
 		"",
 		"the registry URL to use (must exist in \"porter registries list\")",
 	)
+
+	createCmd.PersistentFlags().BoolVar(
+		&remoteBuild,
+		"remote",
+		false,
+		"build the image in the cluster instead of with the local Docker daemon",
+	)
+
+	createCmd.PersistentFlags().StringSliceVar(
+		&buildPlatforms,
+		"platform",
+		[]string{},
+		"the platforms to build the image for, such as linux/amd64,linux/arm64 (multiple platforms require docker buildx)",
+	)
 }
 
 var supportedKinds = map[string]string{"web": "", "job": "", "worker": ""}
@@ -198,6 +234,12 @@ func createFull(_ *types.GetAuthenticatedUserResponse, client *api.Client, args
 		}
 	}
 
+	platforms, err := getBuildPlatforms(client, buildPlatforms)
+
+	if err != nil {
+		return err
+	}
+
 	createAgent := &deploy.CreateAgent{
 		Client: client,
 		CreateOpts: &deploy.CreateOpts{
@@ -209,6 +251,8 @@ func createFull(_ *types.GetAuthenticatedUserResponse, client *api.Client, args
 				LocalDockerfile: dockerfile,
 				Method:          buildMethod,
 				AdditionalEnv:   additionalEnv,
+				RemoteBuild:     remoteBuild,
+				Platforms:       platforms,
 			},
 			Kind:        args[0],
 			ReleaseName: name,
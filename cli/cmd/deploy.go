@@ -9,6 +9,7 @@ import (
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/deploy"
+	"github.com/porter-dev/porter/internal/platform"
 	"github.com/spf13/cobra"
 )
 
@@ -49,6 +50,27 @@ in your remote Git repository. For example, if a Dockerfile is found at ./docker
 specify it as follows:
 
   %s
+
+If a Docker daemon is not available, you can build the image in the cluster by passing the --remote
+flag. The build context is uploaded to the cluster, which builds the image and pushes it to the image
+repository of the application. For example:
+
+  %s
+
+Docker builds only reuse the final stage of the current image by default. To cache every stage of
+multi-stage builds in the image repository, pass the --cache flag, which requires docker buildx.
+Builds on branches other than the default branch of the Git repository have a cache of their own,
+which falls back to the cache of the default branch:
+
+  %s
+
+Images are built for linux/amd64 by default. To build images for other platforms, such as the arm64
+nodes of AWS Graviton instances, pass the --platform flag. Images of multiple platforms are pushed to
+the image repository as a single multi-platform image, which requires docker buildx. Buildpack builds
+only support the platform of the Docker daemon, which must be linux/amd64 for the default Paketo and
+Heroku builders. Remote builds run on the platform of the cluster's nodes. For example:
+
+  %s
 `,
 		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter update\":"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app example-app"),
@@ -56,6 +78,9 @@ specify it as follows:
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app remote-git-app --source github"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app example-app --values my-values.yaml"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app example-app --method docker --dockerfile ./docker/prod.Dockerfile"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app example-app --remote"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app example-app --method docker --cache"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app example-app --method docker --platform linux/amd64,linux/arm64"),
 	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, updateFull)
@@ -184,10 +209,15 @@ You can update the configuration with only a new tag with the --tag flag, which
 the image that the application uses if no --values file is specified:
 
   %s
+
+To preview the changes to the application without deploying them, use the --dry-run flag:
+
+  %s
 `,
 		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter update config\":"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update config --app example-app --values my-values.yaml"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update config --app example-app --tag custom-tag"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update config --app example-app --values my-values.yaml --dry-run"),
 	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, updateUpgrade)
@@ -205,6 +235,10 @@ var tag string
 var dockerfile string
 var method string
 var stream bool
+var remoteBuild bool
+var registryCache bool
+var buildPlatforms []string
+var dryRun bool
 var buildFlagsEnv []string
 
 func init() {
@@ -288,6 +322,27 @@ func init() {
 		"stream update logs to porter dashboard",
 	)
 
+	updateCmd.PersistentFlags().BoolVar(
+		&remoteBuild,
+		"remote",
+		false,
+		"build the image in the cluster instead of with the local Docker daemon",
+	)
+
+	updateCmd.PersistentFlags().BoolVar(
+		&registryCache,
+		"cache",
+		false,
+		"import and export the cache of every stage of Docker builds from the image repository (requires docker buildx)",
+	)
+
+	updateCmd.PersistentFlags().StringSliceVar(
+		&buildPlatforms,
+		"platform",
+		[]string{},
+		"the platforms to build the image for, such as linux/amd64,linux/arm64 (multiple platforms require docker buildx)",
+	)
+
 	updateCmd.AddCommand(updateGetEnvCmd)
 
 	updateGetEnvCmd.PersistentFlags().StringVar(
@@ -300,6 +355,13 @@ func init() {
 	updateCmd.AddCommand(updateBuildCmd)
 	updateCmd.AddCommand(updatePushCmd)
 	updateCmd.AddCommand(updateConfigCmd)
+
+	updateConfigCmd.PersistentFlags().BoolVar(
+		&dryRun,
+		"dry-run",
+		false,
+		"print the changes that the update would make without deploying them",
+	)
 }
 
 func updateFull(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -378,7 +440,11 @@ func updatePush(_ *types.GetAuthenticatedUserResponse, client *api.Client, args
 	return updatePushWithAgent(updateAgent)
 }
 
-func updateUpgrade(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+func updateUpgrade(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if dryRun {
+		return diffUpgrade(user, client, args)
+	}
+
 	updateAgent, err := updateGetAgent(client)
 
 	if err != nil {
@@ -405,6 +471,23 @@ func updateGetAgent(client *api.Client) (*deploy.DeployAgent, error) {
 		}
 	}
 
+	var buildCache *deploy.BuildCacheOpts
+
+	if registryCache {
+		// builds with a registry cache authenticate with the Porter credential helper
+		if err := dockerConfig(nil, client, nil); err != nil {
+			return nil, err
+		}
+
+		buildCache = &deploy.BuildCacheOpts{}
+	}
+
+	platforms, err := getBuildPlatforms(client, buildPlatforms)
+
+	if err != nil {
+		return nil, err
+	}
+
 	// initialize the update agent
 	return deploy.NewDeployAgent(client, app, &deploy.DeployOpts{
 		SharedOpts: &deploy.SharedOpts{
@@ -416,6 +499,9 @@ func updateGetAgent(client *api.Client) (*deploy.DeployAgent, error) {
 			OverrideTag:     tag,
 			Method:          buildMethod,
 			AdditionalEnv:   additionalEnv,
+			RemoteBuild:     remoteBuild,
+			BuildCache:      buildCache,
+			Platforms:       platforms,
 		},
 		Local: source != "github",
 	})
@@ -606,3 +692,20 @@ func updateUpgradeWithAgent(updateAgent *deploy.DeployAgent) error {
 
 	return nil
 }
+
+// getBuildPlatforms validates the platforms which an image is built for. Builds for
+// multiple platforms push the image with buildx, which authenticates with the Porter
+// credential helper.
+func getBuildPlatforms(client *api.Client, platforms []string) ([]string, error) {
+	if errs := platform.Validate(platforms); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	if len(platforms) > 1 {
+		if err := dockerConfig(nil, client, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return platforms, nil
+}
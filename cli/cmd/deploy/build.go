@@ -1,15 +1,21 @@
 package deploy
 
 import (
+	"compress/gzip"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/docker/docker/pkg/archive"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/docker"
+	"github.com/porter-dev/porter/cli/cmd/gitutils"
 	"github.com/porter-dev/porter/cli/cmd/pack"
+	"github.com/porter-dev/porter/internal/integrations/buildpacks"
 )
 
 // BuildAgent builds a new Docker container image for a new version of an application
@@ -49,6 +55,11 @@ func (b *BuildAgent) BuildDocker(
 		Env:               b.env,
 		DockerfilePath:    dockerfilePath,
 		IsDockerfileInCtx: isDockerfileInCtx,
+		Platforms:         b.Platforms,
+	}
+
+	if b.BuildCache != nil {
+		opts.Cache = b.getCacheOpts(buildCtx, currentTag)
 	}
 
 	return dockerAgent.BuildLocal(
@@ -56,6 +67,49 @@ func (b *BuildAgent) BuildDocker(
 	)
 }
 
+// getCacheOpts returns the registry cache of a Docker build, based on the branch which
+// is checked out in the build context
+func (b *BuildAgent) getCacheOpts(buildCtx, currentTag string) *docker.CacheOpts {
+	var branch, defaultBranch string
+
+	remote, currBranch, err := gitutils.GetRemoteBranch(buildCtx)
+
+	if err == nil {
+		branch = currBranch
+
+		if remote != nil {
+			defaultBranch, _ = gitutils.GetDefaultBranch(buildCtx, remote.Name)
+		}
+	} else if headRef := os.Getenv("GITHUB_HEAD_REF"); headRef != "" {
+		// pull request workflows check out a detached merge commit
+		branch = headRef
+	}
+
+	cache := docker.NewRegistryCacheOpts(b.imageRepo, currentTag, branch, defaultBranch)
+
+	if b.BuildCache.Ref != "" {
+		cache.Ref = b.BuildCache.Ref
+		cache.ImportRefs = append([]string{b.BuildCache.Ref}, cache.ImportRefs...)
+	}
+
+	cache.ImportRefs = append(cache.ImportRefs, b.BuildCache.From...)
+
+	// remove duplicate references, keeping the first of each
+	refs := make([]string, 0, len(cache.ImportRefs))
+	seen := make(map[string]bool)
+
+	for _, ref := range cache.ImportRefs {
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	cache.ImportRefs = refs
+
+	return cache
+}
+
 // BuildPack uses the cloud-native buildpack client to build a container image
 func (b *BuildAgent) BuildPack(dockerAgent *docker.Agent, dst, tag, prevTag string, buildConfig *types.BuildConfig) error {
 	// retag the image with "pack-cache" tag so that it doesn't re-pull from the registry
@@ -81,6 +135,7 @@ func (b *BuildAgent) BuildPack(dockerAgent *docker.Agent, dst, tag, prevTag stri
 		Tag:          "pack-cache",
 		BuildContext: dst,
 		Env:          b.env,
+		Platforms:    b.Platforms,
 	}
 
 	// call builder
@@ -96,6 +151,200 @@ func (b *BuildAgent) BuildPack(dockerAgent *docker.Agent, dst, tag, prevTag stri
 	)
 }
 
+// DetectBuildConfig detects the buildpacks of a local build context, and returns a build
+// config with the first builder which detected any of them. Build-time variables which the
+// detected buildpacks require are added to env, unless they are already set. If no
+// buildpacks are detected, it returns nil, so that the default builder detects the
+// buildpacks at build time.
+func DetectBuildConfig(buildCtx string, env map[string]string) (*types.BuildConfig, error) {
+	builders, err := buildpacks.DetectBuilders(os.DirFS(buildCtx))
+
+	if err != nil {
+		return nil, fmt.Errorf("could not detect buildpacks of %s: %v", buildCtx, err)
+	}
+
+	for _, builder := range builders {
+		if len(builder.Detected) == 0 || len(builder.Builders) == 0 {
+			continue
+		}
+
+		detected := builder.Detected
+
+		// the web server buildpack installs node itself for static sites with a build step
+		if staticInfo := getBuildpackInfo(detected, "Static"); staticInfo != nil &&
+			staticInfo.Config["build_script"] != nil {
+			detected = removeBuildpackInfo(detected, "NodeJS")
+		}
+
+		buildConfig := &types.BuildConfig{
+			Builder: builder.Builders[0],
+		}
+
+		var names []string
+
+		for _, bp := range detected {
+			buildConfig.Buildpacks = append(buildConfig.Buildpacks, bp.Buildpack)
+			names = append(names, bp.Name)
+
+			for key, val := range getBuildpackEnv(bp) {
+				if _, exists := env[key]; !exists {
+					env[key] = val
+				}
+			}
+		}
+
+		fmt.Printf("detected %s buildpacks: %s\n", builder.Name, strings.Join(names, ", "))
+
+		return buildConfig, nil
+	}
+
+	return nil, nil
+}
+
+// getBuildpackEnv returns the build-time variables which configure a detected buildpack,
+// for the buildpacks which cannot build without them
+func getBuildpackEnv(bp buildpacks.BuildpackInfo) map[string]string {
+	env := make(map[string]string)
+
+	if bp.Name == "Static" {
+		env["BP_WEB_SERVER"] = fmt.Sprintf("%v", bp.Config["web_server"])
+		env["BP_WEB_SERVER_ROOT"] = fmt.Sprintf("%v", bp.Config["web_root"])
+
+		if script, ok := bp.Config["build_script"]; ok {
+			env["BP_NODE_RUN_SCRIPTS"] = fmt.Sprintf("%v", script)
+		}
+	}
+
+	return env
+}
+
+func getBuildpackInfo(infos []buildpacks.BuildpackInfo, name string) *buildpacks.BuildpackInfo {
+	for i := range infos {
+		if infos[i].Name == name {
+			return &infos[i]
+		}
+	}
+
+	return nil
+}
+
+func removeBuildpackInfo(infos []buildpacks.BuildpackInfo, name string) []buildpacks.BuildpackInfo {
+	var res []buildpacks.BuildpackInfo
+
+	for _, info := range infos {
+		if info.Name != name {
+			res = append(res, info)
+		}
+	}
+
+	return res
+}
+
+// BuildRemote uploads the build context to the cluster, which builds the image as a job
+// and pushes it to the image repository. For Docker builds, dockerfilePath is the path to
+// the Dockerfile, while it is ignored for buildpack builds.
+func (b *BuildAgent) BuildRemote(
+	basePath,
+	buildCtx,
+	dockerfilePath,
+	tag string,
+	buildConfig *types.BuildConfig,
+) error {
+	// the builds of the cluster run on its nodes, whatever their platform
+	if len(b.Platforms) > 0 {
+		return fmt.Errorf("remote builds do not support building for specific platforms")
+	}
+
+	req := &types.CreateRemoteBuildRequest{
+		ImageRepoURI: b.imageRepo,
+		Tag:          tag,
+		Method:       string(b.Method),
+		Env:          b.env,
+	}
+
+	var isDockerfileInCtx bool
+	var err error
+
+	if b.Method == DeployBuildTypeDocker {
+		buildCtx, dockerfilePath, isDockerfileInCtx, err = ResolveDockerPaths(
+			basePath,
+			buildCtx,
+			dockerfilePath,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		req.Dockerfile = filepath.ToSlash(dockerfilePath)
+	} else if buildConfig != nil {
+		req.Builder = buildConfig.Builder
+		req.Buildpacks = buildConfig.Buildpacks
+	}
+
+	tar, err := archive.TarWithOptions(buildCtx, &archive.TarOptions{})
+
+	if err != nil {
+		return err
+	}
+
+	if b.Method == DeployBuildTypeDocker && !isDockerfileInCtx {
+		dockerfileCtx, err := os.Open(dockerfilePath)
+
+		if err != nil {
+			return fmt.Errorf("unable to open Dockerfile: %v", err)
+		}
+
+		// add the dockerfile to the build context
+		tar, req.Dockerfile, err = docker.AddDockerfileToBuildContext(dockerfileCtx, tar)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	defer tar.Close()
+
+	build, err := b.client.CreateRemoteBuild(
+		context.Background(),
+		b.ProjectID,
+		b.ClusterID,
+		b.Namespace,
+		req,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Uploading build context to remote build %s\n", build.Name)
+
+	// the build context is gzipped while it is uploaded
+	reader, writer := io.Pipe()
+
+	go func() {
+		gzipWriter := gzip.NewWriter(writer)
+
+		_, err := io.Copy(gzipWriter, tar)
+
+		if err == nil {
+			err = gzipWriter.Close()
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	return b.client.StreamRemoteBuild(
+		context.Background(),
+		b.ProjectID,
+		b.ClusterID,
+		b.Namespace,
+		build.Name,
+		reader,
+		os.Stderr,
+	)
+}
+
 // ResolveDockerPaths returns a path to the dockerfile that is either relative or absolute, and a path
 // to the build context that is absolute.
 //
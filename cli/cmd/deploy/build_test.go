@@ -0,0 +1,102 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+type detectBuildConfigTest struct {
+	name           string
+	files          map[string]string
+	env            map[string]string
+	expBuildConfig *types.BuildConfig
+	expEnv         map[string]string
+}
+
+var detectBuildConfigTests = []detectBuildConfigTest{
+	{
+		name: "node app",
+		files: map[string]string{
+			"package.json": `{"scripts": {"start": "node server.js"}}`,
+			"yarn.lock":    "",
+		},
+		env: map[string]string{},
+		expBuildConfig: &types.BuildConfig{
+			Builder:    "paketobuildpacks/builder:full",
+			Buildpacks: []string{"gcr.io/paketo-buildpacks/nodejs"},
+		},
+		expEnv: map[string]string{},
+	},
+	{
+		name: "python app with a static site",
+		files: map[string]string{
+			"requirements.txt": "flask",
+			"index.html":       "<html></html>",
+		},
+		env: map[string]string{"BP_WEB_SERVER_ROOT": "static"},
+		expBuildConfig: &types.BuildConfig{
+			Builder: "paketobuildpacks/builder:full",
+			Buildpacks: []string{
+				"gcr.io/paketo-buildpacks/python",
+				"gcr.io/paketo-buildpacks/web-servers",
+			},
+		},
+		expEnv: map[string]string{"BP_WEB_SERVER": "nginx", "BP_WEB_SERVER_ROOT": "static"},
+	},
+	{
+		name: "react app",
+		files: map[string]string{
+			"package.json": `{"scripts": {"build": "react-scripts build"}, "dependencies": {"react-scripts": "5.0.0"}}`,
+		},
+		env: map[string]string{},
+		expBuildConfig: &types.BuildConfig{
+			Builder:    "paketobuildpacks/builder:full",
+			Buildpacks: []string{"gcr.io/paketo-buildpacks/web-servers"},
+		},
+		expEnv: map[string]string{
+			"BP_WEB_SERVER":       "nginx",
+			"BP_WEB_SERVER_ROOT":  "build",
+			"BP_NODE_RUN_SCRIPTS": "build",
+		},
+	},
+	{
+		name: "nothing detected",
+		files: map[string]string{
+			"README.md": "",
+		},
+		env:    map[string]string{},
+		expEnv: map[string]string{},
+	},
+}
+
+func TestDetectBuildConfig(t *testing.T) {
+	for _, test := range detectBuildConfigTests {
+		dir := t.TempDir()
+
+		for name, content := range test.files {
+			err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+
+			if err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+		}
+
+		buildConfig, err := DetectBuildConfig(dir, test.env)
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		if !reflect.DeepEqual(buildConfig, test.expBuildConfig) {
+			t.Errorf("%s: expected build config %v, got %v", test.name, test.expBuildConfig, buildConfig)
+		}
+
+		if !reflect.DeepEqual(test.env, test.expEnv) {
+			t.Errorf("%s: expected env %v, got %v", test.name, test.expEnv, test.env)
+		}
+	}
+}
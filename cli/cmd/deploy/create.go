@@ -10,6 +10,7 @@ import (
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/docker"
+	"github.com/porter-dev/porter/internal/platform"
 	"github.com/porter-dev/porter/internal/templater/utils"
 )
 
@@ -283,6 +284,14 @@ func (c *CreateAgent) CreateFromDocker(
 		env[key] = val
 	}
 
+	if opts.Method == DeployBuildTypePack && extraBuildConfig == nil {
+		extraBuildConfig, err = DetectBuildConfig(opts.LocalPath, env)
+
+		if err != nil {
+			return "", err
+		}
+	}
+
 	buildAgent := &BuildAgent{
 		SharedOpts:  opts.SharedOpts,
 		client:      c.Client,
@@ -291,40 +300,58 @@ func (c *CreateAgent) CreateFromDocker(
 		imageExists: false,
 	}
 
-	if opts.Method == DeployBuildTypeDocker {
-		basePath, err := filepath.Abs(".")
+	basePath, err := filepath.Abs(".")
+
+	if err != nil {
+		return "", err
+	}
+
+	if opts.RemoteBuild {
+		// remote builds push the image, so the repository must exist before the build
+		err = c.createImageRepository(regID, imageURL)
 
 		if err != nil {
 			return "", err
 		}
 
-		err = buildAgent.BuildDocker(agent, basePath, opts.LocalPath, opts.LocalDockerfile, imageTag, "")
+		err = buildAgent.BuildRemote(basePath, opts.LocalPath, opts.LocalDockerfile, imageTag, extraBuildConfig)
+
+		if err != nil {
+			return "", err
+		}
 	} else {
-		err = buildAgent.BuildPack(agent, opts.LocalPath, imageTag, "", extraBuildConfig)
-	}
+		// builds for multiple platforms push the image, like remote builds
+		if platform.IsMulti(opts.Platforms) {
+			err = c.createImageRepository(regID, imageURL)
 
-	if err != nil {
-		return "", err
-	}
+			if err != nil {
+				return "", err
+			}
+		}
 
-	// create repository
-	err = c.Client.CreateRepository(
-		context.Background(),
-		opts.ProjectID,
-		regID,
-		&types.CreateRegistryRepositoryRequest{
-			ImageRepoURI: imageURL,
-		},
-	)
+		if opts.Method == DeployBuildTypeDocker {
+			err = buildAgent.BuildDocker(agent, basePath, opts.LocalPath, opts.LocalDockerfile, imageTag, "")
+		} else {
+			err = buildAgent.BuildPack(agent, opts.LocalPath, imageTag, "", extraBuildConfig)
+		}
 
-	if err != nil {
-		return "", err
-	}
+		if err != nil {
+			return "", err
+		}
 
-	err = agent.PushImage(fmt.Sprintf("%s:%s", imageURL, imageTag))
+		if !platform.IsMulti(opts.Platforms) {
+			err = c.createImageRepository(regID, imageURL)
 
-	if err != nil {
-		return "", err
+			if err != nil {
+				return "", err
+			}
+
+			err = agent.PushImage(fmt.Sprintf("%s:%s", imageURL, imageTag))
+
+			if err != nil {
+				return "", err
+			}
+		}
 	}
 
 	subdomain, err := c.CreateSubdomainIfRequired(mergedValues)
@@ -537,3 +564,14 @@ func (c *CreateAgent) CreateSubdomainIfRequired(mergedValues map[string]interfac
 
 	return subdomain, nil
 }
+
+func (c *CreateAgent) createImageRepository(regID uint, imageURL string) error {
+	return c.Client.CreateRepository(
+		context.Background(),
+		c.CreateOpts.ProjectID,
+		regID,
+		&types.CreateRegistryRepositoryRequest{
+			ImageRepoURI: imageURL,
+		},
+	)
+}
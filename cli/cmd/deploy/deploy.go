@@ -13,6 +13,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/docker"
 	"github.com/porter-dev/porter/cli/cmd/github"
+	"github.com/porter-dev/porter/internal/platform"
 	"github.com/porter-dev/porter/internal/templater/utils"
 	"k8s.io/client-go/util/homedir"
 )
@@ -270,6 +271,36 @@ func (d *DeployAgent) Build(overrideBuildConfig *types.BuildConfig) error {
 		d.tag = currentTag
 	}
 
+	buildConfig := d.release.BuildConfig
+
+	if overrideBuildConfig != nil {
+		buildConfig = overrideBuildConfig
+	}
+
+	if d.opts.Method == DeployBuildTypePack && buildConfig == nil {
+		if d.env == nil {
+			d.env = make(map[string]string)
+		}
+
+		buildConfig, err = DetectBuildConfig(buildCtx, d.env)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// remote builds run in the cluster, so there is no local image to use as a cache
+	if d.opts.RemoteBuild {
+		buildAgent := &BuildAgent{
+			SharedOpts: d.opts.SharedOpts,
+			client:     d.client,
+			imageRepo:  d.imageRepo,
+			env:        d.env,
+		}
+
+		return buildAgent.BuildRemote(basePath, buildCtx, d.dockerfilePath, d.tag, buildConfig)
+	}
+
 	currTag, err := d.pullCurrentReleaseImage()
 
 	// if image is not found, don't return an error
@@ -301,17 +332,16 @@ func (d *DeployAgent) Build(overrideBuildConfig *types.BuildConfig) error {
 		)
 	}
 
-	buildConfig := d.release.BuildConfig
-
-	if overrideBuildConfig != nil {
-		buildConfig = overrideBuildConfig
-	}
-
 	return buildAgent.BuildPack(d.agent, buildCtx, d.tag, currTag, buildConfig)
 }
 
 // Push pushes a local image to the remote repository linked in the release
 func (d *DeployAgent) Push() error {
+	// remote builds and builds for multiple platforms push the image themselves
+	if d.opts.RemoteBuild || platform.IsMulti(d.opts.Platforms) {
+		return nil
+	}
+
 	return d.agent.PushImage(fmt.Sprintf("%s:%s", d.imageRepo, d.tag))
 }
 
@@ -320,6 +350,52 @@ func (d *DeployAgent) Push() error {
 // reuses the configuration set for the application. If overrideValues is not nil,
 // it will merge the overriding values with the existing configuration.
 func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}) error {
+	valuesStr, err := d.getUpgradeValues(overrideValues)
+
+	if err != nil {
+		return err
+	}
+
+	return d.client.UpgradeRelease(
+		context.Background(),
+		d.opts.ProjectID,
+		d.opts.ClusterID,
+		d.release.Namespace,
+		d.release.Name,
+		&types.UpgradeReleaseRequest{
+			Values: valuesStr,
+		},
+	)
+}
+
+// DryRunImageAndValues computes the same configuration as UpdateImageAndValues, but
+// only returns the changes that the upgrade would make instead of applying it.
+func (d *DeployAgent) DryRunImageAndValues(overrideValues map[string]interface{}) (*types.DryRunUpgradeReleaseResponse, error) {
+	valuesStr, err := d.getUpgradeValues(overrideValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return d.client.DryRunUpgradeRelease(
+		context.Background(),
+		d.opts.ProjectID,
+		d.opts.ClusterID,
+		d.release.Namespace,
+		d.release.Name,
+		&types.DryRunUpgradeReleaseRequest{
+			Values: valuesStr,
+		},
+	)
+}
+
+// getUpgradeValues merges the override values and the new image tag with the
+// release's existing configuration, and returns the result as a JSON string
+func (d *DeployAgent) getUpgradeValues(overrideValues map[string]interface{}) (string, error) {
+	if overrideValues == nil {
+		overrideValues = make(map[string]interface{})
+	}
+
 	// if this is a job chart, set "paused" to false so that the job doesn't run, unless
 	// the user has explicitly overriden the "paused" field
 	if _, exists := overrideValues["paused"]; d.release.Chart.Name() == "job" && !exists {
@@ -337,7 +413,7 @@ func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}
 		newImage, err := d.getReleaseImage()
 
 		if err != nil {
-			return fmt.Errorf("could not overwrite hello-porter image: %s", err.Error())
+			return "", fmt.Errorf("could not overwrite hello-porter image: %s", err.Error())
 		}
 
 		currImageSection["repository"] = newImage
@@ -354,19 +430,10 @@ func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}
 	bytes, err := json.Marshal(mergedValues)
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return d.client.UpgradeRelease(
-		context.Background(),
-		d.opts.ProjectID,
-		d.opts.ClusterID,
-		d.release.Namespace,
-		d.release.Name,
-		&types.UpgradeReleaseRequest{
-			Values: string(bytes),
-		},
-	)
+	return string(bytes), nil
 }
 
 // GetEnvFromConfig gets the env vars for a standard Porter template config. These env
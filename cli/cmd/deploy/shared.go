@@ -10,4 +10,26 @@ type SharedOpts struct {
 	OverrideTag     string
 	Method          DeployBuildType
 	AdditionalEnv   map[string]string
+
+	// RemoteBuild builds the image in the cluster instead of with the local Docker
+	// daemon, in which case the image is pushed by the build
+	RemoteBuild bool
+
+	// BuildCache enables a registry cache for Docker builds
+	BuildCache *BuildCacheOpts
+
+	// Platforms are the platforms to build the image for. Images of multiple platforms
+	// are pushed to the registry by the build.
+	Platforms []string
+}
+
+// BuildCacheOpts configure the registry cache of Docker builds. By default, the cache is
+// stored in the image repository, with a cache per branch which falls back to the cache of
+// the default branch.
+type BuildCacheOpts struct {
+	// Ref overrides the image reference which the cache is exported to
+	Ref string
+
+	// From are additional image references to import caches from
+	From []string
 }
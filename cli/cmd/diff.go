@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the "porter diff" command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Shows the changes that updating the configuration of an application would make.",
+	Long: fmt.Sprintf(`
+%s
+
+Shows the changes that "porter update config" would make to an application specified by the
+--app flag, without deploying them. The new configuration is rendered by the Porter server, and
+both the changed values and the changed Kubernetes resources are printed. For example:
+
+  %s
+
+You can also preview an update with only a new tag with the --tag flag:
+
+  %s
+
+This is equivalent to running "porter update config" with the --dry-run flag.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter diff\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter diff --app example-app --values my-values.yaml"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter diff --app example-app --tag custom-tag"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, diffUpgrade)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.PersistentFlags().StringVar(
+		&app,
+		"app",
+		"",
+		"Application in the Porter dashboard",
+	)
+
+	diffCmd.MarkPersistentFlagRequired("app")
+
+	diffCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"Namespace of the application",
+	)
+
+	diffCmd.PersistentFlags().StringVarP(
+		&values,
+		"values",
+		"v",
+		"",
+		"Filepath to a values.yaml file",
+	)
+
+	diffCmd.PersistentFlags().StringVarP(
+		&tag,
+		"tag",
+		"t",
+		"",
+		"the specified tag to use, if not \"latest\"",
+	)
+}
+
+func diffUpgrade(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	updateAgent, err := updateGetAgent(client)
+
+	if err != nil {
+		return err
+	}
+
+	valuesObj, err := readValuesFile()
+
+	if err != nil {
+		return err
+	}
+
+	releaseDiff, err := updateAgent.DryRunImageAndValues(valuesObj)
+
+	if err != nil {
+		return err
+	}
+
+	printReleaseDiff(releaseDiff)
+
+	return nil
+}
+
+func printReleaseDiff(releaseDiff *types.DryRunUpgradeReleaseResponse) {
+	if len(releaseDiff.Values) == 0 && len(releaseDiff.Resources) == 0 {
+		color.New(color.FgGreen).Println("No changes for", app)
+		return
+	}
+
+	added := color.New(color.FgGreen)
+	removed := color.New(color.FgRed)
+	modified := color.New(color.FgYellow)
+
+	if len(releaseDiff.Values) > 0 {
+		color.New(color.Bold).Println("Values:")
+
+		for _, valueDiff := range releaseDiff.Values {
+			switch valueDiff.Change {
+			case types.ReleaseDiffChangeAdded:
+				added.Printf("  + %s: %v\n", valueDiff.Path, valueDiff.Next)
+			case types.ReleaseDiffChangeRemoved:
+				removed.Printf("  - %s: %v\n", valueDiff.Path, valueDiff.Previous)
+			default:
+				modified.Printf("  ~ %s: %v -> %v\n", valueDiff.Path, valueDiff.Previous, valueDiff.Next)
+			}
+		}
+
+		fmt.Println()
+	}
+
+	if len(releaseDiff.Resources) > 0 {
+		color.New(color.Bold).Println("Resources:")
+
+		for _, resourceDiff := range releaseDiff.Resources {
+			fmt.Printf("%s/%s (%s)\n", resourceDiff.Kind, resourceDiff.Name, resourceDiff.Change)
+
+			for _, line := range strings.Split(strings.TrimSuffix(resourceDiff.Diff, "\n"), "\n") {
+				switch {
+				case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+					continue
+				case strings.HasPrefix(line, "+"):
+					added.Println(line)
+				case strings.HasPrefix(line, "-"):
+					removed.Println(line)
+				case strings.HasPrefix(line, "@@"):
+					color.New(color.FgCyan).Println(line)
+				default:
+					fmt.Println(line)
+				}
+			}
+
+			fmt.Println()
+		}
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/moby/moby/pkg/stringid"
 	"github.com/moby/term"
 	"github.com/pkg/errors"
+	"github.com/porter-dev/porter/internal/platform"
 )
 
 type BuildOpts struct {
@@ -27,10 +28,32 @@ type BuildOpts struct {
 	IsDockerfileInCtx bool
 
 	Env map[string]string
+
+	// Cache is the registry cache of the build. If it is not set, the build only uses the
+	// inline cache of the image of CurrentTag.
+	Cache *CacheOpts
+
+	// Platforms are the platforms which the image is built for. Images of multiple
+	// platforms are pushed to the registry as a manifest list by the build, since the
+	// Docker daemon only stores images of a single platform.
+	Platforms []string
+}
+
+// GetPlatforms returns the platforms of the build, which default to platform.Default
+func (opts *BuildOpts) GetPlatforms() []string {
+	if len(opts.Platforms) == 0 {
+		return []string{platform.Default}
+	}
+
+	return opts.Platforms
 }
 
 // BuildLocal
 func (a *Agent) BuildLocal(opts *BuildOpts) error {
+	if opts.Cache != nil || platform.IsMulti(opts.Platforms) {
+		return a.buildWithBuildx(opts)
+	}
+
 	dockerfilePath := opts.DockerfilePath
 	tar, err := archive.TarWithOptions(opts.BuildContext, &archive.TarOptions{})
 
@@ -76,7 +99,7 @@ func (a *Agent) BuildLocal(opts *BuildOpts) error {
 			fmt.Sprintf("%s:%s", opts.ImageRepo, opts.CurrentTag),
 		},
 		Remove:   true,
-		Platform: "linux/amd64",
+		Platform: opts.GetPlatforms()[0],
 	})
 
 	if err != nil {
@@ -0,0 +1,95 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/platform"
+)
+
+// BuildxBuilderName is the buildx builder which runs builds with a registry cache or for
+// multiple platforms. The default builder of the Docker daemon can neither export caches
+// to a registry nor build manifest lists, so these builds run in a builder container.
+const BuildxBuilderName = "porter-builder"
+
+// buildWithBuildx builds an image with buildx. Images of a single platform are loaded
+// into the Docker daemon, so that they can be pushed like images of other builds, while
+// images of multiple platforms are pushed to the registry by the build.
+func (a *Agent) buildWithBuildx(opts *BuildOpts) error {
+	if err := exec.Command("docker", "buildx", "version").Run(); err != nil {
+		return fmt.Errorf("builds with a registry cache or multiple platforms require the docker buildx plugin: %v", err)
+	}
+
+	// create the builder container on the first build with buildx
+	if err := exec.Command("docker", "buildx", "inspect", BuildxBuilderName).Run(); err != nil {
+		createCmd := exec.Command(
+			"docker", "buildx", "create",
+			"--name", BuildxBuilderName,
+			"--driver", "docker-container",
+		)
+
+		if out, err := createCmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("could not create buildx builder %s: %s", BuildxBuilderName, strings.TrimSpace(string(out)))
+		}
+	}
+
+	// registry credentials are read from the Docker config by buildx, which uses the
+	// same credential helpers as docker push
+	cmd := exec.Command("docker", getBuildxArgs(opts)...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+func getBuildxArgs(opts *BuildOpts) []string {
+	dockerfilePath := opts.DockerfilePath
+
+	// unlike the Docker API, buildx resolves the Dockerfile against the working directory
+	if opts.IsDockerfileInCtx {
+		dockerfilePath = filepath.Join(opts.BuildContext, dockerfilePath)
+	}
+
+	args := []string{
+		"buildx", "build",
+		"--builder", BuildxBuilderName,
+		"--file", dockerfilePath,
+		"--tag", fmt.Sprintf("%s:%s", opts.ImageRepo, opts.Tag),
+		"--platform", strings.Join(opts.GetPlatforms(), ","),
+	}
+
+	if platform.IsMulti(opts.Platforms) {
+		args = append(args, "--push")
+	} else {
+		args = append(args, "--load")
+	}
+
+	if opts.Cache != nil {
+		for _, ref := range opts.Cache.ImportRefs {
+			args = append(args, "--cache-from", fmt.Sprintf("type=registry,ref=%s", ref))
+		}
+
+		args = append(args, "--cache-to", fmt.Sprintf("type=registry,ref=%s,mode=max", opts.Cache.Ref))
+	}
+
+	keys := make([]string, 0, len(opts.Env))
+
+	for key := range opts.Env {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, "--build-arg", fmt.Sprintf("%s=%s", key, opts.Env[key]))
+	}
+
+	// like builds with the Docker daemon, the final stage is cached inline in the image
+	args = append(args, "--build-arg", "BUILDKIT_INLINE_CACHE=1")
+
+	return append(args, opts.BuildContext)
+}
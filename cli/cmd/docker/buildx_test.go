@@ -0,0 +1,67 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetBuildxArgs(t *testing.T) {
+	args := getBuildxArgs(&BuildOpts{
+		ImageRepo:         "gcr.io/project/web",
+		Tag:               "1234567",
+		BuildContext:      "/app",
+		DockerfilePath:    "docker/prod.Dockerfile",
+		IsDockerfileInCtx: true,
+		Env:               map[string]string{"NODE_ENV": "production", "API_URL": "https://api.example.com"},
+		Cache: &CacheOpts{
+			Ref:        "gcr.io/project/web:porter-cache",
+			ImportRefs: []string{"gcr.io/project/web:porter-cache", "gcr.io/project/web:abcdefg"},
+		},
+	})
+
+	expected := []string{
+		"buildx", "build",
+		"--builder", "porter-builder",
+		"--file", "/app/docker/prod.Dockerfile",
+		"--tag", "gcr.io/project/web:1234567",
+		"--platform", "linux/amd64",
+		"--load",
+		"--cache-from", "type=registry,ref=gcr.io/project/web:porter-cache",
+		"--cache-from", "type=registry,ref=gcr.io/project/web:abcdefg",
+		"--cache-to", "type=registry,ref=gcr.io/project/web:porter-cache,mode=max",
+		"--build-arg", "API_URL=https://api.example.com",
+		"--build-arg", "NODE_ENV=production",
+		"--build-arg", "BUILDKIT_INLINE_CACHE=1",
+		"/app",
+	}
+
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("expected args %v, got %v", expected, args)
+	}
+}
+
+func TestGetBuildxArgsMultiPlatform(t *testing.T) {
+	args := getBuildxArgs(&BuildOpts{
+		ImageRepo:         "gcr.io/project/web",
+		Tag:               "1234567",
+		BuildContext:      "/app",
+		DockerfilePath:    "/app/Dockerfile",
+		IsDockerfileInCtx: false,
+		Platforms:         []string{"linux/amd64", "linux/arm64"},
+	})
+
+	expected := []string{
+		"buildx", "build",
+		"--builder", "porter-builder",
+		"--file", "/app/Dockerfile",
+		"--tag", "gcr.io/project/web:1234567",
+		"--platform", "linux/amd64,linux/arm64",
+		"--push",
+		"--build-arg", "BUILDKIT_INLINE_CACHE=1",
+		"/app",
+	}
+
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("expected args %v, got %v", expected, args)
+	}
+}
@@ -0,0 +1,65 @@
+package docker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// mainCacheTag is the tag of the registry cache of builds on the default branch
+const mainCacheTag = "porter-cache"
+
+var invalidTagRegex = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// CacheOpts configure the registry cache of a build
+type CacheOpts struct {
+	// Ref is the image reference which the cache of every build stage is exported to
+	Ref string
+
+	// ImportRefs are the image references which caches are imported from, in order of
+	// preference
+	ImportRefs []string
+}
+
+// NewRegistryCacheOpts returns the registry cache of a build of an image repository.
+// Builds on the default branch, or outside of a Git repository, use the main cache of
+// the image repository, while builds on other branches use a cache of their own which
+// falls back to the main cache. The image of the current tag is imported last, since
+// it only holds the inline cache of its final stage.
+func NewRegistryCacheOpts(imageRepo, currentTag, branch, defaultBranch string) *CacheOpts {
+	mainRef := fmt.Sprintf("%s:%s", imageRepo, mainCacheTag)
+
+	opts := &CacheOpts{
+		Ref:        mainRef,
+		ImportRefs: []string{mainRef},
+	}
+
+	if branchTag := getBranchCacheTag(branch); branch != "" && branch != defaultBranch && branchTag != "" {
+		opts.Ref = fmt.Sprintf("%s:%s", imageRepo, branchTag)
+		opts.ImportRefs = []string{opts.Ref, mainRef}
+	}
+
+	if currentTag != "" {
+		opts.ImportRefs = append(opts.ImportRefs, fmt.Sprintf("%s:%s", imageRepo, currentTag))
+	}
+
+	return opts
+}
+
+// getBranchCacheTag returns the cache tag of a branch. Tags are at most 128 characters
+// which must be alphanumeric, "_", "." or "-".
+func getBranchCacheTag(branch string) string {
+	name := strings.Trim(invalidTagRegex.ReplaceAllString(branch, "-"), ".-")
+
+	if name == "" {
+		return ""
+	}
+
+	tag := fmt.Sprintf("%s-%s", mainCacheTag, name)
+
+	if len(tag) > 128 {
+		tag = tag[:128]
+	}
+
+	return tag
+}
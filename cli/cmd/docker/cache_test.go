@@ -0,0 +1,79 @@
+package docker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type registryCacheTest struct {
+	name          string
+	currentTag    string
+	branch        string
+	defaultBranch string
+	expRef        string
+	expImportRefs []string
+}
+
+var registryCacheTests = []registryCacheTest{
+	{
+		name:          "default branch",
+		currentTag:    "1234567",
+		branch:        "main",
+		defaultBranch: "main",
+		expRef:        "gcr.io/project/web:porter-cache",
+		expImportRefs: []string{"gcr.io/project/web:porter-cache", "gcr.io/project/web:1234567"},
+	},
+	{
+		name:          "feature branch",
+		currentTag:    "1234567",
+		branch:        "feature/Login_v2",
+		defaultBranch: "main",
+		expRef:        "gcr.io/project/web:porter-cache-feature-Login_v2",
+		expImportRefs: []string{
+			"gcr.io/project/web:porter-cache-feature-Login_v2",
+			"gcr.io/project/web:porter-cache",
+			"gcr.io/project/web:1234567",
+		},
+	},
+	{
+		name:          "unknown default branch",
+		branch:        "dev",
+		expRef:        "gcr.io/project/web:porter-cache-dev",
+		expImportRefs: []string{"gcr.io/project/web:porter-cache-dev", "gcr.io/project/web:porter-cache"},
+	},
+	{
+		name:          "outside of a git repository",
+		expRef:        "gcr.io/project/web:porter-cache",
+		expImportRefs: []string{"gcr.io/project/web:porter-cache"},
+	},
+	{
+		name:          "branch without valid tag characters",
+		branch:        "///",
+		defaultBranch: "main",
+		expRef:        "gcr.io/project/web:porter-cache",
+		expImportRefs: []string{"gcr.io/project/web:porter-cache"},
+	},
+}
+
+func TestNewRegistryCacheOpts(t *testing.T) {
+	for _, test := range registryCacheTests {
+		opts := NewRegistryCacheOpts("gcr.io/project/web", test.currentTag, test.branch, test.defaultBranch)
+
+		if opts.Ref != test.expRef {
+			t.Errorf("%s: expected ref %s, got %s", test.name, test.expRef, opts.Ref)
+		}
+
+		if !reflect.DeepEqual(opts.ImportRefs, test.expImportRefs) {
+			t.Errorf("%s: expected import refs %v, got %v", test.name, test.expImportRefs, opts.ImportRefs)
+		}
+	}
+}
+
+func TestGetBranchCacheTagLength(t *testing.T) {
+	tag := getBranchCacheTag(strings.Repeat("a", 200))
+
+	if len(tag) != 128 {
+		t.Errorf("expected tag of 128 characters, got %d", len(tag))
+	}
+}
@@ -0,0 +1,748 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+// envSecretMask replaces the values of secret variables unless --show-secrets is set
+const envSecretMask = "********"
+
+var (
+	envOutput      string
+	envShowSecrets bool
+	envSetSecret   bool
+	envSecretKeys  []string
+	envPrune       bool
+)
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Commands to manage env groups",
+}
+
+var envListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the env groups in a namespace.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listEnvGroups)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envGetCmd = &cobra.Command{
+	Use:   "get [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Shows the variables of an env group.",
+	Long: fmt.Sprintf(`
+%s
+
+Shows the variables of an env group. The values of secret variables are masked, unless the
+--show-secrets flag is set. Use --output json for a machine-readable output:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env get\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env get my-env-group --show-secrets --output json"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, getEnvGroup)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envSetCmd = &cobra.Command{
+	Use:   "set [name] [KEY=VALUE]...",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Sets variables of an env group, creating the env group if it does not exist.",
+	Long: fmt.Sprintf(`
+%s
+
+Sets one or more variables of an env group. Variables which are not passed are left unchanged,
+and the env group is created if it does not exist. Pass the --secret flag to store the
+variables as secrets:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env set\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env set my-env-group DB_PASSWORD=hunter2 --secret"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, setEnvGroupVars)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envUnsetCmd = &cobra.Command{
+	Use:   "unset [name] [KEY]...",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Removes variables from an env group.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, unsetEnvGroupVars)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Deletes an env group.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, deleteEnvGroup)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envRenameCmd = &cobra.Command{
+	Use:   "rename [name] [new-name]",
+	Args:  cobra.ExactArgs(2),
+	Short: "Renames an env group.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, renameEnvGroup)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envPullCmd = &cobra.Command{
+	Use:   "pull [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Writes the variables of an env group to stdout in dotenv format.",
+	Long: fmt.Sprintf(`
+%s
+
+Writes the variables of an env group to stdout in dotenv format. Secret variables are left out
+unless the --show-secrets flag is set. For example:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env pull\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env pull my-env-group --show-secrets > .env"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, pullEnvGroup)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envPushCmd = &cobra.Command{
+	Use:   "push [name] [file]",
+	Args:  cobra.ExactArgs(2),
+	Short: "Sets the variables of an env group from a dotenv file.",
+	Long: fmt.Sprintf(`
+%s
+
+Sets the variables of an env group from a dotenv file, creating the env group if it does not
+exist. Variables which are already secret stay secret, and other variables can be stored as
+secrets with the --secret flag. Variables which are not in the file are left unchanged, unless
+the --prune flag is set:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env push\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env push my-env-group .env --secret DB_PASSWORD --secret API_KEY --prune"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, pushEnvGroup)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+
+	envCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"The namespace of the env group.",
+	)
+
+	envCmd.AddCommand(envListCmd)
+	envCmd.AddCommand(envGetCmd)
+	envCmd.AddCommand(envSetCmd)
+	envCmd.AddCommand(envUnsetCmd)
+	envCmd.AddCommand(envDeleteCmd)
+	envCmd.AddCommand(envRenameCmd)
+	envCmd.AddCommand(envPullCmd)
+	envCmd.AddCommand(envPushCmd)
+
+	for _, cmd := range []*cobra.Command{envListCmd, envGetCmd} {
+		cmd.PersistentFlags().StringVarP(
+			&envOutput,
+			"output",
+			"o",
+			"table",
+			"The output format, either \"table\" or \"json\".",
+		)
+	}
+
+	for _, cmd := range []*cobra.Command{envGetCmd, envPullCmd} {
+		cmd.PersistentFlags().BoolVar(
+			&envShowSecrets,
+			"show-secrets",
+			false,
+			"Include the values of secret variables.",
+		)
+	}
+
+	envSetCmd.PersistentFlags().BoolVar(
+		&envSetSecret,
+		"secret",
+		false,
+		"Store the variables as secrets.",
+	)
+
+	envPushCmd.PersistentFlags().StringArrayVar(
+		&envSecretKeys,
+		"secret",
+		[]string{},
+		"A variable in the file to store as a secret. Can be passed multiple times.",
+	)
+
+	envPushCmd.PersistentFlags().BoolVar(
+		&envPrune,
+		"prune",
+		false,
+		"Remove variables of the env group which are not in the file.",
+	)
+}
+
+// envGroupOutput is the JSON output of "porter env get"
+type envGroupOutput struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	Variables       map[string]string `json:"variables"`
+	SecretVariables map[string]string `json:"secret_variables"`
+}
+
+// envGroupSummary is the JSON output of "porter env list"
+type envGroupSummary struct {
+	Name            string    `json:"name"`
+	Namespace       string    `json:"namespace"`
+	CreatedAt       time.Time `json:"created_at"`
+	Variables       int       `json:"variables"`
+	SecretVariables int       `json:"secret_variables"`
+}
+
+func listEnvGroups(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ListEnvGroups(context.Background(), config.Project, config.Cluster, namespace)
+
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]*envGroupSummary, 0)
+
+	if resp.ConfigMapList != nil {
+		for _, configMap := range resp.ConfigMapList.Items {
+			summary := &envGroupSummary{
+				Name:      configMap.Name,
+				Namespace: configMap.Namespace,
+				CreatedAt: configMap.CreationTimestamp.Time,
+			}
+
+			for _, val := range configMap.Data {
+				if isEnvGroupSecretRef(configMap.Name, val) {
+					summary.SecretVariables++
+				} else {
+					summary.Variables++
+				}
+			}
+
+			summaries = append(summaries, summary)
+		}
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	if envOutput == "json" {
+		return printJSON(summaries)
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "NAME", "VARIABLES", "SECRETS", "CREATED")
+
+	for _, summary := range summaries {
+		fmt.Fprintf(
+			w,
+			"%s\t%d\t%d\t%s\n",
+			summary.Name,
+			summary.Variables,
+			summary.SecretVariables,
+			summary.CreatedAt.Format("2006-01-02 15:04:05"),
+		)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func getEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	envGroup, err := readEnvGroup(client, args[0], envShowSecrets)
+
+	if err != nil {
+		return err
+	}
+
+	if envOutput == "json" {
+		return printJSON(envGroup)
+	}
+
+	keys := make([]string, 0)
+
+	for key := range envGroup.Variables {
+		keys = append(keys, key)
+	}
+
+	for key := range envGroup.SecretVariables {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\n", "KEY", "VALUE", "SECRET")
+
+	for _, key := range keys {
+		if val, ok := envGroup.Variables[key]; ok {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", key, val, "false")
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", key, envGroup.SecretVariables[key], "true")
+		}
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func setEnvGroupVars(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	name := args[0]
+	vars := make(map[string]string)
+
+	for _, arg := range args[1:] {
+		key, val, err := parseEnvAssignment(arg)
+
+		if err != nil {
+			return err
+		}
+
+		vars[key] = val
+	}
+
+	variables := make(map[string]string)
+	secretVariables := make(map[string]string)
+
+	for key, val := range vars {
+		if envSetSecret {
+			secretVariables[key] = val
+		} else {
+			variables[key] = val
+		}
+	}
+
+	if err := writeEnvGroup(client, name, variables, secretVariables, nil); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Set %d variable(s) of env group %s\n", len(vars), name)
+
+	return nil
+}
+
+func unsetEnvGroupVars(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	name := args[0]
+
+	envGroup, err := readEnvGroup(client, name, false)
+
+	if err != nil {
+		return err
+	}
+
+	unset := make([]string, 0)
+
+	for _, key := range args[1:] {
+		_, isVar := envGroup.Variables[key]
+		_, isSecret := envGroup.SecretVariables[key]
+
+		if !isVar && !isSecret {
+			return fmt.Errorf("variable %s not found in env group %s", key, name)
+		}
+
+		unset = append(unset, key)
+	}
+
+	if err := writeEnvGroup(client, name, nil, nil, unset); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Removed %d variable(s) from env group %s\n", len(unset), name)
+
+	return nil
+}
+
+func deleteEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	err := client.DeleteEnvGroup(context.Background(), config.Project, config.Cluster, namespace, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Deleted env group %s\n", args[0])
+
+	return nil
+}
+
+func renameEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	_, err := client.RenameEnvGroup(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		&types.RenameConfigMapRequest{
+			Name:    args[0],
+			NewName: args[1],
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Renamed env group %s to %s\n", args[0], args[1])
+
+	return nil
+}
+
+func pullEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	envGroup, err := readEnvGroup(client, args[0], envShowSecrets)
+
+	if err != nil {
+		return err
+	}
+
+	vars := make(map[string]string)
+
+	for key, val := range envGroup.Variables {
+		vars[key] = val
+	}
+
+	if envShowSecrets {
+		for key, val := range envGroup.SecretVariables {
+			vars[key] = val
+		}
+	} else if len(envGroup.SecretVariables) > 0 {
+		// written to stderr so that the output can be redirected to a file
+		fmt.Fprintf(
+			os.Stderr,
+			"Skipped %d secret variable(s), use --show-secrets to include them\n",
+			len(envGroup.SecretVariables),
+		)
+	}
+
+	return utils.FormatDotEnv(os.Stdout, vars)
+}
+
+func pushEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	name := args[0]
+
+	file, err := os.Open(args[1])
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	vars, err := utils.ParseDotEnv(file)
+
+	if err != nil {
+		return fmt.Errorf("could not parse %s: %v", args[1], err)
+	}
+
+	for _, key := range envSecretKeys {
+		if _, ok := vars[key]; !ok {
+			return fmt.Errorf("secret variable %s not found in %s", key, args[1])
+		}
+	}
+
+	exists, err := envGroupExists(client, name)
+
+	if err != nil {
+		return err
+	}
+
+	currSecrets := make(map[string]string)
+	unset := make([]string, 0)
+
+	if exists {
+		envGroup, err := readEnvGroup(client, name, false)
+
+		if err != nil {
+			return err
+		}
+
+		currSecrets = envGroup.SecretVariables
+
+		if envPrune {
+			for _, curr := range []map[string]string{envGroup.Variables, envGroup.SecretVariables} {
+				for key := range curr {
+					if _, ok := vars[key]; !ok {
+						unset = append(unset, key)
+					}
+				}
+			}
+		}
+	}
+
+	secretKeys := make(map[string]bool)
+
+	for _, key := range envSecretKeys {
+		secretKeys[key] = true
+	}
+
+	variables := make(map[string]string)
+	secretVariables := make(map[string]string)
+
+	for key, val := range vars {
+		if _, isSecret := currSecrets[key]; isSecret || secretKeys[key] {
+			secretVariables[key] = val
+		} else {
+			variables[key] = val
+		}
+	}
+
+	if err := writeEnvGroup(client, name, variables, secretVariables, unset); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf(
+		"Pushed %d variable(s) and %d secret(s) to env group %s",
+		len(variables),
+		len(secretVariables),
+		name,
+	)
+
+	if len(unset) > 0 {
+		color.New(color.FgGreen).Printf(", removed %d variable(s)", len(unset))
+	}
+
+	fmt.Println()
+
+	return nil
+}
+
+// readEnvGroup reads the variables of an env group, splitting them into variables and
+// secret variables. Secret values are masked unless showSecrets is set.
+func readEnvGroup(client *api.Client, name string, showSecrets bool) (*envGroupOutput, error) {
+	resp, err := client.GetEnvGroup(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		&types.GetConfigMapRequest{
+			Name: name,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &envGroupOutput{
+		Name:            name,
+		Namespace:       namespace,
+		Variables:       make(map[string]string),
+		SecretVariables: make(map[string]string),
+	}
+
+	if resp.ConfigMap == nil {
+		return res, nil
+	}
+
+	var secretValues map[string]string
+
+	if showSecrets {
+		secretResp, err := client.GetEnvGroupSecrets(
+			context.Background(),
+			config.Project,
+			config.Cluster,
+			namespace,
+			&types.GetConfigMapSecretsRequest{
+				Name: name,
+			},
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		secretValues = secretResp.SecretVariables
+	}
+
+	for key, val := range resp.ConfigMap.Data {
+		if !isEnvGroupSecretRef(name, val) {
+			res.Variables[key] = val
+		} else if showSecrets {
+			res.SecretVariables[key] = secretValues[key]
+		} else {
+			res.SecretVariables[key] = envSecretMask
+		}
+	}
+
+	return res, nil
+}
+
+// writeEnvGroup sets and removes variables of an env group, creating the env group if
+// it does not exist
+func writeEnvGroup(client *api.Client, name string, variables, secretVariables map[string]string, unset []string) error {
+	if variables == nil {
+		variables = make(map[string]string)
+	}
+
+	if secretVariables == nil {
+		secretVariables = make(map[string]string)
+	}
+
+	exists, err := envGroupExists(client, name)
+
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		if len(unset) > 0 {
+			return fmt.Errorf("env group %s not found", name)
+		}
+
+		_, err := client.CreateEnvGroup(
+			context.Background(),
+			config.Project,
+			config.Cluster,
+			namespace,
+			&types.CreateConfigMapRequest{
+				Name:            name,
+				Variables:       variables,
+				SecretVariables: secretVariables,
+			},
+		)
+
+		return err
+	}
+
+	// a variable which changes between secret and not secret is removed from the secret
+	for key := range variables {
+		if _, ok := secretVariables[key]; !ok {
+			secretVariables[key] = ""
+		}
+	}
+
+	// empty values remove variables
+	for _, key := range unset {
+		variables[key] = ""
+		secretVariables[key] = ""
+	}
+
+	_, err = client.UpdateEnvGroup(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		&types.UpdateConfigMapRequest{
+			Name:            name,
+			Variables:       variables,
+			SecretVariables: secretVariables,
+		},
+	)
+
+	return err
+}
+
+func envGroupExists(client *api.Client, name string) (bool, error) {
+	resp, err := client.ListEnvGroups(context.Background(), config.Project, config.Cluster, namespace)
+
+	if err != nil {
+		return false, err
+	}
+
+	if resp.ConfigMapList == nil {
+		return false, nil
+	}
+
+	for _, configMap := range resp.ConfigMapList.Items {
+		if configMap.Name == name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func isEnvGroupSecretRef(name, val string) bool {
+	return val == fmt.Sprintf("PORTERSECRET_%s", name)
+}
+
+func parseEnvAssignment(arg string) (string, string, error) {
+	eqIndex := strings.Index(arg, "=")
+
+	if eqIndex <= 0 {
+		return "", "", fmt.Errorf("invalid variable %q, expected KEY=VALUE", arg)
+	}
+
+	return arg[:eqIndex], arg[eqIndex+1:], nil
+}
+
+func printJSON(v interface{}) error {
+	bytes, err := json.MarshalIndent(v, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(bytes))
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/switchboard/pkg/parser"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+var exportFile string
+
+// exportCmd represents the "porter export" command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports an application as a porter.yaml file.",
+	Long: fmt.Sprintf(`
+%s
+
+Exports an application specified by the --app flag as a porter.yaml file, which can be applied
+with "porter apply". The file contains the chart of the application, the values which differ
+from the chart defaults and, for applications built from source, the build settings. For
+example:
+
+  %s
+
+The file is written to stdout, unless a path is passed with the --file flag. The project and
+cluster are not part of the file, so "porter apply" uses the current project and cluster:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter export\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter export --app example-app > porter.yaml"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter export --app example-app --namespace staging --file porter.yaml"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, exportRelease)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.PersistentFlags().StringVar(
+		&app,
+		"app",
+		"",
+		"Application in the Porter dashboard",
+	)
+
+	exportCmd.MarkPersistentFlagRequired("app")
+
+	exportCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"Namespace of the application",
+	)
+
+	exportCmd.PersistentFlags().StringVarP(
+		&exportFile,
+		"file",
+		"f",
+		"",
+		"Path to write the porter.yaml file to, instead of stdout",
+	)
+}
+
+func exportRelease(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ExportRelease(context.Background(), config.Project, config.Cluster, namespace, app)
+
+	if err != nil {
+		return err
+	}
+
+	bytes, err := yaml.Marshal(resp)
+
+	if err != nil {
+		return err
+	}
+
+	// make sure that the exported file can be read by "porter apply"
+	if _, err := parser.ParseRawBytes(bytes); err != nil {
+		return fmt.Errorf("exported porter.yaml is not valid: %v", err)
+	}
+
+	if exportFile == "" {
+		_, err = os.Stdout.Write(bytes)
+		return err
+	}
+
+	if err := ioutil.WriteFile(exportFile, bytes, 0644); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Exported %s to %s\n", app, exportFile)
+
+	return nil
+}
@@ -2,7 +2,11 @@ package gitutils
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/cli/cli/git"
@@ -100,3 +104,75 @@ func ParseGithubRemote(remote *git.Remote) (bool, string) {
 
 	return true, strings.Trim(strings.TrimSuffix(remote.FetchURL.Path, ".git"), "/")
 }
+
+// GetDefaultBranch returns the default branch of a remote, as recorded by the last fetch
+// of the remote
+func GetDefaultBranch(fullpath, remoteName string) (string, error) {
+	out, err := exec.Command(
+		"git", "-C", fullpath, "symbolic-ref", "--short", fmt.Sprintf("refs/remotes/%s/HEAD", remoteName),
+	).Output()
+
+	if err != nil {
+		return "", fmt.Errorf("could not read default branch of remote %s: %s", remoteName, err.Error())
+	}
+
+	return strings.TrimPrefix(strings.TrimSpace(string(out)), remoteName+"/"), nil
+}
+
+// GetRepoPath returns the path of a file relative to the top-level directory of the git
+// repository which contains it
+func GetRepoPath(filePath string) (string, error) {
+	out, err := exec.Command(
+		"git", "-C", filepath.Dir(filePath), "rev-parse", "--show-prefix",
+	).Output()
+
+	if err != nil {
+		return "", fmt.Errorf("%s is not in a git repository: %s", filePath, err.Error())
+	}
+
+	return path.Join(strings.TrimSpace(string(out)), filepath.Base(filePath)), nil
+}
+
+// GetRemoteURL returns the URL of the origin remote of the git repository at fullpath,
+// without its scheme, credentials or .git suffix, e.g. github.com/porter-dev/porter
+func GetRemoteURL(fullpath string) (string, error) {
+	out, err := exec.Command(
+		"git", "-C", fullpath, "config", "--get", "remote.origin.url",
+	).Output()
+
+	if err != nil {
+		return "", fmt.Errorf("could not read the origin remote: %s", err.Error())
+	}
+
+	return normalizeRemoteURL(strings.TrimSpace(string(out)))
+}
+
+func normalizeRemoteURL(remoteURL string) (string, error) {
+	var host, repoPath string
+
+	if strings.Contains(remoteURL, "://") {
+		parsed, err := url.Parse(remoteURL)
+
+		if err != nil {
+			// the error is not returned, since the URL may contain credentials
+			return "", fmt.Errorf("the remote URL is not a valid URL")
+		}
+
+		host, repoPath = parsed.Hostname(), parsed.Path
+	} else if i := strings.Index(remoteURL, ":"); i != -1 {
+		// scp-like remotes such as git@github.com:porter-dev/porter.git
+		host, repoPath = remoteURL[:i], remoteURL[i+1:]
+
+		if j := strings.LastIndex(host, "@"); j != -1 {
+			host = host[j+1:]
+		}
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+
+	if host == "" || repoPath == "" {
+		return "", fmt.Errorf("remote URL %s is not a URL of a hosted repository", remoteURL)
+	}
+
+	return fmt.Sprintf("%s/%s", strings.ToLower(host), repoPath), nil
+}
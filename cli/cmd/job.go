@@ -5,13 +5,18 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var jobCmd = &cobra.Command{
@@ -81,7 +86,129 @@ use the --namespace flag:
 	},
 }
 
+var jobRunCmd = &cobra.Command{
+	Use:   "run [name] -- [command]",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Triggers a run of a job and streams its logs.",
+	Long: fmt.Sprintf(`
+%s 
+
+Triggers a new run of a job, using the job template of the job release. The command of the job
+can be overridden by passing it after "--", and environment variables can be overridden with the
+--env flag. The logs of the run are streamed to the terminal, and this command exits with exit
+code 1 if the run fails.
+
+Example commands:
+
+  %s
+
+  %s
+
+This command is namespace-scoped and uses the default namespace. To specify a different namespace, 
+use the --namespace flag:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter job run\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter job run job-example"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter job run job-example --env DEBUG=true -- python manage.py migrate"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter job run job-example --namespace custom-namespace"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, runJob)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var jobListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the jobs in a namespace.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listJobs)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var jobHistoryCmd = &cobra.Command{
+	Use:   "history [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Lists the past runs of a job.",
+	Long: fmt.Sprintf(`
+%s 
+
+Lists the past runs of a job, including runs which have been removed from the cluster. To show the
+details and the end of the logs of a single run, pass the ID of the run with the --run flag.
+
+Example commands:
+
+  %s
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter job history\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter job history job-example"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter job history job-example --run 12"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, jobHistory)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var jobSuspendCmd = &cobra.Command{
+	Use:   "suspend [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Suspends the schedule of a cron job.",
+	Long: fmt.Sprintf(`
+%s 
+
+Suspends the schedule of a cron job, so that no new runs are started until the job is resumed. Runs
+which have already started are not stopped. Note that the schedule may be resumed when the job is
+redeployed.
+
+Example commands:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter job suspend\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter job suspend job-example"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, suspendJob)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var jobResumeCmd = &cobra.Command{
+	Use:   "resume [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Resumes the schedule of a suspended cron job.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, resumeJob)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
 var imageRepoURI string
+var jobEnv []string
+var jobDetach bool
+var jobRunID uint
+var jobHistoryLimit int
 
 func init() {
 	rootCmd.AddCommand(jobCmd)
@@ -128,6 +255,50 @@ func init() {
 	)
 
 	waitCmd.MarkPersistentFlagRequired("name")
+
+	jobCmd.AddCommand(jobRunCmd)
+	jobCmd.AddCommand(jobListCmd)
+	jobCmd.AddCommand(jobHistoryCmd)
+	jobCmd.AddCommand(jobSuspendCmd)
+	jobCmd.AddCommand(jobResumeCmd)
+
+	for _, cmd := range []*cobra.Command{jobRunCmd, jobListCmd, jobHistoryCmd, jobSuspendCmd, jobResumeCmd} {
+		cmd.PersistentFlags().StringVar(
+			&namespace,
+			"namespace",
+			"default",
+			"The namespace of the jobs.",
+		)
+	}
+
+	jobRunCmd.PersistentFlags().StringArrayVarP(
+		&jobEnv,
+		"env",
+		"e",
+		[]string{},
+		"An environment variable to set for the run, in the form KEY=VALUE. Can be specified multiple times.",
+	)
+
+	jobHistoryCmd.PersistentFlags().UintVar(
+		&jobRunID,
+		"run",
+		0,
+		"The ID of a run to show the details and logs of.",
+	)
+
+	jobHistoryCmd.PersistentFlags().IntVar(
+		&jobHistoryLimit,
+		"limit",
+		20,
+		"The maximum number of runs to list.",
+	)
+
+	jobRunCmd.PersistentFlags().BoolVar(
+		&jobDetach,
+		"detach",
+		false,
+		"Don't stream the logs of the run or wait for it to complete.",
+	)
 }
 
 func batchImageUpdate(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -223,3 +394,302 @@ func getJobMatchingRevision(revision uint, jobs []v1.Job) *v1.Job {
 
 	return nil
 }
+
+func runJob(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	env := make(map[string]string)
+
+	for _, envVar := range jobEnv {
+		keyVal := strings.SplitN(envVar, "=", 2)
+
+		if len(keyVal) != 2 || keyVal[0] == "" {
+			return fmt.Errorf("invalid environment variable %s: must be in the form KEY=VALUE", envVar)
+		}
+
+		env[keyVal[0]] = keyVal[1]
+	}
+
+	job, err := client.RunJob(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		args[0],
+		&types.RunJobRequest{
+			Command: args[1:],
+			Env:     env,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Started run %s of job %s\n", job.Name, args[0])
+
+	if jobDetach {
+		return nil
+	}
+
+	kubeConfig := &PorterRunSharedConfig{
+		Client: client,
+	}
+
+	if err := kubeConfig.setSharedConfig(); err != nil {
+		return fmt.Errorf("Could not retrieve kube credentials: %s", err.Error())
+	}
+
+	pod, err := waitForJobPod(kubeConfig, namespace, job.Name)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := pipePodLogsToStdout(kubeConfig, namespace, pod.Name, pod.Spec.Containers[0].Name, true); err != nil {
+		return err
+	}
+
+	return waitForJobRun(kubeConfig, namespace, job.Name)
+}
+
+// waitForJobPod waits for the pod of a job run to start, and returns the pod
+func waitForJobPod(kubeConfig *PorterRunSharedConfig, namespace, jobName string) (*corev1.Pod, error) {
+	for timeWait := time.Now().Add(5 * time.Minute); time.Now().Before(timeWait); time.Sleep(2 * time.Second) {
+		pods, err := kubeConfig.Clientset.CoreV1().Pods(namespace).List(
+			context.Background(),
+			metav1.ListOptions{
+				LabelSelector: fmt.Sprintf("job-name=%s", jobName),
+			},
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range pods.Items {
+			if pod.Status.Phase != corev1.PodPending {
+				return &pod, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("timed out waiting for job %s to start", jobName)
+}
+
+// waitForJobRun waits for a job run to complete, and returns an error if it failed
+func waitForJobRun(kubeConfig *PorterRunSharedConfig, namespace, jobName string) error {
+	for timeWait := time.Now().Add(5 * time.Minute); time.Now().Before(timeWait); time.Sleep(2 * time.Second) {
+		job, err := kubeConfig.Clientset.BatchV1().Jobs(namespace).Get(
+			context.Background(),
+			jobName,
+			metav1.GetOptions{},
+		)
+
+		if err != nil {
+			return err
+		}
+
+		for _, cond := range job.Status.Conditions {
+			if cond.Status != corev1.ConditionTrue {
+				continue
+			}
+
+			if cond.Type == v1.JobComplete {
+				color.New(color.FgGreen).Printf("Job run %s succeeded\n", jobName)
+				return nil
+			} else if cond.Type == v1.JobFailed {
+				return fmt.Errorf("job run %s failed: %s", jobName, cond.Message)
+			}
+		}
+	}
+
+	return fmt.Errorf("timed out waiting for job run %s to complete", jobName)
+}
+
+func listJobs(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	releases, err := client.ListReleases(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		&types.ListReleasesRequest{
+			ReleaseListFilter: &types.ReleaseListFilter{
+				StatusFilter: []string{
+					"deployed",
+					"failed",
+					"pending-install",
+					"pending-upgrade",
+					"pending-rollback",
+				},
+			},
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "NAME", "SCHEDULE", "VERSION", "STATUS")
+
+	for _, rel := range releases {
+		if rel.Chart == nil || rel.Chart.Metadata == nil || rel.Chart.Metadata.Name != "job" {
+			continue
+		}
+
+		schedule := getCronSchedule(rel.Manifest)
+
+		if schedule == "" {
+			schedule = "manual"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", rel.Name, schedule, rel.Version, rel.Info.Status)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+// getCronSchedule returns the schedule of the first cron job in a release manifest, or
+// an empty string if the release does not contain a cron job
+func getCronSchedule(manifest string) string {
+	objs := grapher.ParseObjs(grapher.ImportMultiDocYAML([]byte(manifest)), "")
+
+	for _, obj := range objs {
+		if obj.Kind != "CronJob" {
+			continue
+		}
+
+		if spec, ok := obj.RawYAML["spec"].(map[string]interface{}); ok {
+			if schedule, ok := spec["schedule"].(string); ok {
+				return schedule
+			}
+		}
+	}
+
+	return ""
+}
+
+func jobHistory(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	if jobRunID != 0 {
+		return jobRunDetails(client, args[0])
+	}
+
+	resp, err := client.ListJobRuns(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		args[0],
+		&types.ListJobRunsRequest{Limit: jobHistoryLimit},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "ID", "NAME", "STARTED", "DURATION", "STATUS", "TRIGGERED BY", "IMAGE TAG")
+
+	for _, run := range resp.JobRuns {
+		started, duration := getJobRunTimes(run)
+
+		fmt.Fprintf(
+			w,
+			"%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			run.ID, run.JobName, started, duration, run.Status, run.TriggeredBy, run.ImageTag,
+		)
+	}
+
+	w.Flush()
+
+	if resp.Count > int64(len(resp.JobRuns)) {
+		fmt.Printf("\nShowing %d of %d runs. Use --limit to show more.\n", len(resp.JobRuns), resp.Count)
+	}
+
+	return nil
+}
+
+func jobRunDetails(client *api.Client, name string) error {
+	run, err := client.GetJobRun(context.Background(), config.Project, config.Cluster, namespace, name, jobRunID)
+
+	if err != nil {
+		return err
+	}
+
+	started, duration := getJobRunTimes((*types.JobRun)(run))
+
+	fmt.Printf("Name:         %s\n", run.JobName)
+	fmt.Printf("Status:       %s\n", run.Status)
+	fmt.Printf("Started:      %s\n", started)
+	fmt.Printf("Duration:     %s\n", duration)
+
+	if run.ExitCode != nil {
+		fmt.Printf("Exit code:    %d\n", *run.ExitCode)
+	}
+
+	fmt.Printf("Triggered by: %s\n", run.TriggeredBy)
+	fmt.Printf("Image tag:    %s\n", run.ImageTag)
+
+	if run.LogTail != "" {
+		fmt.Printf("\nLogs:\n%s", run.LogTail)
+	}
+
+	return nil
+}
+
+func getJobRunTimes(run *types.JobRun) (started, duration string) {
+	if run.StartedAt == nil {
+		return "-", "-"
+	}
+
+	end := time.Now()
+
+	if run.FinishedAt != nil {
+		end = *run.FinishedAt
+	}
+
+	return run.StartedAt.Local().Format("2006-01-02 15:04:05"), end.Sub(*run.StartedAt).Round(time.Second).String()
+}
+
+func suspendJob(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	err := client.SuspendCronJob(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		args[0],
+		&types.SuspendCronJobRequest{Suspend: true},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Suspended the schedule of job %s\n", args[0])
+
+	return nil
+}
+
+func resumeJob(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	err := client.SuspendCronJob(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		args[0],
+		&types.SuspendCronJobRequest{Suspend: false},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Resumed the schedule of job %s\n", args[0])
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strings"
 
 	"github.com/buildpacks/pack"
@@ -14,9 +15,17 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/docker"
 	"github.com/porter-dev/porter/cli/cmd/github"
+	"github.com/porter-dev/porter/internal/platform"
 	"k8s.io/client-go/util/homedir"
 )
 
+// amd64OnlyBuilders are the prefixes of the Paketo and Heroku builder images which are
+// only published for linux/amd64
+var amd64OnlyBuilders = []string{
+	"paketobuildpacks/builder:",
+	"heroku/buildpacks:",
+}
+
 type Agent struct{}
 
 func (a *Agent) Build(opts *docker.BuildOpts, buildConfig *types.BuildConfig) error {
@@ -127,9 +136,53 @@ func (a *Agent) Build(opts *docker.BuildOpts, buildConfig *types.BuildConfig) er
 		// FIXME: use all the config vars
 	}
 
+	if err := checkPlatforms(buildOpts.Builder, opts.Platforms, getDaemonPlatform()); err != nil {
+		return err
+	}
+
 	if len(buildOpts.Buildpacks) > 0 && strings.HasPrefix(buildOpts.Builder, "heroku") {
 		buildOpts.Buildpacks = append(buildOpts.Buildpacks, "heroku/procfile")
 	}
 
 	return client.Build(context.Background(), buildOpts)
 }
+
+// checkPlatforms returns an error if the build cannot produce an image for the given
+// platforms. Pack does not accept a platform, and always builds a single image for the
+// platform of the Docker daemon, so an explicit platform must match the daemon platform.
+// The Paketo and Heroku builders additionally only support linux/amd64. If no platforms
+// are given, the image is built for the daemon platform.
+func checkPlatforms(builder string, platforms []string, daemonPlatform string) error {
+	if len(platforms) == 0 {
+		return nil
+	}
+
+	if len(platforms) > 1 {
+		return fmt.Errorf("buildpack builds only support a single platform, got %s", strings.Join(platforms, ", "))
+	}
+
+	for _, prefix := range amd64OnlyBuilders {
+		if strings.HasPrefix(builder, prefix) && platforms[0] != platform.Default {
+			return fmt.Errorf(
+				"platform %s is not supported by the builder %s, which only supports %s",
+				platforms[0], builder, platform.Default,
+			)
+		}
+	}
+
+	if platforms[0] != daemonPlatform {
+		return fmt.Errorf(
+			"buildpack builds only support the platform of the Docker daemon, %s, got %s",
+			daemonPlatform, platforms[0],
+		)
+	}
+
+	return nil
+}
+
+// getDaemonPlatform returns the platform of the images which the Docker daemon builds.
+// Buildpack images are always linux images, and a daemon on macOS or Windows runs them
+// in a linux VM with the architecture of the host.
+func getDaemonPlatform() string {
+	return "linux/" + runtime.GOARCH
+}
@@ -0,0 +1,31 @@
+package pack
+
+import "testing"
+
+func TestCheckPlatforms(t *testing.T) {
+	tests := []struct {
+		builder        string
+		platforms      []string
+		daemonPlatform string
+		expErr         bool
+	}{
+		{"paketobuildpacks/builder:full", nil, "linux/arm64", false},
+		{"paketobuildpacks/builder:full", []string{"linux/amd64"}, "linux/amd64", false},
+		{"paketobuildpacks/builder:full", []string{"linux/amd64"}, "linux/arm64", true},
+		{"paketobuildpacks/builder:full", []string{"linux/arm64"}, "linux/arm64", true},
+		{"heroku/buildpacks:20", []string{"linux/arm64"}, "linux/arm64", true},
+		{"example.com/builders/multi-arch:latest", []string{"linux/arm64"}, "linux/arm64", false},
+		{"example.com/builders/multi-arch:latest", []string{"linux/arm64"}, "linux/amd64", true},
+		{"example.com/builders/multi-arch:latest", []string{"linux/amd64", "linux/arm64"}, "linux/amd64", true},
+	}
+
+	for _, test := range tests {
+		err := checkPlatforms(test.builder, test.platforms, test.daemonPlatform)
+
+		if test.expErr && err == nil {
+			t.Errorf("expected an error for builder %s and platforms %v", test.builder, test.platforms)
+		} else if !test.expErr && err != nil {
+			t.Errorf("expected no error for builder %s and platforms %v, got %v", test.builder, test.platforms, err)
+		}
+	}
+}
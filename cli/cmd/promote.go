@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	promoteTargetNamespace string
+	promoteTargetCluster   uint
+	promoteEnvGroups       []string
+)
+
+// promoteCmd represents the "porter promote" command
+var promoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Promotes an application to another namespace or cluster.",
+	Long: fmt.Sprintf(`
+%s
+
+Promotes an application specified by the --app flag to the namespace given by the
+--target-namespace flag. The application in the target namespace is upgraded to the chart,
+chart version, values and image tag of the source application, and is created if it does not
+exist. For example:
+
+  %s
+
+To promote to another cluster in the project, pass the --target-cluster flag. Values which
+differ between the namespaces can be passed in a values.yaml file with the --values flag, and
+the image tag can be changed with the --tag flag:
+
+  %s
+
+Env groups referenced by the application must exist in the target namespace. If the env groups
+have different names in the target namespace, map them with the --env-group flag:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter promote\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter promote --app example-app --namespace staging --target-namespace production"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter promote --app example-app --target-cluster 2 --target-namespace default --values prod-values.yaml --tag v1.2.0"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter promote --app example-app --target-namespace production --env-group staging-env=production-env"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, promoteRelease)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(promoteCmd)
+
+	promoteCmd.PersistentFlags().StringVar(
+		&app,
+		"app",
+		"",
+		"Application in the Porter dashboard",
+	)
+
+	promoteCmd.MarkPersistentFlagRequired("app")
+
+	promoteCmd.PersistentFlags().StringVar(
+		&namespace,
+		"namespace",
+		"default",
+		"Namespace of the application",
+	)
+
+	promoteCmd.PersistentFlags().StringVar(
+		&promoteTargetNamespace,
+		"target-namespace",
+		"",
+		"Namespace to promote the application to",
+	)
+
+	promoteCmd.MarkPersistentFlagRequired("target-namespace")
+
+	promoteCmd.PersistentFlags().UintVar(
+		&promoteTargetCluster,
+		"target-cluster",
+		0,
+		"ID of the cluster to promote the application to, if not the current cluster",
+	)
+
+	promoteCmd.PersistentFlags().StringVarP(
+		&values,
+		"values",
+		"v",
+		"",
+		"Filepath to a values.yaml file with values for the target namespace",
+	)
+
+	promoteCmd.PersistentFlags().StringVarP(
+		&tag,
+		"tag",
+		"t",
+		"",
+		"the image tag to promote, if not the tag of the application",
+	)
+
+	promoteCmd.PersistentFlags().StringArrayVar(
+		&promoteEnvGroups,
+		"env-group",
+		[]string{},
+		"Maps an env group to an env group of the target namespace, as SOURCE=TARGET. Can be passed multiple times.",
+	)
+}
+
+func promoteRelease(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	envGroups := make(map[string]string)
+
+	for _, mapping := range promoteEnvGroups {
+		source, target, err := parseEnvAssignment(mapping)
+
+		if err != nil || target == "" {
+			return fmt.Errorf("invalid env group mapping %q, expected SOURCE=TARGET", mapping)
+		}
+
+		envGroups[source] = target
+	}
+
+	valuesObj, err := readValuesFile()
+
+	if err != nil {
+		return err
+	}
+
+	valuesYAML := ""
+
+	if len(valuesObj) > 0 {
+		bytes, err := yaml.Marshal(valuesObj)
+
+		if err != nil {
+			return err
+		}
+
+		valuesYAML = string(bytes)
+	}
+
+	resp, err := client.PromoteRelease(
+		context.Background(),
+		config.Project,
+		config.Cluster,
+		namespace,
+		app,
+		&types.PromoteReleaseRequest{
+			TargetClusterID: promoteTargetCluster,
+			TargetNamespace: promoteTargetNamespace,
+			ImageTag:        tag,
+			Values:          valuesYAML,
+			EnvGroups:       envGroups,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	action := "Upgraded"
+
+	if resp.Created {
+		action = "Created"
+	}
+
+	details := []string{fmt.Sprintf("chart %s@%s", resp.Chart, resp.ChartVersion)}
+
+	if resp.ImageTag != "" {
+		details = append(details, fmt.Sprintf("image tag %s", resp.ImageTag))
+	}
+
+	color.New(color.FgGreen).Printf(
+		"%s %s in namespace %s of cluster %d to version %d (%s)\n",
+		action,
+		resp.Name,
+		resp.Namespace,
+		resp.ClusterID,
+		resp.Version,
+		strings.Join(details, ", "),
+	)
+
+	return nil
+}
@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var registryRetentionCmd = &cobra.Command{
+	Use:     "retention",
+	Aliases: []string{"retention-policy", "retention-policies"},
+	Short:   "Commands that manage the image retention policies of a registry",
+	Long: fmt.Sprintf(`
+%s
+
+Retention policies delete old images from the repositories of the current registry. They
+are enforced on a schedule by the Porter server, and are supported for ECR, GCR and DOCR
+registries. A policy can:
+
+  - keep the most recently pushed tags of each repository (--keep-last)
+  - delete untagged images after a number of days (--delete-untagged-after)
+  - never delete the images that live releases are deployed with (on by default)
+
+New policies are dry runs by default, so that only a report of the images which would be
+deleted is stored. Review the report, and then disable the dry run:
+
+  %s
+  %s
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter registry retention\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter registry retention create --repo web --keep-last 50 --delete-untagged-after 7"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter registry retention run 1 --dry-run"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter registry retention update 1 --dry-run=false"),
+	),
+}
+
+var registryRetentionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the retention policies of the current registry",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listRetentionPolicies)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var registryRetentionCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Creates a retention policy for the current registry",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, createRetentionPolicy)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var registryRetentionUpdateCmd = &cobra.Command{
+	Use:   "update [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Updates the rules of the retention policy with the given id",
+	Run: func(cmd *cobra.Command, args []string) {
+		// only the flags which are set are updated
+		retentionUpdateReq = &types.UpdateRegistryRetentionPolicyRequest{}
+
+		if cmd.Flags().Changed("keep-last") {
+			retentionUpdateReq.KeepLastTags = &retentionKeepLast
+		}
+
+		if cmd.Flags().Changed("delete-untagged-after") {
+			retentionUpdateReq.DeleteUntaggedAfterDays = &retentionUntaggedDays
+		}
+
+		if cmd.Flags().Changed("protect-live-releases") {
+			retentionUpdateReq.ProtectLiveReleases = &retentionProtectLive
+		}
+
+		if cmd.Flags().Changed("dry-run") {
+			retentionUpdateReq.DryRun = &retentionDryRun
+		}
+
+		err := checkLoginAndRun(args, updateRetentionPolicy)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var registryRetentionDeleteCmd = &cobra.Command{
+	Use:   "delete [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Deletes the retention policy with the given id",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, deleteRetentionPolicy)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var registryRetentionRunCmd = &cobra.Command{
+	Use:   "run [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Enforces the retention policy with the given id immediately",
+	Long: fmt.Sprintf(`
+%s
+
+Enforces a retention policy immediately, and prints the images which were deleted. Images
+are deleted even if the policy is a dry run, so that a policy can be enforced once after
+its report was reviewed. To only print the images which would be deleted, use --dry-run:
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter registry retention run\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter registry retention run 1 --dry-run"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, runRetentionPolicy)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var registryRetentionReportCmd = &cobra.Command{
+	Use:   "report [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Prints the report of the last time the retention policy with the given id was enforced",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, getRetentionReport)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var retentionRepoName string
+var retentionKeepLast uint
+var retentionUntaggedDays uint
+var retentionProtectLive bool
+var retentionDryRun bool
+var retentionRunDryRun bool
+var retentionRunConfirm bool
+var retentionUpdateReq *types.UpdateRegistryRetentionPolicyRequest
+
+func init() {
+	registryCmd.AddCommand(registryRetentionCmd)
+
+	registryRetentionCmd.AddCommand(registryRetentionListCmd)
+	registryRetentionCmd.AddCommand(registryRetentionCreateCmd)
+	registryRetentionCmd.AddCommand(registryRetentionUpdateCmd)
+	registryRetentionCmd.AddCommand(registryRetentionDeleteCmd)
+	registryRetentionCmd.AddCommand(registryRetentionRunCmd)
+	registryRetentionCmd.AddCommand(registryRetentionReportCmd)
+
+	registryRetentionCreateCmd.Flags().StringVar(
+		&retentionRepoName,
+		"repo",
+		"",
+		"The repository that the policy applies to. By default, the policy applies to every repository of the registry.",
+	)
+
+	for _, cmd := range []*cobra.Command{registryRetentionCreateCmd, registryRetentionUpdateCmd} {
+		cmd.Flags().UintVar(
+			&retentionKeepLast,
+			"keep-last",
+			0,
+			"The number of most recently pushed tags to keep in each repository. Older tags are deleted if set.",
+		)
+
+		cmd.Flags().UintVar(
+			&retentionUntaggedDays,
+			"delete-untagged-after",
+			0,
+			"The number of days after which untagged images are deleted.",
+		)
+
+		cmd.Flags().BoolVar(
+			&retentionProtectLive,
+			"protect-live-releases",
+			true,
+			"Never delete the images that the releases of the project are deployed with.",
+		)
+
+		cmd.Flags().BoolVar(
+			&retentionDryRun,
+			"dry-run",
+			true,
+			"Only report the images which would be deleted when the policy is enforced on schedule.",
+		)
+	}
+
+	registryRetentionRunCmd.Flags().BoolVar(
+		&retentionRunDryRun,
+		"dry-run",
+		false,
+		"Only print the images which would be deleted.",
+	)
+
+	registryRetentionRunCmd.Flags().BoolVarP(
+		&retentionRunConfirm,
+		"yes",
+		"y",
+		false,
+		"Delete images without confirmation.",
+	)
+}
+
+func listRetentionPolicies(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ListRegistryRetentionPolicies(context.Background(), config.Project, config.Registry)
+
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "ID", "REPOSITORY", "KEEP LAST", "UNTAGGED DAYS", "PROTECT LIVE", "DRY RUN", "LAST RUN")
+
+	for _, policy := range *resp {
+		repoName := policy.RepositoryName
+
+		if repoName == "" {
+			repoName = "(all)"
+		}
+
+		lastRun := "never"
+
+		if policy.LastRunAt != nil {
+			lastRun = policy.LastRunAt.String()
+		}
+
+		fmt.Fprintf(
+			w, "%d\t%s\t%d\t%d\t%t\t%t\t%s\n",
+			policy.ID, repoName, policy.KeepLastTags, policy.DeleteUntaggedAfterDays,
+			policy.ProtectLiveReleases, policy.DryRun, lastRun,
+		)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func createRetentionPolicy(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.CreateRegistryRetentionPolicy(
+		context.Background(),
+		config.Project,
+		config.Registry,
+		&types.CreateRegistryRetentionPolicyRequest{
+			RepositoryName:          retentionRepoName,
+			KeepLastTags:            retentionKeepLast,
+			DeleteUntaggedAfterDays: retentionUntaggedDays,
+			ProtectLiveReleases:     &retentionProtectLive,
+			DryRun:                  &retentionDryRun,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Created retention policy with id %d\n", resp.ID)
+
+	if resp.DryRun {
+		fmt.Printf("The policy is a dry run. To review the images it would delete, run: porter registry retention run %d --dry-run\n", resp.ID)
+	}
+
+	return nil
+}
+
+func updateRetentionPolicy(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = client.UpdateRegistryRetentionPolicy(
+		context.Background(),
+		config.Project,
+		config.Registry,
+		uint(id),
+		retentionUpdateReq,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Updated retention policy with id %d\n", id)
+
+	return nil
+}
+
+func deleteRetentionPolicy(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	err = client.DeleteRegistryRetentionPolicy(context.Background(), config.Project, config.Registry, uint(id))
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Deleted retention policy with id %d\n", id)
+
+	return nil
+}
+
+func runRetentionPolicy(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	if !retentionRunDryRun && !retentionRunConfirm {
+		userResp, err := utils.PromptPlaintext(
+			fmt.Sprintf(
+				`Are you sure you'd like to delete the images selected by retention policy %d? %s `,
+				id,
+				color.New(color.FgCyan).Sprintf("[y/n]"),
+			),
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if userResp := strings.ToLower(userResp); userResp != "y" && userResp != "yes" {
+			return nil
+		}
+	}
+
+	resp, err := client.RunRegistryRetentionPolicy(
+		context.Background(),
+		config.Project,
+		config.Registry,
+		uint(id),
+		&types.RunRegistryRetentionPolicyRequest{
+			DryRun: retentionRunDryRun,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	report := types.RegistryRetentionReport(*resp)
+
+	return printRetentionReport(&report)
+}
+
+func getRetentionReport(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	policy, err := client.GetRegistryRetentionPolicy(context.Background(), config.Project, config.Registry, uint(id))
+
+	if err != nil {
+		return err
+	}
+
+	if policy.LastReport == nil {
+		fmt.Printf("Retention policy %d has not been enforced yet\n", id)
+		return nil
+	}
+
+	return printRetentionReport(policy.LastReport)
+}
+
+func printRetentionReport(report *types.RegistryRetentionReport) error {
+	verb := "deleted"
+
+	if report.DryRun {
+		verb = "would delete"
+	}
+
+	fmt.Printf("Retention policy %d evaluated at %s\n", report.PolicyID, report.EvaluatedAt.String())
+
+	for _, repoReport := range report.Repositories {
+		fmt.Println()
+
+		if repoReport.Error != "" {
+			color.New(color.FgRed).Printf("%s: %s\n", repoReport.RepositoryName, repoReport.Error)
+		}
+
+		fmt.Printf(
+			"%s: %s %d images, kept %d images\n",
+			repoReport.RepositoryName, verb, len(repoReport.Deleted), len(repoReport.Kept),
+		)
+
+		if len(repoReport.Deleted) == 0 {
+			continue
+		}
+
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "TAG", "DIGEST", "PUSHED AT", "REASON")
+
+		for _, img := range repoReport.Deleted {
+			tag := img.Tag
+
+			if tag == "" {
+				tag = "<untagged>"
+			}
+
+			pushedAt := "unknown"
+
+			if img.PushedAt != nil {
+				pushedAt = img.PushedAt.String()
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", tag, img.Digest, pushedAt, img.Reason)
+		}
+
+		w.Flush()
+	}
+
+	return nil
+}
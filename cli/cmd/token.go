@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+// tokenCmd represents the "porter token" base command when called
+// without any subcommands
+var tokenCmd = &cobra.Command{
+	Use:     "token",
+	Aliases: []string{"tokens"},
+	Short:   "Commands that manage API tokens for a project",
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Creates an API token for the current project",
+	Long: fmt.Sprintf(`
+%s
+
+Creates an API token for the current project, which can be used as the PORTER_TOKEN in
+CI environments. The token is only printed once, so store it somewhere safe.
+
+Example commands:
+
+  %s
+
+By default, the token has the same permissions as your user and never expires. To set an
+expiry or restrict the token with a policy file, use the --expires-in and --policy flags:
+
+  %s
+
+API tokens which were issued before tokens could be revoked are no longer accepted after the
+cutoff configured on the Porter server, so replace them with a token created by this command.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter token create\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter token create --name github-actions"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter token create --name github-actions --expires-in 720h --policy ./policy.json"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, createToken)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the API tokens for the current project",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listTokens)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Revokes the API token with the given id",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, revokeToken)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var tokenName string
+var tokenExpiresIn time.Duration
+var tokenPolicyFile string
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+
+	tokenCmd.AddCommand(tokenCreateCmd)
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+
+	tokenCreateCmd.PersistentFlags().StringVar(
+		&tokenName,
+		"name",
+		"",
+		"The name of the token.",
+	)
+
+	tokenCreateCmd.PersistentFlags().DurationVar(
+		&tokenExpiresIn,
+		"expires-in",
+		0,
+		"The duration that the token is valid for, for example 720h. The token does not expire by default.",
+	)
+
+	tokenCreateCmd.PersistentFlags().StringVar(
+		&tokenPolicyFile,
+		"policy",
+		"",
+		"Path to a JSON file containing the policy documents that the token is restricted to.",
+	)
+
+	tokenCreateCmd.MarkPersistentFlagRequired("name")
+}
+
+func createToken(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	req := &types.CreateAPITokenRequest{
+		Name: tokenName,
+	}
+
+	if tokenExpiresIn > 0 {
+		expiry := time.Now().Add(tokenExpiresIn)
+		req.Expiry = &expiry
+	}
+
+	if tokenPolicyFile != "" {
+		policyBytes, err := ioutil.ReadFile(tokenPolicyFile)
+
+		if err != nil {
+			return fmt.Errorf("could not read policy file: %w", err)
+		}
+
+		if err := json.Unmarshal(policyBytes, &req.Policy); err != nil {
+			return fmt.Errorf("could not parse policy file: %w", err)
+		}
+	}
+
+	resp, err := client.CreateAPIToken(context.Background(), config.Project, req)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Created token %s with id %s\n", resp.Name, resp.ID)
+	color.New(color.FgYellow).Println("This token will not be shown again:")
+	fmt.Println(resp.Token)
+
+	return nil
+}
+
+func listTokens(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ListAPITokens(context.Background(), config.Project)
+
+	if err != nil {
+		return err
+	}
+
+	tokens := *resp
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "ID", "NAME", "EXPIRES", "LAST USED", "STATUS")
+
+	for _, tok := range tokens {
+		expires, lastUsed, status := "never", "never", "active"
+
+		if tok.Expiry != nil {
+			expires = tok.Expiry.Format(time.RFC3339)
+
+			if tok.Expiry.Before(time.Now()) {
+				status = "expired"
+			}
+		}
+
+		if tok.LastUsed != nil {
+			lastUsed = tok.LastUsed.Format(time.RFC3339)
+		}
+
+		if tok.Revoked {
+			status = "revoked"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", tok.ID, tok.Name, expires, lastUsed, status)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func revokeToken(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	_, err := client.RevokeAPIToken(context.Background(), config.Project, args[0])
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Revoked token with id %s\n", args[0])
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var dotEnvKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// ParseDotEnv reads variables from a dotenv file. Blank lines and lines starting with #
+// are skipped, and an optional "export " prefix is allowed. Values may be wrapped in
+// single quotes, which are taken literally, or double quotes, which support the \n, \t,
+// \" and \\ escapes.
+func ParseDotEnv(r io.Reader) (map[string]string, error) {
+	res := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		eqIndex := strings.Index(line, "=")
+
+		if eqIndex == -1 {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+
+		key := strings.TrimSpace(line[:eqIndex])
+
+		if !dotEnvKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNum, key)
+		}
+
+		val, err := parseDotEnvValue(strings.TrimSpace(line[eqIndex+1:]))
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNum, err)
+		}
+
+		res[key] = val
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func parseDotEnvValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	switch raw[0] {
+	case '\'':
+		end := strings.Index(raw[1:], "'")
+
+		if end == -1 {
+			return "", fmt.Errorf("unterminated single-quoted value")
+		}
+
+		return raw[1 : end+1], nil
+	case '"':
+		var sb strings.Builder
+
+		for i := 1; i < len(raw); i++ {
+			switch c := raw[i]; c {
+			case '"':
+				return sb.String(), nil
+			case '\\':
+				if i+1 == len(raw) {
+					return "", fmt.Errorf("unterminated double-quoted value")
+				}
+
+				i++
+
+				switch raw[i] {
+				case 'n':
+					sb.WriteByte('\n')
+				case 't':
+					sb.WriteByte('\t')
+				default:
+					sb.WriteByte(raw[i])
+				}
+			default:
+				sb.WriteByte(c)
+			}
+		}
+
+		return "", fmt.Errorf("unterminated double-quoted value")
+	}
+
+	// unquoted values end at an inline comment
+	if commentIndex := strings.Index(raw, " #"); commentIndex != -1 {
+		raw = strings.TrimSpace(raw[:commentIndex])
+	}
+
+	return raw, nil
+}
+
+// FormatDotEnv writes variables in dotenv format, sorted by name. Values which cannot be
+// written unquoted are double-quoted.
+func FormatDotEnv(w io.Writer, vars map[string]string) error {
+	keys := make([]string, 0, len(vars))
+
+	for key := range vars {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", key, formatDotEnvValue(vars[key])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatDotEnvValue(val string) string {
+	if val != "" && !strings.ContainsAny(val, " \t\n\"'\\#$`") {
+		return val
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+
+	return `"` + replacer.Replace(val) + `"`
+}
@@ -0,0 +1,90 @@
+package utils_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/cli/cmd/utils"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	input := `
+# database settings
+DB_HOST=localhost
+export DB_PORT=5432
+EMPTY=
+GREETING="hello world" # inline comments are ignored
+MULTILINE="line one\nline two"
+LITERAL='no $expansion\n'
+QUOTE="say \"hi\""
+URL=https://example.com/#anchor
+`
+
+	res, err := utils.ParseDotEnv(strings.NewReader(input))
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expected := map[string]string{
+		"DB_HOST":   "localhost",
+		"DB_PORT":   "5432",
+		"EMPTY":     "",
+		"GREETING":  "hello world",
+		"MULTILINE": "line one\nline two",
+		"LITERAL":   `no $expansion\n`,
+		"QUOTE":     `say "hi"`,
+		"URL":       "https://example.com/#anchor",
+	}
+
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("expected %v, got %v", expected, res)
+	}
+}
+
+func TestParseDotEnvErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing equals":     "DB_HOST",
+		"invalid name":       "1DB=localhost",
+		"unterminated quote": `DB_HOST="localhost`,
+	}
+
+	for name, input := range tests {
+		if _, err := utils.ParseDotEnv(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestFormatDotEnvRoundTrip(t *testing.T) {
+	vars := map[string]string{
+		"PLAIN":     "value",
+		"EMPTY":     "",
+		"SPACES":    "hello world",
+		"MULTILINE": "line one\nline two",
+		"QUOTES":    `say "hi" and 'bye'`,
+		"BACKSLASH": `C:\porter`,
+	}
+
+	var buf bytes.Buffer
+
+	if err := utils.FormatDotEnv(&buf, vars); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if !strings.HasPrefix(buf.String(), "BACKSLASH=") {
+		t.Errorf("expected variables to be sorted, got:\n%s", buf.String())
+	}
+
+	res, err := utils.ParseDotEnv(&buf)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if !reflect.DeepEqual(res, vars) {
+		t.Errorf("expected %v, got %v", vars, res)
+	}
+}
@@ -10,7 +10,12 @@ import (
 	"github.com/porter-dev/porter/api/server/router"
 	"github.com/porter-dev/porter/api/server/shared/config/loader"
 	"github.com/porter-dev/porter/internal/adapter"
+	"github.com/porter-dev/porter/internal/canary"
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/jobrun"
 	"github.com/porter-dev/porter/internal/kubernetes/provisioner"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/registry/retention"
 )
 
 // Version will be linked by an ldflag during build
@@ -47,9 +52,52 @@ func main() {
 
 		errorChan := make(chan error)
 
-		go provisioner.GlobalStreamListener(redis, config.Repo, config.AnalyticsClient, errorChan)
+		go provisioner.GlobalStreamListener(redis, config.Repo, config.AnalyticsClient, config.NotificationRegistry, errorChan)
 	}
 
+	secretRefresher := &envgroup.SecretRefresher{
+		Repo:     config.Repo,
+		Resolver: envgroup.NewSecretResolver(config.Repo, config.SecretVault),
+		DOConf:   config.DOConf,
+		Logger:   config.Logger,
+		Interval: config.ServerConf.EnvGroupSecretSyncInterval,
+	}
+
+	go secretRefresher.Run()
+
+	retentionEnforcer := &retention.Enforcer{
+		Repo:     config.Repo,
+		DOConf:   config.DOConf,
+		Logger:   config.Logger,
+		Interval: config.ServerConf.RegistryRetentionInterval,
+	}
+
+	go retentionEnforcer.Run()
+
+	canaryCleaner := &canary.Cleaner{
+		Repo:   config.Repo,
+		DOConf: config.DOConf,
+		Logger: config.Logger,
+	}
+
+	go canaryCleaner.Run()
+
+	jobRunRecorder := &jobrun.Recorder{
+		Repo:     config.Repo,
+		DOConf:   config.DOConf,
+		Logger:   config.Logger,
+		Interval: config.ServerConf.JobRunRecordInterval,
+	}
+
+	go jobRunRecorder.Run()
+
+	webhookRetrier := &webhook.Retrier{
+		Repo:   config.Repo,
+		Logger: config.Logger,
+	}
+
+	go webhookRetrier.Run()
+
 	appRouter := router.NewAPIRouter(config)
 
 	address := fmt.Sprintf(":%d", config.ServerConf.Port)
@@ -165,7 +165,7 @@ const KeyValueArray: React.FC<Props> = (props) => {
                 };
               })
             }
-            setValues={(values) => {
+            setValues={(values, envGroupName) => {
               setState((prev) => {
                 // Transform array to object similar on what we receive from setValues
                 const prevValues = prev.values.reduce((acc, currentValue) => {
@@ -185,8 +185,15 @@ const KeyValueArray: React.FC<Props> = (props) => {
                   };
                 });
 
+                // Record the env group so the server can redeploy this release when it changes
+                const syncedEnvGroups = prev.syncedEnvGroups || [];
+
                 return {
                   values: [...newValues],
+                  syncedEnvGroups:
+                    envGroupName && !syncedEnvGroups.includes(envGroupName)
+                      ? [...syncedEnvGroups, envGroupName]
+                      : syncedEnvGroups,
                 };
               });
             }}
@@ -387,6 +394,15 @@ export const getFinalVariablesForKeyValueArray: GetFinalVariablesFunction = (
       obj[entry.key] = fixNewlines(entry.value);
     }
   });
+
+  // env groups are recorded next to the variables they were loaded into
+  if (state.syncedEnvGroups?.length) {
+    return {
+      [props.variable]: obj,
+      [props.variable.replace(/[^.]+$/, "synced")]: state.syncedEnvGroups,
+    };
+  }
+
   return {
     [props.variable]: obj,
   };
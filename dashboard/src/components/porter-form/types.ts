@@ -180,6 +180,7 @@ export interface KeyValueArrayFieldState {
   }[];
   showEnvModal: boolean;
   showEditorModal: boolean;
+  syncedEnvGroups?: string[];
 }
 export interface ArrayInputFieldState {}
 export interface SelectFieldState {}
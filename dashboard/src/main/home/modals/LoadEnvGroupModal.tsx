@@ -19,7 +19,7 @@ type PropsType = {
   clusterId: number;
   closeModal: () => void;
   existingValues: Record<string, string>;
-  setValues: (values: Record<string, string>) => void;
+  setValues: (values: Record<string, string>, envGroupName?: string) => void;
 };
 
 type StateType = {
@@ -40,7 +40,10 @@ export default class LoadEnvGroupModal extends Component<PropsType, StateType> {
   };
 
   onSubmit = () => {
-    this.props.setValues(this.state.selectedEnvGroup.data);
+    this.props.setValues(
+      this.state.selectedEnvGroup.data,
+      this.state.selectedEnvGroup.metadata?.name
+    );
     this.props.closeModal();
   };
 
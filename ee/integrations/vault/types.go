@@ -70,3 +70,13 @@ type CreateTokenResponse struct {
 type TokenAuth struct {
 	Token string `json:"client_token"`
 }
+
+type GetKVSecretResponse struct {
+	*VaultGetResponse
+	Data *GetKVSecretData `json:"data"`
+}
+
+type GetKVSecretData struct {
+	Metadata *VaultMetadata         `json:"metadata"`
+	Data     map[string]interface{} `json:"data"`
+}
@@ -151,6 +151,24 @@ const readOnlyPolicyTemplate = `path "%s" {
   capabilities = ["read"]
 }`
 
+// ReadKVSecret reads the data of a secret stored in the KV (version 2) secrets engine,
+// which is used to resolve env group secret variables that reference Vault
+func (c *Client) ReadKVSecret(path string) (map[string]interface{}, error) {
+	resp := &GetKVSecretResponse{}
+
+	err := c.getRequest(fmt.Sprintf("/v1/kv/data/%s", strings.TrimPrefix(path, "/")), resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Data == nil {
+		return nil, fmt.Errorf("secret %s has no data", path)
+	}
+
+	return resp.Data.Data, nil
+}
+
 func (c *Client) getToken(credPath, policyName string) (string, error) {
 	policy := fmt.Sprintf(readOnlyPolicyTemplate, credPath)
 
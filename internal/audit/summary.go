@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces values of sensitive fields in a request summary
+const Redacted = "[REDACTED]"
+
+// maxStringLen is the length after which string values are replaced with their size,
+// so that large payloads such as helm values or kubeconfigs are not stored
+const maxStringLen = 256
+
+// maxDepth is the depth after which nested objects are no longer summarized
+const maxDepth = 4
+
+// payloadKeys are fields which carry a full payload, such as helm values or env group
+// variables, which may contain secrets under any name. Their values are never stored:
+// objects are replaced with their sorted keys, and all other values with their hash.
+var payloadKeys = map[string]bool{
+	"values":           true,
+	"secret_variables": true,
+}
+
+// sensitiveKeyParts are substrings of field names whose values are always redacted
+var sensitiveKeyParts = []string{
+	"secret",
+	"password",
+	"token",
+	"key",
+	"credential",
+	"certificate",
+	"kubeconfig",
+	"cert_data",
+	"private",
+}
+
+// SummarizeRequestBody returns a summary of a JSON request body that is safe to store.
+// Payload fields are replaced with their keys or hash, values for sensitive fields are
+// redacted, long strings are replaced with their length
+// and deeply nested objects are truncated. If the body is empty or is not a JSON object,
+// nil is returned.
+func SummarizeRequestBody(body []byte) map[string]interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+
+	parsed := make(map[string]interface{})
+
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil
+	}
+
+	return summarizeObject(parsed, 0)
+}
+
+func summarizeObject(obj map[string]interface{}, depth int) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	for key, val := range obj {
+		if payloadKeys[strings.ToLower(key)] {
+			res[key] = summarizePayload(val)
+			continue
+		}
+
+		if IsSensitiveKey(key) {
+			res[key] = Redacted
+			continue
+		}
+
+		res[key] = summarizeValue(val, depth+1)
+	}
+
+	return res
+}
+
+func summarizeValue(val interface{}, depth int) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		if depth >= maxDepth {
+			return fmt.Sprintf("[object with %d fields]", len(v))
+		}
+
+		return summarizeObject(v, depth)
+	case []interface{}:
+		if depth >= maxDepth {
+			return fmt.Sprintf("[array with %d items]", len(v))
+		}
+
+		res := make([]interface{}, 0, len(v))
+
+		for _, item := range v {
+			res = append(res, summarizeValue(item, depth+1))
+		}
+
+		return res
+	case string:
+		if len(v) > maxStringLen {
+			return fmt.Sprintf("[string of %d bytes]", len(v))
+		}
+
+		return v
+	default:
+		return v
+	}
+}
+
+func summarizePayload(val interface{}) interface{} {
+	var data []byte
+
+	switch v := val.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		return keys
+	case string:
+		data = []byte(v)
+	default:
+		// marshaling a value which was unmarshaled from JSON cannot fail
+		data, _ = json.Marshal(v)
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
+
+// IsSensitiveKey returns true if the values of a field with this name should
+// never be stored
+func IsSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+
+	return false
+}
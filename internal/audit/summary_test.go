@@ -0,0 +1,102 @@
+package audit_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/porter-dev/porter/internal/audit"
+)
+
+type summarizeTest struct {
+	description string
+	body        string
+	expected    map[string]interface{}
+}
+
+var summarizeTests = []summarizeTest{
+	{
+		description: "empty body",
+		body:        "",
+		expected:    nil,
+	},
+	{
+		description: "non-object body",
+		body:        `["a", "b"]`,
+		expected:    nil,
+	},
+	{
+		description: "plain fields are kept",
+		body:        `{"name": "web", "replicas": 2}`,
+		expected: map[string]interface{}{
+			"name":     "web",
+			"replicas": float64(2),
+		},
+	},
+	{
+		description: "sensitive fields are redacted",
+		body:        `{"name": "env", "aws_secret_access_key": "abc"}`,
+		expected: map[string]interface{}{
+			"name":                  "env",
+			"aws_secret_access_key": audit.Redacted,
+		},
+	},
+	{
+		description: "nested sensitive fields are redacted",
+		body:        `{"variables": {"PORT": "80", "API_TOKEN": "abc"}}`,
+		expected: map[string]interface{}{
+			"variables": map[string]interface{}{
+				"PORT":      "80",
+				"API_TOKEN": audit.Redacted,
+			},
+		},
+	},
+	{
+		description: "long strings are replaced by their size",
+		body:        `{"description": "` + strings.Repeat("a", 300) + `"}`,
+		expected: map[string]interface{}{
+			"description": "[string of 300 bytes]",
+		},
+	},
+	{
+		description: "payload objects are replaced by their keys",
+		body:        `{"values": {"image": {"tag": "latest"}, "env": "DB_URL=postgres://u:p@db"}, "secret_variables": {"DB_PASSWORD": "hunter2"}}`,
+		expected: map[string]interface{}{
+			"values":           []string{"env", "image"},
+			"secret_variables": []string{"DB_PASSWORD"},
+		},
+	},
+	{
+		description: "payload strings are replaced by their hash",
+		body:        `{"values": "password: hunter2"}`,
+		expected: map[string]interface{}{
+			"values": "sha256:" + fmt.Sprintf("%x", sha256.Sum256([]byte("password: hunter2"))),
+		},
+	},
+	{
+		description: "deeply nested objects are truncated",
+		body:        `{"a": {"b": {"c": {"d": {"e": 1}}}}}`,
+		expected: map[string]interface{}{
+			"a": map[string]interface{}{
+				"b": map[string]interface{}{
+					"c": map[string]interface{}{
+						"d": "[object with 1 fields]",
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestSummarizeRequestBody(t *testing.T) {
+	for _, test := range summarizeTests {
+		res := audit.SummarizeRequestBody([]byte(test.body))
+
+		if diff := deep.Equal(test.expected, res); diff != nil {
+			t.Errorf("[ %s ]: summaries not equal:", test.description)
+			t.Error(diff)
+		}
+	}
+}
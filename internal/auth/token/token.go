@@ -17,6 +17,11 @@ const (
 
 type TokenGeneratorConf struct {
 	TokenSecret string
+
+	// LegacyAPITokenCutoff is when API tokens without a token id stop being accepted.
+	// These tokens were issued before API tokens were stored, so they cannot be revoked
+	// or expired, and should be replaced with tokens created by "porter token create".
+	LegacyAPITokenCutoff time.Time
 }
 
 type Token struct {
@@ -25,6 +30,10 @@ type Token struct {
 	ProjectID uint       `json:"project_id"`
 	IBy       uint       `json:"iby"`
 	IAt       *time.Time `json:"iat"`
+
+	// TokenID is the unique id of a stored API token. Tokens which were issued
+	// without being persisted do not have a token id.
+	TokenID string `json:"token_id"`
 }
 
 func GetTokenForUser(userID uint) (*Token, error) {
@@ -58,6 +67,24 @@ func GetTokenForAPI(userID, projID uint) (*Token, error) {
 	}, nil
 }
 
+// GetStoredTokenForAPI returns an API token which references the stored API token
+// with the given unique id, so that it can be expired or revoked
+func GetStoredTokenForAPI(userID, projID uint, tokenID string) (*Token, error) {
+	if tokenID == "" {
+		return nil, fmt.Errorf("token id cannot be empty")
+	}
+
+	tok, err := GetTokenForAPI(userID, projID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tok.TokenID = tokenID
+
+	return tok, nil
+}
+
 func (t *Token) EncodeToken(conf *TokenGeneratorConf) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"sub_kind":   t.SubKind,
@@ -65,6 +92,7 @@ func (t *Token) EncodeToken(conf *TokenGeneratorConf) (string, error) {
 		"iby":        t.IBy,
 		"iat":        fmt.Sprintf("%d", t.IAt.Unix()),
 		"project_id": t.ProjectID,
+		"token_id":   t.TokenID,
 	})
 
 	// Sign and get the complete encoded token as a string using the secret
@@ -105,12 +133,16 @@ func GetTokenFromEncoded(tokenString string, conf *TokenGeneratorConf) (*Token,
 
 		iat := time.Unix(iatUnix, 0)
 
+		// tokens issued before token ids were introduced do not have this claim
+		tokenID, _ := claims["token_id"].(string)
+
 		return &Token{
 			SubKind:   Subject(fmt.Sprintf("%v", claims["sub_kind"])),
 			Sub:       fmt.Sprintf("%v", claims["sub"]),
 			IBy:       uint(iby),
 			IAt:       &iat,
 			ProjectID: uint(projID),
+			TokenID:   tokenID,
 		}, nil
 	}
 
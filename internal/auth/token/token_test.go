@@ -49,3 +49,50 @@ func TestGetAndEncodeTokenForUser(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestGetAndEncodeStoredTokenForAPI(t *testing.T) {
+	conf := &token.TokenGeneratorConf{
+		TokenSecret: "fakesecret",
+	}
+
+	tok, err := token.GetStoredTokenForAPI(1, 2, "abcdef")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	tokString, err := tok.EncodeToken(conf)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	expToken := &token.Token{
+		SubKind:   token.API,
+		Sub:       string(token.API),
+		IBy:       1,
+		ProjectID: 2,
+		TokenID:   "abcdef",
+	}
+
+	gotToken, err := token.GetTokenFromEncoded(tokString, conf)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	gotToken.IAt = nil
+
+	if diff := deep.Equal(expToken, gotToken); diff != nil {
+		t.Errorf("tokens not equal:")
+		t.Error(diff)
+	}
+}
+
+func TestGetStoredTokenForAPIEmptyID(t *testing.T) {
+	_, err := token.GetStoredTokenForAPI(1, 2, "")
+
+	if err == nil {
+		t.Fatalf("expected error for empty token id, got nil")
+	}
+}
@@ -0,0 +1,118 @@
+package canary
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/scheduler"
+	"golang.org/x/oauth2"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+const (
+	// cleanupInterval is how often interrupted rollouts are cleaned up
+	cleanupInterval = time.Minute
+
+	// staleGracePeriod is how long a rollout can go without updating its state, in
+	// addition to its step interval, before it is considered interrupted
+	staleGracePeriod = 10 * time.Minute
+)
+
+// Cleaner removes the canary releases of rollouts which were interrupted, for example by
+// a restart of the server, which sends all traffic back to the release
+type Cleaner struct {
+	Repo   repository.Repository
+	DOConf *oauth2.Config
+	Logger *logger.Logger
+}
+
+// Run cleans up interrupted rollouts every minute, on one replica of the server at a
+// time, and never returns
+func (c *Cleaner) Run() {
+	scheduler.Run(c.Repo, c.Logger, "canary_rollout_cleanup", cleanupInterval, c.CleanupAll)
+}
+
+// CleanupAll cleans up every interrupted rollout. Errors are logged, and do not stop
+// other rollouts from being cleaned up.
+func (c *Cleaner) CleanupAll() {
+	rollouts, err := c.Repo.CanaryRollout().ListInProgressCanaryRollouts()
+
+	if err != nil {
+		c.Logger.Error().Err(err).Msg("could not list canary rollouts")
+		return
+	}
+
+	now := time.Now()
+
+	for _, rollout := range rollouts {
+		if !isInterrupted(rollout, now) {
+			continue
+		}
+
+		if err := c.cleanup(rollout); err != nil {
+			c.Logger.Error().Err(err).Uint("cluster_id", rollout.ClusterID).
+				Str("namespace", rollout.Namespace).
+				Str("release", rollout.ReleaseName).
+				Msg("could not clean up interrupted canary rollout")
+		}
+	}
+}
+
+func (c *Cleaner) cleanup(rollout *models.CanaryRollout) error {
+	cluster, err := c.Repo.Cluster().ReadCluster(rollout.ProjectID, rollout.ClusterID)
+
+	if err != nil {
+		return fmt.Errorf("could not read cluster: %v", err)
+	}
+
+	helmAgent, err := helm.GetAgentOutOfClusterConfig(&helm.Form{
+		Cluster:           cluster,
+		Repo:              c.Repo,
+		DigitalOceanOAuth: c.DOConf,
+		Storage:           "secret",
+		Namespace:         rollout.Namespace,
+	}, c.Logger)
+
+	if err != nil {
+		return fmt.Errorf("could not connect to cluster: %v", err)
+	}
+
+	canaryName := GetCanaryName(rollout.ReleaseName)
+
+	if _, err := helmAgent.UninstallChart(canaryName); err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		return fmt.Errorf("could not remove canary release: %v", err)
+	}
+
+	rollout.Status = types.CanaryRolloutStatusFailed
+	rollout.Error = "rollout was interrupted, and the canary release was removed"
+
+	if _, err := c.Repo.CanaryRollout().UpdateCanaryRollout(rollout); err != nil {
+		return err
+	}
+
+	if rel, err := c.Repo.Release().ReadRelease(rollout.ClusterID, rollout.ReleaseName, rollout.Namespace); err == nil {
+		NewReleaseStepReporter(c.Repo, rel).Report(&types.SubEvent{
+			EventID: "canary",
+			Name:    "Canary rollout",
+			Index:   rolloutStepIndex + 1,
+			Status:  types.EventStatusFailed,
+			Info:    rollout.Error,
+		})
+	}
+
+	return nil
+}
+
+// isInterrupted returns true if a rollout has not updated its state for longer than a
+// step of the rollout should take
+func isInterrupted(rollout *models.CanaryRollout, now time.Time) bool {
+	timeout := time.Duration(rollout.StepIntervalSeconds)*time.Second + staleGracePeriod
+
+	return rollout.UpdatedAt.Add(timeout).Before(now)
+}
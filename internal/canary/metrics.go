@@ -0,0 +1,69 @@
+package canary
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes/prometheus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// MetricsQuerier reads the request metrics of an NGINX ingress. If there were no
+// requests to the ingress in the queried range, found is false.
+type MetricsQuerier interface {
+	// GetErrorRate returns the percentage of requests with a 5xx status
+	GetErrorRate(namespace, ingress string, start, end time.Time) (value float64, found bool, err error)
+
+	// GetLatency returns the average request latency in seconds
+	GetLatency(namespace, ingress string, start, end time.Time) (value float64, found bool, err error)
+}
+
+// PrometheusQuerier reads ingress metrics from the Prometheus server installed in
+// the cluster
+type PrometheusQuerier struct {
+	clientset kubernetes.Interface
+	service   *v1.Service
+}
+
+// NewPrometheusQuerier returns a PrometheusQuerier, or an error if Prometheus is not
+// installed in the cluster
+func NewPrometheusQuerier(clientset kubernetes.Interface) (MetricsQuerier, error) {
+	service, found, err := prometheus.GetPrometheusService(clientset)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("prometheus is not installed in the cluster")
+	}
+
+	return &PrometheusQuerier{clientset, service}, nil
+}
+
+func (p *PrometheusQuerier) GetErrorRate(namespace, ingress string, start, end time.Time) (float64, bool, error) {
+	return p.query("nginx:errors", namespace, ingress, start, end)
+}
+
+func (p *PrometheusQuerier) GetLatency(namespace, ingress string, start, end time.Time) (float64, bool, error) {
+	return p.query("nginx:latency", namespace, ingress, start, end)
+}
+
+func (p *PrometheusQuerier) query(metric, namespace, ingress string, start, end time.Time) (float64, bool, error) {
+	results, err := prometheus.QueryPrometheus(p.clientset, p.service, &prometheus.QueryOpts{
+		Metric:     metric,
+		Kind:       "ingress",
+		Name:       ingress,
+		Namespace:  namespace,
+		StartRange: uint(start.Unix()),
+		EndRange:   uint(end.Unix()),
+		Resolution: "30s",
+	})
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return prometheus.GetLatestValue(results)
+}
@@ -0,0 +1,318 @@
+package canary
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/oauth2"
+	"helm.sh/helm/v3/pkg/postrender"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"sigs.k8s.io/yaml"
+)
+
+var defaultSteps = []int{10, 25, 50, 100}
+
+const (
+	defaultStepIntervalSeconds = 300
+	defaultMaxErrorRate        = 1.0
+	defaultMaxLatency          = 1.0
+)
+
+// the index of each reported step, so that the rollout steps are sorted after the
+// build, push and upgrade steps reported by the CLI
+const (
+	rolloutStepIndex = 400
+	stepIndexOffset  = 410
+	stepIndexSpacing = 10
+)
+
+// SetDefaults sets the default rollout options for any option that is unset
+func SetDefaults(opts *types.CanaryRolloutRequest) {
+	if len(opts.Steps) == 0 {
+		opts.Steps = defaultSteps
+	}
+
+	if opts.StepIntervalSeconds == 0 {
+		opts.StepIntervalSeconds = defaultStepIntervalSeconds
+	}
+
+	if opts.MaxErrorRate == 0 {
+		opts.MaxErrorRate = defaultMaxErrorRate
+	}
+
+	if opts.MaxLatency == 0 {
+		opts.MaxLatency = defaultMaxLatency
+	}
+}
+
+// ValidateSteps checks that the rollout steps are strictly increasing
+func ValidateSteps(steps []int) error {
+	for i := 1; i < len(steps); i++ {
+		if steps[i] <= steps[i-1] {
+			return fmt.Errorf("canary steps must be in increasing order")
+		}
+	}
+
+	return nil
+}
+
+// GetCanaryName returns the name of the canary release for a release
+func GetCanaryName(name string) string {
+	return fmt.Sprintf("%s-canary", name)
+}
+
+// Rollout upgrades a release with new values by first installing the values as a
+// canary release, and then shifting NGINX ingress traffic to the canary in steps
+type Rollout struct {
+	HelmAgent *helm.Agent
+	Metrics   MetricsQuerier
+	Reporter  StepReporter
+
+	// Release is the release to upgrade, and Values are the new values of the release
+	Release *release.Release
+	Values  map[string]interface{}
+
+	Cluster    *models.Cluster
+	Repo       repository.Repository
+	Registries []*models.Registry
+	DOAuth     *oauth2.Config
+
+	Opts *types.CanaryRolloutRequest
+
+	// State is the stored state of the rollout, which is updated at every step so that
+	// interrupted rollouts can be cleaned up
+	State *models.CanaryRollout
+
+	// Sleep waits between steps, and can be overwritten in tests
+	Sleep func(time.Duration)
+}
+
+// Run runs the rollout until the release is upgraded, or until the canary fails a
+// metrics check and is removed
+func (r *Rollout) Run() (err error) {
+	sleep := r.Sleep
+
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	canaryName := GetCanaryName(r.Release.Name)
+
+	r.report(&types.SubEvent{
+		EventID: "canary",
+		Name:    "Canary rollout",
+		Index:   rolloutStepIndex,
+		Status:  types.EventStatusInProgress,
+		Info:    fmt.Sprintf("deploying canary release %s", canaryName),
+	})
+
+	defer func() {
+		status := types.EventStatusSuccess
+		info := fmt.Sprintf("promoted canary to %s", r.Release.Name)
+
+		if err != nil {
+			status = types.EventStatusFailed
+			info = err.Error()
+		}
+
+		r.saveState(func(state *models.CanaryRollout) {
+			state.Status = types.CanaryRolloutStatusSucceeded
+
+			if err != nil {
+				state.Status = types.CanaryRolloutStatusFailed
+				state.Error = err.Error()
+			}
+		})
+
+		r.report(&types.SubEvent{
+			EventID: "canary",
+			Name:    "Canary rollout",
+			Index:   rolloutStepIndex + 1,
+			Status:  status,
+			Info:    info,
+		})
+	}()
+
+	canaryRelease, err := r.HelmAgent.InstallChart(&helm.InstallChartConfig{
+		Chart:         r.Release.Chart,
+		Name:          canaryName,
+		Namespace:     r.Release.Namespace,
+		Values:        r.Values,
+		Cluster:       r.Cluster,
+		Repo:          r.Repo,
+		Registries:    r.Registries,
+		PostRenderers: []postrender.PostRenderer{helm.NewCanaryPostRenderer(r.Opts.Steps[0])},
+	}, r.DOAuth)
+
+	if err != nil {
+		return fmt.Errorf("could not deploy canary release: %v", err)
+	}
+
+	ingresses, err := getIngressNames(canaryRelease.Manifest)
+
+	if err == nil && len(ingresses) == 0 {
+		err = fmt.Errorf("canary release has no ingress to send traffic to")
+	}
+
+	if err != nil {
+		return r.rollback(canaryName, err)
+	}
+
+	for i, weight := range r.Opts.Steps {
+		stepEvent := &types.SubEvent{
+			EventID: fmt.Sprintf("canary-step-%d", i),
+			Name:    fmt.Sprintf("Canary at %d%%", weight),
+			Index:   int64(stepIndexOffset + i*stepIndexSpacing),
+			Status:  types.EventStatusInProgress,
+		}
+
+		r.report(stepEvent)
+
+		stepErr := r.setWeight(ingresses, weight)
+
+		if stepErr == nil {
+			r.saveState(func(state *models.CanaryRollout) {
+				state.Weight = weight
+			})
+
+			start := time.Now()
+
+			sleep(time.Duration(r.Opts.StepIntervalSeconds) * time.Second)
+
+			stepErr = r.checkMetrics(ingresses, start, time.Now())
+		}
+
+		stepEvent.Index++
+
+		if stepErr != nil {
+			stepEvent.Status = types.EventStatusFailed
+			stepEvent.Info = stepErr.Error()
+			r.report(stepEvent)
+
+			return r.rollback(canaryName, stepErr)
+		}
+
+		stepEvent.Status = types.EventStatusSuccess
+		r.report(stepEvent)
+	}
+
+	// promote the canary by upgrading the release with the canary's values
+	_, err = r.HelmAgent.UpgradeReleaseByValues(&helm.UpgradeReleaseConfig{
+		Name:       r.Release.Name,
+		Values:     r.Values,
+		Cluster:    r.Cluster,
+		Repo:       r.Repo,
+		Registries: r.Registries,
+	}, r.DOAuth)
+
+	if err != nil {
+		return r.rollback(canaryName, fmt.Errorf("could not promote canary: %v", err))
+	}
+
+	if _, err := r.HelmAgent.UninstallChart(canaryName); err != nil {
+		return fmt.Errorf("promoted canary, but could not remove canary release: %v", err)
+	}
+
+	return nil
+}
+
+// rollback removes the canary release, which sends all traffic back to the release
+func (r *Rollout) rollback(canaryName string, reason error) error {
+	if _, err := r.HelmAgent.UninstallChart(canaryName); err != nil {
+		return fmt.Errorf("%v, and could not remove canary release: %v", reason, err)
+	}
+
+	return fmt.Errorf("rolled back: %v", reason)
+}
+
+func (r *Rollout) setWeight(ingresses []string, weight int) error {
+	for _, ingress := range ingresses {
+		err := r.HelmAgent.K8sAgent.UpdateIngressAnnotations(r.Release.Namespace, ingress, map[string]string{
+			helm.CanaryWeightAnnotation: fmt.Sprintf("%d", weight),
+		})
+
+		if err != nil {
+			return fmt.Errorf("could not set canary weight: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// checkMetrics returns an error if any of the canary ingresses is over the error rate
+// or latency threshold. Ingresses that received no traffic pass the check.
+func (r *Rollout) checkMetrics(ingresses []string, start, end time.Time) error {
+	for _, ingress := range ingresses {
+		errorRate, found, err := r.Metrics.GetErrorRate(r.Release.Namespace, ingress, start, end)
+
+		if err != nil {
+			return fmt.Errorf("could not query error rate: %v", err)
+		}
+
+		if found && errorRate > r.Opts.MaxErrorRate {
+			return fmt.Errorf(
+				"error rate of ingress %s is %.2f%%, which is over the threshold of %.2f%%",
+				ingress, errorRate, r.Opts.MaxErrorRate,
+			)
+		}
+
+		latency, found, err := r.Metrics.GetLatency(r.Release.Namespace, ingress, start, end)
+
+		if err != nil {
+			return fmt.Errorf("could not query latency: %v", err)
+		}
+
+		if found && latency > r.Opts.MaxLatency {
+			return fmt.Errorf(
+				"latency of ingress %s is %.3fs, which is over the threshold of %.3fs",
+				ingress, latency, r.Opts.MaxLatency,
+			)
+		}
+	}
+
+	return nil
+}
+
+// saveState updates the stored state of the rollout. Failing to store the state does not
+// stop the rollout.
+func (r *Rollout) saveState(update func(state *models.CanaryRollout)) {
+	if r.State == nil {
+		return
+	}
+
+	update(r.State)
+
+	r.Repo.CanaryRollout().UpdateCanaryRollout(r.State)
+}
+
+// report reports a step of the rollout. Failing to report a step does not stop
+// the rollout.
+func (r *Rollout) report(event *types.SubEvent) {
+	if r.Reporter != nil {
+		r.Reporter.Report(event)
+	}
+}
+
+func getIngressNames(manifest string) ([]string, error) {
+	res := make([]string, 0)
+
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		head := &releaseutil.SimpleHead{}
+
+		if err := yaml.Unmarshal([]byte(doc), head); err != nil {
+			return nil, err
+		}
+
+		if strings.EqualFold(head.Kind, "Ingress") && head.Metadata != nil {
+			res = append(res, head.Metadata.Name)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,126 @@
+package canary
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type fakeMetrics struct {
+	errorRate    float64
+	latency      float64
+	foundTraffic bool
+}
+
+func (f *fakeMetrics) GetErrorRate(namespace, ingress string, start, end time.Time) (float64, bool, error) {
+	return f.errorRate, f.foundTraffic, nil
+}
+
+func (f *fakeMetrics) GetLatency(namespace, ingress string, start, end time.Time) (float64, bool, error) {
+	return f.latency, f.foundTraffic, nil
+}
+
+type checkMetricsTest struct {
+	name     string
+	metrics  *fakeMetrics
+	expError string
+}
+
+var checkMetricsTests = []checkMetricsTest{
+	{
+		name:    "under thresholds",
+		metrics: &fakeMetrics{errorRate: 0.5, latency: 0.2, foundTraffic: true},
+	},
+	{
+		name:     "over error rate",
+		metrics:  &fakeMetrics{errorRate: 5, latency: 0.2, foundTraffic: true},
+		expError: "error rate of ingress app-canary-web is 5.00%",
+	},
+	{
+		name:     "over latency",
+		metrics:  &fakeMetrics{errorRate: 0, latency: 2.5, foundTraffic: true},
+		expError: "latency of ingress app-canary-web is 2.500s",
+	},
+	{
+		name:    "no traffic",
+		metrics: &fakeMetrics{errorRate: 100, latency: 10, foundTraffic: false},
+	},
+}
+
+func TestCheckMetrics(t *testing.T) {
+	for _, test := range checkMetricsTests {
+		opts := &types.CanaryRolloutRequest{}
+		SetDefaults(opts)
+
+		rollout := &Rollout{
+			Metrics: test.metrics,
+			Release: &release.Release{Name: "app", Namespace: "default"},
+			Opts:    opts,
+		}
+
+		err := rollout.checkMetrics([]string{"app-canary-web"}, time.Now(), time.Now())
+
+		if test.expError == "" && err != nil {
+			t.Errorf("[ %s ] expected no error, got %v", test.name, err)
+		}
+
+		if test.expError != "" && (err == nil || !strings.Contains(err.Error(), test.expError)) {
+			t.Errorf("[ %s ] expected error containing %q, got %v", test.name, test.expError, err)
+		}
+	}
+}
+
+func TestValidateSteps(t *testing.T) {
+	if err := ValidateSteps([]int{10, 50, 100}); err != nil {
+		t.Errorf("expected increasing steps to be valid, got %v", err)
+	}
+
+	if err := ValidateSteps([]int{50, 10}); err == nil {
+		t.Errorf("expected decreasing steps to be invalid")
+	}
+}
+
+func TestGetIngressNames(t *testing.T) {
+	manifest := `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app-canary-web
+---
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: app-canary-web
+`
+
+	ingresses, err := getIngressNames(manifest)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ingresses) != 1 || ingresses[0] != "app-canary-web" {
+		t.Errorf("expected [app-canary-web], got %v", ingresses)
+	}
+}
+
+func TestIsInterrupted(t *testing.T) {
+	now := time.Now()
+
+	rollout := &models.CanaryRollout{StepIntervalSeconds: 300}
+	rollout.UpdatedAt = now.Add(-10 * time.Minute)
+
+	if isInterrupted(rollout, now) {
+		t.Errorf("expected a rollout within its step interval and grace period to be running")
+	}
+
+	rollout.UpdatedAt = now.Add(-20 * time.Minute)
+
+	if !isInterrupted(rollout, now) {
+		t.Errorf("expected a rollout past its step interval and grace period to be interrupted")
+	}
+}
@@ -0,0 +1,54 @@
+package canary
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// StepReporter reports the progress of a rollout
+type StepReporter interface {
+	Report(event *types.SubEvent) error
+}
+
+// ReleaseStepReporter reports the progress of a rollout as steps of a release,
+// which are shown in the dashboard alongside the build and deploy steps
+type ReleaseStepReporter struct {
+	Repo    repository.Repository
+	Release *models.Release
+}
+
+func NewReleaseStepReporter(repo repository.Repository, release *models.Release) StepReporter {
+	return &ReleaseStepReporter{repo, release}
+}
+
+func (r *ReleaseStepReporter) Report(event *types.SubEvent) error {
+	if r.Release.EventContainer == 0 {
+		container, err := r.Repo.BuildEvent().CreateEventContainer(&models.EventContainer{ReleaseID: r.Release.ID})
+
+		if err != nil {
+			return err
+		}
+
+		r.Release.EventContainer = container.ID
+
+		if r.Release, err = r.Repo.Release().UpdateRelease(r.Release); err != nil {
+			return err
+		}
+	}
+
+	container, err := r.Repo.BuildEvent().ReadEventContainer(r.Release.EventContainer)
+
+	if err != nil {
+		return err
+	}
+
+	return r.Repo.BuildEvent().AppendEvent(container, &models.SubEvent{
+		EventContainerID: container.ID,
+		EventID:          event.EventID,
+		Name:             event.Name,
+		Index:            event.Index,
+		Status:           event.Status,
+		Info:             event.Info,
+	})
+}
@@ -0,0 +1,362 @@
+package envgroup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const secretReferencePrefix = "PORTERSECRET_"
+
+// SecretReference returns the config map value which marks a variable of an env group
+// as stored in the linked secret
+func SecretReference(name string) string {
+	return fmt.Sprintf("%s%s", secretReferencePrefix, name)
+}
+
+// HashSecret returns the hash which is stored for the value of a secret variable. The
+// hash is only used to compare versions, and is never returned by the API, since the
+// values of short secrets could be recovered from it.
+func HashSecret(value string) string {
+	sum := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// ErrEnvGroupNotFound is returned when a config map or secret is not part of an env
+// group created by Porter
+var ErrEnvGroupNotFound = errors.New("env group not found")
+
+// GetLinkedSecret returns the linked secret of an env group. Only secrets which Porter
+// created for the config map of an env group are returned, so that other secrets in the
+// namespace cannot be read as an env group.
+func GetLinkedSecret(agent *kubernetes.Agent, namespace, name string) (*v1.Secret, error) {
+	configMap, err := agent.GetConfigMap(name, namespace)
+
+	if err != nil && k8serrors.IsNotFound(err) {
+		return nil, ErrEnvGroupNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if configMap.Labels["porter"] != "true" {
+		return nil, ErrEnvGroupNotFound
+	}
+
+	secret, err := agent.GetSecret(name, namespace)
+
+	if err != nil && k8serrors.IsNotFound(err) {
+		return nil, ErrEnvGroupNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if secret.Labels["porter"] != "true" || secret.Labels["configmap"] != name {
+		return nil, ErrEnvGroupNotFound
+	}
+
+	return secret, nil
+}
+
+// Snapshot reads the current variables of an env group from its config map and linked
+// secret
+func Snapshot(agent *kubernetes.Agent, namespace, name string) (variables, secretVariables map[string]string, err error) {
+	configMap, err := agent.GetConfigMap(name, namespace)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secretData := make(map[string][]byte)
+	secret, err := agent.GetSecret(name, namespace)
+
+	if err == nil {
+		secretData = secret.Data
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+
+	variables = make(map[string]string)
+	secretVariables = make(map[string]string)
+
+	for key, val := range configMap.Data {
+		if val == SecretReference(name) {
+			secretVariables[key] = string(secretData[key])
+		} else {
+			variables[key] = val
+		}
+	}
+
+	return variables, secretVariables, nil
+}
+
+// RecordVersion stores the current state of an env group as its next version
+func RecordVersion(
+	repo repository.Repository,
+	agent *kubernetes.Agent,
+	cluster *models.Cluster,
+	namespace, name, author string,
+) (*models.EnvGroupVersion, error) {
+	variables, secretVariables, err := Snapshot(agent, namespace, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nextVersion := uint(1)
+	latest, err := repo.EnvGroupVersion().ReadLatestEnvGroupVersion(cluster.ProjectID, cluster.ID, namespace, name)
+
+	if err == nil {
+		nextVersion = latest.Version + 1
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	version := &models.EnvGroupVersion{
+		ProjectID: cluster.ProjectID,
+		ClusterID: cluster.ID,
+		Namespace: namespace,
+		Name:      name,
+		Version:   nextVersion,
+		Author:    author,
+	}
+
+	externalKeys, err := getExternalSecretKeys(repo, cluster, namespace, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	secretHashes := make(map[string]string)
+	secretValues := make(map[string]string)
+
+	for key, val := range secretVariables {
+		secretHashes[key] = HashSecret(val)
+
+		// values synced from an external secret store are not stored by Porter
+		if !externalKeys[key] {
+			secretValues[key] = val
+		}
+	}
+
+	if version.Variables, err = json.Marshal(variables); err != nil {
+		return nil, err
+	}
+
+	if version.SecretHashes, err = json.Marshal(secretHashes); err != nil {
+		return nil, err
+	}
+
+	if version.SecretValues, err = json.Marshal(secretValues); err != nil {
+		return nil, err
+	}
+
+	return repo.EnvGroupVersion().CreateEnvGroupVersion(version)
+}
+
+// RecordInitialVersion stores the current state of an env group as its first version if
+// no versions are stored yet. This is called before an env group which was created
+// before versions were stored is changed, so that the change can be rolled back.
+func RecordInitialVersion(
+	repo repository.Repository,
+	agent *kubernetes.Agent,
+	cluster *models.Cluster,
+	namespace, name string,
+) error {
+	_, err := repo.EnvGroupVersion().ReadLatestEnvGroupVersion(cluster.ProjectID, cluster.ID, namespace, name)
+
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	_, err = RecordVersion(repo, agent, cluster, namespace, name, "")
+
+	return err
+}
+
+// Rollback sets the variables of an env group to those of a stored version, and
+// records the result as a new version
+func Rollback(
+	repo repository.Repository,
+	agent *kubernetes.Agent,
+	cluster *models.Cluster,
+	target *models.EnvGroupVersion,
+	author string,
+) (*v1.ConfigMap, *models.EnvGroupVersion, error) {
+	namespace, name := target.Namespace, target.Name
+
+	targetVariables, err := target.GetVariables()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targetSecrets, err := target.GetSecretValues()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targetSecretHashes, err := target.GetSecretHashes()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	currVariables, currSecrets, err := Snapshot(agent, namespace, name)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// empty values remove keys when the config map and secret are updated
+	configMapData := make(map[string]string)
+	secretData := make(map[string][]byte)
+
+	for key := range currVariables {
+		configMapData[key] = ""
+	}
+
+	for key := range currSecrets {
+		configMapData[key] = ""
+		secretData[key] = []byte{}
+	}
+
+	for key, val := range targetVariables {
+		configMapData[key] = val
+	}
+
+	for key := range targetSecretHashes {
+		configMapData[key] = SecretReference(name)
+
+		// secrets without a stored value are synced from an external secret store, so
+		// their current value is kept
+		if val, ok := targetSecrets[key]; ok {
+			secretData[key] = []byte(val)
+		} else {
+			delete(secretData, key)
+		}
+	}
+
+	if len(secretData) > 0 {
+		err := agent.UpdateLinkedSecret(name, namespace, name, secretData)
+
+		if err != nil && k8serrors.IsNotFound(err) {
+			_, err = agent.CreateLinkedSecret(name, namespace, name, secretData)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	configMap, err := agent.UpdateConfigMap(name, namespace, configMapData)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	version, err := RecordVersion(repo, agent, cluster, namespace, name, author)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return configMap, version, nil
+}
+
+// Diff returns the changes to the variables of an env group between two versions,
+// sorted by key. Secret variables are compared by the hashes of their values.
+func Diff(from, to *models.EnvGroupVersion) ([]*types.EnvGroupVariableDiff, error) {
+	fromVars, fromSecrets, err := getVersionValues(from)
+
+	if err != nil {
+		return nil, err
+	}
+
+	toVars, toSecrets, err := getVersionValues(to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := make(map[string]bool)
+
+	for _, vals := range []map[string]string{fromVars, fromSecrets, toVars, toSecrets} {
+		for key := range vals {
+			keySet[key] = true
+		}
+	}
+
+	keys := make([]string, 0, len(keySet))
+
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	res := make([]*types.EnvGroupVariableDiff, 0)
+
+	for _, key := range keys {
+		oldVal, oldIsVar := fromVars[key]
+		oldHash, oldIsSecret := fromSecrets[key]
+		newVal, newIsVar := toVars[key]
+		newHash, newIsSecret := toSecrets[key]
+
+		diff := &types.EnvGroupVariableDiff{
+			Key:    key,
+			Secret: oldIsSecret || newIsSecret,
+		}
+
+		switch {
+		case !oldIsVar && !oldIsSecret:
+			diff.Change = types.ReleaseDiffChangeAdded
+		case !newIsVar && !newIsSecret:
+			diff.Change = types.ReleaseDiffChangeRemoved
+		case oldIsVar != newIsVar || oldVal != newVal || oldHash != newHash:
+			diff.Change = types.ReleaseDiffChangeModified
+		default:
+			continue
+		}
+
+		if !diff.Secret {
+			diff.Old = oldVal
+			diff.New = newVal
+		}
+
+		res = append(res, diff)
+	}
+
+	return res, nil
+}
+
+func getVersionValues(version *models.EnvGroupVersion) (variables, secretHashes map[string]string, err error) {
+	variables, err = version.GetVariables()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	secretHashes, err = version.GetSecretHashes()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return variables, secretHashes, nil
+}
@@ -0,0 +1,238 @@
+package envgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository/test"
+	"gorm.io/gorm"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func setupEnvGroup(t *testing.T) (*kubernetes.Agent, *models.Cluster) {
+	agent := kubernetes.GetAgentTesting()
+
+	_, err := agent.CreateLinkedSecret("app-env", "default", "app-env", map[string][]byte{
+		"DB_PASSWORD": []byte("hunter2"),
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = agent.CreateConfigMap("app-env", "default", map[string]string{
+		"LOG_LEVEL":   "info",
+		"DB_PASSWORD": envgroup.SecretReference("app-env"),
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return agent, &models.Cluster{
+		Model:     gorm.Model{ID: 1},
+		ProjectID: 1,
+	}
+}
+
+func TestRecordVersion(t *testing.T) {
+	repo := test.NewRepository(true)
+	agent, cluster := setupEnvGroup(t)
+
+	first, err := envgroup.RecordVersion(repo, agent, cluster, "default", "app-env", "admin@example.com")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if first.Version != 1 {
+		t.Errorf("expected version 1, got %d", first.Version)
+	}
+
+	res, err := first.ToEnvGroupVersionType(nil)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if res.Variables["LOG_LEVEL"] != "info" {
+		t.Errorf("expected LOG_LEVEL to be info, got %q", res.Variables["LOG_LEVEL"])
+	}
+
+	if _, found := res.Variables["DB_PASSWORD"]; found {
+		t.Errorf("expected DB_PASSWORD to not be stored as a variable")
+	}
+
+	if changed, found := res.SecretVariables["DB_PASSWORD"]; !found || !changed {
+		t.Errorf("expected DB_PASSWORD to be returned as a changed secret variable")
+	}
+
+	// an initial version should not be recorded once versions exist
+	if err := envgroup.RecordInitialVersion(repo, agent, cluster, "default", "app-env"); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	second, err := envgroup.RecordVersion(repo, agent, cluster, "default", "app-env", "admin@example.com")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if second.Version != 2 {
+		t.Errorf("expected version 2, got %d", second.Version)
+	}
+
+	res, err = second.ToEnvGroupVersionType(first)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if changed, found := res.SecretVariables["DB_PASSWORD"]; !found || changed {
+		t.Errorf("expected DB_PASSWORD to be returned as an unchanged secret variable")
+	}
+}
+
+func TestRollback(t *testing.T) {
+	repo := test.NewRepository(true)
+	agent, cluster := setupEnvGroup(t)
+
+	first, err := envgroup.RecordVersion(repo, agent, cluster, "default", "app-env", "admin@example.com")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// push a bad config: change a variable and a secret, and add a new variable
+	if err := agent.UpdateLinkedSecret("app-env", "default", "app-env", map[string][]byte{
+		"DB_PASSWORD": []byte("wrong"),
+	}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if _, err := agent.UpdateConfigMap("app-env", "default", map[string]string{
+		"LOG_LEVEL": "debug",
+		"NEW_VAR":   "1",
+	}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	second, err := envgroup.RecordVersion(repo, agent, cluster, "default", "app-env", "dev@example.com")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	changes, err := envgroup.Diff(first, second)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expChanges := []types.EnvGroupVariableDiff{
+		{Key: "DB_PASSWORD", Secret: true, Change: types.ReleaseDiffChangeModified},
+		{Key: "LOG_LEVEL", Change: types.ReleaseDiffChangeModified, Old: "info", New: "debug"},
+		{Key: "NEW_VAR", Change: types.ReleaseDiffChangeAdded, New: "1"},
+	}
+
+	if len(changes) != len(expChanges) {
+		t.Fatalf("expected %d changes, got %d", len(expChanges), len(changes))
+	}
+
+	for i, exp := range expChanges {
+		if *changes[i] != exp {
+			t.Errorf("change %d: expected %+v, got %+v", i, exp, *changes[i])
+		}
+	}
+
+	// the stored version must be read back to restore its secret values
+	target, err := repo.EnvGroupVersion().ReadEnvGroupVersion(1, 1, "default", "app-env", 1)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	configMap, version, err := envgroup.Rollback(repo, agent, cluster, target, "admin@example.com")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if version.Version != 3 {
+		t.Errorf("expected rollback to create version 3, got %d", version.Version)
+	}
+
+	if _, found := configMap.Data["NEW_VAR"]; found {
+		t.Errorf("expected NEW_VAR to be removed by the rollback")
+	}
+
+	variables, secretVariables, err := envgroup.Snapshot(agent, "default", "app-env")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(variables) != 1 || variables["LOG_LEVEL"] != "info" {
+		t.Errorf("expected variables to be restored, got %v", variables)
+	}
+
+	if len(secretVariables) != 1 || secretVariables["DB_PASSWORD"] != "hunter2" {
+		t.Errorf("expected secret variables to be restored, got %v", secretVariables)
+	}
+
+	changes, err = envgroup.Diff(first, version)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(changes) != 0 {
+		t.Errorf("expected no changes between version 1 and the rollback, got %d", len(changes))
+	}
+}
+
+func TestGetLinkedSecret(t *testing.T) {
+	agent, _ := setupEnvGroup(t)
+
+	// a secret in the same namespace which is not part of an env group
+	_, err := agent.Clientset.CoreV1().Secrets("default").Create(context.Background(), &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}, metav1.CreateOptions{})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// a config map with the same name does not make it an env group
+	_, err = agent.Clientset.CoreV1().ConfigMaps("default").Create(context.Background(), &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "default"},
+	}, metav1.CreateOptions{})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	secret, err := envgroup.GetLinkedSecret(agent, "default", "app-env")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if string(secret.Data["DB_PASSWORD"]) != "hunter2" {
+		t.Errorf("expected the linked secret of app-env")
+	}
+
+	for _, name := range []string{"db-credentials", "missing"} {
+		if _, err := envgroup.GetLinkedSecret(agent, "default", name); !errors.Is(err, envgroup.ErrEnvGroupNotFound) {
+			t.Errorf("expected %s not to be found, got %v", name, err)
+		}
+	}
+}
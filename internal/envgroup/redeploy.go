@@ -0,0 +1,489 @@
+package envgroup
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// RedeployConfig is the set of parameters required to redeploy the releases which
+// consume an env group
+type RedeployConfig struct {
+	Repo       repository.Repository
+	Cluster    *models.Cluster
+	HelmAgent  *helm.Agent
+	Registries []*models.Registry
+	DOConf     *oauth2.Config
+
+	// Timeout is how long Redeploy waits for the releases to be redeployed. Releases which
+	// have not been redeployed by then continue in the background, and are reported as in
+	// progress. If it is not positive, Redeploy waits for all releases.
+	Timeout time.Duration
+
+	// Logger logs the redeploys which fail after the timeout (optional)
+	Logger *logger.Logger
+}
+
+// ConsumesEnvGroup returns true if the variables of an env group were merged into the
+// values of a release. The env groups whose variables were merged are recorded in
+// `container.env.synced`, and releases which were deployed before they were recorded are
+// detected through the references to the secrets of the env group, which are stored in
+// `container.env.normal` with the value PORTERSECRET_<name>.
+func ConsumesEnvGroup(rel *release.Release, name string) bool {
+	for _, consumed := range ReferencedEnvGroups(rel.Config) {
+		if consumed == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReferencesSecrets returns true if the `container.env.normal` values of a release
+// reference the secrets of an env group
+func ReferencesSecrets(values map[string]interface{}, name string) bool {
+	env, err := getNestedMap(values, "container", "env", "normal")
+
+	if err != nil {
+		return false
+	}
+
+	for _, val := range env {
+		if valStr, ok := val.(string); ok && valStr == SecretReference(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReferencedEnvGroups returns the sorted names of the env groups which are recorded as
+// synced in the values of a release, or whose secrets are referenced in its
+// `container.env.normal` values
+func ReferencedEnvGroups(values map[string]interface{}) []string {
+	names := make(map[string]bool)
+
+	for _, name := range SyncedEnvGroups(values) {
+		names[name] = true
+	}
+
+	if env, err := getNestedMap(values, "container", "env", "normal"); err == nil {
+		for _, val := range env {
+			if valStr, ok := val.(string); ok && strings.HasPrefix(valStr, secretReferencePrefix) {
+				names[strings.TrimPrefix(valStr, secretReferencePrefix)] = true
+			}
+		}
+	}
+
+	res := make([]string, 0, len(names))
+
+	for name := range names {
+		res = append(res, name)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// MapEnvGroups replaces the env groups which are recorded as synced and the references
+// to the secrets of env groups in the `container.env.normal` values of a release, using
+// a mapping from the current to the new env group name. Env groups which are not in the
+// mapping are left unchanged.
+func MapEnvGroups(values map[string]interface{}, mapping map[string]string) {
+	if synced := SyncedEnvGroups(values); len(synced) > 0 {
+		for i, name := range synced {
+			if newName, ok := mapping[name]; ok {
+				synced[i] = newName
+			}
+		}
+
+		if env, err := getNestedMap(values, "container", "env"); err == nil {
+			setSyncedEnvGroups(env, synced)
+		}
+	}
+
+	env, err := getNestedMap(values, "container", "env", "normal")
+
+	if err != nil {
+		return
+	}
+
+	for key, val := range env {
+		valStr, ok := val.(string)
+
+		if !ok || !strings.HasPrefix(valStr, secretReferencePrefix) {
+			continue
+		}
+
+		if newName, ok := mapping[strings.TrimPrefix(valStr, secretReferencePrefix)]; ok {
+			env[key] = SecretReference(newName)
+		}
+	}
+}
+
+// DetectReleases links every release in the namespace which consumes the env group,
+// but is not linked yet, with the "none" redeploy policy. It returns all releases which
+// are linked to the env group.
+func DetectReleases(
+	repo repository.Repository,
+	helmAgent *helm.Agent,
+	cluster *models.Cluster,
+	namespace, name string,
+) ([]*models.EnvGroupRelease, error) {
+	releases, err := helmAgent.ListReleases(namespace, &types.ReleaseListFilter{
+		StatusFilter: []string{"deployed", "failed", "pending-install", "pending-upgrade", "pending-rollback"},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rel := range releases {
+		if !ConsumesEnvGroup(rel, name) {
+			continue
+		}
+
+		_, err := repo.EnvGroupRelease().ReadEnvGroupRelease(cluster.ProjectID, cluster.ID, namespace, name, rel.Name)
+
+		if err == nil {
+			continue
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		_, err = repo.EnvGroupRelease().CreateEnvGroupRelease(&models.EnvGroupRelease{
+			ProjectID:      cluster.ProjectID,
+			ClusterID:      cluster.ID,
+			Namespace:      namespace,
+			EnvGroupName:   name,
+			ReleaseName:    rel.Name,
+			RedeployPolicy: types.EnvGroupRedeployPolicyNone,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return repo.EnvGroupRelease().ListEnvGroupReleases(cluster.ProjectID, cluster.ID, namespace, name)
+}
+
+// Redeploy redeploys each linked release according to its redeploy policy, after the
+// env group was updated from the previous version to the current version. The previous
+// version may be nil if the env group was just created. Releases are redeployed
+// concurrently, and a result is returned for each release in the same order.
+func Redeploy(
+	conf *RedeployConfig,
+	links []*models.EnvGroupRelease,
+	previous, current *models.EnvGroupVersion,
+) []*types.EnvGroupRedeployResult {
+	res := make([]*types.EnvGroupRedeployResult, len(links))
+
+	// buffered so that redeploys which finish after the timeout do not block
+	done := make(chan indexedRedeployResult, len(links))
+	pending := 0
+
+	for i, link := range links {
+		if link.RedeployPolicy == "" || link.RedeployPolicy == types.EnvGroupRedeployPolicyNone {
+			res[i] = &types.EnvGroupRedeployResult{
+				ReleaseName: link.ReleaseName,
+				Strategy:    types.EnvGroupRedeployPolicyNone,
+				Status:      types.EnvGroupRedeployStatusSkipped,
+			}
+
+			continue
+		}
+
+		res[i] = &types.EnvGroupRedeployResult{
+			ReleaseName: link.ReleaseName,
+			Strategy:    link.RedeployPolicy,
+			Status:      types.EnvGroupRedeployStatusInProgress,
+		}
+
+		pending++
+
+		go func(index int, link *models.EnvGroupRelease) {
+			done <- indexedRedeployResult{index, redeployRelease(conf, link, previous, current)}
+		}(i, link)
+	}
+
+	var timeout <-chan time.Time
+
+	if conf.Timeout > 0 {
+		timer := time.NewTimer(conf.Timeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	for ; pending > 0; pending-- {
+		select {
+		case result := <-done:
+			res[result.index] = result.result
+		case <-timeout:
+			go logLateRedeploys(conf, done, pending)
+
+			return res
+		}
+	}
+
+	return res
+}
+
+type indexedRedeployResult struct {
+	index  int
+	result *types.EnvGroupRedeployResult
+}
+
+// logLateRedeploys waits for the redeploys which were still in progress when Redeploy
+// returned, and logs the ones which failed
+func logLateRedeploys(conf *RedeployConfig, done <-chan indexedRedeployResult, pending int) {
+	for ; pending > 0; pending-- {
+		result := (<-done).result
+
+		if result.Status == types.EnvGroupRedeployStatusFailed && conf.Logger != nil {
+			conf.Logger.Error().Str("release", result.ReleaseName).Str("error", result.Error).
+				Msg("could not redeploy release after env group update")
+		}
+	}
+}
+
+// redeployRelease redeploys a single release according to its redeploy policy. The
+// restart policy falls back to an upgrade if variables which are not secret changed,
+// since those are copied into the values of the release, which a restart does not apply.
+func redeployRelease(
+	conf *RedeployConfig,
+	link *models.EnvGroupRelease,
+	previous, current *models.EnvGroupVersion,
+) *types.EnvGroupRedeployResult {
+	res := &types.EnvGroupRedeployResult{
+		ReleaseName: link.ReleaseName,
+		Strategy:    link.RedeployPolicy,
+		Status:      types.EnvGroupRedeployStatusSucceeded,
+	}
+
+	err := checkNoCanaryRollout(conf, link)
+
+	if err == nil && res.Strategy == types.EnvGroupRedeployPolicyRestart {
+		var changed bool
+
+		changed, err = hasChanges(previous, current, false)
+
+		if changed {
+			res.Strategy = types.EnvGroupRedeployPolicyUpgrade
+		}
+	}
+
+	if err == nil {
+		switch res.Strategy {
+		case types.EnvGroupRedeployPolicyRestart:
+			err = restartRelease(conf, link.ReleaseName)
+		case types.EnvGroupRedeployPolicyUpgrade:
+			err = upgradeRelease(conf, link.ReleaseName, previous, current)
+		default:
+			err = fmt.Errorf("unknown redeploy policy %s", res.Strategy)
+		}
+	}
+
+	if err != nil {
+		res.Status = types.EnvGroupRedeployStatusFailed
+		res.Error = err.Error()
+	}
+
+	return res
+}
+
+// checkNoCanaryRollout returns an error if a canary rollout is in progress for the
+// release, since the release is only updated by the rollout until it finishes
+func checkNoCanaryRollout(conf *RedeployConfig, link *models.EnvGroupRelease) error {
+	_, err := conf.Repo.CanaryRollout().ReadInProgressCanaryRollout(conf.Cluster.ID, link.Namespace, link.ReleaseName)
+
+	if err == nil {
+		return fmt.Errorf("a canary rollout is in progress for %s", link.ReleaseName)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+func restartRelease(conf *RedeployConfig, name string) error {
+	rel, err := conf.HelmAgent.GetRelease(name, 0, false)
+
+	if err != nil {
+		return err
+	}
+
+	yamlArr := grapher.ImportMultiDocYAML([]byte(rel.Manifest))
+	controllers := grapher.ParseControllers(yamlArr)
+
+	for _, controller := range controllers {
+		switch controller.Kind {
+		case "Deployment", "StatefulSet", "DaemonSet":
+			err := conf.HelmAgent.K8sAgent.RestartController(rel.Namespace, controller.Kind, controller.Name)
+
+			if err != nil {
+				return fmt.Errorf("could not restart %s %s: %v", strings.ToLower(controller.Kind), controller.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func upgradeRelease(conf *RedeployConfig, name string, previous, current *models.EnvGroupVersion) error {
+	rel, err := conf.HelmAgent.GetRelease(name, 0, false)
+
+	if err != nil {
+		return err
+	}
+
+	values, err := MergeEnvValues(rel.Config, previous, current)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = conf.HelmAgent.UpgradeReleaseByValues(&helm.UpgradeReleaseConfig{
+		Name:       name,
+		Cluster:    conf.Cluster,
+		Repo:       conf.Repo,
+		Registries: conf.Registries,
+		Values:     values,
+	}, conf.DOConf)
+
+	if err != nil {
+		return err
+	}
+
+	// secret values are injected from the linked secret, so the pod templates of the
+	// release do not change when only secrets change: restart the release instead
+	changed, err := hasChanges(previous, current, true)
+
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		return restartRelease(conf, name)
+	}
+
+	return nil
+}
+
+// MergeEnvValues merges the variables of the current version of an env group into the
+// `container.env.normal` values of a release, and records the env group as synced.
+// Variables which were part of the previous version but were removed from the current
+// version are removed from the values.
+func MergeEnvValues(values map[string]interface{}, previous, current *models.EnvGroupVersion) (map[string]interface{}, error) {
+	currVars, err := current.GetVariables()
+
+	if err != nil {
+		return nil, err
+	}
+
+	currSecrets, err := current.GetSecretHashes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+
+	env := values
+
+	for _, field := range []string{"container", "env", "normal"} {
+		next, ok := env[field].(map[string]interface{})
+
+		if !ok {
+			next = make(map[string]interface{})
+			env[field] = next
+		}
+
+		env = next
+	}
+
+	if previous != nil {
+		prevVars, prevSecrets, err := getVersionValues(previous)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, prev := range []map[string]string{prevVars, prevSecrets} {
+			for key := range prev {
+				_, isVar := currVars[key]
+				_, isSecret := currSecrets[key]
+
+				if !isVar && !isSecret {
+					delete(env, key)
+				}
+			}
+		}
+	}
+
+	for key, val := range currVars {
+		env[key] = val
+	}
+
+	for key := range currSecrets {
+		env[key] = SecretReference(current.Name)
+	}
+
+	RecordSyncedEnvGroup(values, current.Name)
+
+	return values, nil
+}
+
+// hasChanges returns true if the secret or non-secret variables of the env group changed
+// from the previous to the current version
+func hasChanges(previous, current *models.EnvGroupVersion, secret bool) (bool, error) {
+	if previous == nil {
+		return false, nil
+	}
+
+	changes, err := Diff(previous, current)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, change := range changes {
+		if change.Secret == secret {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func getNestedMap(obj map[string]interface{}, fields ...string) (map[string]interface{}, error) {
+	curr := obj
+
+	for _, field := range fields {
+		next, ok := curr[field].(map[string]interface{})
+
+		if !ok {
+			return nil, fmt.Errorf("%s is not a nested object", field)
+		}
+
+		curr = next
+	}
+
+	return curr, nil
+}
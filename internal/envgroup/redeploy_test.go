@@ -0,0 +1,221 @@
+package envgroup_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository/test"
+	"gorm.io/gorm"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func newVersion(t *testing.T, version uint, variables, secretHashes map[string]string) *models.EnvGroupVersion {
+	varBytes, err := json.Marshal(variables)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	hashBytes, err := json.Marshal(secretHashes)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return &models.EnvGroupVersion{
+		Name:         "app-env",
+		Namespace:    "default",
+		Version:      version,
+		Variables:    varBytes,
+		SecretHashes: hashBytes,
+	}
+}
+
+func TestMergeEnvValues(t *testing.T) {
+	previous := newVersion(t, 1,
+		map[string]string{"LOG_LEVEL": "info", "OLD_VAR": "1"},
+		map[string]string{"DB_PASSWORD": "abc"},
+	)
+
+	current := newVersion(t, 2,
+		map[string]string{"LOG_LEVEL": "debug"},
+		map[string]string{"DB_PASSWORD": "def", "API_KEY": "ghi"},
+	)
+
+	values := map[string]interface{}{
+		"replicaCount": 2,
+		"container": map[string]interface{}{
+			"port": 80,
+			"env": map[string]interface{}{
+				"normal": map[string]interface{}{
+					"LOG_LEVEL":   "info",
+					"OLD_VAR":     "1",
+					"DB_PASSWORD": "PORTERSECRET_app-env",
+					"APP_ONLY":    "keep",
+				},
+			},
+		},
+	}
+
+	res, err := envgroup.MergeEnvValues(values, previous, current)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expEnv := map[string]interface{}{
+		"LOG_LEVEL":   "debug",
+		"DB_PASSWORD": "PORTERSECRET_app-env",
+		"API_KEY":     "PORTERSECRET_app-env",
+		"APP_ONLY":    "keep",
+	}
+
+	env := res["container"].(map[string]interface{})["env"].(map[string]interface{})["normal"]
+
+	if !reflect.DeepEqual(env, expEnv) {
+		t.Errorf("expected env %v, got %v", expEnv, env)
+	}
+
+	if res["replicaCount"] != 2 {
+		t.Errorf("expected other values to be kept")
+	}
+
+	if synced := envgroup.SyncedEnvGroups(res); !reflect.DeepEqual(synced, []string{"app-env"}) {
+		t.Errorf("expected the env group to be recorded as synced, got %v", synced)
+	}
+
+	// releases without env values get them created
+	res, err = envgroup.MergeEnvValues(nil, nil, current)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	env = res["container"].(map[string]interface{})["env"].(map[string]interface{})["normal"]
+
+	if len(env.(map[string]interface{})) != 3 {
+		t.Errorf("expected 3 variables, got %v", env)
+	}
+}
+
+func TestConsumesEnvGroup(t *testing.T) {
+	rel := &release.Release{
+		Config: map[string]interface{}{
+			"container": map[string]interface{}{
+				"env": map[string]interface{}{
+					"normal": map[string]interface{}{
+						"DB_PASSWORD": "PORTERSECRET_app-env",
+					},
+				},
+			},
+		},
+	}
+
+	if !envgroup.ConsumesEnvGroup(rel, "app-env") {
+		t.Errorf("expected release to consume app-env")
+	}
+
+	if envgroup.ConsumesEnvGroup(rel, "other-env") {
+		t.Errorf("expected release to not consume other-env")
+	}
+
+	if envgroup.ConsumesEnvGroup(&release.Release{}, "app-env") {
+		t.Errorf("expected release without values to not consume app-env")
+	}
+
+	// releases which only consume variables that are not secret are detected through the
+	// recorded env groups
+	rel = &release.Release{
+		Config: map[string]interface{}{
+			"container": map[string]interface{}{
+				"env": map[string]interface{}{
+					"normal": map[string]interface{}{
+						"LOG_LEVEL": "info",
+					},
+					"synced": []interface{}{"app-env"},
+				},
+			},
+		},
+	}
+
+	if !envgroup.ConsumesEnvGroup(rel, "app-env") {
+		t.Errorf("expected release with synced env group to consume app-env")
+	}
+}
+
+func TestReferencedEnvGroups(t *testing.T) {
+	values := map[string]interface{}{
+		"container": map[string]interface{}{
+			"env": map[string]interface{}{
+				"normal": map[string]interface{}{
+					"DB_PASSWORD": "PORTERSECRET_app-env",
+					"API_KEY":     "PORTERSECRET_shared-env",
+					"DB_USER":     "PORTERSECRET_app-env",
+					"LOG_LEVEL":   "info",
+				},
+				"synced": []interface{}{"app-env", "plain-env"},
+			},
+		},
+	}
+
+	names := envgroup.ReferencedEnvGroups(values)
+
+	if exp := []string{"app-env", "plain-env", "shared-env"}; !reflect.DeepEqual(names, exp) {
+		t.Errorf("expected env groups %v, got %v", exp, names)
+	}
+
+	if names := envgroup.ReferencedEnvGroups(map[string]interface{}{}); len(names) != 0 {
+		t.Errorf("expected no env groups for empty values, got %v", names)
+	}
+}
+
+func TestRedeploySkipsReleasesWithoutPolicy(t *testing.T) {
+	current := newVersion(t, 1, map[string]string{}, map[string]string{})
+
+	res := envgroup.Redeploy(&envgroup.RedeployConfig{}, []*models.EnvGroupRelease{
+		{ReleaseName: "web", RedeployPolicy: types.EnvGroupRedeployPolicyNone},
+		{ReleaseName: "worker"},
+	}, nil, current)
+
+	if len(res) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(res))
+	}
+
+	for _, result := range res {
+		if result.Status != types.EnvGroupRedeployStatusSkipped || result.Strategy != types.EnvGroupRedeployPolicyNone {
+			t.Errorf("expected %s to be skipped, got %+v", result.ReleaseName, result)
+		}
+	}
+}
+
+func TestRedeploySkipsCanaryRollouts(t *testing.T) {
+	repo := test.NewRepository(true)
+	cluster := &models.Cluster{Model: gorm.Model{ID: 1}, ProjectID: 1}
+
+	_, err := repo.CanaryRollout().CreateCanaryRollout(&models.CanaryRollout{
+		ProjectID:   1,
+		ClusterID:   1,
+		Namespace:   "default",
+		ReleaseName: "web",
+		Status:      types.CanaryRolloutStatusInProgress,
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	current := newVersion(t, 1, map[string]string{}, map[string]string{})
+
+	res := envgroup.Redeploy(&envgroup.RedeployConfig{Repo: repo, Cluster: cluster}, []*models.EnvGroupRelease{
+		{Namespace: "default", ReleaseName: "web", RedeployPolicy: types.EnvGroupRedeployPolicyUpgrade},
+	}, nil, current)
+
+	if len(res) != 1 || res[0].Status != types.EnvGroupRedeployStatusFailed || res[0].Error == "" {
+		t.Errorf("expected the redeploy of a release with a canary rollout to fail, got %+v", res[0])
+	}
+}
@@ -0,0 +1,107 @@
+package envgroup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/scheduler"
+	"golang.org/x/oauth2"
+)
+
+// SecretRefresher periodically syncs the secret references of all env groups, so that
+// values which change in an external secret store reach the linked secrets
+type SecretRefresher struct {
+	Repo     repository.Repository
+	Resolver *SecretResolver
+	DOConf   *oauth2.Config
+	Logger   *logger.Logger
+
+	Interval time.Duration
+}
+
+// Run syncs the secret references on every interval, on one replica of the server at a
+// time. If the interval is not positive, syncing is disabled and Run returns immediately.
+func (r *SecretRefresher) Run() {
+	scheduler.Run(r.Repo, r.Logger, "env_group_secret_refresh", r.Interval, r.RefreshAll)
+}
+
+// RefreshAll syncs the secret references of every env group. Errors are logged, and do
+// not stop other env groups from being synced.
+func (r *SecretRefresher) RefreshAll() {
+	refs, err := r.Repo.EnvGroupSecretRef().ListAllEnvGroupSecretRefs()
+
+	if err != nil {
+		r.Logger.Error().Err(err).Msg("could not list env group secret references")
+		return
+	}
+
+	type envGroupKey struct {
+		projectID, clusterID uint
+		namespace, name      string
+	}
+
+	envGroups := make([]envGroupKey, 0)
+	seen := make(map[envGroupKey]bool)
+
+	for _, ref := range refs {
+		key := envGroupKey{ref.ProjectID, ref.ClusterID, ref.Namespace, ref.EnvGroupName}
+
+		if !seen[key] {
+			seen[key] = true
+			envGroups = append(envGroups, key)
+		}
+	}
+
+	agents := make(map[uint]*kubernetes.Agent)
+
+	for _, envGroup := range envGroups {
+		agent, ok := agents[envGroup.clusterID]
+
+		if !ok {
+			agent, err = r.getAgent(envGroup.projectID, envGroup.clusterID)
+
+			if err != nil {
+				r.Logger.Error().Err(err).Uint("cluster_id", envGroup.clusterID).
+					Msg("could not connect to cluster to sync env group secrets")
+			}
+
+			// agents which could not be created are cached as nil, so the cluster is
+			// skipped for the rest of this refresh
+			agents[envGroup.clusterID] = agent
+		}
+
+		if agent == nil {
+			continue
+		}
+
+		cluster := &models.Cluster{ProjectID: envGroup.projectID}
+		cluster.ID = envGroup.clusterID
+
+		_, err := SyncSecretRefs(r.Repo, r.Resolver, agent, cluster, envGroup.namespace, envGroup.name)
+
+		if err != nil {
+			r.Logger.Error().Err(err).
+				Str("env_group", envGroup.name).
+				Str("namespace", envGroup.namespace).
+				Msg("could not sync env group secrets")
+		}
+	}
+}
+
+func (r *SecretRefresher) getAgent(projectID, clusterID uint) (*kubernetes.Agent, error) {
+	cluster, err := r.Repo.Cluster().ReadCluster(projectID, clusterID)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read cluster: %v", err)
+	}
+
+	return kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+		Cluster:           cluster,
+		Repo:              r.Repo,
+		DigitalOceanOAuth: r.DOConf,
+	})
+}
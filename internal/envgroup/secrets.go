@@ -0,0 +1,397 @@
+package envgroup
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// VaultSecretReader reads secrets from the KV secrets engine of a Vault server
+type VaultSecretReader interface {
+	ReadKVSecret(path string) (map[string]interface{}, error)
+}
+
+// SecretResolver reads the values of secret references from external secret stores
+type SecretResolver struct {
+	Repo repository.Repository
+
+	// Vault is nil if the Porter instance is not connected to Vault
+	Vault VaultSecretReader
+}
+
+// NewSecretResolver returns a SecretResolver which reads from Vault, if configured, and
+// from AWS Secrets Manager using the AWS integrations of the project
+func NewSecretResolver(repo repository.Repository, vault VaultSecretReader) *SecretResolver {
+	return &SecretResolver{
+		Repo:  repo,
+		Vault: vault,
+	}
+}
+
+// Resolve returns the value of a secret reference
+func (r *SecretResolver) Resolve(ref *models.EnvGroupSecretRef) (string, error) {
+	switch ref.Backend {
+	case types.EnvGroupSecretBackendVault:
+		if r.Vault == nil {
+			return "", fmt.Errorf("vault is not configured for this Porter instance")
+		}
+
+		if ref.Field == "" {
+			return "", fmt.Errorf("a field is required to read a secret from vault")
+		}
+
+		path, err := GetVaultSecretPath(ref.ProjectID, ref.Path)
+
+		if err != nil {
+			return "", err
+		}
+
+		data, err := r.Vault.ReadKVSecret(path)
+
+		if err != nil {
+			return "", err
+		}
+
+		return getSecretField(data, ref)
+	case types.EnvGroupSecretBackendAWSSecretsManager:
+		secretString, err := r.readAWSSecret(ref)
+
+		if err != nil {
+			return "", err
+		}
+
+		if ref.Field == "" {
+			return secretString, nil
+		}
+
+		data := make(map[string]interface{})
+
+		if err := json.Unmarshal([]byte(secretString), &data); err != nil {
+			return "", fmt.Errorf("secret %s is not a JSON object, so field %s cannot be read", ref.Path, ref.Field)
+		}
+
+		return getSecretField(data, ref)
+	}
+
+	return "", fmt.Errorf("unknown secret backend %s", ref.Backend)
+}
+
+// vaultSegmentRegex matches a single segment of a Vault secret path
+var vaultSegmentRegex = regexp.MustCompile(`^[a-zA-Z0-9_.@-]+$`)
+
+// GetVaultSecretPath returns the path in the KV secrets engine of a Vault secret which
+// an env group references. Paths are relative to a prefix for each project, so that a
+// project cannot read the secrets of other projects or the credentials which Porter
+// stores in Vault. Each segment of the path is validated, so that "." and ".." segments
+// cannot escape the prefix.
+func GetVaultSecretPath(projectID uint, path string) (string, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for _, segment := range segments {
+		if segment == "." || segment == ".." || !vaultSegmentRegex.MatchString(segment) {
+			return "", fmt.Errorf("invalid vault secret path %s", path)
+		}
+	}
+
+	return fmt.Sprintf("env_groups/%d/%s", projectID, strings.Join(segments, "/")), nil
+}
+
+// ValidateSecretRefs checks that the secret references of an env group can be resolved
+// for the given project, before they are saved
+func ValidateSecretRefs(projectID uint, refs map[string]*types.EnvGroupSecretReference) error {
+	for key, ref := range refs {
+		if ref == nil || ref.Backend != types.EnvGroupSecretBackendVault {
+			continue
+		}
+
+		if _, err := GetVaultSecretPath(projectID, ref.Path); err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *SecretResolver) readAWSSecret(ref *models.EnvGroupSecretRef) (string, error) {
+	awsInt, err := r.Repo.AWSIntegration().ReadAWSIntegration(ref.ProjectID, ref.AWSIntegrationID)
+
+	if err != nil {
+		return "", fmt.Errorf("could not read aws integration %d: %v", ref.AWSIntegrationID, err)
+	}
+
+	sess, err := awsInt.GetSession()
+
+	if err != nil {
+		return "", err
+	}
+
+	out, err := secretsmanager.New(sess).GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(ref.Path),
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secret %s is not stored as a string", ref.Path)
+	}
+
+	return *out.SecretString, nil
+}
+
+func getSecretField(data map[string]interface{}, ref *models.EnvGroupSecretRef) (string, error) {
+	val, ok := data[ref.Field]
+
+	if !ok {
+		return "", fmt.Errorf("field %s not found in secret %s", ref.Field, ref.Path)
+	}
+
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
+	}
+
+	// non-string values such as numbers are written as JSON
+	valBytes, err := json.Marshal(val)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(valBytes), nil
+}
+
+// SaveSecretRefs creates, updates or deletes the secret references of an env group. A
+// nil reference deletes the reference for that key.
+func SaveSecretRefs(
+	repo repository.Repository,
+	cluster *models.Cluster,
+	namespace, name string,
+	refs map[string]*types.EnvGroupSecretReference,
+) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	currRefs, err := repo.EnvGroupSecretRef().ListEnvGroupSecretRefs(cluster.ProjectID, cluster.ID, namespace, name)
+
+	if err != nil {
+		return err
+	}
+
+	currByKey := make(map[string]*models.EnvGroupSecretRef)
+
+	for _, ref := range currRefs {
+		currByKey[ref.Key] = ref
+	}
+
+	for key, ref := range refs {
+		curr, exists := currByKey[key]
+
+		switch {
+		case ref == nil && exists:
+			err = repo.EnvGroupSecretRef().DeleteEnvGroupSecretRef(curr)
+		case ref == nil:
+			continue
+		case exists:
+			curr.Backend = ref.Backend
+			curr.Path = ref.Path
+			curr.Field = ref.Field
+			curr.AWSIntegrationID = ref.AWSIntegrationID
+			curr.SyncStatus = types.EnvGroupSecretSyncStatusPending
+			curr.SyncError = ""
+
+			_, err = repo.EnvGroupSecretRef().UpdateEnvGroupSecretRef(curr)
+		default:
+			_, err = repo.EnvGroupSecretRef().CreateEnvGroupSecretRef(&models.EnvGroupSecretRef{
+				ProjectID:        cluster.ProjectID,
+				ClusterID:        cluster.ID,
+				Namespace:        namespace,
+				EnvGroupName:     name,
+				Key:              key,
+				Backend:          ref.Backend,
+				Path:             ref.Path,
+				Field:            ref.Field,
+				AWSIntegrationID: ref.AWSIntegrationID,
+				SyncStatus:       types.EnvGroupSecretSyncStatusPending,
+			})
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteSecretRefs deletes the secret references for the given keys of an env group, or
+// all secret references of the env group if no keys are given
+func DeleteSecretRefs(
+	repo repository.Repository,
+	cluster *models.Cluster,
+	namespace, name string,
+	keys ...string,
+) error {
+	refs, err := repo.EnvGroupSecretRef().ListEnvGroupSecretRefs(cluster.ProjectID, cluster.ID, namespace, name)
+
+	if err != nil {
+		return err
+	}
+
+	keySet := make(map[string]bool)
+
+	for _, key := range keys {
+		keySet[key] = true
+	}
+
+	for _, ref := range refs {
+		if len(keys) > 0 && !keySet[ref.Key] {
+			continue
+		}
+
+		if err := repo.EnvGroupSecretRef().DeleteEnvGroupSecretRef(ref); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RenameSecretRefs moves the secret references of an env group to its new name
+func RenameSecretRefs(
+	repo repository.Repository,
+	cluster *models.Cluster,
+	namespace, name, newName string,
+) error {
+	refs, err := repo.EnvGroupSecretRef().ListEnvGroupSecretRefs(cluster.ProjectID, cluster.ID, namespace, name)
+
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		ref.EnvGroupName = newName
+
+		if _, err := repo.EnvGroupSecretRef().UpdateEnvGroupSecretRef(ref); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SyncSecretRefs resolves the secret references of an env group and writes their values
+// to the linked secret of the env group. The sync status of each reference is stored,
+// and the references are returned.
+func SyncSecretRefs(
+	repo repository.Repository,
+	resolver *SecretResolver,
+	agent *kubernetes.Agent,
+	cluster *models.Cluster,
+	namespace, name string,
+) ([]*models.EnvGroupSecretRef, error) {
+	refs, err := repo.EnvGroupSecretRef().ListEnvGroupSecretRefs(cluster.ProjectID, cluster.ID, namespace, name)
+
+	if err != nil || len(refs) == 0 {
+		return refs, err
+	}
+
+	configMapData := make(map[string]string)
+	secretData := make(map[string][]byte)
+	synced := make([]*models.EnvGroupSecretRef, 0)
+
+	for _, ref := range refs {
+		val, err := resolver.Resolve(ref)
+
+		if err != nil {
+			ref.SyncStatus = types.EnvGroupSecretSyncStatusFailed
+			ref.SyncError = err.Error()
+			continue
+		}
+
+		configMapData[ref.Key] = SecretReference(name)
+		secretData[ref.Key] = []byte(val)
+		synced = append(synced, ref)
+	}
+
+	if len(synced) > 0 {
+		if err := writeSecretData(agent, namespace, name, configMapData, secretData); err != nil {
+			for _, ref := range synced {
+				ref.SyncStatus = types.EnvGroupSecretSyncStatusFailed
+				ref.SyncError = err.Error()
+			}
+
+			synced = nil
+		}
+	}
+
+	now := time.Now()
+
+	for _, ref := range synced {
+		ref.SyncStatus = types.EnvGroupSecretSyncStatusSynced
+		ref.SyncError = ""
+		ref.LastSyncedAt = &now
+	}
+
+	for _, ref := range refs {
+		if _, err := repo.EnvGroupSecretRef().UpdateEnvGroupSecretRef(ref); err != nil {
+			return nil, err
+		}
+	}
+
+	return refs, nil
+}
+
+func writeSecretData(
+	agent *kubernetes.Agent,
+	namespace, name string,
+	configMapData map[string]string,
+	secretData map[string][]byte,
+) error {
+	err := agent.UpdateLinkedSecret(name, namespace, name, secretData)
+
+	if err != nil && k8serrors.IsNotFound(err) {
+		_, err = agent.CreateLinkedSecret(name, namespace, name, secretData)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = agent.UpdateConfigMap(name, namespace, configMapData)
+
+	return err
+}
+
+// getExternalSecretKeys returns the keys of an env group whose values are synced from an
+// external secret store
+func getExternalSecretKeys(repo repository.Repository, cluster *models.Cluster, namespace, name string) (map[string]bool, error) {
+	refs, err := repo.EnvGroupSecretRef().ListEnvGroupSecretRefs(cluster.ProjectID, cluster.ID, namespace, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]bool)
+
+	for _, ref := range refs {
+		res[ref.Key] = true
+	}
+
+	return res, nil
+}
@@ -0,0 +1,249 @@
+package envgroup_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+type fakeVault struct {
+	secrets map[string]map[string]interface{}
+}
+
+func (v *fakeVault) ReadKVSecret(path string) (map[string]interface{}, error) {
+	if data, ok := v.secrets[path]; ok {
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("request failed with status code 404")
+}
+
+func TestSyncSecretRefs(t *testing.T) {
+	repo := test.NewRepository(true)
+	agent, cluster := setupEnvGroup(t)
+
+	vault := &fakeVault{
+		secrets: map[string]map[string]interface{}{
+			"env_groups/1/app/prod": {
+				"api_key": "abc123",
+				"port":    float64(5432),
+			},
+		},
+	}
+
+	resolver := envgroup.NewSecretResolver(repo, vault)
+
+	err := envgroup.SaveSecretRefs(repo, cluster, "default", "app-env", map[string]*types.EnvGroupSecretReference{
+		"API_KEY": {Backend: types.EnvGroupSecretBackendVault, Path: "app/prod", Field: "api_key"},
+		"DB_PORT": {Backend: types.EnvGroupSecretBackendVault, Path: "app/prod", Field: "port"},
+		"MISSING": {Backend: types.EnvGroupSecretBackendVault, Path: "app/prod", Field: "missing"},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	refs, err := envgroup.SyncSecretRefs(repo, resolver, agent, cluster, "default", "app-env")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expStatus := map[string]types.EnvGroupSecretSyncStatus{
+		"API_KEY": types.EnvGroupSecretSyncStatusSynced,
+		"DB_PORT": types.EnvGroupSecretSyncStatusSynced,
+		"MISSING": types.EnvGroupSecretSyncStatusFailed,
+	}
+
+	if len(refs) != len(expStatus) {
+		t.Fatalf("expected %d references, got %d", len(expStatus), len(refs))
+	}
+
+	for _, ref := range refs {
+		if ref.SyncStatus != expStatus[ref.Key] {
+			t.Errorf("expected %s to have status %s, got %s (%s)", ref.Key, expStatus[ref.Key], ref.SyncStatus, ref.SyncError)
+		}
+
+		if ref.SyncStatus == types.EnvGroupSecretSyncStatusSynced && ref.LastSyncedAt == nil {
+			t.Errorf("expected %s to have a sync time", ref.Key)
+		}
+
+		if ref.SyncStatus == types.EnvGroupSecretSyncStatusFailed && ref.SyncError == "" {
+			t.Errorf("expected %s to have a sync error", ref.Key)
+		}
+	}
+
+	_, secretVariables, err := envgroup.Snapshot(agent, "default", "app-env")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expSecrets := map[string]string{
+		"DB_PASSWORD": "hunter2",
+		"API_KEY":     "abc123",
+		"DB_PORT":     "5432",
+	}
+
+	if len(secretVariables) != len(expSecrets) {
+		t.Errorf("expected secret variables %v, got %v", expSecrets, secretVariables)
+	}
+
+	for key, val := range expSecrets {
+		if secretVariables[key] != val {
+			t.Errorf("expected %s to be %q, got %q", key, val, secretVariables[key])
+		}
+	}
+
+	// values synced from the external secret store are hashed but not stored
+	version, err := envgroup.RecordVersion(repo, agent, cluster, "default", "app-env", "admin@example.com")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	hashes, err := version.GetSecretHashes()
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	values, err := version.GetSecretValues()
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if hashes["API_KEY"] != envgroup.HashSecret("abc123") {
+		t.Errorf("expected API_KEY to be hashed")
+	}
+
+	if _, found := values["API_KEY"]; found {
+		t.Errorf("expected the value of API_KEY to not be stored")
+	}
+
+	if values["DB_PASSWORD"] != "hunter2" {
+		t.Errorf("expected the value of DB_PASSWORD to be stored")
+	}
+}
+
+func TestResolveVaultNotConfigured(t *testing.T) {
+	repo := test.NewRepository(true)
+	agent, cluster := setupEnvGroup(t)
+
+	err := envgroup.SaveSecretRefs(repo, cluster, "default", "app-env", map[string]*types.EnvGroupSecretReference{
+		"API_KEY": {Backend: types.EnvGroupSecretBackendVault, Path: "app/prod", Field: "api_key"},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	refs, err := envgroup.SyncSecretRefs(repo, envgroup.NewSecretResolver(repo, nil), agent, cluster, "default", "app-env")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(refs) != 1 || refs[0].SyncStatus != types.EnvGroupSecretSyncStatusFailed {
+		t.Fatalf("expected the reference to fail to sync")
+	}
+
+	// removing the reference leaves no references to sync
+	err = envgroup.SaveSecretRefs(repo, cluster, "default", "app-env", map[string]*types.EnvGroupSecretReference{
+		"API_KEY": nil,
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	refs, err = repo.EnvGroupSecretRef().ListEnvGroupSecretRefs(1, 1, "default", "app-env")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(refs) != 0 {
+		t.Errorf("expected no references, got %d", len(refs))
+	}
+}
+
+func TestGetVaultSecretPath(t *testing.T) {
+	tests := []struct {
+		path   string
+		exp    string
+		expErr bool
+	}{
+		{path: "app/prod", exp: "env_groups/1/app/prod"},
+		{path: "/app/prod/", exp: "env_groups/1/app/prod"},
+		{path: "app/v1.2@eu-west_1", exp: "env_groups/1/app/v1.2@eu-west_1"},
+		{path: "secret/porter/2/aws/1", exp: "env_groups/1/secret/porter/2/aws/1"},
+		{path: "../2/app/prod", expErr: true},
+		{path: "app/../../../secret/porter/2/aws/1", expErr: true},
+		{path: "app/./prod", expErr: true},
+		{path: "app//prod", expErr: true},
+		{path: "app/prod?version=1", expErr: true},
+		{path: "app/%2e%2e/prod", expErr: true},
+		{path: "", expErr: true},
+	}
+
+	for _, test := range tests {
+		path, err := envgroup.GetVaultSecretPath(1, test.path)
+
+		if test.expErr {
+			if err == nil {
+				t.Errorf("expected an error for path %q, got %s", test.path, path)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("expected path %q to be valid, got %v", test.path, err)
+		} else if path != test.exp {
+			t.Errorf("expected path %q to resolve to %s, got %s", test.path, test.exp, path)
+		}
+	}
+}
+
+func TestResolveVaultPathConfined(t *testing.T) {
+	repo := test.NewRepository(true)
+	agent, cluster := setupEnvGroup(t)
+
+	// the credentials of integrations are stored alongside env group secrets
+	vault := &fakeVault{
+		secrets: map[string]map[string]interface{}{
+			"secret/porter/2/aws/1": {"aws_secret_access_key": "leaked"},
+		},
+	}
+
+	err := envgroup.SaveSecretRefs(repo, cluster, "default", "app-env", map[string]*types.EnvGroupSecretReference{
+		"LEAKED": {Backend: types.EnvGroupSecretBackendVault, Path: "secret/porter/2/aws/1", Field: "aws_secret_access_key"},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	refs, err := envgroup.SyncSecretRefs(repo, envgroup.NewSecretResolver(repo, vault), agent, cluster, "default", "app-env")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(refs) != 1 || refs[0].SyncStatus != types.EnvGroupSecretSyncStatusFailed {
+		t.Fatalf("expected the reference to fail to sync")
+	}
+
+	err = envgroup.ValidateSecretRefs(cluster.ProjectID, map[string]*types.EnvGroupSecretReference{
+		"LEAKED": {Backend: types.EnvGroupSecretBackendVault, Path: "../../secret/porter/2/aws/1", Field: "aws_secret_access_key"},
+	})
+
+	if err == nil {
+		t.Errorf("expected the reference to be invalid")
+	}
+}
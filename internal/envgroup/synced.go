@@ -0,0 +1,113 @@
+package envgroup
+
+import (
+	"sort"
+)
+
+// syncedField is the field of `container.env` in the values of a release which records
+// the names of the env groups whose variables were merged into the values. Unlike the
+// references to secrets, it also records env groups which only have variables that are
+// not secret.
+const syncedField = "synced"
+
+// SyncedEnvGroups returns the names of the env groups recorded in `container.env.synced`
+// of the values of a release
+func SyncedEnvGroups(values map[string]interface{}) []string {
+	env, err := getNestedMap(values, "container", "env")
+
+	if err != nil {
+		return []string{}
+	}
+
+	synced, _ := env[syncedField].([]interface{})
+	res := make([]string, 0, len(synced))
+
+	for _, val := range synced {
+		if name, ok := val.(string); ok && name != "" {
+			res = append(res, name)
+		}
+	}
+
+	return res
+}
+
+// RecordSyncedEnvGroup records in the values of a release that the variables of an env
+// group were merged into them
+func RecordSyncedEnvGroup(values map[string]interface{}, name string) {
+	env := values
+
+	for _, field := range []string{"container", "env"} {
+		next, ok := env[field].(map[string]interface{})
+
+		if !ok {
+			next = make(map[string]interface{})
+			env[field] = next
+		}
+
+		env = next
+	}
+
+	setSyncedEnvGroups(env, append(SyncedEnvGroups(values), name))
+}
+
+// RemoveSyncedEnvGroup removes an env group from the synced env groups of the values of
+// a release, and returns true if it was recorded
+func RemoveSyncedEnvGroup(values map[string]interface{}, name string) bool {
+	env, err := getNestedMap(values, "container", "env")
+
+	if err != nil {
+		return false
+	}
+
+	removed := false
+	res := make([]string, 0)
+
+	for _, synced := range SyncedEnvGroups(values) {
+		if synced == name {
+			removed = true
+		} else {
+			res = append(res, synced)
+		}
+	}
+
+	if removed {
+		setSyncedEnvGroups(env, res)
+	}
+
+	return removed
+}
+
+// KeepSyncedEnvGroups adds the synced env groups of the current values of a release to
+// the new values it is upgraded with. Clients only record the env groups which they
+// merge, so env groups are only removed from a release when they are unlinked.
+func KeepSyncedEnvGroups(current, values map[string]interface{}) {
+	for _, name := range SyncedEnvGroups(current) {
+		RecordSyncedEnvGroup(values, name)
+	}
+}
+
+// setSyncedEnvGroups sets the sorted and deduplicated names of the synced env groups
+// in the `container.env` values of a release
+func setSyncedEnvGroups(env map[string]interface{}, names []string) {
+	unique := make(map[string]bool)
+
+	for _, name := range names {
+		unique[name] = true
+	}
+
+	sorted := make([]string, 0, len(unique))
+
+	for name := range unique {
+		sorted = append(sorted, name)
+	}
+
+	sort.Strings(sorted)
+
+	res := make([]interface{}, 0, len(sorted))
+
+	for _, name := range sorted {
+		res = append(res, name)
+	}
+
+	env[syncedField] = res
+}
@@ -0,0 +1,47 @@
+package envgroup_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/envgroup"
+)
+
+func TestSyncedEnvGroups(t *testing.T) {
+	values := map[string]interface{}{}
+
+	envgroup.RecordSyncedEnvGroup(values, "web-env")
+	envgroup.RecordSyncedEnvGroup(values, "app-env")
+	envgroup.RecordSyncedEnvGroup(values, "web-env")
+
+	if synced := envgroup.SyncedEnvGroups(values); !reflect.DeepEqual(synced, []string{"app-env", "web-env"}) {
+		t.Errorf("expected synced env groups [app-env web-env], got %v", synced)
+	}
+
+	// values which do not record env groups keep the ones of the current values
+	upgraded := map[string]interface{}{
+		"container": map[string]interface{}{
+			"env": map[string]interface{}{
+				"synced": []interface{}{"worker-env"},
+			},
+		},
+	}
+
+	envgroup.KeepSyncedEnvGroups(values, upgraded)
+
+	if synced := envgroup.SyncedEnvGroups(upgraded); !reflect.DeepEqual(synced, []string{"app-env", "web-env", "worker-env"}) {
+		t.Errorf("expected synced env groups [app-env web-env worker-env], got %v", synced)
+	}
+
+	if !envgroup.RemoveSyncedEnvGroup(upgraded, "web-env") {
+		t.Errorf("expected web-env to be removed")
+	}
+
+	if envgroup.RemoveSyncedEnvGroup(upgraded, "web-env") {
+		t.Errorf("expected web-env to not be recorded after it was removed")
+	}
+
+	if synced := envgroup.SyncedEnvGroups(upgraded); !reflect.DeepEqual(synced, []string{"app-env", "worker-env"}) {
+		t.Errorf("expected synced env groups [app-env worker-env], got %v", synced)
+	}
+}
@@ -0,0 +1,182 @@
+package export
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/porter-dev/porter/api/types"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// ResourceGroupVersion is the version of the porter.yaml resource groups which are
+// exported
+const ResourceGroupVersion = "v1"
+
+// ReleaseOpts are the parameters for exporting a release as a porter.yaml resource
+type ReleaseOpts struct {
+	Release *release.Release
+
+	// RepoURL is the chart repository which the chart of the release was installed from
+	RepoURL string
+
+	// IsApplication is true if the chart is a Porter application chart (web, worker or
+	// job), which `porter apply` builds and deploys
+	IsApplication bool
+
+	// GitActionConfig and BuildConfig are set if the release is built from source
+	GitActionConfig *types.GitActionConfig
+	BuildConfig     *types.BuildConfig
+}
+
+// Resource returns the porter.yaml resource which creates or updates the release with
+// `porter apply`. The project and cluster are not part of the target, so that the
+// resource is applied to the project and cluster of the CLI.
+func Resource(opts *ReleaseOpts) (*types.PorterYAMLResource, error) {
+	rel := opts.Release
+
+	if rel.Chart == nil || rel.Chart.Metadata == nil {
+		return nil, fmt.Errorf("release %s does not have a chart", rel.Name)
+	}
+
+	res := &types.PorterYAMLResource{
+		Name: rel.Name,
+		Source: map[string]interface{}{
+			"name":    rel.Chart.Metadata.Name,
+			"repo":    opts.RepoURL,
+			"version": rel.Chart.Metadata.Version,
+		},
+		Target: map[string]interface{}{
+			"namespace": rel.Namespace,
+		},
+	}
+
+	values := ValuesWithoutDefaults(rel.Config, rel.Chart.Values)
+
+	if !opts.IsApplication {
+		// addons are installed with the config of the resource as values
+		res.Config = values
+		return res, nil
+	}
+
+	// the image of an application is set by `porter apply`, either from the build or
+	// from the image of a registry build
+	delete(values, "image")
+
+	res.Config = map[string]interface{}{
+		"build":  getBuild(rel, opts.GitActionConfig, opts.BuildConfig),
+		"values": values,
+	}
+
+	return res, nil
+}
+
+// ValuesWithoutDefaults returns the values which differ from the default values of a
+// chart. Nested maps are compared key by key, while other values, including lists, are
+// kept only if they differ from the default as a whole.
+func ValuesWithoutDefaults(values, defaults map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	for key, val := range values {
+		defaultVal, hasDefault := defaults[key]
+
+		if !hasDefault {
+			res[key] = val
+			continue
+		}
+
+		valMap, isMap := val.(map[string]interface{})
+		defaultMap, isDefaultMap := defaultVal.(map[string]interface{})
+
+		if isMap && isDefaultMap {
+			if nested := ValuesWithoutDefaults(valMap, defaultMap); len(nested) > 0 {
+				res[key] = nested
+			}
+		} else if !valuesEqual(val, defaultVal) {
+			res[key] = val
+		}
+	}
+
+	return res
+}
+
+// valuesEqual compares values, treating numbers of different types as equal: stored
+// release values are decoded from JSON as float64, while chart values are parsed as int
+func valuesEqual(a, b interface{}) bool {
+	aNum, aIsNum := toFloat(a)
+	bNum, bIsNum := toFloat(b)
+
+	if aIsNum && bIsNum {
+		return aNum == bNum
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// getBuild returns the build config of an application resource. Releases with a build
+// config are built with buildpacks, releases with a Dockerfile are built with docker,
+// and releases which are not built from source are deployed from their image.
+func getBuild(
+	rel *release.Release,
+	gitActionConfig *types.GitActionConfig,
+	buildConfig *types.BuildConfig,
+) map[string]interface{} {
+	build := make(map[string]interface{})
+
+	buildContext := "."
+
+	if gitActionConfig != nil && gitActionConfig.FolderPath != "" {
+		buildContext = gitActionConfig.FolderPath
+	}
+
+	switch {
+	case buildConfig != nil:
+		build["method"] = "pack"
+		build["context"] = buildContext
+		build["builder"] = buildConfig.Builder
+
+		if len(buildConfig.Buildpacks) > 0 {
+			build["buildpacks"] = buildConfig.Buildpacks
+		}
+	case gitActionConfig != nil && gitActionConfig.DockerfilePath != "":
+		build["method"] = "docker"
+		build["context"] = buildContext
+		build["dockerfile"] = gitActionConfig.DockerfilePath
+	case gitActionConfig != nil:
+		build["method"] = "pack"
+		build["context"] = buildContext
+	default:
+		build["method"] = "registry"
+		build["image"] = getImage(rel.Config)
+	}
+
+	return build
+}
+
+func getImage(values map[string]interface{}) string {
+	image, ok := values["image"].(map[string]interface{})
+
+	if !ok {
+		return ""
+	}
+
+	repo, _ := image["repository"].(string)
+
+	if tag, _ := image["tag"].(string); tag != "" {
+		return fmt.Sprintf("%s:%s", repo, tag)
+	}
+
+	return repo
+}
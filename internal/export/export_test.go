@@ -0,0 +1,195 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func getRelease() *release.Release {
+	return &release.Release{
+		Name:      "app",
+		Namespace: "staging",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				Name:    "web",
+				Version: "0.50.0",
+			},
+			Values: map[string]interface{}{
+				"replicaCount": 1,
+				"image": map[string]interface{}{
+					"repository": "public.ecr.aws/o1j4x7p4/hello-porter",
+					"tag":        "latest",
+				},
+				"container": map[string]interface{}{
+					"port": 80,
+					"env": map[string]interface{}{
+						"normal": map[string]interface{}{},
+					},
+				},
+				"ingress": map[string]interface{}{
+					"enabled": true,
+					"hosts":   []interface{}{},
+				},
+			},
+		},
+		Config: map[string]interface{}{
+			"replicaCount": float64(2),
+			"image": map[string]interface{}{
+				"repository": "registry.example.com/app",
+				"tag":        "1a2b3c",
+			},
+			"container": map[string]interface{}{
+				"port": float64(80),
+				"env": map[string]interface{}{
+					"normal": map[string]interface{}{
+						"LOG_LEVEL": "info",
+					},
+				},
+			},
+			"ingress": map[string]interface{}{
+				"enabled": true,
+				"hosts":   []interface{}{"app.example.com"},
+			},
+		},
+	}
+}
+
+func TestValuesWithoutDefaults(t *testing.T) {
+	rel := getRelease()
+
+	values := ValuesWithoutDefaults(rel.Config, rel.Chart.Values)
+
+	expected := map[string]interface{}{
+		"replicaCount": float64(2),
+		"image": map[string]interface{}{
+			"repository": "registry.example.com/app",
+			"tag":        "1a2b3c",
+		},
+		"container": map[string]interface{}{
+			"env": map[string]interface{}{
+				"normal": map[string]interface{}{
+					"LOG_LEVEL": "info",
+				},
+			},
+		},
+		"ingress": map[string]interface{}{
+			"hosts": []interface{}{"app.example.com"},
+		},
+	}
+
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected values %v, got %v", expected, values)
+	}
+}
+
+type resourceTest struct {
+	name     string
+	opts     *ReleaseOpts
+	expBuild map[string]interface{}
+}
+
+var resourceTests = []resourceTest{
+	{
+		name: "registry",
+		opts: &ReleaseOpts{},
+		expBuild: map[string]interface{}{
+			"method": "registry",
+			"image":  "registry.example.com/app:1a2b3c",
+		},
+	},
+	{
+		name: "dockerfile",
+		opts: &ReleaseOpts{
+			GitActionConfig: &types.GitActionConfig{
+				GitRepo:        "porter-dev/app",
+				DockerfilePath: "./docker/Dockerfile",
+				FolderPath:     "./api",
+			},
+		},
+		expBuild: map[string]interface{}{
+			"method":     "docker",
+			"context":    "./api",
+			"dockerfile": "./docker/Dockerfile",
+		},
+	},
+	{
+		name: "buildpacks",
+		opts: &ReleaseOpts{
+			GitActionConfig: &types.GitActionConfig{
+				GitRepo: "porter-dev/app",
+			},
+			BuildConfig: &types.BuildConfig{
+				Builder:    "heroku/buildpacks:20",
+				Buildpacks: []string{"heroku/nodejs"},
+			},
+		},
+		expBuild: map[string]interface{}{
+			"method":     "pack",
+			"context":    ".",
+			"builder":    "heroku/buildpacks:20",
+			"buildpacks": []string{"heroku/nodejs"},
+		},
+	},
+}
+
+func TestResource(t *testing.T) {
+	for _, test := range resourceTests {
+		opts := test.opts
+		opts.Release = getRelease()
+		opts.RepoURL = "https://charts.getporter.dev"
+		opts.IsApplication = true
+
+		res, err := Resource(opts)
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		expSource := map[string]interface{}{
+			"name":    "web",
+			"repo":    "https://charts.getporter.dev",
+			"version": "0.50.0",
+		}
+
+		if !reflect.DeepEqual(res.Source, expSource) {
+			t.Errorf("%s: expected source %v, got %v", test.name, expSource, res.Source)
+		}
+
+		if ns := res.Target["namespace"]; ns != "staging" {
+			t.Errorf("%s: expected target namespace staging, got %v", test.name, ns)
+		}
+
+		if build := res.Config["build"]; !reflect.DeepEqual(build, test.expBuild) {
+			t.Errorf("%s: expected build %v, got %v", test.name, test.expBuild, build)
+		}
+
+		values, _ := res.Config["values"].(map[string]interface{})
+
+		if _, ok := values["image"]; ok {
+			t.Errorf("%s: expected image to be removed from the values", test.name)
+		}
+	}
+}
+
+func TestResourceAddon(t *testing.T) {
+	rel := getRelease()
+	rel.Chart.Metadata.Name = "redis"
+
+	res, err := Resource(&ReleaseOpts{
+		Release: rel,
+		RepoURL: "https://chart-addons.getporter.dev",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the values of addons are the config of the resource
+	if !reflect.DeepEqual(res.Config, ValuesWithoutDefaults(rel.Config, rel.Chart.Values)) {
+		t.Errorf("expected config to be the values of the addon, got %v", res.Config)
+	}
+}
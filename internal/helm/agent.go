@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/porter-dev/porter/internal/helm/grapher"
 	"github.com/porter-dev/porter/internal/helm/loader"
 	"golang.org/x/oauth2"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/postrender"
 	"helm.sh/helm/v3/pkg/release"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -90,6 +93,86 @@ func (a *Agent) ListReleases(
 	return res, nil
 }
 
+// ListLastGoodReleases returns, for each release, the most recent revision before its
+// latest revision which was deployed successfully. This is the revision which a release
+// is rolled back to if its latest revision fails.
+func (a *Agent) ListLastGoodReleases(namespace string) ([]*release.Release, error) {
+	secretList, err := a.K8sAgent.Clientset.CoreV1().Secrets(namespace).List(
+		context.Background(),
+		v1.ListOptions{
+			LabelSelector: "owner=helm",
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	latestVersions := make(map[string]int)
+
+	for _, secret := range secretList.Items {
+		id, version, ok := getReleaseSecretVersion(secret)
+
+		if ok && version > latestVersions[id] {
+			latestVersions[id] = version
+		}
+	}
+
+	lastGoodMap := make(map[string]corev1.Secret)
+
+	for _, secret := range secretList.Items {
+		id, version, ok := getReleaseSecretVersion(secret)
+
+		if !ok || version >= latestVersions[id] {
+			continue
+		}
+
+		if status := secret.Labels["status"]; status != string(release.StatusDeployed) &&
+			status != string(release.StatusSuperseded) {
+			continue
+		}
+
+		if curr, exists := lastGoodMap[id]; exists {
+			if _, currVersion, _ := getReleaseSecretVersion(curr); currVersion > version {
+				continue
+			}
+		}
+
+		lastGoodMap[id] = secret
+	}
+
+	chartList := []string{}
+	res := make([]*release.Release, 0)
+
+	for _, secret := range lastGoodMap {
+		rel, isErr, err := kubernetes.ParseSecretToHelmRelease(secret, chartList)
+
+		if !isErr && err == nil {
+			res = append(res, rel)
+		}
+	}
+
+	return res, nil
+}
+
+// getReleaseSecretVersion returns the namespaced name and the version of the release
+// revision which a helm secret stores
+func getReleaseSecretVersion(secret corev1.Secret) (string, int, bool) {
+	relName, relNameExists := secret.Labels["name"]
+
+	if !relNameExists {
+		return "", 0, false
+	}
+
+	version, err := strconv.Atoi(secret.Labels["version"])
+
+	if err != nil {
+		return "", 0, false
+	}
+
+	return fmt.Sprintf("%s/%s", secret.Namespace, relName), version, true
+}
+
 // GetRelease returns the info of a release.
 func (a *Agent) GetRelease(
 	name string,
@@ -151,6 +234,9 @@ type UpgradeReleaseConfig struct {
 
 	// Optional, if chart should be overriden
 	Chart *chart.Chart
+
+	// Optional, if the upgrade should only be rendered and not applied to the cluster
+	DryRun bool
 }
 
 // UpgradeRelease upgrades a specific release with new values.yaml
@@ -190,6 +276,7 @@ func (a *Agent) UpgradeReleaseByValues(
 
 	cmd := action.NewUpgrade(a.ActionConfig)
 	cmd.Namespace = rel.Namespace
+	cmd.DryRun = conf.DryRun
 
 	if conf.Cluster != nil && a.K8sAgent != nil && conf.Registries != nil && len(conf.Registries) > 0 {
 		cmd.PostRenderer, err = NewDockerSecretsPostRenderer(
@@ -199,6 +286,7 @@ func (a *Agent) UpgradeReleaseByValues(
 			rel.Namespace,
 			conf.Registries,
 			doAuth,
+			conf.DryRun,
 		)
 
 		if err != nil {
@@ -224,6 +312,9 @@ type InstallChartConfig struct {
 	Cluster    *models.Cluster
 	Repo       repository.Repository
 	Registries []*models.Registry
+
+	// Optional, post-renderers that run after the image pull secrets are added
+	PostRenderers []postrender.PostRenderer
 }
 
 // InstallChartFromValuesBytes reads the raw values and calls Agent.InstallChart
@@ -273,6 +364,7 @@ func (a *Agent) InstallChart(
 			conf.Namespace,
 			conf.Registries,
 			doAuth,
+			false,
 		)
 
 		if err != nil {
@@ -280,6 +372,16 @@ func (a *Agent) InstallChart(
 		}
 	}
 
+	if len(conf.PostRenderers) > 0 {
+		renderers := chainedPostRenderer{}
+
+		if cmd.PostRenderer != nil {
+			renderers = append(renderers, cmd.PostRenderer)
+		}
+
+		cmd.PostRenderer = append(renderers, conf.PostRenderers...)
+	}
+
 	if req := conf.Chart.Metadata.Dependencies; req != nil {
 		if err := action.CheckDependencies(conf.Chart, req); err != nil {
 			// TODO: Handle dependency updates.
@@ -308,6 +410,38 @@ func (a *Agent) RollbackRelease(
 	return cmd.Run(name)
 }
 
+// WaitForReleaseReady waits until every Deployment, StatefulSet and DaemonSet of a
+// release has rolled out, and returns an error for the first controller which is not
+// ready before the timeout
+func (a *Agent) WaitForReleaseReady(
+	rel *release.Release,
+	timeout time.Duration,
+) error {
+	deadline := time.Now().Add(timeout)
+	controllers := grapher.ParseControllers(grapher.ImportMultiDocYAML([]byte(rel.Manifest)))
+
+	for _, controller := range controllers {
+		switch strings.ToLower(controller.Kind) {
+		case "deployment", "statefulset", "daemonset":
+		default:
+			continue
+		}
+
+		err := a.K8sAgent.WaitForControllerReady(
+			controller.Kind,
+			rel.Namespace,
+			controller.Name,
+			time.Until(deadline),
+		)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ------------------------ Helm agent helper functions ------------------------ //
 
 // checkIfInstallable validates if a chart can be installed
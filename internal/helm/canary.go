@@ -0,0 +1,98 @@
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v2"
+	"helm.sh/helm/v3/pkg/postrender"
+)
+
+const (
+	// CanaryAnnotation marks an NGINX ingress as the canary for another ingress with
+	// the same host
+	CanaryAnnotation = "nginx.ingress.kubernetes.io/canary"
+
+	// CanaryWeightAnnotation is the percentage of requests that NGINX sends to the
+	// canary ingress
+	CanaryWeightAnnotation = "nginx.ingress.kubernetes.io/canary-weight"
+)
+
+// CanaryPostRenderer is a Helm post-renderer that marks every ingress in a release as
+// an NGINX canary ingress, so that the release can be installed next to a release
+// which serves the same hosts.
+type CanaryPostRenderer struct {
+	Weight int
+}
+
+func NewCanaryPostRenderer(weight int) postrender.PostRenderer {
+	return &CanaryPostRenderer{weight}
+}
+
+func (c *CanaryPostRenderer) Run(
+	renderedManifests *bytes.Buffer,
+) (modifiedManifests *bytes.Buffer, err error) {
+	decoder := yaml.NewDecoder(renderedManifests)
+
+	modifiedManifests = bytes.NewBuffer([]byte{})
+	encoder := yaml.NewEncoder(modifiedManifests)
+	defer encoder.Close()
+
+	for {
+		res := make(resource)
+		err := decoder.Decode(&res)
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if kind, _ := res["kind"].(string); kind == "Ingress" {
+			metadata := getNestedResource(res, "metadata")
+
+			if metadata == nil {
+				metadata = make(resource)
+				res["metadata"] = metadata
+			}
+
+			annotations := getNestedResource(res, "metadata", "annotations")
+
+			if annotations == nil {
+				annotations = make(resource)
+				metadata["annotations"] = annotations
+			}
+
+			annotations[CanaryAnnotation] = "true"
+			annotations[CanaryWeightAnnotation] = fmt.Sprintf("%d", c.Weight)
+		}
+
+		if err := encoder.Encode(res); err != nil {
+			return nil, err
+		}
+	}
+
+	return modifiedManifests, nil
+}
+
+// chainedPostRenderer runs a list of post-renderers in order
+type chainedPostRenderer []postrender.PostRenderer
+
+func (c chainedPostRenderer) Run(
+	renderedManifests *bytes.Buffer,
+) (modifiedManifests *bytes.Buffer, err error) {
+	modifiedManifests = renderedManifests
+
+	for _, renderer := range c {
+		modifiedManifests, err = renderer.Run(modifiedManifests)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return modifiedManifests, nil
+}
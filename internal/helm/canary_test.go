@@ -0,0 +1,65 @@
+package helm_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm"
+	"gopkg.in/yaml.v2"
+)
+
+func TestCanaryPostRenderer(t *testing.T) {
+	manifests := bytes.NewBufferString(`apiVersion: v1
+kind: Service
+metadata:
+  name: app-web
+---
+apiVersion: networking.k8s.io/v1beta1
+kind: Ingress
+metadata:
+  name: app-web
+  annotations:
+    kubernetes.io/ingress.class: nginx
+`)
+
+	res, err := helm.NewCanaryPostRenderer(25).Run(manifests)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoder := yaml.NewDecoder(res)
+	docs := make([]map[string]interface{}, 0)
+
+	for {
+		doc := make(map[string]interface{})
+
+		if err := decoder.Decode(&doc); err != nil {
+			break
+		}
+
+		docs = append(docs, doc)
+	}
+
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d", len(docs))
+	}
+
+	if _, exists := docs[0]["metadata"].(map[interface{}]interface{})["annotations"]; exists {
+		t.Errorf("expected service to not be annotated")
+	}
+
+	annotations := docs[1]["metadata"].(map[interface{}]interface{})["annotations"].(map[interface{}]interface{})
+
+	expAnnotations := map[string]string{
+		"kubernetes.io/ingress.class": "nginx",
+		helm.CanaryAnnotation:         "true",
+		helm.CanaryWeightAnnotation:   "25",
+	}
+
+	for key, val := range expAnnotations {
+		if annotations[key] != val {
+			t.Errorf("expected annotation %s to be %s, got %v", key, val, annotations[key])
+		}
+	}
+}
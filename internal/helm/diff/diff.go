@@ -0,0 +1,229 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/porter-dev/porter/api/types"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"sigs.k8s.io/yaml"
+)
+
+// GetReleaseDiff computes the changes between the manifest and values of the current
+// revision of a release and the manifest and values of a new revision. Resources are
+// sorted by kind and name, and values are sorted by path.
+func GetReleaseDiff(
+	currManifest, nextManifest string,
+	currValues, nextValues map[string]interface{},
+) (*types.ReleaseDiff, error) {
+	resources, err := diffManifests(currManifest, nextManifest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ReleaseDiff{
+		Resources: resources,
+		Values:    diffValues(currValues, nextValues),
+	}, nil
+}
+
+type resourceKey struct {
+	kind string
+	name string
+}
+
+func diffManifests(currManifest, nextManifest string) ([]*types.ResourceDiff, error) {
+	currResources, err := splitManifest(currManifest)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not parse current manifest: %v", err)
+	}
+
+	nextResources, err := splitManifest(nextManifest)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not parse new manifest: %v", err)
+	}
+
+	keys := make([]resourceKey, 0)
+
+	for key := range currResources {
+		keys = append(keys, key)
+	}
+
+	for key := range nextResources {
+		if _, exists := currResources[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+
+		return keys[i].name < keys[j].name
+	})
+
+	res := make([]*types.ResourceDiff, 0)
+
+	for _, key := range keys {
+		curr, currExists := currResources[key]
+		next, nextExists := nextResources[key]
+
+		var change types.ReleaseDiffChange
+
+		switch {
+		case !currExists:
+			change = types.ReleaseDiffChangeAdded
+		case !nextExists:
+			change = types.ReleaseDiffChangeRemoved
+		case curr != next:
+			change = types.ReleaseDiffChangeModified
+		default:
+			continue
+		}
+
+		unified, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(curr),
+			B:        splitLines(next),
+			FromFile: "current",
+			ToFile:   "upgrade",
+			Context:  3,
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &types.ResourceDiff{
+			Kind:   key.kind,
+			Name:   key.name,
+			Change: change,
+			Diff:   unified,
+		})
+	}
+
+	return res, nil
+}
+
+// splitManifest splits a rendered manifest into documents keyed by the kind and
+// name of the resource. Documents without a kind, such as empty templates, are
+// ignored.
+func splitManifest(manifest string) (map[resourceKey]string, error) {
+	res := make(map[resourceKey]string)
+
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		head := &releaseutil.SimpleHead{}
+
+		if err := yaml.Unmarshal([]byte(doc), head); err != nil {
+			return nil, err
+		}
+
+		if head.Kind == "" || head.Metadata == nil {
+			continue
+		}
+
+		res[resourceKey{head.Kind, head.Metadata.Name}] = strings.TrimSpace(doc) + "\n"
+	}
+
+	return res, nil
+}
+
+// splitLines splits a document into lines, where an empty document has no lines
+func splitLines(doc string) []string {
+	if doc == "" {
+		return nil
+	}
+
+	return difflib.SplitLines(strings.TrimSuffix(doc, "\n"))
+}
+
+func diffValues(currValues, nextValues map[string]interface{}) []*types.ValueDiff {
+	currLeaves := make(map[string]interface{})
+	flattenValues("", currValues, currLeaves)
+
+	nextLeaves := make(map[string]interface{})
+	flattenValues("", nextValues, nextLeaves)
+
+	paths := make([]string, 0)
+
+	for path := range currLeaves {
+		paths = append(paths, path)
+	}
+
+	for path := range nextLeaves {
+		if _, exists := currLeaves[path]; !exists {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	res := make([]*types.ValueDiff, 0)
+
+	for _, path := range paths {
+		curr, currExists := currLeaves[path]
+		next, nextExists := nextLeaves[path]
+
+		switch {
+		case !currExists:
+			res = append(res, &types.ValueDiff{
+				Path:   path,
+				Change: types.ReleaseDiffChangeAdded,
+				Next:   next,
+			})
+		case !nextExists:
+			res = append(res, &types.ValueDiff{
+				Path:     path,
+				Change:   types.ReleaseDiffChangeRemoved,
+				Previous: curr,
+			})
+		case !valuesEqual(curr, next):
+			res = append(res, &types.ValueDiff{
+				Path:     path,
+				Change:   types.ReleaseDiffChangeModified,
+				Previous: curr,
+				Next:     next,
+			})
+		}
+	}
+
+	return res
+}
+
+// flattenValues writes every non-map value in values to leaves, keyed by its
+// dot-separated path. Lists are treated as a single value.
+func flattenValues(prefix string, values map[string]interface{}, leaves map[string]interface{}) {
+	for key, val := range values {
+		path := key
+
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if nested, ok := val.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenValues(path, nested, leaves)
+			continue
+		}
+
+		leaves[path] = val
+	}
+}
+
+// valuesEqual compares two values by their YAML representation, so that values
+// that were decoded into different numeric types are still considered equal
+func valuesEqual(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+
+	aBytes, aErr := yaml.Marshal(a)
+	bBytes, bErr := yaml.Marshal(b)
+
+	return aErr == nil && bErr == nil && string(aBytes) == string(bBytes)
+}
@@ -0,0 +1,192 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/diff"
+)
+
+const deploymentV1 = `---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+`
+
+const deploymentV2 = `---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 2
+`
+
+const service = `---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`
+
+type diffValuesTest struct {
+	name       string
+	currValues map[string]interface{}
+	nextValues map[string]interface{}
+	expected   []*types.ValueDiff
+}
+
+var diffValuesTests = []diffValuesTest{
+	{
+		name:       "no changes",
+		currValues: map[string]interface{}{"replicaCount": 1},
+		nextValues: map[string]interface{}{"replicaCount": float64(1)},
+		expected:   []*types.ValueDiff{},
+	},
+	{
+		name: "nested changes",
+		currValues: map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "porter/app",
+				"tag":        "v1",
+			},
+			"ingress": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+		nextValues: map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "porter/app",
+				"tag":        "v2",
+			},
+			"replicaCount": 2,
+		},
+		expected: []*types.ValueDiff{
+			{
+				Path:     "image.tag",
+				Change:   types.ReleaseDiffChangeModified,
+				Previous: "v1",
+				Next:     "v2",
+			},
+			{
+				Path:     "ingress.enabled",
+				Change:   types.ReleaseDiffChangeRemoved,
+				Previous: true,
+			},
+			{
+				Path:   "replicaCount",
+				Change: types.ReleaseDiffChangeAdded,
+				Next:   2,
+			},
+		},
+	},
+}
+
+func TestGetReleaseDiffValues(t *testing.T) {
+	for _, test := range diffValuesTests {
+		res, err := diff.GetReleaseDiff("", "", test.currValues, test.nextValues)
+
+		if err != nil {
+			t.Fatalf("[ %s ] unexpected error: %v", test.name, err)
+		}
+
+		if diff := deep.Equal(test.expected, res.Values); diff != nil {
+			t.Errorf("[ %s ] values diff not equal:", test.name)
+			t.Error(diff)
+		}
+	}
+}
+
+type diffManifestsTest struct {
+	name         string
+	currManifest string
+	nextManifest string
+	expected     []*types.ResourceDiff
+}
+
+var diffManifestsTests = []diffManifestsTest{
+	{
+		name:         "no changes",
+		currManifest: deploymentV1 + service,
+		nextManifest: deploymentV1 + service,
+		expected:     []*types.ResourceDiff{},
+	},
+	{
+		name:         "modified and added",
+		currManifest: deploymentV1,
+		nextManifest: deploymentV2 + service,
+		expected: []*types.ResourceDiff{
+			{
+				Kind:   "Deployment",
+				Name:   "app",
+				Change: types.ReleaseDiffChangeModified,
+				Diff: `--- current
++++ upgrade
+@@ -4,4 +4,4 @@
+ metadata:
+   name: app
+ spec:
+-  replicas: 1
++  replicas: 2
+`,
+			},
+			{
+				Kind:   "Service",
+				Name:   "app",
+				Change: types.ReleaseDiffChangeAdded,
+				Diff: `--- current
++++ upgrade
+@@ -0,0 +1,5 @@
++# Source: web/templates/service.yaml
++apiVersion: v1
++kind: Service
++metadata:
++  name: app
+`,
+			},
+		},
+	},
+	{
+		name:         "removed",
+		currManifest: deploymentV1 + service,
+		nextManifest: deploymentV1,
+		expected: []*types.ResourceDiff{
+			{
+				Kind:   "Service",
+				Name:   "app",
+				Change: types.ReleaseDiffChangeRemoved,
+				Diff: `--- current
++++ upgrade
+@@ -1,5 +0,0 @@
+-# Source: web/templates/service.yaml
+-apiVersion: v1
+-kind: Service
+-metadata:
+-  name: app
+`,
+			},
+		},
+	},
+}
+
+func TestGetReleaseDiffManifests(t *testing.T) {
+	for _, test := range diffManifestsTests {
+		res, err := diff.GetReleaseDiff(test.currManifest, test.nextManifest, nil, nil)
+
+		if err != nil {
+			t.Fatalf("[ %s ] unexpected error: %v", test.name, err)
+		}
+
+		if diff := deep.Equal(test.expected, res.Resources); diff != nil {
+			t.Errorf("[ %s ] resources diff not equal:", test.name)
+			t.Error(diff)
+		}
+	}
+}
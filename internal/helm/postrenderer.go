@@ -32,6 +32,10 @@ type DockerSecretsPostRenderer struct {
 	Namespace string
 	DOAuth    *oauth2.Config
 
+	// DryRun only sets the names of the image pull secrets, without creating or
+	// updating the secrets in the cluster
+	DryRun bool
+
 	registries map[string]*models.Registry
 
 	podSpecs  []resource
@@ -49,6 +53,7 @@ func NewDockerSecretsPostRenderer(
 	namespace string,
 	regs []*models.Registry,
 	doAuth *oauth2.Config,
+	dryRun bool,
 ) (postrender.PostRenderer, error) {
 	// Registries is a map of registry URLs to registry ids
 	registries := make(map[string]*models.Registry)
@@ -81,6 +86,7 @@ func NewDockerSecretsPostRenderer(
 		Agent:      agent,
 		Namespace:  namespace,
 		DOAuth:     doAuth,
+		DryRun:     dryRun,
 		registries: registries,
 		podSpecs:   make([]resource, 0),
 		resources:  make([]resource, 0),
@@ -148,6 +154,7 @@ func (d *DockerSecretsPostRenderer) Run(
 					Agent:      d.Agent,
 					Namespace:  d.Namespace,
 					DOAuth:     d.DOAuth,
+					DryRun:     d.DryRun,
 					registries: d.registries,
 					podSpecs:   make([]resource, 0),
 					resources:  make([]resource, 0),
@@ -167,12 +174,7 @@ func (d *DockerSecretsPostRenderer) Run(
 	}
 
 	// create the necessary secrets
-	secrets, err := d.Agent.CreateImagePullSecrets(
-		d.Repo,
-		d.Namespace,
-		linkedRegs,
-		d.DOAuth,
-	)
+	secrets, err := d.getImagePullSecrets(linkedRegs)
 
 	if err != nil {
 		return renderedManifests, nil
@@ -195,6 +197,23 @@ func (d *DockerSecretsPostRenderer) Run(
 	return modifiedManifests, nil
 }
 
+// getImagePullSecrets creates the image pull secrets for the linked registries, and
+// returns the names of the secrets for each registry. For a dry run, the names of the
+// secrets are returned without creating them.
+func (d *DockerSecretsPostRenderer) getImagePullSecrets(linkedRegs map[string]*models.Registry) (map[string]string, error) {
+	if !d.DryRun {
+		return d.Agent.CreateImagePullSecrets(d.Repo, d.Namespace, linkedRegs, d.DOAuth)
+	}
+
+	res := make(map[string]string)
+
+	for regName, reg := range linkedRegs {
+		res[regName] = kubernetes.GetImagePullSecretName(reg)
+	}
+
+	return res, nil
+}
+
 func (d *DockerSecretsPostRenderer) getRegistriesToLink(renderedManifests *bytes.Buffer) (map[string]*models.Registry, error) {
 	// create a map of registry names to registries: these are the registries
 	// that a secret will be generated for, if it does not exist
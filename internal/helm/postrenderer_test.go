@@ -0,0 +1,71 @@
+package helm_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDockerSecretsPostRendererDryRun(t *testing.T) {
+	manifests := bytes.NewBufferString(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app-web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: 123456789012.dkr.ecr.us-east-1.amazonaws.com/app:v1
+`)
+
+	agent := kubernetes.GetAgentTesting()
+
+	renderer, err := helm.NewDockerSecretsPostRenderer(
+		&models.Cluster{Model: gorm.Model{ID: 1}, ProjectID: 1},
+		nil,
+		agent,
+		"default",
+		[]*models.Registry{
+			{
+				Model:            gorm.Model{ID: 1},
+				URL:              "123456789012.dkr.ecr.us-east-1.amazonaws.com",
+				AWSIntegrationID: 1,
+			},
+		},
+		nil,
+		true,
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	res, err := renderer.Run(manifests)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(res.String(), "porter-ecr-1") {
+		t.Errorf("expected the image pull secret to be set, got %s", res.String())
+	}
+
+	secrets, err := agent.Clientset.CoreV1().Secrets("default").List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(secrets.Items) != 0 {
+		t.Errorf("expected a dry run not to create secrets, got %d", len(secrets.Items))
+	}
+}
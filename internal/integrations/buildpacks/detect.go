@@ -0,0 +1,84 @@
+package buildpacks
+
+import (
+	"fmt"
+	"io/fs"
+	"sync"
+)
+
+// NewPaketoBuilderInfo returns the Paketo builders, without any buildpacks
+func NewPaketoBuilderInfo() *BuilderInfo {
+	return &BuilderInfo{
+		Name: "Paketo",
+		Builders: []string{
+			"paketobuildpacks/builder:full",
+		},
+	}
+}
+
+// NewHerokuBuilderInfo returns the Heroku builders, without any buildpacks
+func NewHerokuBuilderInfo() *BuilderInfo {
+	return &BuilderInfo{
+		Name: "Heroku",
+		Builders: []string{
+			"heroku/buildpacks:20",
+			"heroku/buildpacks:18",
+		},
+	}
+}
+
+// DetectBuilders runs all runtimes against the root of fsys, and returns the Paketo and
+// Heroku builders along with their detected buildpacks. Errors of a single runtime are
+// not returned, since the runtime is then listed among the other buildpacks.
+func DetectBuilders(fsys fs.FS) ([]*BuilderInfo, error) {
+	directoryContent, err := fs.ReadDir(fsys, ".")
+
+	if err != nil {
+		return nil, err
+	}
+
+	// every runtime writes to its own builders, which are merged in the order of
+	// Runtimes once all of them are done
+	paketoResults := make([]*BuilderInfo, len(Runtimes))
+	herokuResults := make([]*BuilderInfo, len(Runtimes))
+
+	var wg sync.WaitGroup
+	var panicked bool
+	var mu sync.Mutex
+
+	wg.Add(len(Runtimes))
+	for i := range Runtimes {
+		paketoResults[i] = &BuilderInfo{}
+		herokuResults[i] = &BuilderInfo{}
+
+		go func(idx int) {
+			defer wg.Done()
+			defer func() {
+				if rec := recover(); rec != nil {
+					mu.Lock()
+					panicked = true
+					mu.Unlock()
+				}
+			}()
+
+			Runtimes[idx].Detect(fsys, directoryContent, paketoResults[idx], herokuResults[idx])
+		}(i)
+	}
+	wg.Wait()
+
+	if panicked {
+		return nil, fmt.Errorf("panic detected in runtime detection")
+	}
+
+	paketo := NewPaketoBuilderInfo()
+	heroku := NewHerokuBuilderInfo()
+
+	for i := range Runtimes {
+		paketo.Detected = append(paketo.Detected, paketoResults[i].Detected...)
+		paketo.Others = append(paketo.Others, paketoResults[i].Others...)
+		heroku.Detected = append(heroku.Detected, herokuResults[i].Detected...)
+		heroku.Others = append(heroku.Others, herokuResults[i].Others...)
+	}
+
+	return []*BuilderInfo{paketo, heroku}, nil
+}
@@ -0,0 +1,153 @@
+package buildpacks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// matches <TargetFramework>net6.0</TargetFramework> and netcoreapp3.1, along with the
+// first framework of <TargetFrameworks>
+var targetFrameworkRe = regexp.MustCompile(`<TargetFrameworks?>\s*net(?:coreapp)?(\d+\.\d+)`)
+
+type dotnetRuntime struct {
+	wg sync.WaitGroup
+}
+
+func NewDotnetRuntime() Runtime {
+	return &dotnetRuntime{}
+}
+
+func isDotnetProjectFile(name string) bool {
+	return strings.HasSuffix(name, ".csproj") || strings.HasSuffix(name, ".fsproj") ||
+		strings.HasSuffix(name, ".vbproj")
+}
+
+func (runtime *dotnetRuntime) detectProject(results chan struct {
+	string
+	bool
+}, directoryContent []fs.DirEntry) {
+	projectFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		if isDotnetProjectFile(directoryContent[i].Name()) {
+			projectFound = true
+			break
+		}
+	}
+	if projectFound {
+		results <- struct {
+			string
+			bool
+		}{project, true}
+	}
+	runtime.wg.Done()
+}
+
+func (runtime *dotnetRuntime) detectSolution(results chan struct {
+	string
+	bool
+}, directoryContent []fs.DirEntry) {
+	solutionFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		if strings.HasSuffix(directoryContent[i].Name(), ".sln") {
+			solutionFound = true
+			break
+		}
+	}
+	if solutionFound {
+		results <- struct {
+			string
+			bool
+		}{solution, true}
+	}
+	runtime.wg.Done()
+}
+
+// Detect only adds the buildpack to the Paketo builder, since the Heroku builders do not
+// support .NET
+func (runtime *dotnetRuntime) Detect(
+	fsys fs.FS,
+	directoryContent []fs.DirEntry,
+	paketo, heroku *BuilderInfo,
+) error {
+	results := make(chan struct {
+		string
+		bool
+	}, 2)
+
+	runtime.wg.Add(2)
+	go runtime.detectProject(results, directoryContent)
+	go runtime.detectSolution(results, directoryContent)
+	runtime.wg.Wait()
+	close(results)
+
+	paketoBuildpackInfo := BuildpackInfo{
+		Name:      ".NET",
+		Buildpack: "gcr.io/paketo-buildpacks/dotnet-core",
+	}
+
+	if len(results) == 0 {
+		paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+		return nil
+	}
+
+	projectFile := ""
+	globalJSONFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		name := directoryContent[i].Name()
+		if projectFile == "" && isDotnetProjectFile(name) {
+			projectFile = name
+		} else if name == "global.json" {
+			globalJSONFound = true
+		}
+	}
+
+	config := make(map[string]interface{})
+
+	if projectFile != "" {
+		config["project_file"] = projectFile
+
+		data, err := readFile(fsys, projectFile)
+		if err != nil {
+			paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+			return err
+		}
+
+		if match := targetFrameworkRe.FindStringSubmatch(data); match != nil {
+			config["dotnet_version"] = match[1]
+		}
+	}
+
+	// global.json pins the version of the SDK which builds the project
+	if globalJSONFound {
+		data, err := readFile(fsys, "global.json")
+		if err != nil {
+			paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+			return err
+		}
+
+		var globalJSON struct {
+			SDK struct {
+				Version string `json:"version"`
+			} `json:"sdk"`
+		}
+
+		err = json.NewDecoder(strings.NewReader(data)).Decode(&globalJSON)
+		if err != nil {
+			paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+			return fmt.Errorf("error decoding global.json contents to struct: %v", err)
+		}
+
+		if globalJSON.SDK.Version != "" {
+			config["sdk_version"] = globalJSON.SDK.Version
+		}
+	}
+
+	paketoBuildpackInfo.Config = config
+	paketo.Detected = append(paketo.Detected, paketoBuildpackInfo)
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package buildpacks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+)
+
+// githubFS is a read-only file system of a directory in a GitHub repository, which reads
+// files and directories through the contents API
+type githubFS struct {
+	client             *github.Client
+	owner, name, dir   string
+	repoContentOptions github.RepositoryContentGetOptions
+}
+
+// NewGithubFS returns a file system of the directory dir of the GitHub repository
+// owner/name, at the SHA, branch or tag of repoContentOptions
+func NewGithubFS(
+	client *github.Client,
+	owner, name, dir string,
+	repoContentOptions github.RepositoryContentGetOptions,
+) fs.FS {
+	return &githubFS{
+		client:             client,
+		owner:              owner,
+		name:               name,
+		dir:                dir,
+		repoContentOptions: repoContentOptions,
+	}
+}
+
+func (gfs *githubFS) getContents(op, name string) (*github.RepositoryContent, []*github.RepositoryContent, error) {
+	if !fs.ValidPath(name) {
+		return nil, nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	repoPath := path.Join(gfs.dir, name)
+
+	if repoPath == "." {
+		repoPath = ""
+	}
+
+	fileContent, directoryContent, resp, err := gfs.client.Repositories.GetContents(
+		context.Background(),
+		gfs.owner,
+		gfs.name,
+		repoPath,
+		&gfs.repoContentOptions,
+	)
+
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			err = fs.ErrNotExist
+		}
+
+		return nil, nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+
+	return fileContent, directoryContent, nil
+}
+
+func (gfs *githubFS) Open(name string) (fs.File, error) {
+	fileContent, directoryContent, err := gfs.getContents("open", name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if fileContent != nil {
+		data, err := fileContent.GetContent()
+
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+
+		return &githubFile{
+			info:   &githubFileInfo{fileContent},
+			Reader: strings.NewReader(data),
+		}, nil
+	}
+
+	return &githubDir{
+		name: name,
+		info: &githubFileInfo{&github.RepositoryContent{
+			Name: github.String(path.Base(name)),
+			Type: github.String("dir"),
+		}},
+		entries: getDirEntries(directoryContent),
+	}, nil
+}
+
+func (gfs *githubFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	fileContent, directoryContent, err := gfs.getContents("readdir", name)
+
+	if err != nil {
+		return nil, err
+	} else if fileContent != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+
+	return getDirEntries(directoryContent), nil
+}
+
+func (gfs *githubFS) ReadFile(name string) ([]byte, error) {
+	fileContent, _, err := gfs.getContents("read", name)
+
+	if err != nil {
+		return nil, err
+	} else if fileContent == nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errors.New("is a directory")}
+	}
+
+	data, err := fileContent.GetContent()
+
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+
+	return []byte(data), nil
+}
+
+// getDirEntries returns the entries of a directory sorted by name, as required by
+// fs.ReadDirFS
+func getDirEntries(directoryContent []*github.RepositoryContent) []fs.DirEntry {
+	entries := make([]fs.DirEntry, 0, len(directoryContent))
+
+	for _, content := range directoryContent {
+		entries = append(entries, fs.FileInfoToDirEntry(&githubFileInfo{content}))
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	return entries
+}
+
+type githubFileInfo struct {
+	content *github.RepositoryContent
+}
+
+func (info *githubFileInfo) Name() string {
+	return info.content.GetName()
+}
+
+func (info *githubFileInfo) Size() int64 {
+	return int64(info.content.GetSize())
+}
+
+func (info *githubFileInfo) Mode() fs.FileMode {
+	if info.IsDir() {
+		return fs.ModeDir | 0555
+	}
+
+	return 0444
+}
+
+func (info *githubFileInfo) ModTime() time.Time {
+	return time.Time{}
+}
+
+func (info *githubFileInfo) IsDir() bool {
+	return info.content.GetType() == "dir"
+}
+
+func (info *githubFileInfo) Sys() interface{} {
+	return info.content
+}
+
+type githubFile struct {
+	*strings.Reader
+
+	info *githubFileInfo
+}
+
+func (f *githubFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *githubFile) Close() error {
+	return nil
+}
+
+type githubDir struct {
+	name    string
+	info    *githubFileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *githubDir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *githubDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+func (d *githubDir) Close() error {
+	return nil
+}
+
+func (d *githubDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	remaining := len(d.entries) - d.offset
+
+	if count > 0 && remaining == 0 {
+		return nil, io.EOF
+	}
+
+	if count > 0 && count < remaining {
+		remaining = count
+	}
+
+	entries := d.entries[d.offset : d.offset+remaining]
+	d.offset += remaining
+
+	return entries, nil
+}
@@ -0,0 +1,135 @@
+package buildpacks
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-github/v41/github"
+)
+
+// newGithubTestClient returns a client of a server which serves files and directories
+// in the same way as the GitHub contents API
+func newGithubTestClient(t *testing.T, files map[string]string) *github.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repoPath := strings.Trim(strings.TrimPrefix(r.URL.Path, "/repos/owner/name/contents"), "/")
+
+		if content, ok := files[repoPath]; ok {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"type":     "file",
+				"name":     path.Base(repoPath),
+				"path":     repoPath,
+				"size":     len(content),
+				"encoding": "base64",
+				"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+			})
+
+			return
+		}
+
+		var entries []map[string]interface{}
+		seen := make(map[string]bool)
+
+		for file := range files {
+			rel := file
+
+			if repoPath != "" {
+				if !strings.HasPrefix(file, repoPath+"/") {
+					continue
+				}
+
+				rel = strings.TrimPrefix(file, repoPath+"/")
+			}
+
+			name := strings.Split(rel, "/")[0]
+
+			if seen[name] {
+				continue
+			}
+
+			seen[name] = true
+
+			entry := map[string]interface{}{
+				"type": "file",
+				"name": name,
+				"path": path.Join(repoPath, name),
+				"size": len(files[file]),
+			}
+
+			if name != rel {
+				entry["type"] = "dir"
+				entry["size"] = 0
+			}
+
+			entries = append(entries, entry)
+		}
+
+		if len(entries) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		json.NewEncoder(w).Encode(entries)
+	}))
+
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client
+}
+
+var githubTestFiles = map[string]string{
+	"README.md":               "# app",
+	"app/package.json":        `{"scripts": {"start": "node server.js"}}`,
+	"app/server.js":           "require('http').createServer().listen(8080)",
+	"app/src/routes/index.js": "module.exports = {}",
+}
+
+func TestGithubFS(t *testing.T) {
+	client := newGithubTestClient(t, githubTestFiles)
+
+	fsys := NewGithubFS(client, "owner", "name", "app", github.RepositoryContentGetOptions{})
+
+	err := fstest.TestFS(fsys, "package.json", "server.js", "src/routes/index.js")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestDetectBuildersGithub(t *testing.T) {
+	client := newGithubTestClient(t, githubTestFiles)
+
+	builders, err := DetectBuilders(
+		NewGithubFS(client, "owner", "name", "app", github.RepositoryContentGetOptions{}),
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(builders) != 2 || builders[0].Name != "Paketo" || builders[1].Name != "Heroku" {
+		t.Fatalf("expected the Paketo and Heroku builders, got %v", builders)
+	}
+
+	// the Heroku builders do not support .NET and static sites
+	expRuntimes := map[string]int{"Paketo": len(Runtimes), "Heroku": len(Runtimes) - 2}
+
+	for _, builder := range builders {
+		if len(builder.Detected) != 1 || builder.Detected[0].Name != "NodeJS" {
+			t.Errorf("expected NodeJS to be detected by %s, got %v", builder.Name, builder.Detected)
+		}
+
+		if count := len(builder.Detected) + len(builder.Others); count != expRuntimes[builder.Name] {
+			t.Errorf("expected %d runtimes to be listed by %s, got %d", expRuntimes[builder.Name], builder.Name, count)
+		}
+	}
+}
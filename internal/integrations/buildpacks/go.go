@@ -1,9 +1,8 @@
 package buildpacks
 
 import (
+	"io/fs"
 	"sync"
-
-	"github.com/google/go-github/v41/github"
 )
 
 type goRuntime struct {
@@ -17,10 +16,10 @@ func NewGoRuntime() Runtime {
 func (runtime *goRuntime) detectMod(results chan struct {
 	string
 	bool
-}, directoryContent []*github.RepositoryContent) {
+}, directoryContent []fs.DirEntry) {
 	goModFound := false
 	for i := 0; i < len(directoryContent); i++ {
-		name := directoryContent[i].GetName()
+		name := directoryContent[i].Name()
 		if name == "go.mod" {
 			goModFound = true
 			break
@@ -38,14 +37,14 @@ func (runtime *goRuntime) detectMod(results chan struct {
 func (runtime *goRuntime) detectDep(results chan struct {
 	string
 	bool
-}, directoryContent []*github.RepositoryContent) {
+}, directoryContent []fs.DirEntry) {
 	gopkgFound := false
 	vendorFound := false
 	for i := 0; i < len(directoryContent); i++ {
-		name := directoryContent[i].GetName()
+		name := directoryContent[i].Name()
 		if name == "Gopkg.toml" {
 			gopkgFound = true
-		} else if name == "vendor" && directoryContent[i].GetType() == "dir" {
+		} else if name == "vendor" && directoryContent[i].IsDir() {
 			vendorFound = true
 		}
 		if gopkgFound && vendorFound {
@@ -62,10 +61,8 @@ func (runtime *goRuntime) detectDep(results chan struct {
 }
 
 func (runtime *goRuntime) Detect(
-	client *github.Client,
-	directoryContent []*github.RepositoryContent,
-	owner, name, path string,
-	repoContentOptions github.RepositoryContentGetOptions,
+	fsys fs.FS,
+	directoryContent []fs.DirEntry,
 	paketo, heroku *BuilderInfo,
 ) error {
 	results := make(chan struct {
@@ -0,0 +1,243 @@
+package buildpacks
+
+import (
+	"io/fs"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	// maven properties and compiler plugin settings which set the Java version
+	mavenJavaVersionRe = regexp.MustCompile(
+		`<(java\.version|maven\.compiler\.release|maven\.compiler\.source|maven\.compiler\.target|release|source)>\s*(1\.)?(\d+)\s*</`,
+	)
+
+	// sourceCompatibility = '11', sourceCompatibility = JavaVersion.VERSION_11 or
+	// languageVersion = JavaLanguageVersion.of(17)
+	gradleJavaVersionRe = regexp.MustCompile(
+		`(?:sourceCompatibility\s*=\s*(?:JavaVersion\.VERSION_)?['"]?(?:1[._])?(\d+)|JavaLanguageVersion\.of\(\s*(\d+)\s*\))`,
+	)
+
+	// java.runtime.version=11 in system.properties, which is read by the Heroku buildpack
+	systemPropertiesJavaVersionRe = regexp.MustCompile(`(?m)^\s*java\.runtime\.version\s*=\s*(?:1\.)?(\d+)`)
+)
+
+// defaultJavaVersion is the default JVM version of the Paketo Java buildpack
+const defaultJavaVersion = "11"
+
+type javaRuntime struct {
+	wg sync.WaitGroup
+}
+
+func NewJavaRuntime() Runtime {
+	return &javaRuntime{}
+}
+
+func (runtime *javaRuntime) detectMaven(results chan struct {
+	string
+	bool
+}, directoryContent []fs.DirEntry) {
+	pomFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		name := directoryContent[i].Name()
+		if name == "pom.xml" || name == "pom.yml" || name == "pom.yaml" {
+			pomFound = true
+			break
+		}
+	}
+	if pomFound {
+		results <- struct {
+			string
+			bool
+		}{maven, true}
+	}
+	runtime.wg.Done()
+}
+
+func (runtime *javaRuntime) detectGradle(results chan struct {
+	string
+	bool
+}, directoryContent []fs.DirEntry) {
+	buildGradleFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		name := directoryContent[i].Name()
+		if name == "build.gradle" || name == "build.gradle.kts" {
+			buildGradleFound = true
+			break
+		}
+	}
+	if buildGradleFound {
+		results <- struct {
+			string
+			bool
+		}{gradle, true}
+	}
+	runtime.wg.Done()
+}
+
+func (runtime *javaRuntime) detectStandalone(results chan struct {
+	string
+	bool
+}, directoryContent []fs.DirEntry) {
+	jarFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		name := directoryContent[i].Name()
+		if strings.HasSuffix(name, ".jar") || strings.HasSuffix(name, ".war") {
+			jarFound = true
+			break
+		}
+	}
+	if jarFound {
+		results <- struct {
+			string
+			bool
+		}{standalone, true}
+	}
+	runtime.wg.Done()
+}
+
+func getMavenJavaVersion(pomContent string) string {
+	if match := mavenJavaVersionRe.FindStringSubmatch(pomContent); match != nil {
+		return match[3]
+	}
+
+	return ""
+}
+
+func getGradleJavaVersion(buildGradleContent string) string {
+	if match := gradleJavaVersionRe.FindStringSubmatch(buildGradleContent); match != nil {
+		if match[1] != "" {
+			return match[1]
+		}
+
+		return match[2]
+	}
+
+	return ""
+}
+
+func (runtime *javaRuntime) Detect(
+	fsys fs.FS,
+	directoryContent []fs.DirEntry,
+	paketo, heroku *BuilderInfo,
+) error {
+	results := make(chan struct {
+		string
+		bool
+	}, 3)
+
+	runtime.wg.Add(3)
+	go runtime.detectMaven(results, directoryContent)
+	go runtime.detectGradle(results, directoryContent)
+	go runtime.detectStandalone(results, directoryContent)
+	runtime.wg.Wait()
+	close(results)
+
+	paketoBuildpackInfo := BuildpackInfo{
+		Name:      "Java",
+		Buildpack: "gcr.io/paketo-buildpacks/java",
+	}
+	herokuBuildpackInfo := BuildpackInfo{
+		Name:      "Java",
+		Buildpack: "heroku/java",
+	}
+
+	if len(results) == 0 {
+		paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+		heroku.Others = append(heroku.Others, herokuBuildpackInfo)
+		return nil
+	}
+
+	foundMaven := false
+	foundGradle := false
+	for result := range results {
+		if result.string == maven {
+			foundMaven = true
+		} else if result.string == gradle {
+			foundGradle = true
+		}
+	}
+
+	buildTool := standalone
+	buildFile := ""
+	if foundMaven {
+		buildTool = maven
+
+		// versions are only read from XML poms, and not from polyglot poms
+		for i := 0; i < len(directoryContent); i++ {
+			if directoryContent[i].Name() == "pom.xml" {
+				buildFile = "pom.xml"
+			}
+		}
+	} else if foundGradle {
+		buildTool = gradle
+		buildFile = "build.gradle"
+
+		for i := 0; i < len(directoryContent); i++ {
+			if directoryContent[i].Name() == "build.gradle.kts" {
+				buildFile = "build.gradle.kts"
+			}
+		}
+
+		// the Heroku Java buildpack only builds Maven projects
+		herokuBuildpackInfo.Buildpack = "heroku/gradle"
+	}
+
+	javaVersion := ""
+	springBoot := false
+
+	if buildFile != "" {
+		data, err := readFile(fsys, buildFile)
+		if err != nil {
+			paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+			heroku.Others = append(heroku.Others, herokuBuildpackInfo)
+			return err
+		}
+
+		if buildTool == maven {
+			javaVersion = getMavenJavaVersion(data)
+		} else {
+			javaVersion = getGradleJavaVersion(data)
+		}
+
+		springBoot = strings.Contains(data, "spring-boot")
+	}
+
+	if javaVersion == "" {
+		for i := 0; i < len(directoryContent); i++ {
+			if directoryContent[i].Name() != "system.properties" {
+				continue
+			}
+
+			data, err := readFile(fsys, "system.properties")
+			if err != nil {
+				paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+				heroku.Others = append(heroku.Others, herokuBuildpackInfo)
+				return err
+			}
+
+			if match := systemPropertiesJavaVersionRe.FindStringSubmatch(data); match != nil {
+				javaVersion = match[1]
+			}
+		}
+	}
+
+	if javaVersion == "" {
+		javaVersion = defaultJavaVersion
+	}
+
+	paketoBuildpackInfo.Config = make(map[string]interface{})
+	paketoBuildpackInfo.Config["build_tool"] = buildTool
+	paketoBuildpackInfo.Config["java_version"] = javaVersion
+	paketoBuildpackInfo.Config["spring_boot"] = springBoot
+	paketo.Detected = append(paketo.Detected, paketoBuildpackInfo)
+
+	herokuBuildpackInfo.Config = make(map[string]interface{})
+	herokuBuildpackInfo.Config["build_tool"] = buildTool
+	herokuBuildpackInfo.Config["java_version"] = javaVersion
+	herokuBuildpackInfo.Config["spring_boot"] = springBoot
+	heroku.Detected = append(heroku.Detected, herokuBuildpackInfo)
+
+	return nil
+}
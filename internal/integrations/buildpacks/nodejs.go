@@ -1,14 +1,13 @@
 package buildpacks
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"strings"
 	"sync"
 
 	"github.com/Masterminds/semver/v3"
-	"github.com/google/go-github/v41/github"
 )
 
 var (
@@ -31,11 +30,11 @@ func NewNodeRuntime() Runtime {
 func (runtime *nodejsRuntime) detectYarn(results chan struct {
 	string
 	bool
-}, directoryContent []*github.RepositoryContent) {
+}, directoryContent []fs.DirEntry) {
 	yarnLockFound := false
 	packageJSONFound := false
 	for i := 0; i < len(directoryContent); i++ {
-		name := directoryContent[i].GetName()
+		name := directoryContent[i].Name()
 		if name == "yarn.lock" {
 			yarnLockFound = true
 		} else if name == "package.json" {
@@ -57,10 +56,10 @@ func (runtime *nodejsRuntime) detectYarn(results chan struct {
 func (runtime *nodejsRuntime) detectNPM(results chan struct {
 	string
 	bool
-}, directoryContent []*github.RepositoryContent) {
+}, directoryContent []fs.DirEntry) {
 	packageJSONFound := false
 	for i := 0; i < len(directoryContent); i++ {
-		name := directoryContent[i].GetName()
+		name := directoryContent[i].Name()
 		if name == "package.json" {
 			packageJSONFound = true
 			break
@@ -78,10 +77,10 @@ func (runtime *nodejsRuntime) detectNPM(results chan struct {
 func (runtime *nodejsRuntime) detectStandalone(results chan struct {
 	string
 	bool
-}, directoryContent []*github.RepositoryContent) {
+}, directoryContent []fs.DirEntry) {
 	jsFileFound := false
 	for i := 0; i < len(directoryContent); i++ {
-		name := directoryContent[i].GetName()
+		name := directoryContent[i].Name()
 		if name == "server.js" || name == "app.js" || name == "main.js" || name == "index.js" {
 			jsFileFound = true
 			break
@@ -158,10 +157,8 @@ func validateNodeVersion(content string) (string, error) {
 }
 
 func (runtime *nodejsRuntime) Detect(
-	client *github.Client,
-	directoryContent []*github.RepositoryContent,
-	owner, name, path string,
-	repoContentOptions github.RepositoryContentGetOptions,
+	fsys fs.FS,
+	directoryContent []fs.DirEntry,
 	paketo, heroku *BuilderInfo,
 ) error {
 	results := make(chan struct {
@@ -206,17 +203,11 @@ func (runtime *nodejsRuntime) Detect(
 
 	if foundYarn || foundNPM {
 		// it is safe to assume that the project contains a package.json
-		fileContent, _, _, err := client.Repositories.GetContents(
-			context.Background(),
-			owner,
-			name,
-			fmt.Sprintf("%s/package.json", path),
-			&repoContentOptions,
-		)
+		data, err := readFile(fsys, "package.json")
 		if err != nil {
 			paketo.Others = append(paketo.Others, paketoBuildpackInfo)
 			heroku.Others = append(heroku.Others, herokuBuildpackInfo)
-			return fmt.Errorf("error fetching contents of package.json: %v", err)
+			return err
 		}
 		var packageJSON struct {
 			Scripts map[string]string `json:"scripts"`
@@ -225,12 +216,6 @@ func (runtime *nodejsRuntime) Detect(
 			} `json:"engines"`
 		}
 
-		data, err := fileContent.GetContent()
-		if err != nil {
-			paketo.Others = append(paketo.Others, paketoBuildpackInfo)
-			heroku.Others = append(heroku.Others, herokuBuildpackInfo)
-			return fmt.Errorf("error calling GetContent() on package.json: %v", err)
-		}
 		err = json.NewDecoder(strings.NewReader(data)).Decode(&packageJSON)
 		if err != nil {
 			paketo.Others = append(paketo.Others, paketoBuildpackInfo)
@@ -243,7 +228,7 @@ func (runtime *nodejsRuntime) Detect(
 			nvmrcFound := false
 			nodeVersionFound := false
 			for i := 0; i < len(directoryContent); i++ {
-				name := directoryContent[i].GetName()
+				name := directoryContent[i].Name()
 				if name == ".nvmrc" {
 					nvmrcFound = true
 				} else if name == ".node-version" {
@@ -253,23 +238,11 @@ func (runtime *nodejsRuntime) Detect(
 
 			if nvmrcFound {
 				// copy exact behavior of https://github.com/paketo-buildpacks/node-engine/blob/main/nvmrc_parser.go
-				fileContent, _, _, err = client.Repositories.GetContents(
-					context.Background(),
-					owner,
-					name,
-					fmt.Sprintf("%s/.nvmrc", path),
-					&repoContentOptions,
-				)
-				if err != nil {
-					paketo.Others = append(paketo.Others, paketoBuildpackInfo)
-					heroku.Others = append(heroku.Others, herokuBuildpackInfo)
-					return fmt.Errorf("error fetching contents of .nvmrc: %v", err)
-				}
-				data, err = fileContent.GetContent()
+				data, err = readFile(fsys, ".nvmrc")
 				if err != nil {
 					paketo.Others = append(paketo.Others, paketoBuildpackInfo)
 					heroku.Others = append(heroku.Others, herokuBuildpackInfo)
-					return fmt.Errorf("error calling GetContent() on .nvmrc: %v", err)
+					return err
 				}
 				nvmrcVersion, err := validateNvmrc(data)
 				if err != nil {
@@ -286,23 +259,11 @@ func (runtime *nodejsRuntime) Detect(
 
 			if packageJSON.Engines.Node == "" && nodeVersionFound {
 				// copy exact behavior of https://github.com/paketo-buildpacks/node-engine/blob/main/node_version_parser.go
-				fileContent, _, _, err = client.Repositories.GetContents(
-					context.Background(),
-					owner,
-					name,
-					fmt.Sprintf("%s/.node-version", path),
-					&repoContentOptions,
-				)
-				if err != nil {
-					paketo.Others = append(paketo.Others, paketoBuildpackInfo)
-					heroku.Others = append(heroku.Others, herokuBuildpackInfo)
-					return fmt.Errorf("error fetching contents of .node-version: %v", err)
-				}
-				data, err = fileContent.GetContent()
+				data, err = readFile(fsys, ".node-version")
 				if err != nil {
 					paketo.Others = append(paketo.Others, paketoBuildpackInfo)
 					heroku.Others = append(heroku.Others, herokuBuildpackInfo)
-					return fmt.Errorf("error calling GetContent() on .node-version: %v", err)
+					return err
 				}
 				nodeVersion, err := validateNodeVersion(data)
 				if err != nil {
@@ -0,0 +1,162 @@
+package buildpacks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// phpFrameworks are the composer packages of frameworks, along with the directory which
+// the framework serves requests from
+var phpFrameworks = []struct {
+	name    string
+	pkg     string
+	webRoot string
+}{
+	{"laravel", "laravel/framework", "public"},
+	{"symfony", "symfony/framework-bundle", "public"},
+	{"lumen", "laravel/lumen-framework", "public"},
+	{"slim", "slim/slim", "public"},
+	{"codeigniter", "codeigniter4/framework", "public"},
+}
+
+type phpRuntime struct {
+	wg sync.WaitGroup
+}
+
+func NewPHPRuntime() Runtime {
+	return &phpRuntime{}
+}
+
+func (runtime *phpRuntime) detectComposer(results chan struct {
+	string
+	bool
+}, directoryContent []fs.DirEntry) {
+	composerJSONFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		name := directoryContent[i].Name()
+		if name == "composer.json" {
+			composerJSONFound = true
+			break
+		}
+	}
+	if composerJSONFound {
+		results <- struct {
+			string
+			bool
+		}{composer, true}
+	}
+	runtime.wg.Done()
+}
+
+func (runtime *phpRuntime) detectStandalone(results chan struct {
+	string
+	bool
+}, directoryContent []fs.DirEntry) {
+	phpFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		name := directoryContent[i].Name()
+		if strings.HasSuffix(name, ".php") {
+			phpFound = true
+			break
+		}
+	}
+	if phpFound {
+		results <- struct {
+			string
+			bool
+		}{standalone, true}
+	}
+	runtime.wg.Done()
+}
+
+func (runtime *phpRuntime) Detect(
+	fsys fs.FS,
+	directoryContent []fs.DirEntry,
+	paketo, heroku *BuilderInfo,
+) error {
+	results := make(chan struct {
+		string
+		bool
+	}, 2)
+
+	runtime.wg.Add(2)
+	go runtime.detectComposer(results, directoryContent)
+	go runtime.detectStandalone(results, directoryContent)
+	runtime.wg.Wait()
+	close(results)
+
+	paketoBuildpackInfo := BuildpackInfo{
+		Name:      "PHP",
+		Buildpack: "gcr.io/paketo-buildpacks/php",
+	}
+	herokuBuildpackInfo := BuildpackInfo{
+		Name:      "PHP",
+		Buildpack: "heroku/php",
+	}
+
+	if len(results) == 0 {
+		paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+		heroku.Others = append(heroku.Others, herokuBuildpackInfo)
+		return nil
+	}
+
+	foundComposer := false
+	for result := range results {
+		if result.string == composer {
+			foundComposer = true
+		}
+	}
+
+	if !foundComposer {
+		paketo.Detected = append(paketo.Detected, paketoBuildpackInfo)
+		heroku.Detected = append(heroku.Detected, herokuBuildpackInfo)
+		return nil
+	}
+
+	data, err := readFile(fsys, "composer.json")
+	if err != nil {
+		paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+		heroku.Others = append(heroku.Others, herokuBuildpackInfo)
+		return err
+	}
+
+	var composerJSON struct {
+		Require map[string]string `json:"require"`
+	}
+
+	err = json.NewDecoder(strings.NewReader(data)).Decode(&composerJSON)
+	if err != nil {
+		paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+		heroku.Others = append(heroku.Others, herokuBuildpackInfo)
+		return fmt.Errorf("error decoding composer.json contents to struct: %v", err)
+	}
+
+	config := make(map[string]interface{})
+
+	// the version constraint is used as is by both buildpacks
+	if phpVersion := composerJSON.Require["php"]; phpVersion != "" {
+		config["php_version"] = phpVersion
+	}
+
+	for _, framework := range phpFrameworks {
+		if _, ok := composerJSON.Require[framework.pkg]; ok {
+			config["framework"] = framework.name
+			config["web_root"] = framework.webRoot
+			break
+		}
+	}
+
+	paketoBuildpackInfo.Config = config
+	paketo.Detected = append(paketo.Detected, paketoBuildpackInfo)
+
+	herokuBuildpackInfo.Config = make(map[string]interface{})
+	for key, val := range config {
+		herokuBuildpackInfo.Config[key] = val
+	}
+	heroku.Detected = append(heroku.Detected, herokuBuildpackInfo)
+
+	return nil
+}
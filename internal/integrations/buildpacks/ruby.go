@@ -2,13 +2,11 @@ package buildpacks
 
 import (
 	"bufio"
-	"context"
 	"fmt"
+	"io/fs"
 	"regexp"
 	"strings"
 	"sync"
-
-	"github.com/google/go-github/v41/github"
 )
 
 type rubyRuntime struct {
@@ -115,20 +113,11 @@ func (runtime *rubyRuntime) detectPassenger(gemfileContent string, results chan
 	runtime.wg.Done()
 }
 
-func (runtime *rubyRuntime) detectRackup(
-	client *github.Client, owner, name string,
-	repoContentOptions github.RepositoryContentGetOptions, results chan struct {
-		string
-		bool
-	},
-) {
-	fileContent, _, _, err := client.Repositories.GetContents(context.Background(),
-		owner, name, "Gemfile.lock", &repoContentOptions)
-	if err != nil {
-		runtime.wg.Done()
-		return
-	}
-	gemfileLockContent, err := fileContent.GetContent()
+func (runtime *rubyRuntime) detectRackup(fsys fs.FS, results chan struct {
+	string
+	bool
+}) {
+	gemfileLockContent, err := readFile(fsys, "Gemfile.lock")
 	if err != nil {
 		runtime.wg.Done()
 		return
@@ -180,10 +169,8 @@ func (runtime *rubyRuntime) detectRake(gemfileContent string, results chan struc
 }
 
 func (runtime *rubyRuntime) Detect(
-	client *github.Client,
-	directoryContent []*github.RepositoryContent,
-	owner, name, path string,
-	repoContentOptions github.RepositoryContentGetOptions,
+	fsys fs.FS,
+	directoryContent []fs.DirEntry,
 	paketo, heroku *BuilderInfo,
 ) error {
 	gemfileFound := false
@@ -191,7 +178,7 @@ func (runtime *rubyRuntime) Detect(
 	configRuFound := false
 	rakefileFound := false
 	for i := range directoryContent {
-		name := directoryContent[i].GetName()
+		name := directoryContent[i].Name()
 		if name == "Gemfile" {
 			gemfileFound = true
 		} else if name == "Gemfile.lock" {
@@ -218,17 +205,11 @@ func (runtime *rubyRuntime) Detect(
 		return nil
 	}
 
-	fileContent, _, _, err := client.Repositories.GetContents(context.Background(), owner, name, "Gemfile", &repoContentOptions)
-	if err != nil {
-		paketo.Others = append(paketo.Others, paketoBuildpackInfo)
-		heroku.Others = append(heroku.Others, herokuBuildpackInfo)
-		return fmt.Errorf("error fetching contents of Gemfile for %s/%s: %v", owner, name, err)
-	}
-	gemfileContent, err := fileContent.GetContent()
+	gemfileContent, err := readFile(fsys, "Gemfile")
 	if err != nil {
 		paketo.Others = append(paketo.Others, paketoBuildpackInfo)
 		heroku.Others = append(heroku.Others, herokuBuildpackInfo)
-		return fmt.Errorf("error calling GetContent() on Gemfile for %s/%s: %v", owner, name, err)
+		return err
 	}
 
 	count := 6
@@ -265,7 +246,7 @@ func (runtime *rubyRuntime) Detect(
 	}
 	go runtime.detectPassenger(gemfileContent, results)
 	if !configRuFound && gemfileLockFound {
-		go runtime.detectRackup(client, owner, name, repoContentOptions, results)
+		go runtime.detectRackup(fsys, results)
 	}
 	if rakefileFound {
 		go runtime.detectRake(gemfileContent, results)
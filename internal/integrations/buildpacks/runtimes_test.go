@@ -0,0 +1,375 @@
+package buildpacks
+
+import (
+	"io/fs"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+type runtimeTest struct {
+	name       string
+	runtime    Runtime
+	files      map[string]string
+	expPaketo  *BuildpackInfo
+	expHeroku  *BuildpackInfo
+	herokuNone bool
+}
+
+var runtimeTests = []runtimeTest{
+	{
+		name:    "java maven",
+		runtime: NewJavaRuntime(),
+		files: map[string]string{
+			"pom.xml": `<project>
+  <properties>
+    <java.version>17</java.version>
+  </properties>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+  </parent>
+</project>`,
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "Java",
+			Buildpack: "gcr.io/paketo-buildpacks/java",
+			Config:    map[string]interface{}{"build_tool": "maven", "java_version": "17", "spring_boot": true},
+		},
+		expHeroku: &BuildpackInfo{
+			Name:      "Java",
+			Buildpack: "heroku/java",
+			Config:    map[string]interface{}{"build_tool": "maven", "java_version": "17", "spring_boot": true},
+		},
+	},
+	{
+		name:    "java maven compiler plugin",
+		runtime: NewJavaRuntime(),
+		files: map[string]string{
+			"pom.xml": `<project><build><plugins><plugin>
+  <artifactId>maven-compiler-plugin</artifactId>
+  <configuration><source>1.8</source></configuration>
+</plugin></plugins></build></project>`,
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "Java",
+			Buildpack: "gcr.io/paketo-buildpacks/java",
+			Config:    map[string]interface{}{"build_tool": "maven", "java_version": "8", "spring_boot": false},
+		},
+		expHeroku: &BuildpackInfo{
+			Name:      "Java",
+			Buildpack: "heroku/java",
+			Config:    map[string]interface{}{"build_tool": "maven", "java_version": "8", "spring_boot": false},
+		},
+	},
+	{
+		name:    "java gradle kotlin",
+		runtime: NewJavaRuntime(),
+		files: map[string]string{
+			"build.gradle.kts": `java {
+    toolchain {
+        languageVersion.set(JavaLanguageVersion.of(21))
+    }
+}`,
+			"settings.gradle.kts": "",
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "Java",
+			Buildpack: "gcr.io/paketo-buildpacks/java",
+			Config:    map[string]interface{}{"build_tool": "gradle", "java_version": "21", "spring_boot": false},
+		},
+		expHeroku: &BuildpackInfo{
+			Name:      "Java",
+			Buildpack: "heroku/gradle",
+			Config:    map[string]interface{}{"build_tool": "gradle", "java_version": "21", "spring_boot": false},
+		},
+	},
+	{
+		name:    "java gradle system properties",
+		runtime: NewJavaRuntime(),
+		files: map[string]string{
+			"build.gradle":      `plugins { id 'org.springframework.boot' version '2.6.3' }`,
+			"system.properties": "java.runtime.version=1.8\n",
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "Java",
+			Buildpack: "gcr.io/paketo-buildpacks/java",
+			Config:    map[string]interface{}{"build_tool": "gradle", "java_version": "8", "spring_boot": false},
+		},
+		expHeroku: &BuildpackInfo{
+			Name:      "Java",
+			Buildpack: "heroku/gradle",
+			Config:    map[string]interface{}{"build_tool": "gradle", "java_version": "8", "spring_boot": false},
+		},
+	},
+	{
+		name:    "java standalone jar",
+		runtime: NewJavaRuntime(),
+		files: map[string]string{
+			"app.jar": "",
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "Java",
+			Buildpack: "gcr.io/paketo-buildpacks/java",
+			Config:    map[string]interface{}{"build_tool": "standalone", "java_version": "11", "spring_boot": false},
+		},
+		expHeroku: &BuildpackInfo{
+			Name:      "Java",
+			Buildpack: "heroku/java",
+			Config:    map[string]interface{}{"build_tool": "standalone", "java_version": "11", "spring_boot": false},
+		},
+	},
+	{
+		name:    "java not detected",
+		runtime: NewJavaRuntime(),
+		files: map[string]string{
+			"main.go": "",
+		},
+	},
+	{
+		name:    "php laravel",
+		runtime: NewPHPRuntime(),
+		files: map[string]string{
+			"composer.json": `{"require": {"php": "^8.0", "laravel/framework": "^9.0"}}`,
+			"artisan":       "",
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "PHP",
+			Buildpack: "gcr.io/paketo-buildpacks/php",
+			Config:    map[string]interface{}{"php_version": "^8.0", "framework": "laravel", "web_root": "public"},
+		},
+		expHeroku: &BuildpackInfo{
+			Name:      "PHP",
+			Buildpack: "heroku/php",
+			Config:    map[string]interface{}{"php_version": "^8.0", "framework": "laravel", "web_root": "public"},
+		},
+	},
+	{
+		name:    "php composer without framework",
+		runtime: NewPHPRuntime(),
+		files: map[string]string{
+			"composer.json": `{"require": {"monolog/monolog": "^2.0"}}`,
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "PHP",
+			Buildpack: "gcr.io/paketo-buildpacks/php",
+			Config:    map[string]interface{}{},
+		},
+		expHeroku: &BuildpackInfo{
+			Name:      "PHP",
+			Buildpack: "heroku/php",
+			Config:    map[string]interface{}{},
+		},
+	},
+	{
+		name:    "php standalone",
+		runtime: NewPHPRuntime(),
+		files: map[string]string{
+			"index.php": "<?php echo 'hello';",
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "PHP",
+			Buildpack: "gcr.io/paketo-buildpacks/php",
+		},
+		expHeroku: &BuildpackInfo{
+			Name:      "PHP",
+			Buildpack: "heroku/php",
+		},
+	},
+	{
+		name:    "php not detected",
+		runtime: NewPHPRuntime(),
+		files: map[string]string{
+			"package.json": "{}",
+		},
+	},
+	{
+		name:    "dotnet csproj",
+		runtime: NewDotnetRuntime(),
+		files: map[string]string{
+			"Web.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web">
+  <PropertyGroup>
+    <TargetFramework>net6.0</TargetFramework>
+  </PropertyGroup>
+</Project>`,
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      ".NET",
+			Buildpack: "gcr.io/paketo-buildpacks/dotnet-core",
+			Config:    map[string]interface{}{"project_file": "Web.csproj", "dotnet_version": "6.0"},
+		},
+		herokuNone: true,
+	},
+	{
+		name:    "dotnet netcoreapp with global.json",
+		runtime: NewDotnetRuntime(),
+		files: map[string]string{
+			"Api.fsproj":  `<Project><PropertyGroup><TargetFrameworks>netcoreapp3.1;net5.0</TargetFrameworks></PropertyGroup></Project>`,
+			"global.json": `{"sdk": {"version": "3.1.416"}}`,
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      ".NET",
+			Buildpack: "gcr.io/paketo-buildpacks/dotnet-core",
+			Config: map[string]interface{}{
+				"project_file":   "Api.fsproj",
+				"dotnet_version": "3.1",
+				"sdk_version":    "3.1.416",
+			},
+		},
+		herokuNone: true,
+	},
+	{
+		name:    "dotnet solution",
+		runtime: NewDotnetRuntime(),
+		files: map[string]string{
+			"App.sln": "",
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      ".NET",
+			Buildpack: "gcr.io/paketo-buildpacks/dotnet-core",
+			Config:    map[string]interface{}{},
+		},
+		herokuNone: true,
+	},
+	{
+		name:    "dotnet not detected",
+		runtime: NewDotnetRuntime(),
+		files: map[string]string{
+			"README.md": "",
+		},
+		herokuNone: true,
+	},
+	{
+		name:    "static react",
+		runtime: NewStaticRuntime(),
+		files: map[string]string{
+			"package.json": `{"scripts": {"build": "react-scripts build"}, "dependencies": {"react-scripts": "5.0.0"}}`,
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "Static",
+			Buildpack: "gcr.io/paketo-buildpacks/web-servers",
+			Config: map[string]interface{}{
+				"web_server":   "nginx",
+				"build_script": "build",
+				"framework":    "react",
+				"web_root":     "build",
+			},
+		},
+		herokuNone: true,
+	},
+	{
+		name:    "static vite",
+		runtime: NewStaticRuntime(),
+		files: map[string]string{
+			"index.html":   "<html></html>",
+			"package.json": `{"scripts": {"build": "vite build"}, "devDependencies": {"vite": "^2.8.0"}}`,
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "Static",
+			Buildpack: "gcr.io/paketo-buildpacks/web-servers",
+			Config: map[string]interface{}{
+				"web_server":   "nginx",
+				"build_script": "build",
+				"framework":    "vite",
+				"web_root":     "dist",
+			},
+		},
+		herokuNone: true,
+	},
+	{
+		name:    "static staticfile",
+		runtime: NewStaticRuntime(),
+		files: map[string]string{
+			"Staticfile": "root: public\n",
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "Static",
+			Buildpack: "gcr.io/paketo-buildpacks/web-servers",
+			Config:    map[string]interface{}{"web_server": "nginx", "web_root": "public"},
+		},
+		herokuNone: true,
+	},
+	{
+		name:    "static html",
+		runtime: NewStaticRuntime(),
+		files: map[string]string{
+			"index.html": "<html></html>",
+		},
+		expPaketo: &BuildpackInfo{
+			Name:      "Static",
+			Buildpack: "gcr.io/paketo-buildpacks/web-servers",
+			Config:    map[string]interface{}{"web_server": "nginx", "web_root": "."},
+		},
+		herokuNone: true,
+	},
+	{
+		name:    "static node server not detected",
+		runtime: NewStaticRuntime(),
+		files: map[string]string{
+			"index.html":   "<html></html>",
+			"package.json": `{"scripts": {"start": "node server.js"}, "dependencies": {"express": "^4.17.0"}}`,
+		},
+		herokuNone: true,
+	},
+}
+
+func TestRuntimeDetect(t *testing.T) {
+	for _, test := range runtimeTests {
+		fsys := make(fstest.MapFS)
+		for name, content := range test.files {
+			fsys[name] = &fstest.MapFile{Data: []byte(content)}
+		}
+
+		directoryContent, err := fs.ReadDir(fsys, ".")
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		paketo := &BuilderInfo{Name: "Paketo"}
+		heroku := &BuilderInfo{Name: "Heroku"}
+
+		err = test.runtime.Detect(fsys, directoryContent, paketo, heroku)
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		checkBuilderInfo(t, test.name, "paketo", paketo, test.expPaketo, false)
+		checkBuilderInfo(t, test.name, "heroku", heroku, test.expHeroku, test.herokuNone)
+	}
+}
+
+func checkBuilderInfo(t *testing.T, testName, builder string, info *BuilderInfo, exp *BuildpackInfo, none bool) {
+	t.Helper()
+
+	if none {
+		if len(info.Detected) != 0 || len(info.Others) != 0 {
+			t.Errorf("%s: expected no %s buildpacks, got detected %v and others %v",
+				testName, builder, info.Detected, info.Others)
+		}
+
+		return
+	}
+
+	if exp == nil {
+		if len(info.Detected) != 0 {
+			t.Errorf("%s: expected no detected %s buildpacks, got %v", testName, builder, info.Detected)
+		}
+
+		if len(info.Others) != 1 {
+			t.Errorf("%s: expected one other %s buildpack, got %v", testName, builder, info.Others)
+		}
+
+		return
+	}
+
+	if len(info.Detected) != 1 {
+		t.Errorf("%s: expected one detected %s buildpack, got %v", testName, builder, info.Detected)
+		return
+	}
+
+	if !reflect.DeepEqual(info.Detected[0], *exp) {
+		t.Errorf("%s: expected %s buildpack %v, got %v", testName, builder, *exp, info.Detected[0])
+	}
+}
@@ -1,7 +1,8 @@
 package buildpacks
 
 import (
-	"github.com/google/go-github/v41/github"
+	"fmt"
+	"io/fs"
 )
 
 const (
@@ -26,6 +27,23 @@ const (
 	rackup    = "rackup"
 	rake      = "rake"
 
+	// Java
+	maven  = "maven"
+	gradle = "gradle"
+
+	// PHP
+	composer = "composer"
+
+	// .NET
+	project  = "project"
+	solution = "solution"
+
+	// Static sites
+	staticfile = "staticfile"
+	html       = "html"
+	react      = "react"
+	vite       = "vite"
+
 	// Common
 	standalone = "standalone"
 
@@ -49,12 +67,8 @@ type BuilderInfo struct {
 
 type Runtime interface {
 	Detect(
-		*github.Client, // github client to pull contents of files
-		[]*github.RepositoryContent, // the root folder structure of the git repo
-		string, // owner
-		string, // name
-		string, // path
-		github.RepositoryContentGetOptions, // SHA, branch or tag
+		fs.FS, // the directory to detect the runtime in, such as a local directory or a git repo
+		[]fs.DirEntry, // the root folder structure of the directory
 		*BuilderInfo, // paketo
 		*BuilderInfo, // heroku
 	) error
@@ -66,4 +80,18 @@ var Runtimes = []Runtime{
 	NewNodeRuntime(),
 	NewPythonRuntime(),
 	NewRubyRuntime(),
+	NewJavaRuntime(),
+	NewPHPRuntime(),
+	NewDotnetRuntime(),
+	NewStaticRuntime(),
+}
+
+// readFile returns the content of a file in the root of a directory
+func readFile(fsys fs.FS, file string) (string, error) {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return "", fmt.Errorf("error fetching contents of %s: %v", file, err)
+	}
+
+	return string(data), nil
 }
@@ -0,0 +1,211 @@
+package buildpacks
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+type staticRuntime struct {
+	wg sync.WaitGroup
+}
+
+func NewStaticRuntime() Runtime {
+	return &staticRuntime{}
+}
+
+type staticPackageJSON struct {
+	Scripts         map[string]string `json:"scripts"`
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+}
+
+func (p *staticPackageJSON) hasDependency(name string) bool {
+	_, inDeps := p.Dependencies[name]
+	_, inDevDeps := p.DevDependencies[name]
+
+	return inDeps || inDevDeps
+}
+
+func (runtime *staticRuntime) detectStaticfile(results chan struct {
+	string
+	bool
+}, directoryContent []fs.DirEntry) {
+	staticfileFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		name := directoryContent[i].Name()
+		if name == "Staticfile" {
+			staticfileFound = true
+			break
+		}
+	}
+	if staticfileFound {
+		results <- struct {
+			string
+			bool
+		}{staticfile, true}
+	}
+	runtime.wg.Done()
+}
+
+func (runtime *staticRuntime) detectHTML(results chan struct {
+	string
+	bool
+}, directoryContent []fs.DirEntry) {
+	indexFound := false
+	packageJSONFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		name := directoryContent[i].Name()
+		if name == "index.html" {
+			indexFound = true
+		} else if name == "package.json" {
+			packageJSONFound = true
+		}
+	}
+	// sites with a package.json are built before they are served
+	if indexFound && !packageJSONFound {
+		results <- struct {
+			string
+			bool
+		}{html, true}
+	}
+	runtime.wg.Done()
+}
+
+func (runtime *staticRuntime) detectReact(packageJSON *staticPackageJSON, results chan struct {
+	string
+	bool
+}) {
+	if packageJSON.hasDependency("react-scripts") && packageJSON.Scripts["build"] != "" {
+		results <- struct {
+			string
+			bool
+		}{react, true}
+	}
+	runtime.wg.Done()
+}
+
+func (runtime *staticRuntime) detectVite(packageJSON *staticPackageJSON, results chan struct {
+	string
+	bool
+}) {
+	if packageJSON.hasDependency("vite") && packageJSON.Scripts["build"] != "" {
+		results <- struct {
+			string
+			bool
+		}{vite, true}
+	}
+	runtime.wg.Done()
+}
+
+// getStaticfileRoot returns the root directory of a Staticfile, which is the directory
+// of the Staticfile by default
+func getStaticfileRoot(content string) string {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.SplitN(scanner.Text(), ":", 2)
+		if len(line) == 2 && strings.TrimSpace(line[0]) == "root" {
+			if root := strings.TrimSpace(line[1]); root != "" {
+				return root
+			}
+		}
+	}
+
+	return "."
+}
+
+// Detect only adds the buildpack to the Paketo builder, since the Heroku builders do not
+// support static sites
+func (runtime *staticRuntime) Detect(
+	fsys fs.FS,
+	directoryContent []fs.DirEntry,
+	paketo, heroku *BuilderInfo,
+) error {
+	paketoBuildpackInfo := BuildpackInfo{
+		Name:      "Static",
+		Buildpack: "gcr.io/paketo-buildpacks/web-servers",
+	}
+
+	packageJSONFound := false
+	for i := 0; i < len(directoryContent); i++ {
+		if directoryContent[i].Name() == "package.json" {
+			packageJSONFound = true
+			break
+		}
+	}
+
+	packageJSON := &staticPackageJSON{}
+
+	if packageJSONFound {
+		data, err := readFile(fsys, "package.json")
+		if err != nil {
+			paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+			return err
+		}
+
+		err = json.NewDecoder(strings.NewReader(data)).Decode(packageJSON)
+		if err != nil {
+			paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+			return fmt.Errorf("error decoding package.json contents to struct: %v", err)
+		}
+	}
+
+	results := make(chan struct {
+		string
+		bool
+	}, 4)
+
+	runtime.wg.Add(4)
+	go runtime.detectStaticfile(results, directoryContent)
+	go runtime.detectHTML(results, directoryContent)
+	go runtime.detectReact(packageJSON, results)
+	go runtime.detectVite(packageJSON, results)
+	runtime.wg.Wait()
+	close(results)
+
+	if len(results) == 0 {
+		paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+		return nil
+	}
+
+	found := make(map[string]bool)
+	for result := range results {
+		found[result.string] = true
+	}
+
+	config := map[string]interface{}{
+		"web_server": "nginx",
+	}
+
+	if found[react] || found[vite] {
+		// the site is built with the build script of package.json, and the output
+		// directory of the build is served
+		config["build_script"] = "build"
+
+		if found[react] {
+			config["framework"] = react
+			config["web_root"] = "build"
+		} else {
+			config["framework"] = vite
+			config["web_root"] = "dist"
+		}
+	} else if found[staticfile] {
+		data, err := readFile(fsys, "Staticfile")
+		if err != nil {
+			paketo.Others = append(paketo.Others, paketoBuildpackInfo)
+			return err
+		}
+
+		config["web_root"] = getStaticfileRoot(data)
+	} else {
+		config["web_root"] = "."
+	}
+
+	paketoBuildpackInfo.Config = config
+	paketo.Detected = append(paketo.Detected, paketoBuildpackInfo)
+
+	return nil
+}
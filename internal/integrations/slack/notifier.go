@@ -1,71 +1,38 @@
 package slack
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
-	"time"
 
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
 )
 
-type Notifier interface {
-	Notify(opts *NotifyOpts) error
-}
-
-type DeploymentStatus string
-
-const (
-	StatusHelmDeployed DeploymentStatus = "helm_deployed"
-	StatusPodCrashed   DeploymentStatus = "pod_crashed"
-	StatusHelmFailed   DeploymentStatus = "helm_failed"
-)
-
-type NotifyOpts struct {
-	// ProjectID is the id of the Porter project that this deployment belongs to
-	ProjectID uint
-
-	// ClusterID is the id of the Porter cluster that this deployment belongs to
-	ClusterID uint
-
-	// ClusterName is the name of the cluster that this deployment was deployed in
-	ClusterName string
-
-	// Status is the current status of the deployment.
-	Status DeploymentStatus
-
-	// Info is any additional information about this status, such as an error message if
-	// the deployment failed.
-	Info string
-
-	// Name is the name of the deployment that this notification refers to.
-	Name string
-
-	// Namespace is the Kubernetes namespace of the deployment that this notification refers to.
-	Namespace string
-
-	URL string
-
-	Timestamp *time.Time
-
-	Version int
-}
-
 type SlackNotifier struct {
 	slackInts []*integrations.SlackIntegration
-	Config    *types.NotificationConfig
 }
 
-func NewSlackNotifier(conf *types.NotificationConfig, slackInts ...*integrations.SlackIntegration) Notifier {
+func NewSlackNotifier(slackInts ...*integrations.SlackIntegration) notifier.Notifier {
 	return &SlackNotifier{
 		slackInts: slackInts,
-		Config:    conf,
 	}
 }
 
+// NewChannelNotifier creates a Slack notifier for a notification channel, which posts
+// to the channel's incoming webhook URL
+func NewChannelNotifier(_ *models.NotificationChannel, conf *types.NotificationChannelConfig) (notifier.Notifier, error) {
+	if conf.URL == "" {
+		return nil, fmt.Errorf("slack notification channels require a webhook url")
+	}
+
+	return NewSlackNotifier(&integrations.SlackIntegration{
+		Webhook: []byte(conf.URL),
+	}), nil
+}
+
 type SlackPayload struct {
 	Blocks []*SlackBlock `json:"blocks"`
 }
@@ -80,22 +47,7 @@ type SlackText struct {
 	Text string `json:"text"`
 }
 
-func (s *SlackNotifier) Notify(opts *NotifyOpts) error {
-	if s.Config != nil {
-		if !s.Config.Enabled {
-			return nil
-		}
-		if opts.Status == StatusHelmDeployed && !s.Config.Success {
-			return nil
-		}
-		if opts.Status == StatusPodCrashed && !s.Config.Failure {
-			return nil
-		}
-		if opts.Status == StatusHelmFailed && !s.Config.Failure {
-			return nil
-		}
-	}
-
+func (s *SlackNotifier) Notify(opts *notifier.Event) error {
 	// we create a basic payload as a fallback if the detailed payload with "info" fails, due to
 	// marshaling errors on the Slack API side.
 	blocks, basicBlocks := getSlackBlocks(opts)
@@ -120,39 +72,47 @@ func (s *SlackNotifier) Notify(opts *NotifyOpts) error {
 		return err
 	}
 
-	basicReqBody := bytes.NewReader(basicPayload)
-	reqBody := bytes.NewReader(payload)
-	client := &http.Client{
-		Timeout: time.Second * 5,
-	}
+	// every integration is sent the event, and the last failure is returned
+	var sendErr error
 
 	for _, slackInt := range s.slackInts {
-		resp, err := client.Post(string(slackInt.Webhook), "application/json", reqBody)
-
-		if err != nil || resp.StatusCode != 200 {
-			client.Post(string(slackInt.Webhook), "application/json", basicReqBody)
+		if _, err := notifier.PostBody(string(slackInt.Webhook), payload, nil); err != nil {
+			if _, err := notifier.PostBody(string(slackInt.Webhook), basicPayload, nil); err != nil {
+				sendErr = err
+			}
 		}
 	}
 
-	return nil
+	return sendErr
 }
 
-func getSlackBlocks(opts *NotifyOpts) ([]*SlackBlock, []*SlackBlock) {
+func getSlackBlocks(opts *notifier.Event) ([]*SlackBlock, []*SlackBlock) {
 	res := []*SlackBlock{}
 
-	if opts.Status == StatusHelmDeployed || opts.Status == StatusHelmFailed {
+	switch opts.Type {
+	case types.NotificationEventDeploySuccess,
+		types.NotificationEventDeployFailure,
+		types.NotificationEventDeployRolledBack,
+		types.NotificationEventReleaseCreated,
+		types.NotificationEventReleaseRolledBack,
+		types.NotificationEventReleaseDeleted:
 		res = append(res, getHelmMessageBlock(opts))
-	} else if opts.Status == StatusPodCrashed {
+	case types.NotificationEventPodCrash, types.NotificationEventJobFailure:
 		res = append(res, getPodCrashedMessageBlock(opts))
+	case types.NotificationEventProvisioningDone:
+		res = append(res, getProvisioningDoneMessageBlock(opts))
 	}
 
 	res = append(
 		res,
 		getDividerBlock(),
 		getMarkdownBlock(fmt.Sprintf("*Name:* %s", "`"+opts.Name+"`")),
-		getMarkdownBlock(fmt.Sprintf("*Namespace:* %s", "`"+opts.Namespace+"`")),
 	)
 
+	if opts.Namespace != "" {
+		res = append(res, getMarkdownBlock(fmt.Sprintf("*Namespace:* %s", "`"+opts.Namespace+"`")))
+	}
+
 	if opts.Timestamp != nil {
 		res = append(res, getMarkdownBlock(fmt.Sprintf(
 			"*Timestamp:* <!date^%d^Alerted at {date_num} {time_secs}|Alerted at %s>",
@@ -162,7 +122,7 @@ func getSlackBlocks(opts *NotifyOpts) ([]*SlackBlock, []*SlackBlock) {
 		)
 	}
 
-	if opts.Status == StatusHelmDeployed || opts.Status == StatusHelmFailed {
+	if opts.Version != 0 {
 		res = append(res, getMarkdownBlock(fmt.Sprintf("*Version:* %d", opts.Version)))
 	}
 
@@ -193,20 +153,36 @@ func getMarkdownBlock(md string) *SlackBlock {
 	}
 }
 
-func getHelmMessageBlock(opts *NotifyOpts) *SlackBlock {
+func getHelmMessageBlock(opts *notifier.Event) *SlackBlock {
 	var md string
 
-	switch opts.Status {
-	case StatusHelmDeployed:
+	switch opts.Type {
+	case types.NotificationEventDeploySuccess:
 		md = getHelmSuccessMessage(opts)
-	case StatusHelmFailed:
+	case types.NotificationEventDeployFailure:
 		md = getHelmFailedMessage(opts)
+	case types.NotificationEventDeployRolledBack:
+		md = getHelmRolledBackMessage(opts)
+	case types.NotificationEventReleaseCreated:
+		md = fmt.Sprintf(
+			":tada: Your application %s was created on Porter! <%s|View the new release.>",
+			"`"+opts.Name+"`",
+			opts.URL,
+		)
+	case types.NotificationEventReleaseRolledBack:
+		md = fmt.Sprintf(
+			":rewind: Your application %s was rolled back on Porter. <%s|View the release.>",
+			"`"+opts.Name+"`",
+			opts.URL,
+		)
+	case types.NotificationEventReleaseDeleted:
+		md = fmt.Sprintf(":wastebasket: Your application %s was deleted from Porter.", "`"+opts.Name+"`")
 	}
 
 	return getMarkdownBlock(md)
 }
 
-func getPodCrashedMessageBlock(opts *NotifyOpts) *SlackBlock {
+func getPodCrashedMessageBlock(opts *notifier.Event) *SlackBlock {
 	md := fmt.Sprintf(
 		":x: Your application %s crashed on Porter. <%s|View the application.>",
 		"`"+opts.Name+"`",
@@ -216,22 +192,25 @@ func getPodCrashedMessageBlock(opts *NotifyOpts) *SlackBlock {
 	return getMarkdownBlock(md)
 }
 
-func getInfoBlock(opts *NotifyOpts) *SlackBlock {
-	var md string
+func getProvisioningDoneMessageBlock(opts *notifier.Event) *SlackBlock {
+	md := fmt.Sprintf(":white_check_mark: %s was successfully provisioned on Porter.", "`"+opts.Name+"`")
 
-	switch opts.Status {
-	case StatusHelmFailed:
-		md = getFailedInfoMessage(opts)
-	case StatusPodCrashed:
-		md = getFailedInfoMessage(opts)
-	default:
-		return nil
+	if opts.URL != "" {
+		md += fmt.Sprintf(" <%s|View it here.>", opts.URL)
 	}
 
 	return getMarkdownBlock(md)
 }
 
-func getHelmSuccessMessage(opts *NotifyOpts) string {
+func getInfoBlock(opts *notifier.Event) *SlackBlock {
+	if !opts.Type.IsFailure() {
+		return nil
+	}
+
+	return getMarkdownBlock(getFailedInfoMessage(opts))
+}
+
+func getHelmSuccessMessage(opts *notifier.Event) string {
 	return fmt.Sprintf(
 		":rocket: Your application %s was successfully updated on Porter! <%s|View the new release.>",
 		"`"+opts.Name+"`",
@@ -239,7 +218,7 @@ func getHelmSuccessMessage(opts *NotifyOpts) string {
 	)
 }
 
-func getHelmFailedMessage(opts *NotifyOpts) string {
+func getHelmFailedMessage(opts *notifier.Event) string {
 	return fmt.Sprintf(
 		":x: Your application %s failed to deploy on Porter. <%s|View the status here.>",
 		"`"+opts.Name+"`",
@@ -247,7 +226,16 @@ func getHelmFailedMessage(opts *NotifyOpts) string {
 	)
 }
 
-func getFailedInfoMessage(opts *NotifyOpts) string {
+func getHelmRolledBackMessage(opts *notifier.Event) string {
+	return fmt.Sprintf(
+		":rewind: Version %d of your application %s failed its health checks on Porter and was rolled back. <%s|View the status here.>",
+		opts.Version,
+		"`"+opts.Name+"`",
+		opts.URL,
+	)
+}
+
+func getFailedInfoMessage(opts *notifier.Event) string {
 	info := opts.Info
 
 	// TODO: this casing is quite ugly and looks for particular types of API server
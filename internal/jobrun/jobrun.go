@@ -0,0 +1,224 @@
+package jobrun
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// TriggeredByAnnotation is set on manually triggered jobs to the email of the user
+	// who triggered the run
+	TriggeredByAnnotation = "porter.run/triggered-by"
+
+	// TriggeredBySchedule is recorded for runs which were started by a cron job
+	TriggeredBySchedule = "schedule"
+
+	// TriggeredByDeploy is recorded for runs which were started by deploying the release
+	TriggeredByDeploy = "deploy"
+
+	// ReleaseLabel is the label on jobs which contains the name of the job release
+	ReleaseLabel = "meta.helm.sh/release-name"
+
+	// LogTailLines is the number of log lines which are stored for each run
+	LogTailLines int64 = 100
+)
+
+// Record creates or updates the stored run of a Kubernetes job. When the job has
+// finished, the exit code and the end of the logs of the job container are captured,
+// so that they remain available after the job is deleted. Jobs which do not belong to
+// a release are ignored, in which case a nil run is returned.
+func Record(
+	repo repository.Repository,
+	agent *kubernetes.Agent,
+	projectID, clusterID uint,
+	job *batchv1.Job,
+) (*models.JobRun, error) {
+	releaseName, ok := job.Labels[ReleaseLabel]
+
+	if !ok || job.UID == "" {
+		return nil, nil
+	}
+
+	run, err := repo.JobRun().ReadJobRunByUID(clusterID, string(job.UID))
+	isNew := errors.Is(err, gorm.ErrRecordNotFound)
+
+	if err != nil && !isNew {
+		return nil, err
+	}
+
+	// finished runs do not change, so they only need to be captured once
+	if !isNew && run.Status.IsFinished() {
+		return run, nil
+	}
+
+	if isNew {
+		run = &models.JobRun{
+			ProjectID:   projectID,
+			ClusterID:   clusterID,
+			Namespace:   job.Namespace,
+			ReleaseName: releaseName,
+			JobName:     job.Name,
+			JobUID:      string(job.UID),
+			TriggeredBy: getTriggeredBy(job),
+		}
+	}
+
+	run.Status = getStatus(job)
+
+	if job.Status.StartTime != nil {
+		startedAt := job.Status.StartTime.Time
+		run.StartedAt = &startedAt
+	}
+
+	container := getJobContainer(job.Spec.Template.Spec.Containers)
+
+	if container != nil {
+		run.ImageTag = getImageTag(container.Image)
+	}
+
+	if run.Status.IsFinished() {
+		finishedAt := getFinishedAt(job)
+		run.FinishedAt = &finishedAt
+
+		if container != nil {
+			captureLogs(agent, run, container.Name)
+		}
+	}
+
+	if isNew {
+		return repo.JobRun().CreateJobRun(run)
+	}
+
+	return repo.JobRun().UpdateJobRun(run)
+}
+
+// RecordAll records the runs of all jobs, and returns the first error that occurred
+func RecordAll(
+	repo repository.Repository,
+	agent *kubernetes.Agent,
+	projectID, clusterID uint,
+	jobs []batchv1.Job,
+) error {
+	var firstErr error
+
+	for i := range jobs {
+		if _, err := Record(repo, agent, projectID, clusterID, &jobs[i]); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func getStatus(job *batchv1.Job) types.JobRunStatus {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != v1.ConditionTrue {
+			continue
+		}
+
+		if cond.Type == batchv1.JobComplete {
+			return types.JobRunSucceeded
+		} else if cond.Type == batchv1.JobFailed {
+			return types.JobRunFailed
+		}
+	}
+
+	if job.Status.Active > 0 {
+		return types.JobRunRunning
+	}
+
+	return types.JobRunPending
+}
+
+func getFinishedAt(job *batchv1.Job) time.Time {
+	if job.Status.CompletionTime != nil {
+		return job.Status.CompletionTime.Time
+	}
+
+	// failed jobs do not have a completion time, so the time of the failed condition
+	// is used instead
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == v1.ConditionTrue {
+			return cond.LastTransitionTime.Time
+		}
+	}
+
+	return time.Now()
+}
+
+func getTriggeredBy(job *batchv1.Job) string {
+	if user, ok := job.Annotations[TriggeredByAnnotation]; ok && user != "" {
+		return user
+	}
+
+	for _, ownerRef := range job.OwnerReferences {
+		if ownerRef.Kind == "CronJob" {
+			return TriggeredBySchedule
+		}
+	}
+
+	return TriggeredByDeploy
+}
+
+// getJobContainer returns the container which runs the job, skipping the sidecars that
+// are added by the job chart
+func getJobContainer(containers []v1.Container) *v1.Container {
+	for i, container := range containers {
+		if container.Name != "sidecar" && container.Name != "cloud-sql-proxy" {
+			return &containers[i]
+		}
+	}
+
+	return nil
+}
+
+func getImageTag(image string) string {
+	if i := strings.LastIndex(image, "@"); i != -1 {
+		return image[i+1:]
+	}
+
+	if i := strings.LastIndex(image, ":"); i != -1 && i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+
+	return "latest"
+}
+
+// captureLogs stores the exit code and log tail of the most recent pod of a finished
+// run. Pods may already have been deleted, in which case nothing is captured.
+func captureLogs(agent *kubernetes.Agent, run *models.JobRun, containerName string) {
+	pods, err := agent.GetJobPods(run.Namespace, run.JobName)
+
+	if err != nil || len(pods) == 0 {
+		return
+	}
+
+	pod := pods[0]
+
+	for _, p := range pods {
+		if pod.CreationTimestamp.Before(&p.CreationTimestamp) {
+			pod = p
+		}
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName && status.State.Terminated != nil {
+			exitCode := status.State.Terminated.ExitCode
+			run.ExitCode = &exitCode
+		}
+	}
+
+	if logs, err := agent.GetPodLogTail(run.Namespace, pod.Name, containerName, LogTailLines); err == nil {
+		run.LogTail = logs
+	}
+}
@@ -0,0 +1,174 @@
+package jobrun_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/jobrun"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/repository/test"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+func newJob(name string) *batchv1.Job {
+	startTime := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       k8stypes.UID("uid-" + name),
+			Labels: map[string]string{
+				jobrun.ReleaseLabel: "migrate",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{Name: "sidecar", Image: "public.ecr.aws/porter/job-sidecar:latest"},
+						{Name: "migrate-job", Image: "registry.example.com:5000/migrate:abc123"},
+					},
+				},
+			},
+		},
+		Status: batchv1.JobStatus{
+			StartTime: &startTime,
+			Active:    1,
+		},
+	}
+}
+
+func TestRecordCapturesFinishedRun(t *testing.T) {
+	job := newJob("migrate-run-1")
+	job.Annotations = map[string]string{jobrun.TriggeredByAnnotation: "user@example.com"}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "migrate-run-1-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{"job-name": "migrate-run-1"},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: "migrate-job",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{ExitCode: 3},
+					},
+				},
+			},
+		},
+	}
+
+	repo := test.NewRepository(true)
+	agent := kubernetes.GetAgentTesting(pod)
+
+	run, err := jobrun.Record(repo, agent, 1, 1, job)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if run.Status != types.JobRunRunning || run.FinishedAt != nil || run.LogTail != "" {
+		t.Errorf("incorrect running job run: %+v", run)
+	}
+
+	if run.TriggeredBy != "user@example.com" {
+		t.Errorf("incorrect triggered by: expected user@example.com, got %s", run.TriggeredBy)
+	}
+
+	if run.ImageTag != "abc123" {
+		t.Errorf("incorrect image tag: expected abc123, got %s", run.ImageTag)
+	}
+
+	job.Status.Active = 0
+	job.Status.Failed = 1
+	job.Status.Conditions = []batchv1.JobCondition{
+		{
+			Type:               batchv1.JobFailed,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+		},
+	}
+
+	finished, err := jobrun.Record(repo, agent, 1, 1, job)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if finished.ID != run.ID {
+		t.Errorf("expected existing run %d to be updated, got run %d", run.ID, finished.ID)
+	}
+
+	if finished.Status != types.JobRunFailed || finished.FinishedAt == nil {
+		t.Errorf("incorrect finished job run: %+v", finished)
+	}
+
+	if finished.ExitCode == nil || *finished.ExitCode != 3 {
+		t.Errorf("expected exit code 3, got %v", finished.ExitCode)
+	}
+
+	if finished.LogTail == "" {
+		t.Errorf("expected log tail to be captured")
+	}
+
+	runs, count, err := repo.JobRun().ListJobRunsByRelease(1, 1, "default", "migrate", &types.ListJobRunsRequest{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 || len(runs) != 1 {
+		t.Errorf("expected 1 stored run, got %d", count)
+	}
+}
+
+func TestRecordTriggeredBy(t *testing.T) {
+	repo := test.NewRepository(true)
+	agent := kubernetes.GetAgentTesting()
+
+	scheduled := newJob("migrate-1234")
+	scheduled.OwnerReferences = []metav1.OwnerReference{{Kind: "CronJob", Name: "migrate"}}
+
+	run, err := jobrun.Record(repo, agent, 1, 1, scheduled)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if run.TriggeredBy != jobrun.TriggeredBySchedule {
+		t.Errorf("incorrect triggered by: expected %s, got %s", jobrun.TriggeredBySchedule, run.TriggeredBy)
+	}
+
+	run, err = jobrun.Record(repo, agent, 1, 1, newJob("migrate"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if run.TriggeredBy != jobrun.TriggeredByDeploy {
+		t.Errorf("incorrect triggered by: expected %s, got %s", jobrun.TriggeredByDeploy, run.TriggeredBy)
+	}
+}
+
+func TestRecordSkipsJobsWithoutRelease(t *testing.T) {
+	job := newJob("other")
+	job.Labels = nil
+
+	run, err := jobrun.Record(test.NewRepository(true), kubernetes.GetAgentTesting(), 1, 1, job)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if run != nil {
+		t.Errorf("expected job without a release to be skipped")
+	}
+}
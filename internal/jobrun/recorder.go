@@ -0,0 +1,143 @@
+package jobrun
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/scheduler"
+	"golang.org/x/oauth2"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// clusterRequestTimeout is the timeout of each request to the API server of a cluster,
+// so that an unreachable cluster does not hold up the clusters after it
+const clusterRequestTimeout = 30 * time.Second
+
+// Recorder periodically records the runs of the jobs of every cluster, so that runs
+// which finish while nobody views them are captured before the jobs are deleted
+type Recorder struct {
+	Repo   repository.Repository
+	DOConf *oauth2.Config
+	Logger *logger.Logger
+
+	Interval time.Duration
+
+	// nextClusterID is the id of the cluster which the next run starts from, so that
+	// clusters which were skipped at the end of a run are recorded first in the next one
+	nextClusterID uint
+
+	// record records the job runs of a cluster, and is overridden in tests
+	record func(cluster *models.Cluster) error
+}
+
+// Run records the job runs on every interval, on one replica of the server at a time.
+// If the interval is not positive, recording is disabled and Run returns immediately.
+func (r *Recorder) Run() {
+	scheduler.Run(r.Repo, r.Logger, "job_run_record", r.Interval, r.RecordAllClusters)
+}
+
+// RecordAllClusters records the runs of the release jobs of every cluster. Errors are
+// logged, and do not stop the jobs of other clusters from being recorded. Clusters which
+// have not been recorded within one interval are skipped until the next run, so that a
+// run ends before the scheduler lease of two intervals expires and another replica
+// starts recording. The next run starts from the first skipped cluster, so that every
+// cluster is eventually recorded.
+func (r *Recorder) RecordAllClusters() {
+	clusters, err := r.Repo.Cluster().ListAllClusters()
+
+	if err != nil {
+		r.Logger.Error().Err(err).Msg("could not list clusters to record job runs")
+		return
+	}
+
+	record := r.record
+
+	if record == nil {
+		record = r.recordCluster
+	}
+
+	deadline := time.Now().Add(r.Interval)
+
+	for i, cluster := range r.orderClusters(clusters) {
+		if r.Interval > 0 && time.Now().After(deadline) {
+			r.nextClusterID = cluster.ID
+
+			r.Logger.Warn().Int("skipped_clusters", len(clusters)-i).
+				Msg("recording job runs took longer than the interval, skipping the remaining clusters")
+
+			return
+		}
+
+		if err := record(cluster); err != nil {
+			r.Logger.Error().Err(err).Uint("cluster_id", cluster.ID).
+				Msg("could not record job runs")
+		}
+	}
+
+	r.nextClusterID = 0
+}
+
+// orderClusters sorts the clusters by id, starting from the cluster which the previous
+// run stopped at and wrapping around to the clusters before it
+func (r *Recorder) orderClusters(clusters []*models.Cluster) []*models.Cluster {
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ID < clusters[j].ID
+	})
+
+	start := sort.Search(len(clusters), func(i int) bool {
+		return clusters[i].ID >= r.nextClusterID
+	})
+
+	res := make([]*models.Cluster, 0, len(clusters))
+	res = append(res, clusters[start:]...)
+	res = append(res, clusters[:start]...)
+
+	return res
+}
+
+func (r *Recorder) recordCluster(cluster *models.Cluster) error {
+	agent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+		Cluster:           cluster,
+		Repo:              r.Repo,
+		DigitalOceanOAuth: r.DOConf,
+		Timeout:           clusterRequestTimeout,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	jobs, err := listReleaseJobs(agent)
+
+	if err != nil {
+		return err
+	}
+
+	return RecordAll(r.Repo, agent, cluster.ProjectID, cluster.ID, jobs)
+}
+
+// listReleaseJobs lists the jobs in every namespace which belong to a release
+func listReleaseJobs(agent *kubernetes.Agent) ([]batchv1.Job, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), clusterRequestTimeout)
+	defer cancel()
+
+	resp, err := agent.Clientset.BatchV1().Jobs(metav1.NamespaceAll).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: ReleaseLabel,
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Items, nil
+}
@@ -0,0 +1,53 @@
+package jobrun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+func TestRecordAllClustersResumesSkippedClusters(t *testing.T) {
+	repo := test.NewRepository(true)
+
+	for i := 0; i < 3; i++ {
+		if _, err := repo.Cluster().CreateCluster(&models.Cluster{ProjectID: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recorded := make([]uint, 0)
+
+	r := &Recorder{
+		Repo:     repo,
+		Logger:   logger.NewConsole(false),
+		Interval: 10 * time.Millisecond,
+	}
+
+	// recording a cluster takes longer than the interval, so only one cluster is
+	// recorded on each run
+	r.record = func(cluster *models.Cluster) error {
+		recorded = append(recorded, cluster.ID)
+		time.Sleep(2 * r.Interval)
+
+		return nil
+	}
+
+	for i := 0; i < 4; i++ {
+		r.RecordAllClusters()
+	}
+
+	expected := []uint{1, 2, 3, 1}
+
+	if len(recorded) != len(expected) {
+		t.Fatalf("expected clusters %v to be recorded, got %v", expected, recorded)
+	}
+
+	for i := range expected {
+		if recorded[i] != expected[i] {
+			t.Fatalf("expected clusters %v to be recorded, got %v", expected, recorded)
+		}
+	}
+}
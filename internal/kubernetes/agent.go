@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"sort"
 	"strings"
 	"time"
 
@@ -350,6 +351,186 @@ func (a *Agent) DeleteJob(name, namespace string) error {
 	)
 }
 
+// JobRunOpts are the options for a manual run of a job template
+type JobRunOpts struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+
+	// Command overrides the command of the first container of the job, if set
+	Command []string
+
+	// Env is added to the first container of the job, and overrides any existing
+	// variables with the same name
+	Env map[string]string
+}
+
+// NewJobFromTemplate returns a job which runs the pod template of an existing job or
+// cron job. Labels which are set by the job controller are removed from the template,
+// so that the new job is given its own selector.
+func NewJobFromTemplate(namespace string, template *batchv1.JobTemplateSpec, opts *JobRunOpts) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        opts.Name,
+			Namespace:   namespace,
+			Labels:      make(map[string]string),
+			Annotations: make(map[string]string),
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+
+	for key, val := range template.Labels {
+		if !isJobControllerLabel(key) {
+			job.Labels[key] = val
+		}
+	}
+
+	for key, val := range opts.Labels {
+		job.Labels[key] = val
+	}
+
+	for key, val := range opts.Annotations {
+		job.Annotations[key] = val
+	}
+
+	job.Spec.Selector = nil
+	job.Spec.ManualSelector = nil
+
+	podLabels := make(map[string]string)
+
+	for key, val := range job.Spec.Template.Labels {
+		if !isJobControllerLabel(key) {
+			podLabels[key] = val
+		}
+	}
+
+	job.Spec.Template.Labels = podLabels
+
+	if len(job.Spec.Template.Spec.Containers) == 0 {
+		return job
+	}
+
+	container := &job.Spec.Template.Spec.Containers[0]
+
+	if len(opts.Command) > 0 {
+		container.Command = opts.Command
+		container.Args = nil
+	}
+
+	envKeys := make([]string, 0, len(opts.Env))
+
+	for key := range opts.Env {
+		envKeys = append(envKeys, key)
+	}
+
+	sort.Strings(envKeys)
+
+	for _, key := range envKeys {
+		found := false
+
+		for i, envVar := range container.Env {
+			if envVar.Name == key {
+				container.Env[i] = v1.EnvVar{Name: key, Value: opts.Env[key]}
+				found = true
+			}
+		}
+
+		if !found {
+			container.Env = append(container.Env, v1.EnvVar{Name: key, Value: opts.Env[key]})
+		}
+	}
+
+	return job
+}
+
+func isJobControllerLabel(key string) bool {
+	return key == "controller-uid" || key == "job-name"
+}
+
+// CreateJob creates a job in the given namespace
+func (a *Agent) CreateJob(namespace string, job *batchv1.Job) (*batchv1.Job, error) {
+	return a.Clientset.BatchV1().Jobs(namespace).Create(
+		context.TODO(),
+		job,
+		metav1.CreateOptions{},
+	)
+}
+
+// SetCronJobSuspend suspends or resumes the schedule of a cron job. Jobs which are
+// already running are not affected.
+func (a *Agent) SetCronJobSuspend(namespace, name string, suspend bool) (*batchv1beta1.CronJob, error) {
+	cronJob, err := a.Clientset.BatchV1beta1().CronJobs(namespace).Get(
+		context.TODO(),
+		name,
+		metav1.GetOptions{},
+	)
+
+	if err != nil && errors.IsNotFound(err) {
+		return nil, IsNotFoundError
+	} else if err != nil {
+		return nil, err
+	}
+
+	cronJob.Spec.Suspend = &suspend
+
+	return a.Clientset.BatchV1beta1().CronJobs(namespace).Update(
+		context.TODO(),
+		cronJob,
+		metav1.UpdateOptions{},
+	)
+}
+
+// RestartedAtAnnotation is set on the pod template of a controller to trigger a
+// rolling restart
+const RestartedAtAnnotation = "porter.run/restarted-at"
+
+// RestartController performs a rolling restart of a Deployment, StatefulSet or
+// DaemonSet by updating an annotation on its pod template
+func (a *Agent) RestartController(namespace, kind, name string) error {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						RestartedAtAnnotation: time.Now().UTC().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+
+	patchBytes, err := json.Marshal(patch)
+
+	if err != nil {
+		return err
+	}
+
+	appsClient := a.Clientset.AppsV1()
+
+	switch strings.ToLower(kind) {
+	case "deployment":
+		_, err = appsClient.Deployments(namespace).Patch(
+			context.TODO(), name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{},
+		)
+	case "statefulset":
+		_, err = appsClient.StatefulSets(namespace).Patch(
+			context.TODO(), name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{},
+		)
+	case "daemonset":
+		_, err = appsClient.DaemonSets(namespace).Patch(
+			context.TODO(), name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{},
+		)
+	default:
+		return fmt.Errorf("cannot restart controller of kind %s", kind)
+	}
+
+	if err != nil && errors.IsNotFound(err) {
+		return IsNotFoundError
+	}
+
+	return err
+}
+
 // GetJobPods lists all pods belonging to a job in a namespace
 func (a *Agent) GetJobPods(namespace, jobName string) ([]v1.Pod, error) {
 	resp, err := a.Clientset.CoreV1().Pods(namespace).List(
@@ -383,6 +564,35 @@ func (a *Agent) GetIngress(namespace string, name string) (*v1beta1.Ingress, err
 	return resp, nil
 }
 
+type mergeAnnotationsData struct {
+	Metadata struct {
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+}
+
+// UpdateIngressAnnotations merges the given annotations into the annotations of the
+// ingress with the given name and namespace
+func (a *Agent) UpdateIngressAnnotations(namespace, name string, annotations map[string]string) error {
+	mergeAnnotations := &mergeAnnotationsData{}
+	mergeAnnotations.Metadata.Annotations = annotations
+
+	patchBytes, err := json.Marshal(mergeAnnotations)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = a.Clientset.ExtensionsV1beta1().Ingresses(namespace).Patch(
+		context.TODO(),
+		name,
+		types.MergePatchType,
+		patchBytes,
+		metav1.PatchOptions{},
+	)
+
+	return err
+}
+
 var IsNotFoundError = fmt.Errorf("not found")
 
 type BadRequestError struct {
@@ -717,6 +927,25 @@ func (a *Agent) GetPreviousPodLogs(namespace string, name string, selectedContai
 	return logs, nil
 }
 
+// GetPodLogTail returns the last lines of the logs of a container in a pod, which
+// does not need to be running
+func (a *Agent) GetPodLogTail(namespace, name, container string, lines int64) (string, error) {
+	podLogOpts := v1.PodLogOptions{
+		TailLines: &lines,
+		Container: container,
+	}
+
+	res, err := a.Clientset.CoreV1().Pods(namespace).GetLogs(name, &podLogOpts).DoRaw(context.TODO())
+
+	if err != nil && errors.IsNotFound(err) {
+		return "", IsNotFoundError
+	} else if err != nil {
+		return "", fmt.Errorf("Cannot get logs from pod %s: %s", name, err.Error())
+	}
+
+	return string(res), nil
+}
+
 // StopJobWithJobSidecar sends a termination signal to a job running with a sidecar
 func (a *Agent) StopJobWithJobSidecar(namespace, name string) error {
 	jobPods, err := a.GetJobPods(namespace, name)
@@ -812,26 +1041,10 @@ func (a *Agent) StreamControllerStatus(kind string, selectors string, rw *websoc
 			informers.WithTweakListOptions(tweakListOptionsFunc),
 		)
 
-		var informer cache.SharedInformer
-
-		// Spins up an informer depending on kind. Convert to lowercase for robustness
-		switch strings.ToLower(kind) {
-		case "deployment":
-			informer = factory.Apps().V1().Deployments().Informer()
-		case "statefulset":
-			informer = factory.Apps().V1().StatefulSets().Informer()
-		case "replicaset":
-			informer = factory.Apps().V1().ReplicaSets().Informer()
-		case "daemonset":
-			informer = factory.Apps().V1().DaemonSets().Informer()
-		case "job":
-			informer = factory.Batch().V1().Jobs().Informer()
-		case "cronjob":
-			informer = factory.Batch().V1beta1().CronJobs().Informer()
-		case "namespace":
-			informer = factory.Core().V1().Namespaces().Informer()
-		case "pod":
-			informer = factory.Core().V1().Pods().Informer()
+		informer, err := getControllerInformer(factory, kind)
+
+		if err != nil {
+			return err
 		}
 
 		stopper := make(chan struct{})
@@ -894,6 +1107,186 @@ func (a *Agent) StreamControllerStatus(kind string, selectors string, rw *websoc
 	return a.RunWebsocketTask(run)
 }
 
+// getControllerInformer spins up an informer depending on kind
+func getControllerInformer(factory informers.SharedInformerFactory, kind string) (cache.SharedInformer, error) {
+	// convert to lowercase for robustness
+	switch strings.ToLower(kind) {
+	case "deployment":
+		return factory.Apps().V1().Deployments().Informer(), nil
+	case "statefulset":
+		return factory.Apps().V1().StatefulSets().Informer(), nil
+	case "replicaset":
+		return factory.Apps().V1().ReplicaSets().Informer(), nil
+	case "daemonset":
+		return factory.Apps().V1().DaemonSets().Informer(), nil
+	case "job":
+		return factory.Batch().V1().Jobs().Informer(), nil
+	case "cronjob":
+		return factory.Batch().V1beta1().CronJobs().Informer(), nil
+	case "namespace":
+		return factory.Core().V1().Namespaces().Informer(), nil
+	case "pod":
+		return factory.Core().V1().Pods().Informer(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported kind %s", kind)
+}
+
+// WaitForControllerReady watches the status of a Deployment, StatefulSet or DaemonSet
+// until all of its replicas are updated and ready. If the controller is not ready before
+// the timeout, an error describing its last observed status is returned.
+func (a *Agent) WaitForControllerReady(kind, namespace, name string, timeout time.Duration) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		a.Clientset,
+		0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}),
+	)
+
+	informer, err := getControllerInformer(factory, kind)
+
+	if err != nil {
+		return err
+	}
+
+	stopper := make(chan struct{})
+	defer close(stopper)
+
+	// buffered so that the event handlers never block after the wait has returned
+	statusChan := make(chan ControllerStatus, 1)
+
+	sendStatus := func(obj interface{}) {
+		status, ok := GetControllerStatus(obj)
+
+		if !ok {
+			return
+		}
+
+		// only the latest status is relevant, so drop any status which has not been read
+		select {
+		case <-statusChan:
+		default:
+		}
+
+		statusChan <- status
+	}
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: sendStatus,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			sendStatus(newObj)
+		},
+	})
+
+	go informer.Run(stopper)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	lastStatus := ControllerStatus{}
+	found := false
+
+	for {
+		select {
+		case status := <-statusChan:
+			if status.Ready() {
+				return nil
+			}
+
+			lastStatus = status
+			found = true
+		case <-timer.C:
+			if !found {
+				return fmt.Errorf("%s %s was not found after %s", strings.ToLower(kind), name, timeout)
+			}
+
+			return fmt.Errorf(
+				"%s %s had %d/%d ready replicas after %s",
+				strings.ToLower(kind), name, lastStatus.ReadyReplicas, lastStatus.DesiredReplicas, timeout,
+			)
+		}
+	}
+}
+
+// ControllerStatus is the rollout status of a controller
+type ControllerStatus struct {
+	DesiredReplicas int32
+	UpdatedReplicas int32
+	ReadyReplicas   int32
+
+	// TotalReplicas counts the replicas of both the latest and any older spec,
+	// so that replicas of an older spec which are still running are not counted
+	// as ready replicas of the latest spec
+	TotalReplicas     int32
+	AvailableReplicas int32
+
+	// ObservedLatest is true if the controller has observed its latest spec
+	ObservedLatest bool
+}
+
+// Ready returns true if the latest spec has been rolled out to all replicas, no
+// replicas of an older spec remain, and all replicas are ready and available. This
+// follows the checks of kubectl rollout status.
+func (s ControllerStatus) Ready() bool {
+	return s.ObservedLatest &&
+		s.UpdatedReplicas >= s.DesiredReplicas &&
+		s.TotalReplicas <= s.UpdatedReplicas &&
+		s.ReadyReplicas >= s.DesiredReplicas &&
+		s.AvailableReplicas >= s.DesiredReplicas
+}
+
+// GetControllerStatus returns the status of a Deployment, StatefulSet or DaemonSet
+func GetControllerStatus(obj interface{}) (ControllerStatus, bool) {
+	switch controller := obj.(type) {
+	case *appsv1.Deployment:
+		desired := int32(1)
+
+		if controller.Spec.Replicas != nil {
+			desired = *controller.Spec.Replicas
+		}
+
+		return ControllerStatus{
+			DesiredReplicas:   desired,
+			UpdatedReplicas:   controller.Status.UpdatedReplicas,
+			ReadyReplicas:     controller.Status.ReadyReplicas,
+			TotalReplicas:     controller.Status.Replicas,
+			AvailableReplicas: controller.Status.AvailableReplicas,
+			ObservedLatest:    controller.Status.ObservedGeneration >= controller.Generation,
+		}, true
+	case *appsv1.StatefulSet:
+		desired := int32(1)
+
+		if controller.Spec.Replicas != nil {
+			desired = *controller.Spec.Replicas
+		}
+
+		return ControllerStatus{
+			DesiredReplicas: desired,
+			UpdatedReplicas: controller.Status.UpdatedReplicas,
+			ReadyReplicas:   controller.Status.ReadyReplicas,
+			TotalReplicas:   controller.Status.Replicas,
+			// a revision mismatch already covers older replicas, and available
+			// replicas are only reported when the StatefulSetMinReadySeconds gate is on
+			AvailableReplicas: controller.Status.ReadyReplicas,
+			ObservedLatest: controller.Status.ObservedGeneration >= controller.Generation &&
+				controller.Status.UpdateRevision == controller.Status.CurrentRevision,
+		}, true
+	case *appsv1.DaemonSet:
+		return ControllerStatus{
+			DesiredReplicas:   controller.Status.DesiredNumberScheduled,
+			UpdatedReplicas:   controller.Status.UpdatedNumberScheduled,
+			ReadyReplicas:     controller.Status.NumberReady,
+			TotalReplicas:     controller.Status.UpdatedNumberScheduled,
+			AvailableReplicas: controller.Status.NumberAvailable,
+			ObservedLatest:    controller.Status.ObservedGeneration >= controller.Generation,
+		}, true
+	}
+
+	return ControllerStatus{}, false
+}
+
 var b64 = base64.StdEncoding
 
 var magicGzip = []byte{0x1f, 0x8b, 0x08}
@@ -1112,6 +1505,12 @@ func (a *Agent) Provision(
 	return err
 }
 
+// GetImagePullSecretName returns the name of the image pull secret which is created for a
+// registry by CreateImagePullSecrets
+func GetImagePullSecretName(reg *models.Registry) string {
+	return fmt.Sprintf("porter-%s-%d", reg.ToRegistryType().Service, reg.ID)
+}
+
 // CreateImagePullSecrets will create the required image pull secrets and
 // return a map from the registry name to the name of the secret.
 func (a *Agent) CreateImagePullSecrets(
@@ -1131,7 +1530,7 @@ func (a *Agent) CreateImagePullSecrets(
 			return nil, err
 		}
 
-		secretName := fmt.Sprintf("porter-%s-%d", val.ToRegistryType().Service, val.ID)
+		secretName := GetImagePullSecretName(val)
 
 		secret, err := a.Clientset.CoreV1().Secrets(namespace).Get(
 			context.TODO(),
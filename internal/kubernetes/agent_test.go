@@ -1,6 +1,7 @@
 package kubernetes_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/porter-dev/porter/internal/kubernetes"
@@ -10,6 +11,9 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -63,3 +67,299 @@ func TestOutOfClusterConfig(t *testing.T) {
 		}
 	}
 }
+
+type controllerStatusTest struct {
+	name      string
+	obj       interface{}
+	expReady  bool
+	expParsed bool
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+var controllerStatusTests = []controllerStatusTest{
+	{
+		name: "deployment fully rolled out",
+		obj: &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           2,
+				UpdatedReplicas:    2,
+				ReadyReplicas:      2,
+				AvailableReplicas:  2,
+			},
+		},
+		expReady:  true,
+		expParsed: true,
+	},
+	{
+		name: "deployment with stale generation",
+		obj: &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 3},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(2)},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				UpdatedReplicas:    2,
+				ReadyReplicas:      2,
+			},
+		},
+		expReady:  false,
+		expParsed: true,
+	},
+	{
+		name: "deployment with unready replicas",
+		obj: &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 1},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(3)},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 1,
+				UpdatedReplicas:    3,
+				ReadyReplicas:      1,
+			},
+		},
+		expReady:  false,
+		expParsed: true,
+	},
+	{
+		name: "deployment with failing new replica and ready old replica",
+		obj: &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(1)},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration:  2,
+				Replicas:            2,
+				UpdatedReplicas:     1,
+				ReadyReplicas:       1,
+				AvailableReplicas:   1,
+				UnavailableReplicas: 1,
+			},
+		},
+		expReady:  false,
+		expParsed: true,
+	},
+	{
+		name: "statefulset mid-rollout",
+		obj: &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Generation: 1},
+			Spec:       appsv1.StatefulSetSpec{Replicas: int32Ptr(1)},
+			Status: appsv1.StatefulSetStatus{
+				ObservedGeneration: 1,
+				UpdatedReplicas:    1,
+				ReadyReplicas:      1,
+				CurrentRevision:    "rev-1",
+				UpdateRevision:     "rev-2",
+			},
+		},
+		expReady:  false,
+		expParsed: true,
+	},
+	{
+		name: "daemonset fully scheduled",
+		obj: &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Generation: 1},
+			Status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     1,
+				DesiredNumberScheduled: 4,
+				UpdatedNumberScheduled: 4,
+				NumberReady:            4,
+				NumberAvailable:        4,
+			},
+		},
+		expReady:  true,
+		expParsed: true,
+	},
+	{
+		name:      "unsupported object",
+		obj:       &v1.Pod{},
+		expReady:  false,
+		expParsed: false,
+	},
+}
+
+func TestGetControllerStatus(t *testing.T) {
+	for _, tc := range controllerStatusTests {
+		status, ok := kubernetes.GetControllerStatus(tc.obj)
+
+		if ok != tc.expParsed {
+			t.Errorf("%s: expected parsed to be %t, got %t", tc.name, tc.expParsed, ok)
+		}
+
+		if ready := status.Ready(); ok && ready != tc.expReady {
+			t.Errorf("%s: expected ready to be %t, got %t", tc.name, tc.expReady, ready)
+		}
+	}
+}
+
+func TestNewJobFromTemplate(t *testing.T) {
+	template := &batchv1.JobTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app.kubernetes.io/name": "migrate",
+				"controller-uid":         "1234",
+				"job-name":               "migrate-1",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"controller-uid": "1234"},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app.kubernetes.io/name": "migrate",
+						"controller-uid":         "1234",
+						"job-name":               "migrate-1",
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:    "job",
+							Command: []string{"python"},
+							Args:    []string{"run.py"},
+							Env: []v1.EnvVar{
+								{Name: "DEBUG", Value: "false"},
+								{Name: "PORT", Value: "80"},
+							},
+						},
+						{
+							Name: "sidecar",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	job := kubernetes.NewJobFromTemplate("default", template, &kubernetes.JobRunOpts{
+		Name:        "migrate-run-abc123",
+		Labels:      map[string]string{"meta.helm.sh/release-name": "migrate"},
+		Annotations: map[string]string{"porter.run/triggered-by": "user@example.com"},
+		Command:     []string{"python", "manage.py", "migrate"},
+		Env:         map[string]string{"DEBUG": "true", "EXTRA": "1"},
+	})
+
+	if job.Name != "migrate-run-abc123" || job.Namespace != "default" {
+		t.Errorf("incorrect job name or namespace: %s/%s", job.Namespace, job.Name)
+	}
+
+	if job.Spec.Selector != nil {
+		t.Errorf("expected selector of template to be removed")
+	}
+
+	for _, labels := range []map[string]string{job.Labels, job.Spec.Template.Labels} {
+		if _, ok := labels["controller-uid"]; ok {
+			t.Errorf("expected controller-uid label to be removed")
+		}
+
+		if _, ok := labels["job-name"]; ok {
+			t.Errorf("expected job-name label to be removed")
+		}
+	}
+
+	if job.Labels["app.kubernetes.io/name"] != "migrate" || job.Labels["meta.helm.sh/release-name"] != "migrate" {
+		t.Errorf("incorrect job labels: %v", job.Labels)
+	}
+
+	if job.Annotations["porter.run/triggered-by"] != "user@example.com" {
+		t.Errorf("incorrect job annotations: %v", job.Annotations)
+	}
+
+	container := job.Spec.Template.Spec.Containers[0]
+
+	if len(container.Command) != 3 || container.Command[1] != "manage.py" || container.Args != nil {
+		t.Errorf("incorrect command: %v %v", container.Command, container.Args)
+	}
+
+	expEnv := []v1.EnvVar{
+		{Name: "DEBUG", Value: "true"},
+		{Name: "PORT", Value: "80"},
+		{Name: "EXTRA", Value: "1"},
+	}
+
+	if len(container.Env) != len(expEnv) {
+		t.Fatalf("incorrect env: %v", container.Env)
+	}
+
+	for i, envVar := range expEnv {
+		if container.Env[i] != envVar {
+			t.Errorf("incorrect env var %d: expected %v, got %v", i, envVar, container.Env[i])
+		}
+	}
+
+	// the template must not be modified
+	if template.Spec.Template.Spec.Containers[0].Env[0].Value != "false" || template.Spec.Selector == nil {
+		t.Errorf("template was modified")
+	}
+}
+
+func TestSetCronJobSuspend(t *testing.T) {
+	agent := kubernetes.GetAgentTesting(&batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cleanup",
+			Namespace: "default",
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule: "*/5 * * * *",
+		},
+	})
+
+	cronJob, err := agent.SetCronJobSuspend("default", "cleanup", true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cronJob.Spec.Suspend == nil || !*cronJob.Spec.Suspend {
+		t.Errorf("expected cron job to be suspended")
+	}
+
+	cronJob, err = agent.SetCronJobSuspend("default", "cleanup", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cronJob.Spec.Suspend == nil || *cronJob.Spec.Suspend {
+		t.Errorf("expected cron job to be resumed")
+	}
+
+	if _, err := agent.SetCronJobSuspend("default", "missing", true); err != kubernetes.IsNotFoundError {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestRestartController(t *testing.T) {
+	agent := kubernetes.GetAgentTesting(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+		},
+	})
+
+	if err := agent.RestartController("default", "Deployment", "web"); err != nil {
+		t.Fatal(err)
+	}
+
+	depl, err := agent.Clientset.AppsV1().Deployments("default").Get(context.TODO(), "web", metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, found := depl.Spec.Template.Annotations[kubernetes.RestartedAtAnnotation]; !found {
+		t.Errorf("expected pod template to have the restart annotation")
+	}
+
+	if err := agent.RestartController("default", "Deployment", "missing"); err != kubernetes.IsNotFoundError {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	if err := agent.RestartController("default", "CronJob", "web"); err == nil {
+		t.Errorf("expected error when restarting a cron job")
+	}
+}
@@ -105,6 +105,9 @@ type OutOfClusterConfig struct {
 
 	// Only required if using DigitalOcean OAuth as an auth mechanism
 	DigitalOceanOAuth *oauth2.Config
+
+	// Timeout is the timeout of each request to the API server (optional)
+	Timeout time.Duration
 }
 
 // ToRESTConfig creates a kubernetes REST client factory -- it calls ClientConfig on
@@ -124,6 +127,11 @@ func (conf *OutOfClusterConfig) ToRESTConfig() (*rest.Config, error) {
 	}
 
 	rest.SetKubernetesDefaults(restConf)
+
+	if conf.Timeout > 0 {
+		restConf.Timeout = conf.Timeout
+	}
+
 	return restConf, nil
 }
 
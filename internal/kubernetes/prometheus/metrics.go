@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
@@ -273,6 +275,48 @@ func parseQuery(rawQuery []byte, metric string) ([]*promParsedSingletonQuery, er
 	return res, nil
 }
 
+// GetLatestValue returns the most recent value of the first series in the results
+// of a query, which is meant for queries that return a single aggregated series such
+// as "nginx:errors" and "nginx:latency". If the query returned no data, found is false.
+func GetLatestValue(results []*promParsedSingletonQuery) (value float64, found bool, err error) {
+	if len(results) == 0 || len(results[0].Results) == 0 {
+		return 0, false, nil
+	}
+
+	latest := results[0].Results[len(results[0].Results)-1]
+
+	var rawVal interface{}
+
+	for _, val := range []interface{}{
+		latest.CPU, latest.Replicas, latest.Memory, latest.Bytes, latest.ErrorPct, latest.Latency,
+	} {
+		if val != nil {
+			rawVal = val
+			break
+		}
+	}
+
+	// prometheus encodes sample values as strings
+	strVal, ok := rawVal.(string)
+
+	if !ok {
+		return 0, false, nil
+	}
+
+	value, err = strconv.ParseFloat(strVal, 64)
+
+	if err != nil {
+		return 0, false, fmt.Errorf("could not parse prometheus value %s: %v", strVal, err)
+	}
+
+	// NaN is returned when there was no traffic in the queried range
+	if math.IsNaN(value) {
+		return 0, false, nil
+	}
+
+	return value, true, nil
+}
+
 func getSelectionRegex(kind, name string) (string, error) {
 	var suffix string
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/porter-dev/porter/internal/analytics"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/repository"
 )
 
@@ -86,6 +88,7 @@ func GlobalStreamListener(
 	client *redis.Client,
 	repo repository.Repository,
 	analyticsClient analytics.AnalyticsSegmentClient,
+	notificationRegistry *notifier.Registry,
 	errorChan chan error,
 ) {
 	for {
@@ -124,6 +127,8 @@ func GlobalStreamListener(
 					continue
 				}
 
+				notifyProvisioningDone(repo, notificationRegistry, infra)
+
 				// create ECR/EKS
 				if kind == string(types.InfraECR) {
 					reg := &models.Registry{
@@ -429,3 +434,24 @@ func GlobalStreamListener(
 		}
 	}
 }
+
+// notifyProvisioningDone sends a provisioning_done event to the notification channels
+// of the infra's project
+func notifyProvisioningDone(repo repository.Repository, registry *notifier.Registry, infra *models.Infra) {
+	projNotifier, err := notifier.NewProjectNotifier(registry, repo, &notifier.ProjectNotifierOpts{
+		ProjectID: infra.ProjectID,
+	})
+
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+
+	projNotifier.Notify(&notifier.Event{
+		Type:      types.NotificationEventProvisioningDone,
+		ProjectID: infra.ProjectID,
+		Name:      infra.GetUniqueName(),
+		Timestamp: &now,
+	})
+}
@@ -0,0 +1,149 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// WaitForJobPod waits for the pod of a job to be created and for the given container of
+// the pod to start, which can be an init container. The pod is returned once the
+// container is running or has terminated.
+func (a *Agent) WaitForJobPod(namespace, jobName, container string, timeout time.Duration) (*v1.Pod, error) {
+	for timeWait := time.Now().Add(timeout); time.Now().Before(timeWait); time.Sleep(2 * time.Second) {
+		pods, err := a.GetJobPods(namespace, jobName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range pods {
+			statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+
+			for _, status := range statuses {
+				if status.Name != container {
+					continue
+				}
+
+				if status.State.Running != nil || status.State.Terminated != nil {
+					return &pod, nil
+				}
+
+				// fail early on images which cannot be pulled, which never start
+				if waiting := status.State.Waiting; waiting != nil &&
+					(waiting.Reason == "ErrImagePull" || waiting.Reason == "ImagePullBackOff" || waiting.Reason == "InvalidImageName") {
+					return nil, fmt.Errorf("container %s of job %s cannot start: %s", container, jobName, waiting.Message)
+				}
+			}
+
+			if pod.Status.Phase == v1.PodFailed {
+				return nil, fmt.Errorf("pod of job %s failed: %s", jobName, pod.Status.Message)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("timed out waiting for container %s of job %s to start", container, jobName)
+}
+
+// AttachToContainer writes stdin to the stdin of a running container, and returns once
+// stdin has been consumed and the container has closed its output
+func (a *Agent) AttachToContainer(namespace, podName, container string, stdin io.Reader) error {
+	restConf, err := a.RESTClientGetter.ToRESTConfig()
+
+	if err != nil {
+		return err
+	}
+
+	req := a.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("attach").
+		VersionedParams(&v1.PodAttachOptions{
+			Container: container,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(restConf, "POST", req.URL())
+
+	if err != nil {
+		return err
+	}
+
+	output := &bytes.Buffer{}
+
+	err = exec.Stream(remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: output,
+		Stderr: output,
+	})
+
+	if err != nil {
+		if out := strings.TrimSpace(output.String()); out != "" {
+			return fmt.Errorf("%v: %s", err, out)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// FollowJobLogs waits for a container of the pod of a job to start, and writes the logs
+// of the container to w until the container terminates
+func (a *Agent) FollowJobLogs(namespace, jobName, container string, timeout time.Duration, w io.Writer) error {
+	pod, err := a.WaitForJobPod(namespace, jobName, container, timeout)
+
+	if err != nil {
+		return err
+	}
+
+	podLogs, err := a.Clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container: container,
+		Follow:    true,
+	}).Stream(context.Background())
+
+	if err != nil {
+		return fmt.Errorf("Cannot open log stream for pod %s: %s", pod.Name, err.Error())
+	}
+
+	defer podLogs.Close()
+
+	_, err = io.Copy(w, podLogs)
+
+	return err
+}
+
+// WaitForJobCompletion waits for a job to either complete or fail, and returns the job
+func (a *Agent) WaitForJobCompletion(namespace, jobName string, timeout time.Duration) (*batchv1.Job, error) {
+	for timeWait := time.Now().Add(timeout); time.Now().Before(timeWait); time.Sleep(2 * time.Second) {
+		job, err := a.Clientset.BatchV1().Jobs(namespace).Get(
+			context.Background(),
+			jobName,
+			metav1.GetOptions{},
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cond := range job.Status.Conditions {
+			if cond.Status == v1.ConditionTrue && (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) {
+				return job, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("timed out waiting for job %s to complete", jobName)
+}
@@ -0,0 +1,322 @@
+package remotebuild
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelKey is set on the jobs of remote builds
+	LabelKey = "porter.run/remote-build"
+
+	// ContextContainerName is the init container which reads the build context from stdin
+	ContextContainerName = "context"
+
+	// BuildContainerName is the container which builds and pushes the image
+	BuildContainerName = "build"
+
+	KanikoImage  = "gcr.io/kaniko-project/executor:v1.7.0"
+	ContextImage = "busybox:1.34"
+
+	// DefaultBuilder is the builder used by buildpack builds, matching local builds
+	DefaultBuilder = "paketobuildpacks/builder:full"
+
+	workspacePath    = "/workspace"
+	layersPath       = "/layers"
+	platformPath     = "/platform"
+	dockerConfigPath = "/porter/docker"
+)
+
+var (
+	envNameRegex     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	invalidNameRegex = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// BuildOpts are the options of a remote build
+type BuildOpts struct {
+	Name      string
+	Namespace string
+
+	// Method is either "docker" or "pack"
+	Method string
+
+	ImageRepo string
+	Tag       string
+
+	// Dockerfile is the path of the Dockerfile relative to the build context
+	Dockerfile string
+
+	Builder    string
+	Buildpacks []string
+
+	// Env are build args for Docker builds, and platform env for buildpack builds
+	Env map[string]string
+
+	// DockerConfigSecret is a secret of type kubernetes.io/dockerconfigjson with the
+	// credentials of the registry which the image is pushed to
+	DockerConfigSecret string
+}
+
+// GetJobName returns a unique name for the job of a build of an image repository
+func GetJobName(imageRepo string) (string, error) {
+	suffix := make([]byte, 4)
+
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+
+	name := strings.ToLower(path.Base(imageRepo))
+	name = invalidNameRegex.ReplaceAllString(name, "-")
+	name = strings.Trim(name, "-")
+
+	// job names are used as pod labels, which are at most 63 characters
+	if len(name) > 40 {
+		name = strings.Trim(name[:40], "-")
+	}
+
+	if name == "" {
+		name = "image"
+	}
+
+	return fmt.Sprintf("porter-build-%s-%s", name, hex.EncodeToString(suffix)), nil
+}
+
+// GetJobTemplate returns the job which runs a remote build. The pod of the job starts
+// with an init container which extracts a gzipped tar of the build context from stdin,
+// after which the image is built and pushed without a Docker daemon, by kaniko for Docker
+// builds and by the buildpack lifecycle for buildpack builds.
+func GetJobTemplate(opts *BuildOpts) (*batchv1.Job, error) {
+	for key := range opts.Env {
+		if !envNameRegex.MatchString(key) {
+			return nil, fmt.Errorf("invalid build environment variable name %s", key)
+		}
+	}
+
+	labels := map[string]string{
+		LabelKey: "true",
+	}
+
+	ttl := int32(3600)
+	backoffLimit := int32(0)
+	deadline := int64(3600)
+
+	volumes := []v1.Volume{
+		{
+			Name: "workspace",
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: "docker-config",
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: opts.DockerConfigSecret,
+					Items: []v1.KeyToPath{
+						{
+							Key:  v1.DockerConfigJsonKey,
+							Path: "config.json",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	workspaceMount := v1.VolumeMount{
+		Name:      "workspace",
+		MountPath: workspacePath,
+	}
+
+	initContainers := []v1.Container{
+		{
+			Name:  ContextContainerName,
+			Image: ContextImage,
+			Command: []string{
+				"sh",
+				"-c",
+				// buildpacks run as a non-root user, which must be able to write to the app
+				fmt.Sprintf("tar -xzf - -C %s && chmod -R a+rwX %s", workspacePath, workspacePath),
+			},
+			Stdin:        true,
+			StdinOnce:    true,
+			VolumeMounts: []v1.VolumeMount{workspaceMount},
+		},
+	}
+
+	var buildContainer v1.Container
+
+	switch opts.Method {
+	case "docker":
+		buildContainer = getKanikoContainer(opts)
+	case "pack":
+		if len(opts.Buildpacks) > 0 {
+			return nil, fmt.Errorf("remote buildpack builds only support the buildpacks of the builder")
+		}
+
+		volumes = append(
+			volumes,
+			v1.Volume{
+				Name: "layers",
+				VolumeSource: v1.VolumeSource{
+					EmptyDir: &v1.EmptyDirVolumeSource{},
+				},
+			},
+			v1.Volume{
+				Name: "platform",
+				VolumeSource: v1.VolumeSource{
+					EmptyDir: &v1.EmptyDirVolumeSource{},
+				},
+			},
+		)
+
+		if len(opts.Env) > 0 {
+			initContainers = append(initContainers, getPlatformEnvContainer(opts))
+		}
+
+		buildContainer = getLifecycleContainer(opts)
+	default:
+		return nil, fmt.Errorf("build method should either be \"docker\" or \"pack\"")
+	}
+
+	buildContainer.Name = BuildContainerName
+	buildContainer.VolumeMounts = append(
+		buildContainer.VolumeMounts,
+		workspaceMount,
+		v1.VolumeMount{
+			Name:      "docker-config",
+			MountPath: dockerConfigPath,
+			ReadOnly:  true,
+		},
+	)
+	buildContainer.Env = append(buildContainer.Env, v1.EnvVar{
+		Name:  "DOCKER_CONFIG",
+		Value: dockerConfigPath,
+	})
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opts.Name,
+			Namespace: opts.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			TTLSecondsAfterFinished: &ttl,
+			BackoffLimit:            &backoffLimit,
+			ActiveDeadlineSeconds:   &deadline,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy:  v1.RestartPolicyNever,
+					InitContainers: initContainers,
+					Containers:     []v1.Container{buildContainer},
+					Volumes:        volumes,
+				},
+			},
+		},
+	}, nil
+}
+
+func getKanikoContainer(opts *BuildOpts) v1.Container {
+	dockerfile := opts.Dockerfile
+
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+
+	args := []string{
+		fmt.Sprintf("--context=dir://%s", workspacePath),
+		fmt.Sprintf("--dockerfile=%s", path.Join(workspacePath, dockerfile)),
+		fmt.Sprintf("--destination=%s:%s", opts.ImageRepo, opts.Tag),
+	}
+
+	for _, key := range sortedKeys(opts.Env) {
+		args = append(args, fmt.Sprintf("--build-arg=%s=%s", key, opts.Env[key]))
+	}
+
+	return v1.Container{
+		Image: KanikoImage,
+		Args:  args,
+	}
+}
+
+// getPlatformEnvContainer writes the build env to the platform directory, which is
+// where the buildpack lifecycle reads the env of buildpacks from
+func getPlatformEnvContainer(opts *BuildOpts) v1.Container {
+	script := []string{fmt.Sprintf("mkdir -p %s/env", platformPath)}
+	env := make([]v1.EnvVar, 0)
+
+	for _, key := range sortedKeys(opts.Env) {
+		script = append(script, fmt.Sprintf(`printf '%%s' "$%s" > %s/env/%s`, key, platformPath, key))
+
+		env = append(env, v1.EnvVar{
+			Name:  key,
+			Value: opts.Env[key],
+		})
+	}
+
+	return v1.Container{
+		Name:    "platform-env",
+		Image:   ContextImage,
+		Command: []string{"sh", "-c", strings.Join(script, " && ")},
+		Env:     env,
+		VolumeMounts: []v1.VolumeMount{
+			{
+				Name:      "platform",
+				MountPath: platformPath,
+			},
+		},
+	}
+}
+
+func getLifecycleContainer(opts *BuildOpts) v1.Container {
+	builder := opts.Builder
+
+	if builder == "" {
+		builder = DefaultBuilder
+	}
+
+	return v1.Container{
+		Image: builder,
+		Command: []string{
+			"/cnb/lifecycle/creator",
+			fmt.Sprintf("-app=%s", workspacePath),
+			fmt.Sprintf("-layers=%s", layersPath),
+			fmt.Sprintf("-platform=%s", platformPath),
+			fmt.Sprintf("%s:%s", opts.ImageRepo, opts.Tag),
+		},
+		VolumeMounts: []v1.VolumeMount{
+			{
+				Name:      "layers",
+				MountPath: layersPath,
+			},
+			{
+				Name:      "platform",
+				MountPath: platformPath,
+			},
+		},
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
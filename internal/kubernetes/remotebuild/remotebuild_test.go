@@ -0,0 +1,245 @@
+package remotebuild_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/kubernetes/remotebuild"
+	v1 "k8s.io/api/core/v1"
+)
+
+type jobTemplateTest struct {
+	name           string
+	opts           *remotebuild.BuildOpts
+	expErr         string
+	expInit        []string
+	expImage       string
+	expArgs        []string
+	expCommand     []string
+	expVolumeCount int
+}
+
+var jobTemplateTests = []jobTemplateTest{
+	{
+		name: "docker",
+		opts: &remotebuild.BuildOpts{
+			Name:               "porter-build-web-abcd1234",
+			Namespace:          "default",
+			Method:             "docker",
+			ImageRepo:          "gcr.io/project/web",
+			Tag:                "1234567",
+			Dockerfile:         "docker/prod.Dockerfile",
+			Env:                map[string]string{"NODE_ENV": "production", "API_URL": "https://api.example.com"},
+			DockerConfigSecret: "porter-gcr-1",
+		},
+		expInit:  []string{remotebuild.ContextContainerName},
+		expImage: remotebuild.KanikoImage,
+		expArgs: []string{
+			"--context=dir:///workspace",
+			"--dockerfile=/workspace/docker/prod.Dockerfile",
+			"--destination=gcr.io/project/web:1234567",
+			"--build-arg=API_URL=https://api.example.com",
+			"--build-arg=NODE_ENV=production",
+		},
+		expVolumeCount: 2,
+	},
+	{
+		name: "docker with default dockerfile",
+		opts: &remotebuild.BuildOpts{
+			Method:    "docker",
+			ImageRepo: "gcr.io/project/web",
+			Tag:       "latest",
+		},
+		expInit:  []string{remotebuild.ContextContainerName},
+		expImage: remotebuild.KanikoImage,
+		expArgs: []string{
+			"--context=dir:///workspace",
+			"--dockerfile=/workspace/Dockerfile",
+			"--destination=gcr.io/project/web:latest",
+		},
+		expVolumeCount: 2,
+	},
+	{
+		name: "pack with env",
+		opts: &remotebuild.BuildOpts{
+			Method:    "pack",
+			ImageRepo: "gcr.io/project/web",
+			Tag:       "latest",
+			Builder:   "heroku/buildpacks:20",
+			Env:       map[string]string{"BP_NODE_VERSION": "16"},
+		},
+		expInit:  []string{remotebuild.ContextContainerName, "platform-env"},
+		expImage: "heroku/buildpacks:20",
+		expCommand: []string{
+			"/cnb/lifecycle/creator",
+			"-app=/workspace",
+			"-layers=/layers",
+			"-platform=/platform",
+			"gcr.io/project/web:latest",
+		},
+		expVolumeCount: 4,
+	},
+	{
+		name: "pack with default builder",
+		opts: &remotebuild.BuildOpts{
+			Method:    "pack",
+			ImageRepo: "gcr.io/project/web",
+			Tag:       "latest",
+		},
+		expInit:  []string{remotebuild.ContextContainerName},
+		expImage: remotebuild.DefaultBuilder,
+		expCommand: []string{
+			"/cnb/lifecycle/creator",
+			"-app=/workspace",
+			"-layers=/layers",
+			"-platform=/platform",
+			"gcr.io/project/web:latest",
+		},
+		expVolumeCount: 4,
+	},
+	{
+		name: "pack with buildpacks",
+		opts: &remotebuild.BuildOpts{
+			Method:     "pack",
+			ImageRepo:  "gcr.io/project/web",
+			Tag:        "latest",
+			Buildpacks: []string{"heroku/python"},
+		},
+		expErr: "remote buildpack builds only support the buildpacks of the builder",
+	},
+	{
+		name: "invalid env name",
+		opts: &remotebuild.BuildOpts{
+			Method:    "docker",
+			ImageRepo: "gcr.io/project/web",
+			Tag:       "latest",
+			Env:       map[string]string{"NODE ENV": "production"},
+		},
+		expErr: "invalid build environment variable name NODE ENV",
+	},
+	{
+		name: "invalid method",
+		opts: &remotebuild.BuildOpts{
+			Method:    "registry",
+			ImageRepo: "gcr.io/project/web",
+			Tag:       "latest",
+		},
+		expErr: "build method should either be \"docker\" or \"pack\"",
+	},
+}
+
+func TestGetJobTemplate(t *testing.T) {
+	for _, test := range jobTemplateTests {
+		job, err := remotebuild.GetJobTemplate(test.opts)
+
+		if test.expErr != "" {
+			if err == nil || err.Error() != test.expErr {
+				t.Errorf("%s: expected error %q, got %v", test.name, test.expErr, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		if job.Labels[remotebuild.LabelKey] != "true" || job.Spec.Template.Labels[remotebuild.LabelKey] != "true" {
+			t.Errorf("%s: expected remote build label on job and pod", test.name)
+		}
+
+		podSpec := job.Spec.Template.Spec
+
+		if podSpec.RestartPolicy != v1.RestartPolicyNever || *job.Spec.BackoffLimit != 0 {
+			t.Errorf("%s: expected build to not be retried", test.name)
+		}
+
+		initNames := make([]string, 0)
+
+		for _, container := range podSpec.InitContainers {
+			initNames = append(initNames, container.Name)
+		}
+
+		if !reflect.DeepEqual(initNames, test.expInit) {
+			t.Errorf("%s: expected init containers %v, got %v", test.name, test.expInit, initNames)
+		}
+
+		if !podSpec.InitContainers[0].Stdin || !podSpec.InitContainers[0].StdinOnce {
+			t.Errorf("%s: expected context container to read from stdin once", test.name)
+		}
+
+		if len(podSpec.Containers) != 1 {
+			t.Fatalf("%s: expected 1 container, got %d", test.name, len(podSpec.Containers))
+		}
+
+		build := podSpec.Containers[0]
+
+		if build.Name != remotebuild.BuildContainerName {
+			t.Errorf("%s: expected container name %s, got %s", test.name, remotebuild.BuildContainerName, build.Name)
+		}
+
+		if build.Image != test.expImage {
+			t.Errorf("%s: expected image %s, got %s", test.name, test.expImage, build.Image)
+		}
+
+		if len(build.Args) != 0 || len(test.expArgs) != 0 {
+			if !reflect.DeepEqual(build.Args, test.expArgs) {
+				t.Errorf("%s: expected args %v, got %v", test.name, test.expArgs, build.Args)
+			}
+		}
+
+		if len(build.Command) != 0 || len(test.expCommand) != 0 {
+			if !reflect.DeepEqual(build.Command, test.expCommand) {
+				t.Errorf("%s: expected command %v, got %v", test.name, test.expCommand, build.Command)
+			}
+		}
+
+		if len(podSpec.Volumes) != test.expVolumeCount {
+			t.Errorf("%s: expected %d volumes, got %d", test.name, test.expVolumeCount, len(podSpec.Volumes))
+		}
+
+		for _, volume := range podSpec.Volumes {
+			if volume.Name == "docker-config" && volume.Secret.SecretName != test.opts.DockerConfigSecret {
+				t.Errorf("%s: expected docker config secret %s, got %s", test.name, test.opts.DockerConfigSecret, volume.Secret.SecretName)
+			}
+		}
+
+		hasDockerConfig := false
+
+		for _, env := range build.Env {
+			if env.Name == "DOCKER_CONFIG" && env.Value == "/porter/docker" {
+				hasDockerConfig = true
+			}
+		}
+
+		if !hasDockerConfig {
+			t.Errorf("%s: expected DOCKER_CONFIG to be set on build container", test.name)
+		}
+	}
+}
+
+func TestGetJobName(t *testing.T) {
+	tests := map[string]*regexp.Regexp{
+		"gcr.io/project/web":                  regexp.MustCompile(`^porter-build-web-[0-9a-f]{8}$`),
+		"registry.example.com/team/My_App.v2": regexp.MustCompile(`^porter-build-my-app-v2-[0-9a-f]{8}$`),
+		"gcr.io/project/___":                  regexp.MustCompile(`^porter-build-image-[0-9a-f]{8}$`),
+		"gcr.io/project/" + "averyveryveryveryveryveryverylongimagename-with-suffix": regexp.MustCompile(`^porter-build-averyveryveryveryveryveryverylongimagena-[0-9a-f]{8}$`),
+	}
+
+	for imageRepo, exp := range tests {
+		name, err := remotebuild.GetJobName(imageRepo)
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", imageRepo, err)
+		}
+
+		if !exp.MatchString(name) {
+			t.Errorf("%s: expected name to match %s, got %s", imageRepo, exp.String(), name)
+		}
+
+		if len(name) > 63 {
+			t.Errorf("%s: expected name of at most 63 characters, got %d", imageRepo, len(name))
+		}
+	}
+}
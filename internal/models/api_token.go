@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// APIToken is a persisted, revocable token that is issued for a project
+type APIToken struct {
+	gorm.Model
+
+	UniqueID string `gorm:"unique"`
+
+	ProjectID       uint
+	CreatedByUserID uint
+
+	Name     string
+	Expiry   *time.Time
+	LastUsed *time.Time
+	Revoked  bool
+
+	// PolicyBytes is the JSON-encoded types.Policy attached to the token
+	PolicyBytes []byte
+}
+
+// IsExpired returns true if the token has an expiry which has passed
+func (a *APIToken) IsExpired() bool {
+	return a.Expiry != nil && a.Expiry.Before(time.Now())
+}
+
+// GetPolicy returns the decoded policy attached to the token, or nil if the token
+// does not have a policy
+func (a *APIToken) GetPolicy() (types.Policy, error) {
+	if len(a.PolicyBytes) == 0 {
+		return nil, nil
+	}
+
+	policy := types.Policy{}
+
+	if err := json.Unmarshal(a.PolicyBytes, &policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ToAPITokenType generates an external APIToken to be shared over REST
+func (a *APIToken) ToAPITokenType() *types.APIToken {
+	// an error here would mean the stored policy was corrupted, in which case we
+	// return the token without the policy
+	policy, _ := a.GetPolicy()
+
+	return &types.APIToken{
+		ID:              a.UniqueID,
+		CreatedAt:       a.CreatedAt,
+		ProjectID:       a.ProjectID,
+		CreatedByUserID: a.CreatedByUserID,
+		Name:            a.Name,
+		Expiry:          a.Expiry,
+		LastUsed:        a.LastUsed,
+		Revoked:         a.Revoked,
+		Policy:          policy,
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ApplyManifest records the releases which were deployed by applying a porter.yaml file,
+// so that releases which are removed from the file can be pruned on the next apply
+type ApplyManifest struct {
+	gorm.Model
+
+	ProjectID uint
+	ClusterID uint
+	Namespace string
+
+	// Name identifies the porter.yaml file within the namespace
+	Name string
+
+	// Resources is the JSON-encoded list of releases deployed by the last apply
+	Resources []byte
+}
+
+// GetResources returns the releases deployed by the last apply
+func (a *ApplyManifest) GetResources() ([]*types.ApplyManifestResource, error) {
+	res := make([]*types.ApplyManifestResource, 0)
+
+	if len(a.Resources) == 0 {
+		return res, nil
+	}
+
+	if err := json.Unmarshal(a.Resources, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SetResources sets the releases deployed by the last apply
+func (a *ApplyManifest) SetResources(resources []*types.ApplyManifestResource) error {
+	bytes, err := json.Marshal(resources)
+
+	if err != nil {
+		return err
+	}
+
+	a.Resources = bytes
+
+	return nil
+}
+
+func (a *ApplyManifest) ToApplyManifestType() (*types.ApplyManifest, error) {
+	resources, err := a.GetResources()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ApplyManifest{
+		ID:        a.ID,
+		UpdatedAt: a.UpdatedAt,
+		Name:      a.Name,
+		Namespace: a.Namespace,
+		Resources: resources,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// AuditEvent is a record of a mutating API call
+type AuditEvent struct {
+	gorm.Model
+
+	ProjectID   uint `gorm:"index"`
+	ClusterID   uint
+	Namespace   string
+	ReleaseName string
+
+	UserID     uint
+	APITokenID string
+
+	Verb   string
+	Method string
+
+	// Path is the route template of the request, such as /api/projects/{project_id}/api_token
+	Path string
+
+	// RequestSummary is the JSON-encoded, redacted summary of the request body
+	RequestSummary []byte
+
+	Status int
+}
+
+// ToAuditEventType generates an external AuditEvent to be shared over REST
+func (a *AuditEvent) ToAuditEventType() *types.AuditEvent {
+	var summary map[string]interface{}
+
+	if len(a.RequestSummary) > 0 {
+		// a summary that cannot be decoded is omitted
+		json.Unmarshal(a.RequestSummary, &summary)
+	}
+
+	return &types.AuditEvent{
+		ID:             a.ID,
+		CreatedAt:      a.CreatedAt,
+		ProjectID:      a.ProjectID,
+		ClusterID:      a.ClusterID,
+		Namespace:      a.Namespace,
+		ReleaseName:    a.ReleaseName,
+		UserID:         a.UserID,
+		APITokenID:     a.APITokenID,
+		Verb:           types.APIVerb(a.Verb),
+		Method:         a.Method,
+		Path:           a.Path,
+		RequestSummary: summary,
+		Status:         a.Status,
+	}
+}
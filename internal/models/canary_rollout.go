@@ -0,0 +1,28 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// CanaryRollout is the state of a canary rollout of a release, which is stored so that
+// rollouts interrupted by a restart of the server can be cleaned up
+type CanaryRollout struct {
+	gorm.Model
+
+	ProjectID   uint
+	ClusterID   uint
+	Namespace   string
+	ReleaseName string
+
+	Status types.CanaryRolloutStatus
+
+	// Weight is the percentage of traffic sent to the canary at the current step
+	Weight int
+
+	// StepIntervalSeconds is how long each step of the rollout runs, which is how long
+	// the rollout can go without updating its state
+	StepIntervalSeconds uint
+
+	Error string
+}
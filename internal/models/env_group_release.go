@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// EnvGroupRelease links a release to an env group that it consumes, along with the
+// policy for redeploying the release when the env group is updated
+type EnvGroupRelease struct {
+	gorm.Model
+
+	ProjectID    uint
+	ClusterID    uint
+	Namespace    string
+	EnvGroupName string
+	ReleaseName  string
+
+	RedeployPolicy types.EnvGroupRedeployPolicy
+}
+
+func (e *EnvGroupRelease) ToEnvGroupReleaseType() *types.EnvGroupRelease {
+	return &types.EnvGroupRelease{
+		ID:             e.ID,
+		EnvGroupName:   e.EnvGroupName,
+		Namespace:      e.Namespace,
+		ReleaseName:    e.ReleaseName,
+		RedeployPolicy: e.RedeployPolicy,
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// EnvGroupSecretRef is a secret variable of an env group whose value is synced from an
+// external secret store into the linked secret of the env group
+type EnvGroupSecretRef struct {
+	gorm.Model
+
+	ProjectID    uint
+	ClusterID    uint
+	Namespace    string
+	EnvGroupName string
+
+	// Key is the name of the secret variable
+	Key string
+
+	Backend          types.EnvGroupSecretBackend
+	Path             string
+	Field            string
+	AWSIntegrationID uint
+
+	SyncStatus   types.EnvGroupSecretSyncStatus
+	SyncError    string
+	LastSyncedAt *time.Time
+}
+
+func (e *EnvGroupSecretRef) ToEnvGroupSecretStatusType() *types.EnvGroupSecretStatus {
+	return &types.EnvGroupSecretStatus{
+		Key: e.Key,
+		EnvGroupSecretReference: &types.EnvGroupSecretReference{
+			Backend:          e.Backend,
+			Path:             e.Path,
+			Field:            e.Field,
+			AWSIntegrationID: e.AWSIntegrationID,
+		},
+		Status:       e.SyncStatus,
+		Error:        e.SyncError,
+		LastSyncedAt: e.LastSyncedAt,
+	}
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// EnvGroupVersion is a numbered snapshot of the variables of an env group
+type EnvGroupVersion struct {
+	gorm.Model
+
+	ProjectID uint
+	ClusterID uint
+	Namespace string
+	Name      string
+	Version   uint
+
+	// Author is the email of the user who made the change
+	Author string
+
+	// Variables is the JSON-encoded map of variables which are not secret
+	Variables []byte
+
+	// SecretHashes is the JSON-encoded map of secret variables to the hashes of their
+	// values, which are used to show which secrets changed between versions. The hashes
+	// are never returned by the API.
+	SecretHashes []byte
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// SecretValues is the JSON-encoded map of secret variables, which is used to restore
+	// the secrets when rolling back to this version
+	SecretValues []byte
+}
+
+// GetVariables returns the variables of this version which are not secret
+func (v *EnvGroupVersion) GetVariables() (map[string]string, error) {
+	return decodeStringMap(v.Variables)
+}
+
+// GetSecretHashes returns the hashes of the secret variables of this version
+func (v *EnvGroupVersion) GetSecretHashes() (map[string]string, error) {
+	return decodeStringMap(v.SecretHashes)
+}
+
+// GetSecretValues returns the values of the secret variables of this version
+func (v *EnvGroupVersion) GetSecretValues() (map[string]string, error) {
+	return decodeStringMap(v.SecretValues)
+}
+
+// ToEnvGroupVersionType returns the API type of this version. The hashes of secret
+// values are not returned; each secret variable only reports whether its value changed
+// since the previous version, which is nil for the first version.
+func (v *EnvGroupVersion) ToEnvGroupVersionType(previous *EnvGroupVersion) (*types.EnvGroupVersion, error) {
+	variables, err := v.GetVariables()
+
+	if err != nil {
+		return nil, err
+	}
+
+	secretHashes, err := v.GetSecretHashes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	prevSecretHashes := make(map[string]string)
+
+	if previous != nil {
+		if prevSecretHashes, err = previous.GetSecretHashes(); err != nil {
+			return nil, err
+		}
+	}
+
+	secretVariables := make(map[string]bool)
+
+	for key, hash := range secretHashes {
+		prevHash, ok := prevSecretHashes[key]
+		secretVariables[key] = !ok || prevHash != hash
+	}
+
+	return &types.EnvGroupVersion{
+		ID:              v.ID,
+		CreatedAt:       v.CreatedAt,
+		Name:            v.Name,
+		Namespace:       v.Namespace,
+		Version:         v.Version,
+		Author:          v.Author,
+		Variables:       variables,
+		SecretVariables: secretVariables,
+	}, nil
+}
+
+func decodeStringMap(data []byte) (map[string]string, error) {
+	res := make(map[string]string)
+
+	if len(data) == 0 {
+		return res, nil
+	}
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -15,6 +15,10 @@ type Environment struct {
 	GitRepoName       string
 
 	Name string
+
+	// APITokenID is the unique id of the cluster-scoped API token issued to the
+	// preview environment workflows, which is revoked when the environment is deleted
+	APITokenID string
 }
 
 func (e *Environment) ToEnvironmentType() *types.Environment {
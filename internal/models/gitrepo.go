@@ -53,6 +53,10 @@ type GitActionConfig struct {
 	IsInstallation bool `json:"is_installation"`
 
 	Version string `json:"version" gorm:"default:v0.0.1"`
+
+	// The unique id of the cluster-scoped API token issued to the action, which is
+	// revoked when the release is deleted
+	APITokenID string `json:"-"`
 }
 
 // ToGitActionConfigType generates an external GitActionConfig to be shared over REST
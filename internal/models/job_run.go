@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// JobRun is a record of a single run of a job release, which is kept after the
+// Kubernetes job has been garbage collected
+type JobRun struct {
+	gorm.Model
+
+	ProjectID   uint
+	ClusterID   uint
+	Namespace   string
+	ReleaseName string
+
+	JobName string
+
+	// JobUID is the uid of the Kubernetes job, which distinguishes runs that reuse the
+	// same job name
+	JobUID string `gorm:"unique"`
+
+	Status     types.JobRunStatus
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	ExitCode   *int32
+
+	TriggeredBy string
+	ImageTag    string
+
+	LogTail string
+}
+
+// ToJobRunType converts the job run to its API type. The log tail is only included if
+// withLogs is set, since it is large compared to the rest of the record.
+func (j *JobRun) ToJobRunType(withLogs bool) *types.JobRun {
+	res := &types.JobRun{
+		ID:          j.ID,
+		CreatedAt:   j.CreatedAt,
+		ProjectID:   j.ProjectID,
+		ClusterID:   j.ClusterID,
+		Namespace:   j.Namespace,
+		ReleaseName: j.ReleaseName,
+		JobName:     j.JobName,
+		Status:      j.Status,
+		StartedAt:   j.StartedAt,
+		FinishedAt:  j.FinishedAt,
+		ExitCode:    j.ExitCode,
+		TriggeredBy: j.TriggeredBy,
+		ImageTag:    j.ImageTag,
+	}
+
+	if withLogs {
+		res.LogTail = j.LogTail
+	}
+
+	return res
+}
@@ -54,3 +54,31 @@ func (conf *JobNotificationConfig) ShouldNotify() bool {
 	// check the last notified time against the notification limit
 	return conf.LastNotifiedTime.Before(time.Now().Add(-24 * time.Hour))
 }
+
+// DefaultAutoRollbackWindowSeconds is the default time that controllers have to
+// become ready after an upgrade before the release is rolled back
+const DefaultAutoRollbackWindowSeconds = 300
+
+type AutoRollbackConfig struct {
+	gorm.Model
+
+	Enabled bool
+
+	WindowSeconds uint
+}
+
+func (conf *AutoRollbackConfig) ToAutoRollbackConfigType() *types.AutoRollbackConfig {
+	return &types.AutoRollbackConfig{
+		Enabled:       conf.Enabled,
+		WindowSeconds: conf.WindowSeconds,
+	}
+}
+
+// GetWindow returns the time that controllers have to become ready after an upgrade
+func (conf *AutoRollbackConfig) GetWindow() time.Duration {
+	if conf.WindowSeconds == 0 {
+		return DefaultAutoRollbackWindowSeconds * time.Second
+	}
+
+	return time.Duration(conf.WindowSeconds) * time.Second
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// NotificationChannel is a destination for project or release notifications, delivered
+// by the notifier registered for its kind
+type NotificationChannel struct {
+	gorm.Model
+
+	ProjectID uint
+
+	// ReleaseID restricts the channel to events for a single release. If 0, the channel
+	// receives events for the entire project.
+	ReleaseID uint
+
+	Name string
+	Kind types.NotificationChannelKind
+
+	// Events is a comma-separated list of the event types routed to this channel. If
+	// empty, all events are routed to this channel.
+	Events string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// Config is the JSON-encoded types.NotificationChannelConfig
+	Config []byte
+}
+
+// GetConfig returns the decoded configuration of this channel
+func (c *NotificationChannel) GetConfig() (*types.NotificationChannelConfig, error) {
+	conf := &types.NotificationChannelConfig{}
+
+	if err := json.Unmarshal(c.Config, conf); err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+// GetEvents returns the list of event types routed to this channel
+func (c *NotificationChannel) GetEvents() []types.NotificationEventType {
+	res := make([]types.NotificationEventType, 0)
+
+	if c.Events == "" {
+		return res
+	}
+
+	for _, event := range strings.Split(c.Events, ",") {
+		res = append(res, types.NotificationEventType(event))
+	}
+
+	return res
+}
+
+// SetEvents sets the list of event types routed to this channel
+func (c *NotificationChannel) SetEvents(events []types.NotificationEventType) {
+	strEvents := make([]string, 0, len(events))
+
+	for _, event := range events {
+		strEvents = append(strEvents, string(event))
+	}
+
+	c.Events = strings.Join(strEvents, ",")
+}
+
+// Routes returns true if events of the given type should be sent to this channel
+func (c *NotificationChannel) Routes(event types.NotificationEventType) bool {
+	events := c.GetEvents()
+
+	if len(events) == 0 {
+		return true
+	}
+
+	for _, e := range events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *NotificationChannel) ToNotificationChannelType() *types.NotificationChannel {
+	return &types.NotificationChannel{
+		ID:        c.ID,
+		CreatedAt: c.CreatedAt,
+		ProjectID: c.ProjectID,
+		ReleaseID: c.ReleaseID,
+		Name:      c.Name,
+		Kind:      c.Kind,
+		Events:    c.GetEvents(),
+	}
+}
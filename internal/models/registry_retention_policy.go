@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// RegistryRetentionPolicy decides which images are deleted from the repositories of a
+// registry when the policy is enforced on schedule
+type RegistryRetentionPolicy struct {
+	gorm.Model
+
+	ProjectID  uint
+	RegistryID uint
+
+	// RepositoryName is empty if the policy applies to every repository of the registry
+	RepositoryName string
+
+	KeepLastTags            uint
+	DeleteUntaggedAfterDays uint
+	ProtectLiveReleases     bool
+	DryRun                  bool
+
+	LastRunAt *time.Time
+
+	// LastReport is the JSON-encoded report of the last time the policy was enforced
+	LastReport []byte
+}
+
+// ToRegistryRetentionPolicyType returns the policy without its last report, which
+// is read with GetLastReport
+func (p *RegistryRetentionPolicy) ToRegistryRetentionPolicyType() *types.RegistryRetentionPolicy {
+	return &types.RegistryRetentionPolicy{
+		ID:                      p.ID,
+		ProjectID:               p.ProjectID,
+		RegistryID:              p.RegistryID,
+		RepositoryName:          p.RepositoryName,
+		KeepLastTags:            p.KeepLastTags,
+		DeleteUntaggedAfterDays: p.DeleteUntaggedAfterDays,
+		ProtectLiveReleases:     p.ProtectLiveReleases,
+		DryRun:                  p.DryRun,
+		LastRunAt:               p.LastRunAt,
+	}
+}
+
+// GetLastReport decodes the report of the last time the policy was enforced, and
+// returns nil if the policy has never run
+func (p *RegistryRetentionPolicy) GetLastReport() (*types.RegistryRetentionReport, error) {
+	if len(p.LastReport) == 0 {
+		return nil, nil
+	}
+
+	report := &types.RegistryRetentionReport{}
+
+	if err := json.Unmarshal(p.LastReport, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -25,6 +25,7 @@ type Release struct {
 	EventContainer     uint
 	NotificationConfig uint
 	BuildConfig        uint
+	AutoRollbackConfig uint
 }
 
 func (r *Release) ToReleaseType() *types.PorterRelease {
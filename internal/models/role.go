@@ -1,6 +1,8 @@
 package models
 
 import (
+	"encoding/json"
+
 	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
 )
@@ -20,8 +22,50 @@ type Role struct {
 
 func (r *Role) ToRoleType() *types.Role {
 	return &types.Role{
-		Kind:      r.Kind,
-		UserID:    r.UserID,
-		ProjectID: r.ProjectID,
+		Kind:         r.Kind,
+		UserID:       r.UserID,
+		ProjectID:    r.ProjectID,
+		CustomRoleID: r.CustomRoleID,
+	}
+}
+
+// CustomRole is a named policy defined for a project, which roles of kind
+// "custom" reference
+type CustomRole struct {
+	gorm.Model
+
+	ProjectID uint
+	Name      string
+
+	// PolicyBytes is the JSON-encoded types.Policy for the role
+	PolicyBytes []byte
+}
+
+// GetPolicy returns the decoded policy for the custom role
+func (c *CustomRole) GetPolicy() (types.Policy, error) {
+	policy := types.Policy{}
+
+	if len(c.PolicyBytes) == 0 {
+		return policy, nil
+	}
+
+	if err := json.Unmarshal(c.PolicyBytes, &policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ToCustomRoleType generates an external CustomRole to be shared over REST
+func (c *CustomRole) ToCustomRoleType() *types.CustomRole {
+	// an error here would mean the stored policy was corrupted, in which case we
+	// return the role without the policy
+	policy, _ := c.GetPolicy()
+
+	return &types.CustomRole{
+		ID:        c.ID,
+		ProjectID: c.ProjectID,
+		Name:      c.Name,
+		Policy:    policy,
 	}
 }
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SchedulerLock is a lease on a background job, so that only one replica of the server
+// runs the job at a time. The lease is held until it expires, and is renewed by its
+// holder on every run.
+type SchedulerLock struct {
+	gorm.Model
+
+	Name      string `gorm:"unique"`
+	Holder    string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// WebhookDelivery is a single event sent to a webhook notification channel. The same
+// payload is sent on every attempt, so that receivers can deduplicate deliveries.
+type WebhookDelivery struct {
+	gorm.Model
+
+	ProjectID             uint
+	NotificationChannelID uint
+
+	DeliveryID string `gorm:"unique"`
+	EventType  types.NotificationEventType
+	Status     types.WebhookDeliveryStatus
+
+	Payload []byte
+
+	// NextAttemptAt is when a pending delivery is retried next, or nil if the delivery
+	// is not retried
+	NextAttemptAt *time.Time `gorm:"index"`
+
+	// ClaimedUntil is when the claim of the replica attempting the delivery expires. A
+	// delivery is only attempted while it is claimed, so that it is not sent twice at once.
+	ClaimedUntil *time.Time
+
+	Attempts []WebhookDeliveryAttempt
+}
+
+// WebhookDeliveryAttempt is the result of a single request for a webhook delivery
+type WebhookDeliveryAttempt struct {
+	gorm.Model
+
+	WebhookDeliveryID uint
+
+	StatusCode int
+	Error      string
+}
+
+func (d *WebhookDelivery) ToWebhookDeliveryType() *types.WebhookDelivery {
+	attempts := make([]*types.WebhookDeliveryAttempt, 0, len(d.Attempts))
+
+	for _, attempt := range d.Attempts {
+		attempts = append(attempts, &types.WebhookDeliveryAttempt{
+			CreatedAt:  attempt.CreatedAt,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+		})
+	}
+
+	return &types.WebhookDelivery{
+		ID:                    d.ID,
+		CreatedAt:             d.CreatedAt,
+		NotificationChannelID: d.NotificationChannelID,
+		DeliveryID:            d.DeliveryID,
+		EventType:             d.EventType,
+		Status:                d.Status,
+		Payload:               string(d.Payload),
+		NextAttemptAt:         d.NextAttemptAt,
+		Attempts:              attempts,
+	}
+}
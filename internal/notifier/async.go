@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// NewAsyncFactory wraps a notifier factory so that its notifiers send events in the
+// background, and a slow or unreachable endpoint does not block the request which raised
+// the event. Since failures are not returned to the caller, they are logged instead.
+// Channels which store and retry their deliveries, like webhooks, do not need this.
+func NewAsyncFactory(factory NotifierFactory, logger *logger.Logger) NotifierFactory {
+	return func(channel *models.NotificationChannel, conf *types.NotificationChannelConfig) (Notifier, error) {
+		n, err := factory(channel, conf)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &asyncNotifier{
+			notifier: n,
+			channel:  channel,
+			logger:   logger,
+		}, nil
+	}
+}
+
+type asyncNotifier struct {
+	notifier Notifier
+	channel  *models.NotificationChannel
+	logger   *logger.Logger
+}
+
+func (a *asyncNotifier) Notify(event *Event) error {
+	go func() {
+		if err := a.notifier.Notify(event); err != nil {
+			a.logger.Warn().Err(err).
+				Uint("project_id", a.channel.ProjectID).
+				Uint("channel_id", a.channel.ID).
+				Str("kind", string(a.channel.Kind)).
+				Str("event", string(event.Type)).
+				Msg("could not send notification")
+		}
+	}()
+
+	return nil
+}
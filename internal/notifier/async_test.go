@@ -0,0 +1,58 @@
+package notifier_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// blockingNotifier fails every event once it is unblocked
+type blockingNotifier struct {
+	unblock chan struct{}
+	sent    chan *notifier.Event
+}
+
+func (b *blockingNotifier) Notify(event *notifier.Event) error {
+	<-b.unblock
+	b.sent <- event
+
+	return fmt.Errorf("endpoint is unreachable")
+}
+
+func TestAsyncFactoryDoesNotBlock(t *testing.T) {
+	n := &blockingNotifier{
+		unblock: make(chan struct{}),
+		sent:    make(chan *notifier.Event, 1),
+	}
+
+	factory := notifier.NewAsyncFactory(func(_ *models.NotificationChannel, _ *types.NotificationChannelConfig) (notifier.Notifier, error) {
+		return n, nil
+	}, logger.NewConsole(false))
+
+	asyncNotifier, err := factory(&models.NotificationChannel{Kind: types.NotificationChannelTeams}, &types.NotificationChannelConfig{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// failures are logged, so they are not returned even though the notifier fails
+	if err := asyncNotifier.Notify(&notifier.Event{Type: types.NotificationEventPodCrash}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	close(n.unblock)
+
+	select {
+	case event := <-n.sent:
+		if event.Type != types.NotificationEventPodCrash {
+			t.Errorf("incorrect event type: expected %s, got %s", types.NotificationEventPodCrash, event.Type)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("event was not sent")
+	}
+}
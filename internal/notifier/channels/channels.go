@@ -0,0 +1,28 @@
+package channels
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/slack"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/discord"
+	"github.com/porter-dev/porter/internal/notifier/smtp"
+	"github.com/porter-dev/porter/internal/notifier/teams"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// NewDefaultRegistry returns a notifier registry with all built-in notification
+// channel kinds registered. Webhook deliveries are stored in the given repository, and
+// failures of the other channels are logged with the given logger.
+func NewDefaultRegistry(repo repository.Repository, logger *logger.Logger) *notifier.Registry {
+	registry := notifier.NewRegistry()
+
+	registry.Register(types.NotificationChannelSlack, notifier.NewAsyncFactory(slack.NewChannelNotifier, logger))
+	registry.Register(types.NotificationChannelWebhook, webhook.NewFactory(repo))
+	registry.Register(types.NotificationChannelTeams, notifier.NewAsyncFactory(teams.NewTeamsNotifier, logger))
+	registry.Register(types.NotificationChannelDiscord, notifier.NewAsyncFactory(discord.NewDiscordNotifier, logger))
+	registry.Register(types.NotificationChannelSMTP, notifier.NewAsyncFactory(smtp.NewSMTPNotifier, logger))
+
+	return registry
+}
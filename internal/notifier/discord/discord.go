@@ -0,0 +1,101 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+const (
+	colorSuccess = 0x2EB886
+	colorFailure = 0xE01E5A
+
+	// maxDescriptionLength is the maximum length of an embed description accepted by Discord
+	maxDescriptionLength = 4096
+)
+
+// DiscordNotifier sends events to a Discord channel webhook
+type DiscordNotifier struct {
+	url string
+}
+
+// NewDiscordNotifier creates a notifier for a Discord notification channel
+func NewDiscordNotifier(_ *models.NotificationChannel, conf *types.NotificationChannelConfig) (notifier.Notifier, error) {
+	if conf.URL == "" {
+		return nil, fmt.Errorf("discord notification channels require a webhook url")
+	}
+
+	return &DiscordNotifier{conf.URL}, nil
+}
+
+type Payload struct {
+	Username string   `json:"username"`
+	Embeds   []*Embed `json:"embeds"`
+}
+
+type Embed struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	Color       int      `json:"color"`
+	Timestamp   string   `json:"timestamp,omitempty"`
+	Fields      []*Field `json:"fields,omitempty"`
+}
+
+type Field struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func (d *DiscordNotifier) Notify(event *notifier.Event) error {
+	return notifier.PostJSON(d.url, GetPayload(event), nil)
+}
+
+// GetPayload converts an event to a Discord webhook payload
+func GetPayload(event *notifier.Event) *Payload {
+	embed := &Embed{
+		Title: event.Title(),
+		URL:   event.URL,
+		Color: colorSuccess,
+		Fields: []*Field{
+			{Name: "Name", Value: event.Name, Inline: true},
+		},
+	}
+
+	if event.Type.IsFailure() {
+		embed.Color = colorFailure
+	}
+
+	if event.Namespace != "" {
+		embed.Fields = append(embed.Fields, &Field{Name: "Namespace", Value: event.Namespace, Inline: true})
+	}
+
+	if event.ClusterName != "" {
+		embed.Fields = append(embed.Fields, &Field{Name: "Cluster", Value: event.ClusterName, Inline: true})
+	}
+
+	if event.Version != 0 {
+		embed.Fields = append(embed.Fields, &Field{Name: "Version", Value: fmt.Sprintf("%d", event.Version), Inline: true})
+	}
+
+	if event.Timestamp != nil {
+		embed.Timestamp = event.Timestamp.UTC().Format(time.RFC3339)
+	}
+
+	if info := event.Info; info != "" {
+		if len(info) > maxDescriptionLength-8 {
+			info = info[0:maxDescriptionLength-11] + "..."
+		}
+
+		embed.Description = fmt.Sprintf("```\n%s\n```", info)
+	}
+
+	return &Payload{
+		Username: "Porter",
+		Embeds:   []*Embed{embed},
+	}
+}
@@ -0,0 +1,151 @@
+package notifier
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ProjectNotifierOpts are the options for building the notifier of a project
+type ProjectNotifierOpts struct {
+	ProjectID uint
+
+	// ReleaseID is the id of the release that events are sent for. Channels which are
+	// restricted to a different release are skipped. If 0, only project-wide channels
+	// are used.
+	ReleaseID uint
+
+	// Config is the notification config of the release, if one exists
+	Config *types.NotificationConfig
+}
+
+// NewProjectNotifier returns a notifier which sends events to every notification
+// channel in the project that routes the event, as well as to the project's Slack
+// integrations.
+func NewProjectNotifier(
+	registry *Registry,
+	repo repository.Repository,
+	opts *ProjectNotifierOpts,
+) (Notifier, error) {
+	notifiers := make(MultiNotifier, 0)
+
+	slackInts, err := repo.SlackIntegration().ListSlackIntegrationsByProjectID(opts.ProjectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, slackInt := range slackInts {
+		n, err := registry.NewNotifier(&models.NotificationChannel{
+			ProjectID: opts.ProjectID,
+			Name:      slackInt.Channel,
+			Kind:      types.NotificationChannelSlack,
+		}, &types.NotificationChannelConfig{
+			URL: string(slackInt.Webhook),
+		})
+
+		if err != nil {
+			continue
+		}
+
+		// Slack integrations are not configured with routing rules, so they receive the
+		// events that they have always received
+		notifiers = append(notifiers, &routedNotifier{
+			notifier: n,
+			routes:   isSlackIntegrationEvent,
+		})
+	}
+
+	channels, err := repo.NotificationChannel().ListNotificationChannelsByProjectID(opts.ProjectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, channel := range channels {
+		if channel.ReleaseID != 0 && channel.ReleaseID != opts.ReleaseID {
+			continue
+		}
+
+		n, err := NewChannelNotifier(registry, channel)
+
+		// channels are validated when they are created, so a channel which cannot be
+		// loaded is skipped instead of blocking the remaining channels
+		if err != nil {
+			continue
+		}
+
+		notifiers = append(notifiers, n)
+	}
+
+	return &configNotifier{
+		notifier: notifiers,
+		config:   opts.Config,
+	}, nil
+}
+
+// NewChannelNotifier creates a notifier for a stored notification channel, which only
+// sends events that are routed to the channel
+func NewChannelNotifier(registry *Registry, channel *models.NotificationChannel) (Notifier, error) {
+	conf, err := channel.GetConfig()
+
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := registry.NewNotifier(channel, conf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &routedNotifier{
+		notifier: n,
+		routes:   channel.Routes,
+	}, nil
+}
+
+// ShouldNotify returns true if a release notification config allows the event type
+// to be sent. A nil config allows all events.
+func ShouldNotify(conf *types.NotificationConfig, event types.NotificationEventType) bool {
+	if conf == nil {
+		return true
+	}
+
+	if !conf.Enabled {
+		return false
+	}
+
+	if event.IsFailure() {
+		return conf.Failure
+	}
+
+	return conf.Success
+}
+
+func isSlackIntegrationEvent(event types.NotificationEventType) bool {
+	switch event {
+	case types.NotificationEventDeploySuccess,
+		types.NotificationEventDeployFailure,
+		types.NotificationEventDeployRolledBack,
+		types.NotificationEventPodCrash,
+		types.NotificationEventJobFailure:
+		return true
+	}
+
+	return false
+}
+
+// configNotifier applies the notification config of a release to all channels
+type configNotifier struct {
+	notifier Notifier
+	config   *types.NotificationConfig
+}
+
+func (c *configNotifier) Notify(event *Event) error {
+	if !ShouldNotify(c.config, event.Type) {
+		return nil
+	}
+
+	return c.notifier.Notify(event)
+}
@@ -0,0 +1,179 @@
+package notifier_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+// recordingNotifier records the events sent to each channel URL
+type recordingNotifier struct {
+	url  string
+	sent map[string][]types.NotificationEventType
+}
+
+func (r *recordingNotifier) Notify(event *notifier.Event) error {
+	r.sent[r.url] = append(r.sent[r.url], event.Type)
+	return nil
+}
+
+func newRecordingRegistry(sent map[string][]types.NotificationEventType) *notifier.Registry {
+	registry := notifier.NewRegistry()
+
+	factory := func(_ *models.NotificationChannel, conf *types.NotificationChannelConfig) (notifier.Notifier, error) {
+		return &recordingNotifier{conf.URL, sent}, nil
+	}
+
+	registry.Register(types.NotificationChannelSlack, factory)
+	registry.Register(types.NotificationChannelWebhook, factory)
+
+	return registry
+}
+
+type projectNotifierTest struct {
+	name      string
+	releaseID uint
+	config    *types.NotificationConfig
+	events    []types.NotificationEventType
+	expected  map[string][]types.NotificationEventType
+}
+
+var projectNotifierTests = []projectNotifierTest{
+	{
+		name:      "routes events by type and release",
+		releaseID: 1,
+		events: []types.NotificationEventType{
+			types.NotificationEventDeploySuccess,
+			types.NotificationEventDeployFailure,
+			types.NotificationEventProvisioningDone,
+		},
+		expected: map[string][]types.NotificationEventType{
+			"https://slack.example.com": {
+				types.NotificationEventDeploySuccess,
+				types.NotificationEventDeployFailure,
+			},
+			"https://all.example.com": {
+				types.NotificationEventDeploySuccess,
+				types.NotificationEventDeployFailure,
+				types.NotificationEventProvisioningDone,
+			},
+			"https://failures.example.com": {
+				types.NotificationEventDeployFailure,
+			},
+			"https://release-1.example.com": {
+				types.NotificationEventDeploySuccess,
+				types.NotificationEventDeployFailure,
+				types.NotificationEventProvisioningDone,
+			},
+		},
+	},
+	{
+		name:      "applies the release notification config",
+		releaseID: 2,
+		config: &types.NotificationConfig{
+			Enabled: true,
+			Success: false,
+			Failure: true,
+		},
+		events: []types.NotificationEventType{
+			types.NotificationEventDeploySuccess,
+			types.NotificationEventPodCrash,
+		},
+		expected: map[string][]types.NotificationEventType{
+			"https://slack.example.com":    {types.NotificationEventPodCrash},
+			"https://all.example.com":      {types.NotificationEventPodCrash},
+			"https://failures.example.com": {types.NotificationEventPodCrash},
+		},
+	},
+	{
+		name:      "disabled release notification config",
+		releaseID: 1,
+		config:    &types.NotificationConfig{Enabled: false, Success: true, Failure: true},
+		events:    []types.NotificationEventType{types.NotificationEventDeployFailure},
+		expected:  map[string][]types.NotificationEventType{},
+	},
+}
+
+func TestNewProjectNotifier(t *testing.T) {
+	for _, tc := range projectNotifierTests {
+		repo := test.NewRepository(true)
+
+		_, err := repo.SlackIntegration().CreateSlackIntegration(&ints.SlackIntegration{
+			ProjectID: 1,
+			Webhook:   []byte("https://slack.example.com"),
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		channels := []struct {
+			url       string
+			releaseID uint
+			events    []types.NotificationEventType
+		}{
+			{"https://all.example.com", 0, nil},
+			{"https://failures.example.com", 0, []types.NotificationEventType{
+				types.NotificationEventDeployFailure,
+				types.NotificationEventPodCrash,
+			}},
+			{"https://release-1.example.com", 1, nil},
+		}
+
+		for _, c := range channels {
+			conf, _ := json.Marshal(&types.NotificationChannelConfig{URL: c.url})
+
+			channel := &models.NotificationChannel{
+				ProjectID: 1,
+				ReleaseID: c.releaseID,
+				Kind:      types.NotificationChannelWebhook,
+				Config:    conf,
+			}
+
+			channel.SetEvents(c.events)
+
+			if _, err := repo.NotificationChannel().CreateNotificationChannel(channel); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		sent := make(map[string][]types.NotificationEventType)
+
+		n, err := notifier.NewProjectNotifier(newRecordingRegistry(sent), repo, &notifier.ProjectNotifierOpts{
+			ProjectID: 1,
+			ReleaseID: tc.releaseID,
+			Config:    tc.config,
+		})
+
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+
+		for _, event := range tc.events {
+			if err := n.Notify(&notifier.Event{Type: event, ProjectID: 1}); err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+		}
+
+		if diff := deep.Equal(sent, tc.expected); diff != nil {
+			t.Errorf("%s: incorrect events sent", tc.name)
+			t.Error(diff)
+		}
+	}
+}
+
+func TestRegistryUnknownKind(t *testing.T) {
+	registry := notifier.NewRegistry()
+
+	_, err := registry.NewNotifier(&models.NotificationChannel{Kind: types.NotificationChannelTeams}, &types.NotificationChannelConfig{})
+
+	if err == nil {
+		t.Fatalf("expected error for unregistered kind")
+	}
+}
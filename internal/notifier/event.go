@@ -0,0 +1,99 @@
+package notifier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// Event is a single notification about a release, job or infra in a project. It is
+// delivered to every notification channel that routes its type.
+type Event struct {
+	// Type is the type of the event, used to route the event to notification channels
+	Type types.NotificationEventType `json:"type"`
+
+	// ProjectID is the id of the Porter project that this event belongs to
+	ProjectID uint `json:"project_id"`
+
+	// ClusterID is the id of the Porter cluster that this event belongs to
+	ClusterID uint `json:"cluster_id,omitempty"`
+
+	// ClusterName is the name of the cluster that this event belongs to
+	ClusterName string `json:"cluster_name,omitempty"`
+
+	// Info is any additional information about this event, such as an error message if
+	// the deployment failed.
+	Info string `json:"info,omitempty"`
+
+	// Name is the name of the release, job or infra that this event refers to.
+	Name string `json:"name"`
+
+	// Namespace is the Kubernetes namespace of the release or job that this event refers to.
+	Namespace string `json:"namespace,omitempty"`
+
+	// URL is a link to the resource in the Porter dashboard
+	URL string `json:"url,omitempty"`
+
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+
+	Version int `json:"version,omitempty"`
+}
+
+// Title returns a short, plain-text summary of the event
+func (e *Event) Title() string {
+	switch e.Type {
+	case types.NotificationEventDeploySuccess:
+		return fmt.Sprintf("%s was deployed", e.Name)
+	case types.NotificationEventDeployFailure:
+		return fmt.Sprintf("%s failed to deploy", e.Name)
+	case types.NotificationEventDeployRolledBack:
+		return fmt.Sprintf("%s was rolled back", e.Name)
+	case types.NotificationEventPodCrash:
+		return fmt.Sprintf("%s crashed", e.Name)
+	case types.NotificationEventJobFailure:
+		return fmt.Sprintf("Job %s failed", e.Name)
+	case types.NotificationEventProvisioningDone:
+		return fmt.Sprintf("%s was provisioned", e.Name)
+	case types.NotificationEventReleaseCreated:
+		return fmt.Sprintf("%s was created", e.Name)
+	case types.NotificationEventReleaseRolledBack:
+		return fmt.Sprintf("%s was rolled back", e.Name)
+	case types.NotificationEventReleaseDeleted:
+		return fmt.Sprintf("%s was deleted", e.Name)
+	}
+
+	return e.Name
+}
+
+// Text returns a plain-text description of the event, which includes the title and
+// any available details
+func (e *Event) Text() string {
+	res := e.Title()
+
+	if e.Namespace != "" {
+		res += fmt.Sprintf("\nNamespace: %s", e.Namespace)
+	}
+
+	if e.ClusterName != "" {
+		res += fmt.Sprintf("\nCluster: %s", e.ClusterName)
+	}
+
+	if e.Version != 0 {
+		res += fmt.Sprintf("\nVersion: %d", e.Version)
+	}
+
+	if e.Timestamp != nil {
+		res += fmt.Sprintf("\nTimestamp: %s", e.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC"))
+	}
+
+	if e.Info != "" {
+		res += fmt.Sprintf("\n\n%s", e.Info)
+	}
+
+	if e.URL != "" {
+		res += fmt.Sprintf("\n\n%s", e.URL)
+	}
+
+	return res
+}
@@ -0,0 +1,122 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// AllowPrivateAddresses allows notifications to be sent to loopback, private and
+// link-local addresses. It is only meant to be set in tests, which send notifications
+// to local servers.
+var AllowPrivateAddresses = false
+
+// sharedAddressSpace is the carrier-grade NAT range, which is commonly used for cluster
+// networks but is not covered by net.IP.IsPrivate
+var _, sharedAddressSpace, _ = net.ParseCIDR("100.64.0.0/10")
+
+// the urls of notification channels are set by users, so the client only connects to
+// public addresses. The address is checked after it has been resolved, so that a host
+// name cannot resolve to an internal address, and proxies are not used since the
+// address of the proxy would be checked instead. Redirects are dialed with the
+// same check.
+var httpClient = &http.Client{
+	Timeout: time.Second * 5,
+	Transport: &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: time.Second * 5,
+	},
+}
+
+var dialer = &net.Dialer{
+	Timeout: time.Second * 5,
+	Control: checkDialAddress,
+}
+
+// Dial connects to an address which is set by a user, like the server of an email
+// notification channel, with the same address checks and timeout as PostBody
+func Dial(network, address string) (net.Conn, error) {
+	return dialer.Dial(network, address)
+}
+
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+
+	if ip == nil {
+		return fmt.Errorf("could not parse address %s", host)
+	}
+
+	if !AllowPrivateAddresses && !IsPublicIP(ip) {
+		return fmt.Errorf("notifications cannot be sent to non-public address %s", ip)
+	}
+
+	return nil
+}
+
+// IsPublicIP returns false for loopback, private, link-local, shared and multicast
+// addresses, which notifications are not sent to
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// PostJSON sends the JSON-encoded payload to the url with the given headers, and
+// returns an error if the response does not have a 2xx status code
+func PostJSON(url string, payload interface{}, headers map[string]string) error {
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = PostBody(url, body, headers)
+
+	return err
+}
+
+// PostBody sends an already-encoded JSON body to the url with the given headers. It
+// returns the response status code, which is 0 if no response was received, and an
+// error if the response does not have a 2xx status code.
+func PostBody(url string, body []byte, headers map[string]string) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for key, val := range headers {
+		req.Header.Set(key, val)
+	}
+
+	resp, err := httpClient.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("request to %s failed with status code %d", req.URL.Host, resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
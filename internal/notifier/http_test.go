@@ -0,0 +1,52 @@
+package notifier_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := map[string]bool{
+		"1.1.1.1":          true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.0.0.1":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"::ffff:127.0.0.1": false,
+	}
+
+	for addr, expected := range tests {
+		if got := notifier.IsPublicIP(net.ParseIP(addr)); got != expected {
+			t.Errorf("incorrect result for %s: expected %t, got %t", addr, expected, got)
+		}
+	}
+}
+
+func TestPostBodyRejectsLoopback(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+
+	defer server.Close()
+
+	if _, err := notifier.PostBody(server.URL, []byte("{}"), nil); err == nil {
+		t.Errorf("expected error for request to loopback address")
+	}
+
+	if requests != 0 {
+		t.Errorf("expected no requests to be sent, got %d", requests)
+	}
+}
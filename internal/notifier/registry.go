@@ -0,0 +1,109 @@
+package notifier
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// Notifier delivers events to a single notification channel
+type Notifier interface {
+	Notify(event *Event) error
+}
+
+// NotifierFactory creates a Notifier for a notification channel from its decoded
+// configuration. The channel may not be stored yet, in which case its ID is 0.
+type NotifierFactory func(channel *models.NotificationChannel, conf *types.NotificationChannelConfig) (Notifier, error)
+
+// Registry maps notification channel kinds to the factories which create notifiers
+// for those channels
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[types.NotificationChannelKind]NotifierFactory
+}
+
+// NewRegistry returns an empty notifier registry
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[types.NotificationChannelKind]NotifierFactory),
+	}
+}
+
+// Register adds a factory for a notification channel kind, overwriting any factory
+// that was previously registered for that kind
+func (r *Registry) Register(kind types.NotificationChannelKind, factory NotifierFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.factories[kind] = factory
+}
+
+// Kinds returns the sorted list of registered notification channel kinds
+func (r *Registry) Kinds() []types.NotificationChannelKind {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]types.NotificationChannelKind, 0, len(r.factories))
+
+	for kind := range r.factories {
+		res = append(res, kind)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i] < res[j]
+	})
+
+	return res
+}
+
+// NewNotifier creates a notifier for a channel with the given configuration
+func (r *Registry) NewNotifier(channel *models.NotificationChannel, conf *types.NotificationChannelConfig) (Notifier, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[channel.Kind]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("notification channel kind %s is not supported", channel.Kind)
+	}
+
+	return factory(channel, conf)
+}
+
+// MultiNotifier sends each event to all of its notifiers, even if some fail
+type MultiNotifier []Notifier
+
+// Notify sends the event to every notifier, and returns an error which combines the
+// errors of all failed notifiers
+func (m MultiNotifier) Notify(event *Event) error {
+	errStrs := make([]string, 0)
+
+	for _, n := range m {
+		if err := n.Notify(event); err != nil {
+			errStrs = append(errStrs, err.Error())
+		}
+	}
+
+	if len(errStrs) > 0 {
+		return fmt.Errorf("%d of %d notifications failed: %s", len(errStrs), len(m), strings.Join(errStrs, "; "))
+	}
+
+	return nil
+}
+
+// routedNotifier only sends events which are routed to its channel
+type routedNotifier struct {
+	notifier Notifier
+	routes   func(event types.NotificationEventType) bool
+}
+
+func (r *routedNotifier) Notify(event *Event) error {
+	if !r.routes(event.Type) {
+		return nil
+	}
+
+	return r.notifier.Notify(event)
+}
@@ -0,0 +1,139 @@
+package smtp
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// sendTimeout is the maximum time to send an email, including the connection to the
+// SMTP server
+const sendTimeout = time.Second * 30
+
+// SMTPNotifier sends events as plain-text emails through an SMTP server
+type SMTPNotifier struct {
+	conf *types.SMTPChannelConfig
+}
+
+// NewSMTPNotifier creates a notifier for an SMTP email notification channel
+func NewSMTPNotifier(_ *models.NotificationChannel, conf *types.NotificationChannelConfig) (notifier.Notifier, error) {
+	if conf.SMTP == nil || conf.SMTP.Host == "" || len(conf.SMTP.To) == 0 {
+		return nil, fmt.Errorf("smtp notification channels require a host and at least one recipient")
+	}
+
+	return &SMTPNotifier{
+		conf: conf.SMTP,
+	}, nil
+}
+
+func (s *SMTPNotifier) Notify(event *notifier.Event) error {
+	var auth smtp.Auth
+
+	if s.conf.Username != "" {
+		auth = smtp.PlainAuth("", s.conf.Username, s.conf.Password, s.conf.Host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", s.conf.Host, s.conf.Port)
+
+	return sendMail(addr, s.conf.Host, auth, s.conf.From, s.conf.To, GetMessage(s.conf.From, s.conf.To, event))
+}
+
+// sendMail works like smtp.SendMail, but connects to the server with the address checks
+// of the notifier package, and fails if the email is not sent within sendTimeout
+func sendMail(addr, host string, a smtp.Auth, from string, to []string, msg []byte) error {
+	for _, line := range append([]string{from}, to...) {
+		if strings.ContainsAny(line, "\r\n") {
+			return fmt.Errorf("smtp: a line must not contain CR or LF")
+		}
+	}
+
+	conn, err := notifier.Dial("tcp", addr)
+
+	if err != nil {
+		return err
+	}
+
+	if err := conn.SetDeadline(time.Now().Add(sendTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, host)
+
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+
+	if a != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp: server doesn't support AUTH")
+		}
+
+		if err := c.Auth(a); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+
+	for _, addr := range to {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// GetMessage returns the RFC 822 email message for an event
+func GetMessage(from string, to []string, event *notifier.Event) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: Porter <%s>\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: [Porter] %s\r\n", sanitizeHeader(event.Title()))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(event.Text(), "\n", "\r\n"))
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
+
+// sanitizeHeader removes line breaks so that event data cannot inject headers
+func sanitizeHeader(val string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(val)
+}
@@ -0,0 +1,117 @@
+package teams
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+const (
+	colorSuccess = "2EB886"
+	colorFailure = "E01E5A"
+)
+
+// TeamsNotifier sends events to a Microsoft Teams incoming webhook
+type TeamsNotifier struct {
+	url string
+}
+
+// NewTeamsNotifier creates a notifier for a Microsoft Teams notification channel
+func NewTeamsNotifier(_ *models.NotificationChannel, conf *types.NotificationChannelConfig) (notifier.Notifier, error) {
+	if conf.URL == "" {
+		return nil, fmt.Errorf("teams notification channels require a webhook url")
+	}
+
+	return &TeamsNotifier{conf.URL}, nil
+}
+
+// MessageCard is the legacy actionable message card format accepted by Teams
+// incoming webhooks
+type MessageCard struct {
+	Type            string           `json:"@type"`
+	Context         string           `json:"@context"`
+	Summary         string           `json:"summary"`
+	ThemeColor      string           `json:"themeColor"`
+	Title           string           `json:"title"`
+	Sections        []*Section       `json:"sections,omitempty"`
+	PotentialAction []*OpenURIAction `json:"potentialAction,omitempty"`
+}
+
+type Section struct {
+	Facts []*Fact `json:"facts,omitempty"`
+	Text  string  `json:"text,omitempty"`
+}
+
+type Fact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type OpenURIAction struct {
+	Type    string       `json:"@type"`
+	Name    string       `json:"name"`
+	Targets []*URITarget `json:"targets"`
+}
+
+type URITarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+func (t *TeamsNotifier) Notify(event *notifier.Event) error {
+	return notifier.PostJSON(t.url, GetMessageCard(event), nil)
+}
+
+// GetMessageCard converts an event to a Teams message card
+func GetMessageCard(event *notifier.Event) *MessageCard {
+	color := colorSuccess
+
+	if event.Type.IsFailure() {
+		color = colorFailure
+	}
+
+	facts := []*Fact{{Name: "Name", Value: event.Name}}
+
+	if event.Namespace != "" {
+		facts = append(facts, &Fact{Name: "Namespace", Value: event.Namespace})
+	}
+
+	if event.ClusterName != "" {
+		facts = append(facts, &Fact{Name: "Cluster", Value: event.ClusterName})
+	}
+
+	if event.Version != 0 {
+		facts = append(facts, &Fact{Name: "Version", Value: fmt.Sprintf("%d", event.Version)})
+	}
+
+	section := &Section{Facts: facts}
+
+	if event.Info != "" {
+		section.Text = event.Info
+	}
+
+	card := &MessageCard{
+		Type:       "MessageCard",
+		Context:    "https://schema.org/extensions",
+		Summary:    event.Title(),
+		ThemeColor: color,
+		Title:      event.Title(),
+		Sections:   []*Section{section},
+	}
+
+	if event.URL != "" {
+		card.PotentialAction = []*OpenURIAction{
+			{
+				Type: "OpenUri",
+				Name: "View on Porter",
+				Targets: []*URITarget{
+					{OS: "default", URI: event.URL},
+				},
+			},
+		}
+	}
+
+	return card
+}
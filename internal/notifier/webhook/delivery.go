@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/scheduler"
+	"gorm.io/gorm"
+)
+
+// MaxAttempts is the number of attempts after which a delivery which has not succeeded
+// is marked as failed
+const MaxAttempts = 5
+
+// RetryBaseDelay is the delay before the first retry of a delivery. The delay doubles
+// after each failed attempt.
+var RetryBaseDelay = 30 * time.Second
+
+// retryPollInterval is how often deliveries which are due to be retried are attempted
+const retryPollInterval = 10 * time.Second
+
+// claimDuration is how long a delivery is claimed for an attempt. It is longer than the
+// timeout of an attempt, so that a claim only expires if its replica stopped.
+const claimDuration = time.Minute
+
+// Deliverer sends stored deliveries to the endpoint of a webhook channel
+type Deliverer struct {
+	repo repository.Repository
+	conf *types.NotificationChannelConfig
+}
+
+func NewDeliverer(repo repository.Repository, conf *types.NotificationChannelConfig) *Deliverer {
+	return &Deliverer{repo, conf}
+}
+
+// Claim claims the delivery for an attempt. It returns false if the delivery is being
+// attempted elsewhere, or has been attempted since it was read.
+func Claim(repo repository.Repository, delivery *models.WebhookDelivery) (bool, error) {
+	now := time.Now()
+
+	return repo.WebhookDelivery().ClaimWebhookDelivery(delivery, now, now.Add(claimDuration))
+}
+
+// Attempt sends the payload of the delivery once and records the attempt. The delivery
+// should be claimed before it is attempted, and its claim is released once the attempt
+// is recorded.
+// The delivery is marked as succeeded if the attempt succeeds, or as failed if it has been attempted
+// MaxAttempts times without succeeding. Otherwise, the time of its next retry is stored.
+func (d *Deliverer) Attempt(delivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	headers := map[string]string{
+		EventHeader:    string(delivery.EventType),
+		DeliveryHeader: delivery.DeliveryID,
+	}
+
+	if d.conf.Secret != "" {
+		headers[SignatureHeader] = Sign(d.conf.Secret, delivery.Payload)
+	}
+
+	statusCode, sendErr := notifier.PostBody(d.conf.URL, delivery.Payload, headers)
+
+	attempt := &models.WebhookDeliveryAttempt{
+		WebhookDeliveryID: delivery.ID,
+		StatusCode:        statusCode,
+	}
+
+	if sendErr != nil {
+		attempt.Error = sendErr.Error()
+	}
+
+	attempt, err := d.repo.WebhookDelivery().CreateWebhookDeliveryAttempt(attempt)
+
+	if err != nil {
+		return delivery, err
+	}
+
+	delivery.Attempts = append(delivery.Attempts, *attempt)
+
+	delivery.NextAttemptAt = nil
+	delivery.ClaimedUntil = nil
+
+	if sendErr == nil {
+		delivery.Status = types.WebhookDeliverySucceeded
+	} else if len(delivery.Attempts) >= MaxAttempts {
+		delivery.Status = types.WebhookDeliveryFailed
+	} else {
+		delivery.Status = types.WebhookDeliveryPending
+
+		nextAttemptAt := time.Now().Add(RetryDelay(len(delivery.Attempts)))
+		delivery.NextAttemptAt = &nextAttemptAt
+	}
+
+	if _, err := d.repo.WebhookDelivery().UpdateWebhookDelivery(delivery); err != nil {
+		return delivery, err
+	}
+
+	return delivery, sendErr
+}
+
+// RetryDelay returns the delay before the next attempt of a delivery which has failed
+// the given number of attempts
+func RetryDelay(attempts int) time.Duration {
+	// the shift is clamped, so that it cannot underflow or overflow the delay
+	shift := attempts - 1
+
+	if shift < 0 {
+		shift = 0
+	} else if shift > MaxAttempts {
+		shift = MaxAttempts
+	}
+
+	return RetryBaseDelay << uint(shift)
+}
+
+// Retrier attempts the stored deliveries which are due to be retried, so that retries
+// continue after a restart of the server
+type Retrier struct {
+	Repo   repository.Repository
+	Logger *logger.Logger
+}
+
+// Run retries due deliveries every few seconds, on one replica of the server at a time,
+// and never returns
+func (r *Retrier) Run() {
+	scheduler.Run(r.Repo, r.Logger, "webhook_delivery_retry", retryPollInterval, r.RetryDue)
+}
+
+// RetryDue attempts every delivery which is due to be retried. Each delivery is claimed
+// before it is attempted, so that a delivery is not sent twice if another replica takes
+// over the lease before the batch is done. Errors are logged, and do not stop other
+// deliveries from being retried.
+func (r *Retrier) RetryDue() {
+	deliveries, err := r.Repo.WebhookDelivery().ListDueWebhookDeliveries(time.Now())
+
+	if err != nil {
+		r.Logger.Error().Err(err).Msg("could not list webhook deliveries to retry")
+		return
+	}
+
+	for _, delivery := range deliveries {
+		claimed, err := Claim(r.Repo, delivery)
+
+		if err != nil {
+			r.Logger.Error().Err(err).Uint("delivery_id", delivery.ID).
+				Msg("could not claim webhook delivery")
+			continue
+		} else if !claimed {
+			continue
+		}
+
+		if err := r.retry(delivery); err != nil {
+			r.Logger.Error().Err(err).Uint("delivery_id", delivery.ID).
+				Msg("could not retry webhook delivery")
+		}
+	}
+}
+
+func (r *Retrier) retry(delivery *models.WebhookDelivery) error {
+	channel, err := r.Repo.NotificationChannel().ReadNotificationChannel(delivery.ProjectID, delivery.NotificationChannelID)
+
+	// deliveries of deleted channels cannot be sent, so they are not retried again
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		delivery.Status = types.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.ClaimedUntil = nil
+
+		_, err = r.Repo.WebhookDelivery().UpdateWebhookDelivery(delivery)
+
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	conf, err := channel.GetConfig()
+
+	if err != nil {
+		return err
+	}
+
+	// a failed send is recorded as an attempt on the delivery, so it is not logged
+	_, _ = NewDeliverer(r.Repo, conf).Attempt(delivery)
+
+	return nil
+}
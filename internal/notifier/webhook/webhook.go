@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+const (
+	// SignatureHeader contains the HMAC-SHA256 signature of the request body, computed
+	// with the secret of the notification channel. Like Github's X-Hub-Signature-256
+	// header, it is in the form sha256=<hex digest>.
+	SignatureHeader = "X-Porter-Signature"
+
+	// EventHeader contains the type of the event that is sent
+	EventHeader = "X-Porter-Event"
+
+	// DeliveryHeader contains the unique id of the delivery, which is the same for
+	// every attempt of the delivery
+	DeliveryHeader = "X-Porter-Delivery"
+)
+
+// WebhookNotifier sends events as JSON payloads to an HTTP endpoint. If the channel
+// has a secret, each payload is signed so that the receiver can verify it. Every
+// delivery and its attempts are stored, and failed deliveries are retried.
+type WebhookNotifier struct {
+	repo    repository.Repository
+	channel *models.NotificationChannel
+	conf    *types.NotificationChannelConfig
+}
+
+// NewFactory returns a notifier factory for generic webhook notification channels,
+// which stores deliveries in the given repository
+func NewFactory(repo repository.Repository) notifier.NotifierFactory {
+	return func(channel *models.NotificationChannel, conf *types.NotificationChannelConfig) (notifier.Notifier, error) {
+		if conf.URL == "" {
+			return nil, fmt.Errorf("webhook notification channels require a url")
+		}
+
+		return &WebhookNotifier{
+			repo:    repo,
+			channel: channel,
+			conf:    conf,
+		}, nil
+	}
+}
+
+// Notify creates a delivery for the event and attempts it in the background, so that
+// a slow endpoint does not block the caller. If the first attempt fails, the delivery
+// is retried later by the Retrier, since the failure is recorded on the delivery.
+func (w *WebhookNotifier) Notify(event *notifier.Event) error {
+	body, err := json.Marshal(event)
+
+	if err != nil {
+		return err
+	}
+
+	deliveryID, err := repository.GenerateRandomBytes(16)
+
+	if err != nil {
+		return err
+	}
+
+	// the delivery is created claimed, so that it is not redelivered during its first attempt
+	claimedUntil := time.Now().Add(claimDuration)
+
+	delivery, err := w.repo.WebhookDelivery().CreateWebhookDelivery(&models.WebhookDelivery{
+		ProjectID:             w.channel.ProjectID,
+		NotificationChannelID: w.channel.ID,
+		DeliveryID:            deliveryID,
+		EventType:             event.Type,
+		Status:                types.WebhookDeliveryPending,
+		Payload:               body,
+		ClaimedUntil:          &claimedUntil,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// a failed first attempt is stored with the time of its retry, so that it is retried
+	// by the Retrier
+	go NewDeliverer(w.repo, w.conf).Attempt(delivery)
+
+	return nil
+}
+
+// Sign returns the signature header value of a payload, in the form sha256=<hex digest>
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if the signature header value matches the payload. Receivers
+// written in Go can use this to validate deliveries.
+func Verify(secret string, body []byte, signature string) bool {
+	if len(signature) != 71 || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+
+	actual := make([]byte, 32)
+
+	if _, err := hex.Decode(actual, []byte(signature[7:])); err != nil {
+		return false
+	}
+
+	computed := hmac.New(sha256.New, []byte(secret))
+	computed.Write(body)
+
+	return hmac.Equal(computed.Sum(nil), actual)
+}
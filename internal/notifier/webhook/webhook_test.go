@@ -0,0 +1,354 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/repository/test"
+	"gorm.io/gorm"
+)
+
+func TestMain(m *testing.M) {
+	// the test servers listen on loopback addresses
+	notifier.AllowPrivateAddresses = true
+
+	os.Exit(m.Run())
+}
+
+func newTestNotifier(t *testing.T, repo repository.Repository, conf *types.NotificationChannelConfig) notifier.Notifier {
+	n, err := webhook.NewFactory(repo)(&models.NotificationChannel{
+		Model:     gorm.Model{ID: 1},
+		ProjectID: 1,
+		Kind:      types.NotificationChannelWebhook,
+	}, conf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return n
+}
+
+// newStoredTestNotifier stores a webhook channel with the given url, so that its
+// deliveries can be retried, and returns a notifier for it
+func newStoredTestNotifier(t *testing.T, repo repository.Repository, url string) notifier.Notifier {
+	conf := &types.NotificationChannelConfig{URL: url}
+	confBytes, err := json.Marshal(conf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel, err := repo.NotificationChannel().CreateNotificationChannel(&models.NotificationChannel{
+		ProjectID: 1,
+		Kind:      types.NotificationChannelWebhook,
+		Config:    confBytes,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := webhook.NewFactory(repo)(channel, conf)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return n
+}
+
+// waitForFirstAttempt waits until the first attempt of the first delivery, which is sent
+// in the background, has been recorded
+func waitForFirstAttempt(t *testing.T, repo repository.Repository) *models.WebhookDelivery {
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		delivery, err := repo.WebhookDelivery().ReadWebhookDelivery(1, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if delivery.Status != types.WebhookDeliveryPending || delivery.NextAttemptAt != nil {
+			return delivery
+		}
+	}
+
+	t.Fatalf("first attempt of delivery was not recorded")
+
+	return nil
+}
+
+// retryUntilDone runs the retrier until the first delivery is no longer pending
+func retryUntilDone(t *testing.T, repo repository.Repository) *models.WebhookDelivery {
+	retrier := &webhook.Retrier{Repo: repo, Logger: logger.NewConsole(false)}
+
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		retrier.RetryDue()
+
+		delivery, err := repo.WebhookDelivery().ReadWebhookDelivery(1, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if delivery.Status != types.WebhookDeliveryPending {
+			return delivery
+		}
+	}
+
+	t.Fatalf("delivery is still pending")
+
+	return nil
+}
+
+func TestWebhookNotifierSignsPayload(t *testing.T) {
+	var gotBody []byte
+	var gotHeaders http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = ioutil.ReadAll(r.Body)
+		gotHeaders = r.Header
+	}))
+
+	defer server.Close()
+
+	repo := test.NewRepository(true)
+
+	n := newTestNotifier(t, repo, &types.NotificationChannelConfig{
+		URL:    server.URL,
+		Secret: "secret",
+	})
+
+	event := &notifier.Event{
+		Type:      types.NotificationEventDeployFailure,
+		ProjectID: 1,
+		Name:      "web",
+		Info:      "image pull failed",
+	}
+
+	if err := n.Notify(event); err != nil {
+		t.Fatal(err)
+	}
+
+	delivery := waitForFirstAttempt(t, repo)
+
+	if got := gotHeaders.Get(webhook.EventHeader); got != string(types.NotificationEventDeployFailure) {
+		t.Errorf("incorrect event header: expected %s, got %s", types.NotificationEventDeployFailure, got)
+	}
+
+	if !webhook.Verify("secret", gotBody, gotHeaders.Get(webhook.SignatureHeader)) {
+		t.Errorf("signature %s does not match payload", gotHeaders.Get(webhook.SignatureHeader))
+	}
+
+	if webhook.Verify("other-secret", gotBody, gotHeaders.Get(webhook.SignatureHeader)) {
+		t.Errorf("signature should not verify with a different secret")
+	}
+
+	gotEvent := &notifier.Event{}
+
+	if err := json.Unmarshal(gotBody, gotEvent); err != nil {
+		t.Fatal(err)
+	}
+
+	if gotEvent.Name != event.Name || gotEvent.Info != event.Info {
+		t.Errorf("incorrect payload: %s", string(gotBody))
+	}
+
+	if delivery.Status != types.WebhookDeliverySucceeded {
+		t.Errorf("incorrect delivery status: expected %s, got %s", types.WebhookDeliverySucceeded, delivery.Status)
+	}
+
+	if got := gotHeaders.Get(webhook.DeliveryHeader); got == "" || got != delivery.DeliveryID {
+		t.Errorf("incorrect delivery header: expected %s, got %s", delivery.DeliveryID, got)
+	}
+
+	if len(delivery.Attempts) != 1 || delivery.Attempts[0].StatusCode != http.StatusOK {
+		t.Errorf("expected a single successful attempt, got %v", delivery.Attempts)
+	}
+}
+
+func TestWebhookNotifierRetriesFailedDelivery(t *testing.T) {
+	prevDelay := webhook.RetryBaseDelay
+	webhook.RetryBaseDelay = time.Millisecond
+	defer func() { webhook.RetryBaseDelay = prevDelay }()
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	defer server.Close()
+
+	repo := test.NewRepository(true)
+
+	n := newStoredTestNotifier(t, repo, server.URL)
+
+	// failed deliveries are recorded and retried, so they do not return an error
+	if err := n.Notify(&notifier.Event{Type: types.NotificationEventPodCrash}); err != nil {
+		t.Fatal(err)
+	}
+
+	delivery := waitForFirstAttempt(t, repo)
+
+	if delivery.Status != types.WebhookDeliveryPending || delivery.NextAttemptAt == nil {
+		t.Fatalf("expected a failed first attempt to be stored with the time of its retry")
+	}
+
+	delivery = retryUntilDone(t, repo)
+
+	if delivery.Status != types.WebhookDeliverySucceeded {
+		t.Fatalf("incorrect delivery status: expected %s, got %s", types.WebhookDeliverySucceeded, delivery.Status)
+	}
+
+	if len(delivery.Attempts) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(delivery.Attempts))
+	}
+
+	if delivery.Attempts[0].StatusCode != http.StatusInternalServerError || delivery.Attempts[0].Error == "" {
+		t.Errorf("expected first attempt to record the failure, got %v", delivery.Attempts[0])
+	}
+}
+
+func TestWebhookNotifierMarksDeliveryFailed(t *testing.T) {
+	prevDelay := webhook.RetryBaseDelay
+	webhook.RetryBaseDelay = time.Millisecond
+	defer func() { webhook.RetryBaseDelay = prevDelay }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	defer server.Close()
+
+	repo := test.NewRepository(true)
+
+	n := newStoredTestNotifier(t, repo, server.URL)
+
+	if err := n.Notify(&notifier.Event{Type: types.NotificationEventJobFailure}); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForFirstAttempt(t, repo)
+
+	delivery := retryUntilDone(t, repo)
+
+	if delivery.Status != types.WebhookDeliveryFailed {
+		t.Fatalf("incorrect delivery status: expected %s, got %s", types.WebhookDeliveryFailed, delivery.Status)
+	}
+
+	if len(delivery.Attempts) != webhook.MaxAttempts {
+		t.Errorf("expected %d attempts, got %d", webhook.MaxAttempts, len(delivery.Attempts))
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	prevDelay := webhook.RetryBaseDelay
+	webhook.RetryBaseDelay = time.Second
+	defer func() { webhook.RetryBaseDelay = prevDelay }()
+
+	if got := webhook.RetryDelay(0); got != time.Second {
+		t.Errorf("expected the delay without attempts to be clamped to %s, got %s", time.Second, got)
+	}
+
+	if got := webhook.RetryDelay(3); got != 4*time.Second {
+		t.Errorf("expected the delay after 3 attempts to be %s, got %s", 4*time.Second, got)
+	}
+
+	if got, max := webhook.RetryDelay(100), webhook.RetryDelay(webhook.MaxAttempts+1); got != max {
+		t.Errorf("expected the delay to be clamped to %s, got %s", max, got)
+	}
+}
+
+func TestWebhookFactoryRequiresURL(t *testing.T) {
+	_, err := webhook.NewFactory(test.NewRepository(true))(&models.NotificationChannel{
+		Kind: types.NotificationChannelWebhook,
+	}, &types.NotificationChannelConfig{})
+
+	if err == nil {
+		t.Errorf("expected error for webhook channel without a url")
+	}
+}
+
+func TestRetryDueClaimsDeliveries(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		time.Sleep(50 * time.Millisecond)
+	}))
+
+	defer server.Close()
+
+	repo := test.NewRepository(true)
+
+	newStoredTestNotifier(t, repo, server.URL)
+
+	nextAttemptAt := time.Now().Add(-time.Second)
+
+	_, err := repo.WebhookDelivery().CreateWebhookDelivery(&models.WebhookDelivery{
+		ProjectID:             1,
+		NotificationChannelID: 1,
+		DeliveryID:            "delivery",
+		EventType:             types.NotificationEventPodCrash,
+		Status:                types.WebhookDeliveryPending,
+		NextAttemptAt:         &nextAttemptAt,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a delivery which was read before it was claimed elsewhere cannot be claimed again
+	stale, err := repo.WebhookDelivery().ReadWebhookDelivery(1, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// two replicas retrying the same deliveries send each delivery once
+	retrier := &webhook.Retrier{Repo: repo, Logger: logger.NewConsole(false)}
+	done := make(chan struct{})
+
+	for i := 0; i < 2; i++ {
+		go func() {
+			retrier.RetryDue()
+			done <- struct{}{}
+		}()
+	}
+
+	<-done
+	<-done
+
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected the delivery to be sent once, got %d requests", n)
+	}
+
+	if claimed, err := webhook.Claim(repo, stale); err != nil || claimed {
+		t.Errorf("expected a delivery that was attempted since it was read to not be claimed")
+	}
+
+	delivery, err := repo.WebhookDelivery().ReadWebhookDelivery(1, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if delivery.Status != types.WebhookDeliverySucceeded || delivery.ClaimedUntil != nil {
+		t.Errorf("expected the delivery to succeed and release its claim, got %+v", delivery)
+	}
+}
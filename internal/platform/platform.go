@@ -0,0 +1,56 @@
+package platform
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Default is the platform of images which are built without any platforms, and the only
+// platform of buildpack builds
+const Default = "linux/amd64"
+
+// platformRegex matches platforms of the form os/arch[/variant], such as linux/arm64/v8
+var platformRegex = regexp.MustCompile(`^[a-z0-9]+/[a-z0-9_]+(/[a-z0-9]+)?$`)
+
+// Error is a problem with a platform of a list of platforms
+type Error struct {
+	// Index is the position of the platform in the list
+	Index int
+
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Validate returns an error for each platform which is not of the form os/arch[/variant],
+// or which is listed more than once
+func Validate(platforms []string) []*Error {
+	res := make([]*Error, 0)
+	seen := make(map[string]bool)
+
+	for i, platform := range platforms {
+		if !platformRegex.MatchString(platform) {
+			res = append(res, &Error{
+				Index:   i,
+				Message: fmt.Sprintf("invalid platform %q: platforms must be of the form os/arch[/variant], such as linux/arm64", platform),
+			})
+		} else if seen[platform] {
+			res = append(res, &Error{
+				Index:   i,
+				Message: fmt.Sprintf("platform %s is listed more than once", platform),
+			})
+		}
+
+		seen[platform] = true
+	}
+
+	return res
+}
+
+// IsMulti returns true if an image is built for multiple platforms, in which case the
+// build pushes the image instead of storing it in the local Docker daemon
+func IsMulti(platforms []string) bool {
+	return len(platforms) > 1
+}
@@ -0,0 +1,51 @@
+package platform_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/platform"
+)
+
+type validatePlatformsTest struct {
+	name      string
+	platforms []string
+	expErr    bool
+}
+
+var validatePlatformsTests = []validatePlatformsTest{
+	{
+		name:      "single platform",
+		platforms: []string{"linux/arm64"},
+	},
+	{
+		name:      "platforms with a variant",
+		platforms: []string{"linux/amd64", "linux/arm/v7"},
+	},
+	{
+		name:      "missing architecture",
+		platforms: []string{"linux"},
+		expErr:    true,
+	},
+	{
+		name:      "uppercase platform",
+		platforms: []string{"Linux/AMD64"},
+		expErr:    true,
+	},
+	{
+		name:      "duplicate platform",
+		platforms: []string{"linux/arm64", "linux/arm64"},
+		expErr:    true,
+	},
+}
+
+func TestValidatePlatforms(t *testing.T) {
+	for _, test := range validatePlatformsTests {
+		errs := platform.Validate(test.platforms)
+
+		if test.expErr && len(errs) == 0 {
+			t.Errorf("%s: expected an error, got nil", test.name)
+		} else if !test.expErr && len(errs) != 0 {
+			t.Errorf("%s: unexpected error: %v", test.name, errs[0])
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package porteryaml
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// formField is a field of a chart's form.yaml which sets a value of the chart
+type formField struct {
+	Variable string
+	Type     string
+
+	// InputType is the type of an input field, such as "number"
+	InputType string
+	HasUnit   bool
+
+	// Options are the values which can be selected by a select field
+	Options []string
+}
+
+type formYAML struct {
+	Tabs []struct {
+		Sections []struct {
+			Contents []struct {
+				Type     string `yaml:"type"`
+				Variable string `yaml:"variable"`
+				Settings struct {
+					Type              string      `yaml:"type"`
+					Unit              interface{} `yaml:"unit"`
+					OmitUnitFromValue bool        `yaml:"omitUnitFromValue"`
+					Options           interface{} `yaml:"options"`
+				} `yaml:"settings"`
+			} `yaml:"contents"`
+		} `yaml:"sections"`
+	} `yaml:"tabs"`
+}
+
+// parseFormFields reads the fields of a form.yaml file which set values
+func parseFormFields(raw []byte) ([]*formField, error) {
+	form := &formYAML{}
+
+	if err := yaml.Unmarshal(raw, form); err != nil {
+		return nil, err
+	}
+
+	res := make([]*formField, 0)
+
+	for _, tab := range form.Tabs {
+		for _, section := range tab.Sections {
+			for _, content := range section.Contents {
+				if content.Variable == "" {
+					continue
+				}
+
+				field := &formField{
+					Variable:  content.Variable,
+					Type:      content.Type,
+					InputType: content.Settings.Type,
+					HasUnit:   content.Settings.Unit != nil && !content.Settings.OmitUnitFromValue,
+				}
+
+				if content.Type == "select" && content.Settings.Type != "provider" {
+					if options, ok := content.Settings.Options.([]interface{}); ok {
+						for _, option := range options {
+							if optionMap, ok := option.(map[string]interface{}); ok {
+								if value, ok := optionMap["value"]; ok {
+									field.Options = append(field.Options, fmt.Sprint(value))
+								}
+							}
+						}
+					}
+				}
+
+				res = append(res, field)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// check returns a description of why the value cannot be set by the field, or an empty
+// string if it can
+func (f *formField) check(val interface{}) string {
+	switch f.Type {
+	case "checkbox":
+		if _, ok := val.(bool); !ok {
+			return "expected a boolean"
+		}
+	case "input":
+		switch val.(type) {
+		case float64, bool:
+		case string:
+			// values of number inputs with a unit are strings, such as "256Mi"
+			if f.InputType == "number" && !f.HasUnit {
+				return "expected a number"
+			}
+		default:
+			if f.InputType == "number" {
+				return "expected a number"
+			}
+
+			return "expected a string"
+		}
+	case "array-input":
+		if _, ok := val.([]interface{}); !ok {
+			return "expected a list"
+		}
+	case "key-value-array":
+		if _, ok := val.(map[string]interface{}); !ok {
+			return "expected a map"
+		}
+	case "select":
+		if len(f.Options) == 0 {
+			return ""
+		}
+
+		strVal := fmt.Sprint(val)
+
+		for _, option := range f.Options {
+			if option == strVal {
+				return ""
+			}
+		}
+
+		return fmt.Sprintf("expected one of %s", strings.Join(f.Options, ", "))
+	}
+
+	return ""
+}
@@ -0,0 +1,95 @@
+package porteryaml
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// lineIndex looks up the line numbers of fields in a porter.yaml file
+type lineIndex struct {
+	root *yaml.Node
+}
+
+func newLineIndex(raw []byte) *lineIndex {
+	doc := &yaml.Node{}
+
+	if err := yaml.Unmarshal(raw, doc); err != nil || len(doc.Content) == 0 {
+		return &lineIndex{}
+	}
+
+	return &lineIndex{
+		root: doc.Content[0],
+	}
+}
+
+// line returns the line of the field at the given path, where each element of the path
+// is either a map key or a list index. If the field does not exist, the line of the
+// closest parent which exists is returned.
+func (l *lineIndex) line(path ...interface{}) int {
+	_, line := l.lookup(path)
+
+	return line
+}
+
+// keys returns the keys of the map at the given path, along with their lines
+func (l *lineIndex) keys(path ...interface{}) map[string]int {
+	res := make(map[string]int)
+
+	node, _ := l.lookup(path)
+
+	if node == nil || node.Kind != yaml.MappingNode {
+		return res
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		res[node.Content[i].Value] = node.Content[i].Line
+	}
+
+	return res
+}
+
+// lookup returns the node at the given path, or nil if it does not exist, along with the
+// line of the deepest field of the path which exists
+func (l *lineIndex) lookup(path []interface{}) (*yaml.Node, int) {
+	if l.root == nil {
+		return nil, 0
+	}
+
+	node := l.root
+	line := node.Line
+
+	for _, elem := range path {
+		var next *yaml.Node
+
+		switch key := elem.(type) {
+		case string:
+			if node.Kind != yaml.MappingNode {
+				return nil, line
+			}
+
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					// use the line of the key, since the value of a map or list starts on
+					// the next line
+					line = node.Content[i].Line
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case int:
+			if node.Kind != yaml.SequenceNode || key < 0 || key >= len(node.Content) {
+				return nil, line
+			}
+
+			next = node.Content[key]
+			line = next.Line
+		}
+
+		if next == nil {
+			return nil, line
+		}
+
+		node = next
+	}
+
+	return node, line
+}
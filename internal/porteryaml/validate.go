@@ -0,0 +1,752 @@
+package porteryaml
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/platform"
+	"github.com/porter-dev/switchboard/pkg/parser"
+	switchboardTypes "github.com/porter-dev/switchboard/pkg/types"
+)
+
+// The drivers which can be used by resources in porter.yaml
+const (
+	DeployDriver   = "porter.deploy"
+	EnvGroupDriver = "porter.env_group"
+	JobRunDriver   = "porter.job_run"
+)
+
+// ApplicationRepoURL is the chart repository of applications, whose values are set
+// under "values" in the config of a resource
+const ApplicationRepoURL = "https://charts.getporter.dev"
+
+// Error is a problem found in a porter.yaml file
+type Error struct {
+	// Line is the line of the field with the problem, or 0 if it is unknown
+	Line int
+
+	// Resource is the name of the resource with the problem, if any
+	Resource string
+
+	Message string
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+
+	if e.Resource != "" {
+		msg = fmt.Sprintf("resource %s: %s", e.Resource, msg)
+	}
+
+	if e.Line > 0 {
+		msg = fmt.Sprintf("line %d: %s", e.Line, msg)
+	}
+
+	return msg
+}
+
+// Source is the chart of a resource deployed by the porter.deploy driver
+type Source struct {
+	Name    string
+	Repo    string
+	Version string
+}
+
+// Chart is the loaded chart of a source
+type Chart struct {
+	// IsApplication is set for charts in the application repository
+	IsApplication bool
+
+	// Form is the form.yaml file of the chart, if it has one
+	Form []byte
+}
+
+type ValidateOpts struct {
+	// LoadChart loads the chart of a source, so that values can be checked against the
+	// form of the chart. A nil chart skips these checks.
+	LoadChart func(source *Source) (*Chart, error)
+}
+
+var (
+	queryRegex     = regexp.MustCompile(`\{(.+)\}`)
+	yamlErrorRegex = regexp.MustCompile(`line (\d+)`)
+)
+
+type validator struct {
+	opts   *ValidateOpts
+	lines  *lineIndex
+	errors []*Error
+}
+
+// Validate checks a porter.yaml file without applying it, and returns all problems found
+// sorted by line
+func Validate(raw []byte, opts *ValidateOpts) []*Error {
+	if opts == nil {
+		opts = &ValidateOpts{}
+	}
+
+	resGroup, err := parser.ParseRawBytes(raw)
+
+	if err != nil {
+		line := 0
+
+		if match := yamlErrorRegex.FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
+
+		return []*Error{{Line: line, Message: err.Error()}}
+	}
+
+	v := &validator{
+		opts:  opts,
+		lines: newLineIndex(raw),
+	}
+
+	v.validate(resGroup)
+
+	sort.Slice(v.errors, func(i, j int) bool {
+		if v.errors[i].Line != v.errors[j].Line {
+			return v.errors[i].Line < v.errors[j].Line
+		}
+
+		return v.errors[i].Error() < v.errors[j].Error()
+	})
+
+	return v.errors
+}
+
+func (v *validator) addError(line int, resource, format string, args ...interface{}) {
+	v.errors = append(v.errors, &Error{
+		Line:     line,
+		Resource: resource,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) checkKeys(resource string, allowed []string, path ...interface{}) {
+	for key, line := range v.lines.keys(path...) {
+		if !contains(allowed, key) {
+			v.addError(line, resource, "unknown field %s", key)
+		}
+	}
+}
+
+func (v *validator) validate(resGroup *switchboardTypes.ResourceGroup) {
+	v.checkKeys("", []string{"version", "resources"})
+
+	if len(resGroup.Resources) == 0 {
+		v.addError(v.lines.line("resources"), "", "no resources declared")
+		return
+	}
+
+	names := make(map[string]bool)
+
+	for i, resource := range resGroup.Resources {
+		if resource.Name == "" {
+			v.addError(v.lines.line("resources", i), "", "resource %d has no name", i)
+			continue
+		}
+
+		if names[resource.Name] {
+			v.addError(v.lines.line("resources", i, "name"), resource.Name, "duplicate resource name")
+		}
+
+		names[resource.Name] = true
+	}
+
+	for i, resource := range resGroup.Resources {
+		v.validateResource(i, resource, names)
+	}
+
+	v.checkCycles(resGroup)
+}
+
+func (v *validator) validateResource(i int, resource *switchboardTypes.Resource, names map[string]bool) {
+	name := resource.Name
+
+	v.checkKeys(name, []string{"name", "driver", "source", "target", "config", "depends_on"}, "resources", i)
+
+	for j, dependency := range resource.DependsOn {
+		line := v.lines.line("resources", i, "depends_on", j)
+
+		if dependency == name {
+			v.addError(line, name, "resource cannot depend on itself")
+		} else if !names[dependency] {
+			v.addError(line, name, "depends on resource %s, which is not declared", dependency)
+		}
+	}
+
+	v.checkQueries(name, resource.Config, resource.DependsOn, "resources", i, "config")
+
+	v.validateTarget(i, resource)
+
+	switch resource.Driver {
+	case "", DeployDriver:
+		source := v.validateSource(i, resource)
+		v.validateDeployConfig(i, resource, source)
+	case EnvGroupDriver:
+		if len(resource.Source) > 0 {
+			v.addError(v.lines.line("resources", i, "source"), name, "source is not used by the %s driver", EnvGroupDriver)
+		}
+
+		v.validateEnvGroupConfig(i, resource)
+	case JobRunDriver:
+		if len(resource.Source) > 0 {
+			v.addError(v.lines.line("resources", i, "source"), name, "source is not used by the %s driver", JobRunDriver)
+		}
+
+		v.validateJobRunConfig(i, resource)
+	default:
+		v.addError(
+			v.lines.line("resources", i, "driver"), name,
+			"unknown driver %s: must be one of %s, %s or %s", resource.Driver, DeployDriver, EnvGroupDriver, JobRunDriver,
+		)
+	}
+}
+
+func (v *validator) validateTarget(i int, resource *switchboardTypes.Resource) {
+	v.checkKeys(resource.Name, []string{"project", "cluster", "namespace"}, "resources", i, "target")
+
+	for _, key := range []string{"project", "cluster"} {
+		if val, ok := resource.Target[key]; ok && !isPositiveInteger(val) {
+			v.addError(v.lines.line("resources", i, "target", key), resource.Name, "target %s must be a positive integer", key)
+		}
+	}
+
+	if val, ok := resource.Target["namespace"]; ok {
+		if _, ok := val.(string); !ok {
+			v.addError(v.lines.line("resources", i, "target", "namespace"), resource.Name, "target namespace must be a string")
+		}
+	}
+}
+
+func (v *validator) validateSource(i int, resource *switchboardTypes.Resource) *Source {
+	name := resource.Name
+
+	v.checkKeys(name, []string{"name", "repo", "version"}, "resources", i, "source")
+
+	source := &Source{
+		Name:    os.Getenv("PORTER_SOURCE_NAME"),
+		Repo:    os.Getenv("PORTER_SOURCE_REPO"),
+		Version: os.Getenv("PORTER_SOURCE_VERSION"),
+	}
+
+	valid := true
+
+	for key, dst := range map[string]*string{
+		"name":    &source.Name,
+		"repo":    &source.Repo,
+		"version": &source.Version,
+	} {
+		val, ok := resource.Source[key]
+
+		if !ok {
+			continue
+		}
+
+		strVal, ok := val.(string)
+
+		if !ok {
+			// versions such as 1.0 are parsed as numbers, and must be quoted
+			v.addError(v.lines.line("resources", i, "source", key), name, "source %s must be a string", key)
+			valid = false
+			continue
+		}
+
+		if *dst == "" {
+			*dst = strVal
+		}
+	}
+
+	if source.Name == "" {
+		v.addError(v.lines.line("resources", i, "source"), name, "source name is required")
+		valid = false
+	}
+
+	if source.Repo != "" {
+		if u, err := url.ParseRequestURI(source.Repo); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			v.addError(v.lines.line("resources", i, "source", "repo"), name, "source repo %s is not a valid URL", source.Repo)
+			valid = false
+		}
+	}
+
+	if !valid {
+		return nil
+	}
+
+	if source.Version == "" {
+		source.Version = "latest"
+	}
+
+	return source
+}
+
+func (v *validator) validateDeployConfig(i int, resource *switchboardTypes.Resource, source *Source) {
+	name := resource.Name
+
+	var chart *Chart
+
+	if source != nil && v.opts.LoadChart != nil {
+		var err error
+
+		chart, err = v.opts.LoadChart(source)
+
+		if err != nil {
+			v.addError(v.lines.line("resources", i, "source"), name, "could not load chart: %v", err)
+		}
+	}
+
+	isApplication := false
+
+	if chart != nil {
+		isApplication = chart.IsApplication
+	} else if source != nil {
+		_, hasBuild := resource.Config["build"]
+		isApplication = source.Repo == ApplicationRepoURL || (source.Repo == "" && hasBuild)
+	}
+
+	values := resource.Config
+	valuesPath := []interface{}{"resources", i, "config"}
+
+	if isApplication {
+		v.validateApplicationConfig(i, resource)
+
+		values, _ = resource.Config["values"].(map[string]interface{})
+		valuesPath = append(valuesPath, "values")
+	}
+
+	if chart == nil || len(chart.Form) == 0 || values == nil {
+		return
+	}
+
+	fields, err := parseFormFields(chart.Form)
+
+	if err != nil {
+		v.addError(v.lines.line("resources", i, "source"), name, "could not read form.yaml of chart %s: %v", source.Name, err)
+		return
+	}
+
+	for _, field := range fields {
+		val, ok := getValue(values, field.Variable)
+
+		if !ok || isQuery(val) {
+			continue
+		}
+
+		if msg := field.check(val); msg != "" {
+			path := append(append([]interface{}{}, valuesPath...), splitVariable(field.Variable)...)
+
+			v.addError(v.lines.line(path...), name, "invalid value for %s: %s", field.Variable, msg)
+		}
+	}
+}
+
+func (v *validator) validateApplicationConfig(i int, resource *switchboardTypes.Resource) {
+	name := resource.Name
+
+	v.checkKeys(name, []string{"waitForJob", "build", "values"}, "resources", i, "config")
+
+	if val, ok := resource.Config["waitForJob"]; ok {
+		if _, ok := val.(bool); !ok {
+			v.addError(v.lines.line("resources", i, "config", "waitForJob"), name, "waitForJob must be a boolean")
+		}
+	}
+
+	if val, ok := resource.Config["values"]; ok {
+		if _, ok := val.(map[string]interface{}); !ok {
+			v.addError(v.lines.line("resources", i, "config", "values"), name, "values must be a map")
+		}
+	}
+
+	buildLine := v.lines.line("resources", i, "config", "build")
+
+	build, ok := resource.Config["build"].(map[string]interface{})
+
+	if !ok {
+		v.addError(buildLine, name, "build must be a map with a method")
+		return
+	}
+
+	v.checkKeys(
+		name,
+		[]string{"method", "context", "dockerfile", "image", "builder", "buildpacks", "remote", "cache", "platforms"},
+		"resources", i, "config", "build",
+	)
+
+	for _, key := range []string{"method", "context", "dockerfile", "image", "builder"} {
+		if val, ok := build[key]; ok && !isString(val) {
+			v.addError(v.lines.line("resources", i, "config", "build", key), name, "build %s must be a string", key)
+		}
+	}
+
+	if val, ok := build["buildpacks"]; ok && !isStringList(val) {
+		v.addError(v.lines.line("resources", i, "config", "build", "buildpacks"), name, "build buildpacks must be a list of strings")
+	}
+
+	if val, ok := build["remote"]; ok {
+		if _, ok := val.(bool); !ok {
+			v.addError(v.lines.line("resources", i, "config", "build", "remote"), name, "build remote must be a boolean")
+		} else if remote, _ := val.(bool); remote {
+			if buildpacks, _ := build["buildpacks"].([]interface{}); len(buildpacks) > 0 {
+				v.addError(
+					v.lines.line("resources", i, "config", "build", "buildpacks"), name,
+					"build buildpacks are not supported by remote builds, which use the buildpacks of the builder",
+				)
+			}
+		}
+	}
+
+	method, _ := build["method"].(string)
+
+	switch method {
+	case "pack", "docker":
+	case "registry":
+		if image, _ := build["image"].(string); image == "" {
+			v.addError(buildLine, name, "build image is required for the registry method")
+		}
+	default:
+		v.addError(
+			v.lines.line("resources", i, "config", "build", "method"), name,
+			"build method should either be \"docker\", \"pack\" or \"registry\"",
+		)
+	}
+
+	if val, ok := build["cache"]; ok {
+		remote, _ := build["remote"].(bool)
+
+		v.validateBuildCache(i, name, val, method, remote)
+	}
+
+	if val, ok := build["platforms"]; ok {
+		remote, _ := build["remote"].(bool)
+
+		v.validateBuildPlatforms(i, name, val, method, remote)
+	}
+}
+
+func (v *validator) validateBuildPlatforms(i int, name string, val interface{}, method string, remote bool) {
+	platformsLine := v.lines.line("resources", i, "config", "build", "platforms")
+
+	if !isStringList(val) {
+		v.addError(platformsLine, name, "build platforms must be a list of strings")
+		return
+	}
+
+	platforms := toStringList(val)
+	invalid := make(map[int]bool)
+
+	for _, err := range platform.Validate(platforms) {
+		v.addError(v.lines.line("resources", i, "config", "build", "platforms", err.Index), name, "%s", err.Message)
+		invalid[err.Index] = true
+	}
+
+	for j, p := range platforms {
+		if method == "pack" && !invalid[j] && p != platform.Default {
+			v.addError(
+				v.lines.line("resources", i, "config", "build", "platforms", j), name,
+				"platform %s is not supported by the pack method, which only supports %s", p, platform.Default,
+			)
+		}
+	}
+
+	if method == "registry" {
+		v.addError(platformsLine, name, "build platforms are not used by the registry method")
+	} else if remote && len(platforms) > 0 {
+		v.addError(platformsLine, name, "build platforms are not supported by remote builds, which run on the platform of the cluster's nodes")
+	}
+}
+
+func (v *validator) validateBuildCache(i int, name string, val interface{}, method string, remote bool) {
+	cacheLine := v.lines.line("resources", i, "config", "build", "cache")
+
+	cache, ok := val.(map[string]interface{})
+
+	if !ok {
+		v.addError(cacheLine, name, "build cache must be a map")
+		return
+	}
+
+	v.checkKeys(name, []string{"enabled", "ref", "from"}, "resources", i, "config", "build", "cache")
+
+	if _, ok := cache["enabled"].(bool); !ok && cache["enabled"] != nil {
+		v.addError(v.lines.line("resources", i, "config", "build", "cache", "enabled"), name, "build cache enabled must be a boolean")
+	}
+
+	if val, ok := cache["ref"]; ok && !isString(val) {
+		v.addError(v.lines.line("resources", i, "config", "build", "cache", "ref"), name, "build cache ref must be a string")
+	}
+
+	if val, ok := cache["from"]; ok && !isStringList(val) {
+		v.addError(v.lines.line("resources", i, "config", "build", "cache", "from"), name, "build cache from must be a list of strings")
+	}
+
+	if enabled, _ := cache["enabled"].(bool); !enabled {
+		return
+	}
+
+	if method != "docker" {
+		v.addError(cacheLine, name, "build cache is only supported by the docker method")
+	} else if remote {
+		v.addError(cacheLine, name, "build cache is not supported by remote builds")
+	}
+}
+
+func (v *validator) validateEnvGroupConfig(i int, resource *switchboardTypes.Resource) {
+	name := resource.Name
+
+	v.checkKeys(name, []string{"variables", "secretVariables"}, "resources", i, "config")
+
+	variables := make(map[string]bool)
+
+	for _, key := range []string{"variables", "secretVariables"} {
+		val, ok := resource.Config[key]
+
+		if !ok {
+			continue
+		}
+
+		vars, ok := val.(map[string]interface{})
+
+		if !ok {
+			v.addError(v.lines.line("resources", i, "config", key), name, "%s must be a map", key)
+			continue
+		}
+
+		for varName, varVal := range vars {
+			line := v.lines.line("resources", i, "config", key, varName)
+
+			if !isScalar(varVal) {
+				v.addError(line, name, "value of variable %s must be a string", varName)
+			}
+
+			if variables[varName] {
+				v.addError(line, name, "variable %s is both a variable and a secret variable", varName)
+			}
+
+			variables[varName] = true
+		}
+	}
+}
+
+func (v *validator) validateJobRunConfig(i int, resource *switchboardTypes.Resource) {
+	name := resource.Name
+
+	v.checkKeys(name, []string{"job", "command", "env", "timeout"}, "resources", i, "config")
+
+	if val, ok := resource.Config["job"]; ok && !isString(val) {
+		v.addError(v.lines.line("resources", i, "config", "job"), name, "job must be a string")
+	}
+
+	if val, ok := resource.Config["command"]; ok && !isStringList(val) {
+		v.addError(v.lines.line("resources", i, "config", "command"), name, "command must be a list of strings")
+	}
+
+	if val, ok := resource.Config["env"]; ok {
+		env, ok := val.(map[string]interface{})
+
+		if !ok {
+			v.addError(v.lines.line("resources", i, "config", "env"), name, "env must be a map")
+		}
+
+		for key, envVal := range env {
+			if !isScalar(envVal) {
+				v.addError(v.lines.line("resources", i, "config", "env", key), name, "value of env variable %s must be a string", key)
+			}
+		}
+	}
+
+	if val, ok := resource.Config["timeout"]; ok && !isQuery(val) && !isPositiveInteger(val) {
+		v.addError(v.lines.line("resources", i, "config", "timeout"), name, "timeout must be a positive number of seconds")
+	}
+}
+
+// checkQueries makes sure that the resources referenced by queries such as
+// "{ .db.host }" are dependencies, since only the outputs of dependencies can be queried
+func (v *validator) checkQueries(resource string, val interface{}, dependencies []string, path ...interface{}) {
+	switch typedVal := val.(type) {
+	case map[string]interface{}:
+		for key, elem := range typedVal {
+			v.checkQueries(resource, elem, dependencies, append(append([]interface{}{}, path...), key)...)
+		}
+	case []interface{}:
+		for i, elem := range typedVal {
+			v.checkQueries(resource, elem, dependencies, append(append([]interface{}{}, path...), i)...)
+		}
+	case string:
+		match := queryRegex.FindStringSubmatch(typedVal)
+
+		if match == nil {
+			return
+		}
+
+		query := strings.TrimPrefix(strings.TrimSpace(match[1]), ".")
+		referenced := strings.FieldsFunc(query, func(r rune) bool {
+			return r == '.' || r == '['
+		})
+
+		if len(referenced) == 0 {
+			return
+		}
+
+		if !contains(dependencies, referenced[0]) {
+			v.addError(
+				v.lines.line(path...), resource,
+				"query %s references resource %s, which must be listed in depends_on", typedVal, referenced[0],
+			)
+		}
+	}
+}
+
+// checkCycles reports dependencies between resources which form a cycle
+func (v *validator) checkCycles(resGroup *switchboardTypes.ResourceGroup) {
+	resources := make(map[string]*switchboardTypes.Resource)
+	indices := make(map[string]int)
+
+	for i, resource := range resGroup.Resources {
+		resources[resource.Name] = resource
+		indices[resource.Name] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int)
+
+	var visit func(name string, chain []string)
+
+	visit = func(name string, chain []string) {
+		resource, ok := resources[name]
+
+		if !ok || state[name] == visited {
+			return
+		}
+
+		if state[name] == visiting {
+			v.addError(
+				v.lines.line("resources", indices[name], "depends_on"), name,
+				"circular dependency: %s", strings.Join(append(chain, name), " -> "),
+			)
+			return
+		}
+
+		state[name] = visiting
+
+		for _, dependency := range resource.DependsOn {
+			if dependency != name {
+				visit(dependency, append(chain, name))
+			}
+		}
+
+		state[name] = visited
+	}
+
+	for _, resource := range resGroup.Resources {
+		if state[resource.Name] == unvisited {
+			visit(resource.Name, nil)
+		}
+	}
+}
+
+// getValue returns the value of a form variable such as "container.port"
+func getValue(values map[string]interface{}, variable string) (interface{}, bool) {
+	var curr interface{} = values
+
+	for _, key := range strings.Split(variable, ".") {
+		currMap, ok := curr.(map[string]interface{})
+
+		if !ok {
+			return nil, false
+		}
+
+		if curr, ok = currMap[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return curr, true
+}
+
+func splitVariable(variable string) []interface{} {
+	res := make([]interface{}, 0)
+
+	for _, key := range strings.Split(variable, ".") {
+		res = append(res, key)
+	}
+
+	return res
+}
+
+func isQuery(val interface{}) bool {
+	strVal, ok := val.(string)
+
+	return ok && queryRegex.MatchString(strVal)
+}
+
+func isString(val interface{}) bool {
+	_, ok := val.(string)
+
+	return ok
+}
+
+func isScalar(val interface{}) bool {
+	switch val.(type) {
+	case string, float64, bool:
+		return true
+	}
+
+	return false
+}
+
+func isStringList(val interface{}) bool {
+	list, ok := val.([]interface{})
+
+	if !ok {
+		return false
+	}
+
+	for _, elem := range list {
+		if !isString(elem) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func toStringList(val interface{}) []string {
+	list, _ := val.([]interface{})
+	res := make([]string, 0, len(list))
+
+	for _, elem := range list {
+		str, _ := elem.(string)
+		res = append(res, str)
+	}
+
+	return res
+}
+
+// isPositiveInteger checks numbers in porter.yaml, which are parsed as floats
+func isPositiveInteger(val interface{}) bool {
+	floatVal, ok := val.(float64)
+
+	return ok && floatVal > 0 && floatVal == math.Trunc(floatVal)
+}
+
+func contains(arr []string, str string) bool {
+	for _, elem := range arr {
+		if elem == str {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,381 @@
+package porteryaml
+
+import (
+	"reflect"
+	"testing"
+)
+
+const validPorterYAML = `version: v1
+resources:
+- name: shared-env
+  driver: porter.env_group
+  config:
+    variables:
+      LOG_LEVEL: info
+      WORKERS: 4
+- name: migrate
+  driver: porter.job_run
+  depends_on:
+  - shared-env
+  config:
+    command: ["python", "manage.py", "migrate"]
+    timeout: 600
+- name: web
+  depends_on:
+  - migrate
+  - shared-env
+  source:
+    name: web
+    repo: https://charts.getporter.dev
+    version: 0.50.0
+  target:
+    project: 1
+    cluster: 2
+    namespace: default
+  config:
+    build:
+      method: pack
+      context: .
+      buildpacks:
+      - heroku/python
+    values:
+      container:
+        port: 8000
+        env:
+          normal:
+            LOG_LEVEL: "{ .shared-env.variables.LOG_LEVEL }"
+- name: redis
+  source:
+    name: redis
+    repo: https://chart-addons.getporter.dev
+  config:
+    replicas: 1
+`
+
+const form = `tabs:
+- name: main
+  sections:
+  - name: container
+    contents:
+    - type: input
+      variable: container.port
+      settings:
+        type: number
+    - type: key-value-array
+      variable: container.env.normal
+    - type: checkbox
+      variable: ingress.enabled
+    - type: select
+      variable: resources.tier
+      settings:
+        options:
+        - label: Small
+          value: small
+        - label: Large
+          value: large
+    - type: input
+      variable: resources.memory
+      settings:
+        type: number
+        unit: Mi
+`
+
+type validateTest struct {
+	name     string
+	raw      string
+	expected []*Error
+}
+
+var validateTests = []validateTest{
+	{
+		name:     "valid",
+		raw:      validPorterYAML,
+		expected: nil,
+	},
+	{
+		name: "invalid yaml",
+		raw: `version: v1
+resources:
+- name: web
+   driver: porter.deploy
+`,
+		expected: []*Error{{
+			Line:    4,
+			Message: "error converting YAML to JSON: yaml: line 4: mapping values are not allowed in this context",
+		}},
+	},
+	{
+		name: "unknown driver and field",
+		raw: `version: v1
+resources:
+- name: web
+  driver: porter.helm
+  dependsOn:
+  - db
+`,
+		expected: []*Error{
+			{Line: 4, Resource: "web", Message: "unknown driver porter.helm: must be one of porter.deploy, porter.env_group or porter.job_run"},
+			{Line: 5, Resource: "web", Message: "unknown field dependsOn"},
+		},
+	},
+	{
+		name: "dependencies",
+		raw: `version: v1
+resources:
+- name: a
+  driver: porter.env_group
+  depends_on:
+  - b
+  - c
+  config:
+    variables:
+      HOST: "{ .d.host }"
+- name: b
+  driver: porter.env_group
+  depends_on:
+  - a
+`,
+		expected: []*Error{
+			{Line: 5, Resource: "a", Message: "circular dependency: a -> b -> a"},
+			{Line: 7, Resource: "a", Message: "depends on resource c, which is not declared"},
+			{Line: 10, Resource: "a", Message: "query { .d.host } references resource d, which must be listed in depends_on"},
+		},
+	},
+	{
+		name: "source and target",
+		raw: `version: v1
+resources:
+- name: web
+  source:
+    name: web
+    repo: charts.getporter.dev
+    version: 1.0
+  target:
+    project: one
+    namespace: default
+`,
+		expected: []*Error{
+			{Line: 6, Resource: "web", Message: "source repo charts.getporter.dev is not a valid URL"},
+			{Line: 7, Resource: "web", Message: "source version must be a string"},
+			{Line: 9, Resource: "web", Message: "target project must be a positive integer"},
+		},
+	},
+	{
+		name: "build",
+		raw: `version: v1
+resources:
+- name: web
+  source:
+    name: web
+    repo: https://charts.getporter.dev
+  config:
+    waitForJob: "yes"
+    build:
+      method: registry
+      buildpacks: heroku/python
+      remote: "true"
+`,
+		expected: []*Error{
+			{Line: 8, Resource: "web", Message: "waitForJob must be a boolean"},
+			{Line: 9, Resource: "web", Message: "build image is required for the registry method"},
+			{Line: 11, Resource: "web", Message: "build buildpacks must be a list of strings"},
+			{Line: 12, Resource: "web", Message: "build remote must be a boolean"},
+		},
+	},
+	{
+		name: "remote build",
+		raw: `version: v1
+resources:
+- name: web
+  source:
+    name: web
+  config:
+    build:
+      method: pack
+      buildpacks:
+      - heroku/python
+      remote: true
+- name: worker
+  source:
+    name: worker
+  config:
+    build:
+      method: docker
+      dockerfile: ./Dockerfile
+      remote: true
+`,
+		expected: []*Error{
+			{Line: 9, Resource: "web", Message: "build buildpacks are not supported by remote builds, which use the buildpacks of the builder"},
+		},
+	},
+	{
+		name: "build cache",
+		raw: `version: v1
+resources:
+- name: web
+  source:
+    name: web
+  config:
+    build:
+      method: docker
+      cache:
+        enabled: true
+        ref: gcr.io/project/web:cache
+        from:
+        - gcr.io/project/base:porter-cache
+- name: worker
+  source:
+    name: worker
+  config:
+    build:
+      method: pack
+      cache:
+        enabled: "yes"
+        from: gcr.io/project/worker:cache
+        mode: max
+- name: job
+  source:
+    name: job
+  config:
+    build:
+      method: docker
+      remote: true
+      cache:
+        enabled: true
+`,
+		expected: []*Error{
+			{Line: 21, Resource: "worker", Message: "build cache enabled must be a boolean"},
+			{Line: 22, Resource: "worker", Message: "build cache from must be a list of strings"},
+			{Line: 23, Resource: "worker", Message: "unknown field mode"},
+			{Line: 31, Resource: "job", Message: "build cache is not supported by remote builds"},
+		},
+	},
+	{
+		name: "build platforms",
+		raw: `version: v1
+resources:
+- name: web
+  source:
+    name: web
+  config:
+    build:
+      method: docker
+      platforms:
+      - linux/amd64
+      - linux/arm64
+      - linux/arm64
+      - arm64
+- name: worker
+  source:
+    name: worker
+  config:
+    build:
+      method: pack
+      platforms:
+      - linux/amd64
+      - linux/arm64
+- name: job
+  source:
+    name: job
+  config:
+    build:
+      method: docker
+      remote: true
+      platforms: linux/arm64
+- name: cron
+  source:
+    name: cron
+  config:
+    build:
+      method: docker
+      remote: true
+      platforms:
+      - linux/arm64
+`,
+		expected: []*Error{
+			{Line: 12, Resource: "web", Message: "platform linux/arm64 is listed more than once"},
+			{Line: 13, Resource: "web", Message: "invalid platform \"arm64\": platforms must be of the form os/arch[/variant], such as linux/arm64"},
+			{Line: 22, Resource: "worker", Message: "platform linux/arm64 is not supported by the pack method, which only supports linux/amd64"},
+			{Line: 30, Resource: "job", Message: "build platforms must be a list of strings"},
+			{Line: 38, Resource: "cron", Message: "build platforms are not supported by remote builds, which run on the platform of the cluster's nodes"},
+		},
+	},
+	{
+		name: "env group and job run",
+		raw: `version: v1
+resources:
+- name: env
+  driver: porter.env_group
+  config:
+    variables:
+      HOST: localhost
+    secretVariables:
+      HOST: secret
+- name: migrate
+  driver: porter.job_run
+  config:
+    command: python manage.py migrate
+    timeout: -1
+`,
+		expected: []*Error{
+			{Line: 9, Resource: "env", Message: "variable HOST is both a variable and a secret variable"},
+			{Line: 13, Resource: "migrate", Message: "command must be a list of strings"},
+			{Line: 14, Resource: "migrate", Message: "timeout must be a positive number of seconds"},
+		},
+	},
+}
+
+func TestValidate(t *testing.T) {
+	for _, test := range validateTests {
+		errs := Validate([]byte(test.raw), nil)
+
+		if !reflect.DeepEqual(errs, test.expected) {
+			t.Errorf("%s: expected errors %v, got %v", test.name, test.expected, errs)
+		}
+	}
+}
+
+func TestValidateForm(t *testing.T) {
+	raw := `version: v1
+resources:
+- name: web
+  source:
+    name: web
+  config:
+    build:
+      method: pack
+    values:
+      container:
+        port: "8000"
+        env:
+          normal: []
+      ingress:
+        enabled: true
+      resources:
+        tier: medium
+        memory: 256Mi
+`
+
+	errs := Validate([]byte(raw), &ValidateOpts{
+		LoadChart: func(source *Source) (*Chart, error) {
+			if source.Name != "web" || source.Version != "latest" {
+				t.Errorf("unexpected source %v", source)
+			}
+
+			return &Chart{
+				IsApplication: true,
+				Form:          []byte(form),
+			}, nil
+		},
+	})
+
+	expected := []*Error{
+		{Line: 11, Resource: "web", Message: "invalid value for container.port: expected a number"},
+		{Line: 13, Resource: "web", Message: "invalid value for container.env.normal: expected a map"},
+		{Line: 17, Resource: "web", Message: "invalid value for resources.tier: expected one of small, large"},
+	}
+
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected errors %v, got %v", expected, errs)
+	}
+}
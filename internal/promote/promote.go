@@ -0,0 +1,87 @@
+package promote
+
+import (
+	"github.com/porter-dev/porter/internal/envgroup"
+	"github.com/porter-dev/porter/internal/templater/utils"
+)
+
+// Options are the changes applied to the values of a release when it is promoted to a
+// target namespace or cluster
+type Options struct {
+	// ImageTag replaces the image tag of the release, if set
+	ImageTag string
+
+	// Overrides are merged on top of the values of the release
+	Overrides map[string]interface{}
+
+	// EnvGroups maps the env groups referenced by the release to the env groups of the
+	// target namespace
+	EnvGroups map[string]string
+}
+
+// Values returns the values of the promoted release, starting from a copy of the values
+// of the source release. Env groups are mapped before the overrides are merged, so that
+// overrides can reference env groups of the target namespace directly.
+func Values(source map[string]interface{}, opts *Options) map[string]interface{} {
+	values := copyMap(source)
+
+	envgroup.MapEnvGroups(values, opts.EnvGroups)
+
+	if opts.ImageTag != "" {
+		image, ok := values["image"].(map[string]interface{})
+
+		if !ok {
+			image = make(map[string]interface{})
+			values["image"] = image
+		}
+
+		image["tag"] = opts.ImageTag
+	}
+
+	if len(opts.Overrides) > 0 {
+		values = utils.CoalesceValues(values, copyMap(opts.Overrides))
+	}
+
+	return values
+}
+
+// ImageTag returns the image tag in the values of a release, or an empty string if the
+// release does not have an image
+func ImageTag(values map[string]interface{}) string {
+	image, ok := values["image"].(map[string]interface{})
+
+	if !ok {
+		return ""
+	}
+
+	tag, _ := image["tag"].(string)
+
+	return tag
+}
+
+func copyMap(obj map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(obj))
+
+	for key, val := range obj {
+		res[key] = copyValue(val)
+	}
+
+	return res
+}
+
+func copyValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return copyMap(v)
+	case []interface{}:
+		res := make([]interface{}, len(v))
+
+		for i := range v {
+			res[i] = copyValue(v[i])
+		}
+
+		return res
+	default:
+		return v
+	}
+}
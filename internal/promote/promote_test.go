@@ -0,0 +1,92 @@
+package promote
+
+import (
+	"reflect"
+	"testing"
+)
+
+func getSourceValues() map[string]interface{} {
+	return map[string]interface{}{
+		"replicaCount": 1,
+		"image": map[string]interface{}{
+			"repository": "registry.example.com/app",
+			"tag":        "staging-1a2b3c",
+		},
+		"container": map[string]interface{}{
+			"env": map[string]interface{}{
+				"normal": map[string]interface{}{
+					"LOG_LEVEL":    "debug",
+					"DB_PASSWORD":  "PORTERSECRET_staging-env",
+					"STRIPE_TOKEN": "PORTERSECRET_shared-env",
+				},
+			},
+		},
+	}
+}
+
+func TestValues(t *testing.T) {
+	source := getSourceValues()
+
+	values := Values(source, &Options{
+		ImageTag: "v1.2.0",
+		Overrides: map[string]interface{}{
+			"replicaCount": 3,
+			"container": map[string]interface{}{
+				"env": map[string]interface{}{
+					"normal": map[string]interface{}{
+						"LOG_LEVEL": "info",
+					},
+				},
+			},
+		},
+		EnvGroups: map[string]string{
+			"staging-env": "production-env",
+		},
+	})
+
+	expected := map[string]interface{}{
+		"replicaCount": 3,
+		"image": map[string]interface{}{
+			"repository": "registry.example.com/app",
+			"tag":        "v1.2.0",
+		},
+		"container": map[string]interface{}{
+			"env": map[string]interface{}{
+				"normal": map[string]interface{}{
+					"LOG_LEVEL":    "info",
+					"DB_PASSWORD":  "PORTERSECRET_production-env",
+					"STRIPE_TOKEN": "PORTERSECRET_shared-env",
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected values %v, got %v", expected, values)
+	}
+
+	// the values of the source release must not be modified
+	if !reflect.DeepEqual(source, getSourceValues()) {
+		t.Errorf("source values were modified: %v", source)
+	}
+}
+
+func TestValuesWithoutOptions(t *testing.T) {
+	values := Values(getSourceValues(), &Options{})
+
+	if !reflect.DeepEqual(values, getSourceValues()) {
+		t.Errorf("expected values to be unchanged, got %v", values)
+	}
+
+	if tag := ImageTag(values); tag != "staging-1a2b3c" {
+		t.Errorf("expected image tag staging-1a2b3c, got %s", tag)
+	}
+}
+
+func TestValuesWithoutImage(t *testing.T) {
+	values := Values(map[string]interface{}{}, &Options{ImageTag: "v1.2.0"})
+
+	if tag := ImageTag(values); tag != "v1.2.0" {
+		t.Errorf("expected image tag v1.2.0, got %s", tag)
+	}
+}
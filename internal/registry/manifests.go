@@ -0,0 +1,499 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/digitalocean/godo"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/repository"
+	"golang.org/x/oauth2"
+)
+
+// indexMediaTypes are the media types of manifests which reference the manifests of
+// other images, which are pushed for multi-platform images
+var indexMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+// Manifest is an image manifest in an image repository, with all of the tags which
+// point to it. Untagged manifests have no tags.
+type Manifest struct {
+	Digest    string
+	MediaType string
+	Tags      []string
+
+	// PushedAt is nil if the registry does not report when the manifest was pushed
+	PushedAt *time.Time
+
+	// Children are the digests of the manifests which an image index references, for
+	// multi-platform images. Children are usually untagged, but cannot be deleted while
+	// the index exists.
+	Children []string
+}
+
+// IsIndex returns true if the manifest is an image index of a multi-platform image
+func (m *Manifest) IsIndex() bool {
+	for _, mediaType := range indexMediaTypes {
+		if m.MediaType == mediaType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ErrManifestsNotSupported is returned when a registry does not support listing or
+// deleting the manifests of an image repository
+var ErrManifestsNotSupported = fmt.Errorf("listing and deleting image manifests is only supported for ECR, GCR and DOCR registries")
+
+// ListManifests lists all manifests of an image repository, including untagged ones.
+// DOCR does not list untagged manifests, which are removed by its garbage collection.
+func (r *Registry) ListManifests(
+	repoName string,
+	repo repository.Repository,
+	doAuth *oauth2.Config, // only required if using DOCR
+) ([]*Manifest, error) {
+	if r.AWSIntegrationID != 0 {
+		return r.listECRManifests(repoName, repo)
+	}
+
+	if r.GCPIntegrationID != 0 {
+		return r.listGCRManifests(repoName, repo)
+	}
+
+	if r.DOIntegrationID != 0 {
+		return r.listDOCRManifests(repoName, repo, doAuth)
+	}
+
+	return nil, ErrManifestsNotSupported
+}
+
+// DeleteImages removes tags and manifests from an image repository. Tags are removed
+// before manifests, and manifests are deleted by their digest, so they should not have
+// any tags left.
+func (r *Registry) DeleteImages(
+	repoName string,
+	tags, digests []string,
+	repo repository.Repository,
+	doAuth *oauth2.Config, // only required if using DOCR
+) error {
+	if r.AWSIntegrationID != 0 {
+		return r.deleteECRImages(repoName, tags, digests, repo)
+	}
+
+	if r.GCPIntegrationID != 0 {
+		return r.deleteGCRImages(repoName, tags, digests, repo)
+	}
+
+	if r.DOIntegrationID != 0 {
+		return r.deleteDOCRImages(repoName, tags, digests, repo, doAuth)
+	}
+
+	return ErrManifestsNotSupported
+}
+
+func (r *Registry) getECRService(repo repository.Repository) (*ecr.ECR, error) {
+	awsInt, err := repo.AWSIntegration().ReadAWSIntegration(
+		r.ProjectID,
+		r.AWSIntegrationID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := awsInt.GetSession()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ecr.New(sess), nil
+}
+
+func (r *Registry) listECRManifests(repoName string, repo repository.Repository) ([]*Manifest, error) {
+	svc, err := r.getECRService(repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Manifest, 0)
+
+	err = svc.DescribeImagesPages(&ecr.DescribeImagesInput{
+		RepositoryName: &repoName,
+	}, func(page *ecr.DescribeImagesOutput, lastPage bool) bool {
+		for _, img := range page.ImageDetails {
+			res = append(res, &Manifest{
+				Digest:    aws.StringValue(img.ImageDigest),
+				MediaType: aws.StringValue(img.ImageManifestMediaType),
+				Tags:      aws.StringValueSlice(img.ImageTags),
+				PushedAt:  img.ImagePushedAt,
+			})
+		}
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, manifest := range res {
+		if !manifest.IsIndex() {
+			continue
+		}
+
+		resp, err := svc.BatchGetImage(&ecr.BatchGetImageInput{
+			RepositoryName:     &repoName,
+			ImageIds:           []*ecr.ImageIdentifier{{ImageDigest: aws.String(manifest.Digest)}},
+			AcceptedMediaTypes: aws.StringSlice(indexMediaTypes),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(resp.Images) == 0 {
+			return nil, fmt.Errorf("could not get image index %s", manifest.Digest)
+		}
+
+		manifest.Children, err = getIndexChildren(aws.StringValue(resp.Images[0].ImageManifest))
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (r *Registry) deleteECRImages(repoName string, tags, digests []string, repo repository.Repository) error {
+	svc, err := r.getECRService(repo)
+
+	if err != nil {
+		return err
+	}
+
+	ids := make([]*ecr.ImageIdentifier, 0, len(tags)+len(digests))
+
+	for _, tag := range tags {
+		ids = append(ids, &ecr.ImageIdentifier{ImageTag: aws.String(tag)})
+	}
+
+	for _, digest := range digests {
+		ids = append(ids, &ecr.ImageIdentifier{ImageDigest: aws.String(digest)})
+	}
+
+	// ECR deletes at most 100 images per request
+	for start := 0; start < len(ids); start += 100 {
+		end := start + 100
+
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		resp, err := svc.BatchDeleteImage(&ecr.BatchDeleteImageInput{
+			RepositoryName: &repoName,
+			ImageIds:       ids[start:end],
+		})
+
+		if err != nil {
+			return err
+		}
+
+		for _, failure := range resp.Failures {
+			// tags which were deleted with a previous tag of the same image are not found
+			if aws.StringValue(failure.FailureCode) == ecr.ImageFailureCodeImageNotFound {
+				continue
+			}
+
+			return fmt.Errorf("could not delete image %s: %s", failure.ImageId.String(), aws.StringValue(failure.FailureReason))
+		}
+	}
+
+	return nil
+}
+
+type gcrManifestResp struct {
+	Manifest map[string]struct {
+		MediaType      string   `json:"mediaType"`
+		Tag            []string `json:"tag"`
+		TimeUploadedMs string   `json:"timeUploadedMs"`
+	} `json:"manifest"`
+}
+
+// doGCRRequest sends a request to the docker registry API of a GCR repository
+func (r *Registry) doGCRRequest(
+	repo repository.Repository,
+	method, repoName, path string,
+	accept []string,
+) (*http.Response, error) {
+	gcp, err := repo.GCPIntegration().ReadGCPIntegration(
+		r.ProjectID,
+		r.GCPIntegrationID,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parsedURL, err := url.Parse("https://" + r.URL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedPath := strings.Trim(parsedURL.Path, "/")
+
+	req, err := http.NewRequest(
+		method,
+		fmt.Sprintf("https://%s/v2/%s/%s/%s", parsedURL.Host, trimmedPath, repoName, path),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.SetBasicAuth("_json_key", string(gcp.GCPKeyData))
+
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+
+	resp, err := (&http.Client{}).Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s %s returned status code %d", method, req.URL.Path, resp.StatusCode)
+	}
+
+	return resp, nil
+}
+
+func (r *Registry) listGCRManifests(repoName string, repo repository.Repository) ([]*Manifest, error) {
+	resp, err := r.doGCRRequest(repo, http.MethodGet, repoName, "tags/list", nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	gcrResp := gcrManifestResp{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&gcrResp); err != nil {
+		return nil, fmt.Errorf("could not read GCR manifests: %v", err)
+	}
+
+	res := make([]*Manifest, 0, len(gcrResp.Manifest))
+
+	for digest, gcrManifest := range gcrResp.Manifest {
+		manifest := &Manifest{
+			Digest:    digest,
+			MediaType: gcrManifest.MediaType,
+			Tags:      gcrManifest.Tag,
+		}
+
+		if ms, err := strconv.ParseInt(gcrManifest.TimeUploadedMs, 10, 64); err == nil && ms > 0 {
+			pushedAt := time.Unix(0, ms*int64(time.Millisecond)).UTC()
+			manifest.PushedAt = &pushedAt
+		}
+
+		if manifest.IsIndex() {
+			manifest.Children, err = r.getGCRIndexChildren(repoName, digest, repo)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		res = append(res, manifest)
+	}
+
+	return res, nil
+}
+
+func (r *Registry) getGCRIndexChildren(repoName, digest string, repo repository.Repository) ([]string, error) {
+	resp, err := r.doGCRRequest(repo, http.MethodGet, repoName, "manifests/"+digest, indexMediaTypes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	index := &imageIndex{}
+
+	if err := json.NewDecoder(resp.Body).Decode(index); err != nil {
+		return nil, fmt.Errorf("could not read image index %s: %v", digest, err)
+	}
+
+	return index.digests(), nil
+}
+
+func (r *Registry) deleteGCRImages(repoName string, tags, digests []string, repo repository.Repository) error {
+	// tags are deleted through the manifests endpoint, in the same way as digests
+	for _, ref := range append(append([]string{}, tags...), digests...) {
+		resp, err := r.doGCRRequest(repo, http.MethodDelete, repoName, "manifests/"+ref, nil)
+
+		if err != nil {
+			return fmt.Errorf("could not delete %s: %v", ref, err)
+		}
+
+		resp.Body.Close()
+	}
+
+	return nil
+}
+
+func (r *Registry) getDOCRClient(repo repository.Repository, doAuth *oauth2.Config) (*godo.Client, string, error) {
+	oauthInt, err := repo.OAuthIntegration().ReadOAuthIntegration(
+		r.ProjectID,
+		r.DOIntegrationID,
+	)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	tok, _, err := oauth.GetAccessToken(oauthInt.SharedOAuthModel, doAuth, oauth.MakeUpdateOAuthIntegrationTokenFunction(oauthInt, repo))
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	urlArr := strings.Split(r.URL, "/")
+
+	if len(urlArr) != 2 {
+		return nil, "", fmt.Errorf("invalid digital ocean registry url")
+	}
+
+	return godo.NewFromToken(tok), urlArr[1], nil
+}
+
+func (r *Registry) listDOCRManifests(
+	repoName string,
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+) ([]*Manifest, error) {
+	client, name, err := r.getDOCRClient(repo, doAuth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := make(map[string]*Manifest)
+	res := make([]*Manifest, 0)
+	opts := &godo.ListOptions{PerPage: 100}
+
+	for {
+		tags, resp, err := client.Registry.ListRepositoryTags(context.Background(), name, repoName, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tag := range tags {
+			manifest, ok := manifests[tag.ManifestDigest]
+
+			if !ok {
+				updatedAt := tag.UpdatedAt
+				manifest = &Manifest{Digest: tag.ManifestDigest, PushedAt: &updatedAt}
+				manifests[tag.ManifestDigest] = manifest
+				res = append(res, manifest)
+			}
+
+			manifest.Tags = append(manifest.Tags, tag.Tag)
+
+			// a manifest was pushed when its latest tag was updated
+			if manifest.PushedAt.Before(tag.UpdatedAt) {
+				updatedAt := tag.UpdatedAt
+				manifest.PushedAt = &updatedAt
+			}
+		}
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+
+		page, err := resp.Links.CurrentPage()
+
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Page = page + 1
+	}
+
+	return res, nil
+}
+
+func (r *Registry) deleteDOCRImages(
+	repoName string,
+	tags, digests []string,
+	repo repository.Repository,
+	doAuth *oauth2.Config,
+) error {
+	client, name, err := r.getDOCRClient(repo, doAuth)
+
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if _, err := client.Registry.DeleteTag(context.Background(), name, repoName, tag); err != nil {
+			return fmt.Errorf("could not delete tag %s: %v", tag, err)
+		}
+	}
+
+	for _, digest := range digests {
+		if _, err := client.Registry.DeleteManifest(context.Background(), name, repoName, digest); err != nil {
+			return fmt.Errorf("could not delete manifest %s: %v", digest, err)
+		}
+	}
+
+	return nil
+}
+
+// imageIndex is a manifest list or OCI image index
+type imageIndex struct {
+	Manifests []struct {
+		Digest string `json:"digest"`
+	} `json:"manifests"`
+}
+
+func (i *imageIndex) digests() []string {
+	res := make([]string, 0, len(i.Manifests))
+
+	for _, manifest := range i.Manifests {
+		res = append(res, manifest.Digest)
+	}
+
+	return res
+}
+
+func getIndexChildren(manifest string) ([]string, error) {
+	index := &imageIndex{}
+
+	if err := json.Unmarshal([]byte(manifest), index); err != nil {
+		return nil, fmt.Errorf("could not read image index: %v", err)
+	}
+
+	return index.digests(), nil
+}
@@ -488,41 +488,20 @@ func (r *Registry) listECRImages(repoName string, repo repository.Repository) ([
 
 	svc := ecr.New(sess)
 
-	resp, err := svc.ListImages(&ecr.ListImagesInput{
-		RepositoryName: &repoName,
-	})
-
-	if err != nil {
-		return nil, err
-	}
+	imageDetails := make([]*ecr.ImageDetail, 0)
 
-	describeResp, err := svc.DescribeImages(&ecr.DescribeImagesInput{
+	// untagged images are listed as well, but have no tags to add to the result
+	err = svc.DescribeImagesPages(&ecr.DescribeImagesInput{
 		RepositoryName: &repoName,
-		ImageIds:       resp.ImageIds,
+	}, func(page *ecr.DescribeImagesOutput, lastPage bool) bool {
+		imageDetails = append(imageDetails, page.ImageDetails...)
+		return true
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	imageDetails := describeResp.ImageDetails
-
-	nextToken := describeResp.NextToken
-
-	for nextToken != nil {
-		describeResp, err := svc.DescribeImages(&ecr.DescribeImagesInput{
-			RepositoryName: &repoName,
-			ImageIds:       resp.ImageIds,
-		})
-
-		if err != nil {
-			return nil, err
-		}
-
-		nextToken = describeResp.NextToken
-		imageDetails = append(imageDetails, describeResp.ImageDetails...)
-	}
-
 	res := make([]*ptypes.Image, 0)
 
 	for _, img := range imageDetails {
@@ -0,0 +1,281 @@
+package retention
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/scheduler"
+	"golang.org/x/oauth2"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// liveStatuses are the statuses of the latest revisions of releases whose images are
+// protected, which includes releases that are being deployed
+var liveStatuses = []string{"deployed", "failed", "pending-install", "pending-upgrade", "pending-rollback"}
+
+// Enforcer periodically enforces the retention policies of all registries, deleting the
+// images they select unless a policy is a dry run
+type Enforcer struct {
+	Repo   repository.Repository
+	DOConf *oauth2.Config
+	Logger *logger.Logger
+
+	Interval time.Duration
+}
+
+// Run enforces the retention policies on every interval, on one replica of the server
+// at a time. If the interval is not positive, enforcement is disabled and Run returns
+// immediately.
+func (e *Enforcer) Run() {
+	scheduler.Run(e.Repo, e.Logger, "registry_retention", e.Interval, e.EnforceAll)
+}
+
+// EnforceAll enforces every retention policy, and only reports the images a policy
+// selects if it is a dry run. Errors are logged, and do not stop other policies from
+// being enforced.
+func (e *Enforcer) EnforceAll() {
+	policies, err := e.Repo.RegistryRetentionPolicy().ListAllRegistryRetentionPolicies()
+
+	if err != nil {
+		e.Logger.Error().Err(err).Msg("could not list registry retention policies")
+		return
+	}
+
+	for _, policy := range policies {
+		report, err := e.Enforce(policy, policy.DryRun)
+
+		if err != nil {
+			e.Logger.Error().Err(err).Uint("policy_id", policy.ID).Uint("registry_id", policy.RegistryID).
+				Msg("could not enforce registry retention policy")
+
+			continue
+		}
+
+		for _, repoReport := range report.Repositories {
+			if repoReport.Error != "" {
+				e.Logger.Error().Uint("policy_id", policy.ID).Str("repository", repoReport.RepositoryName).
+					Msg(repoReport.Error)
+			}
+		}
+	}
+}
+
+// Enforce evaluates a retention policy against every repository it applies to, and
+// deletes the selected images unless dryRun is set. The report is stored as the last
+// report of the policy. If the policy protects live releases and the images of the live
+// releases cannot be listed, nothing is evaluated.
+func (e *Enforcer) Enforce(
+	policy *models.RegistryRetentionPolicy,
+	dryRun bool,
+) (*types.RegistryRetentionReport, error) {
+	reg, err := e.Repo.Registry().ReadRegistry(policy.ProjectID, policy.RegistryID)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read registry: %v", err)
+	}
+
+	_reg := registry.Registry(*reg)
+	regAPI := &_reg
+
+	repoNames, err := e.getRepositoryNames(policy, regAPI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var live map[string]map[string]bool
+
+	if policy.ProtectLiveReleases {
+		live, err = e.getLiveImages(policy.ProjectID)
+
+		if err != nil {
+			return nil, fmt.Errorf("could not list the images of live releases: %v", err)
+		}
+	}
+
+	report := &types.RegistryRetentionReport{
+		PolicyID:     policy.ID,
+		DryRun:       dryRun,
+		EvaluatedAt:  time.Now().UTC(),
+		Repositories: make([]*types.RepositoryRetentionReport, 0, len(repoNames)),
+	}
+
+	for _, repoName := range repoNames {
+		manifests, err := regAPI.ListManifests(repoName, e.Repo, e.DOConf)
+
+		if err != nil {
+			report.Repositories = append(report.Repositories, &types.RepositoryRetentionReport{
+				RepositoryName: repoName,
+				Kept:           make([]*types.RetentionImage, 0),
+				Deleted:        make([]*types.RetentionImage, 0),
+				Error:          fmt.Sprintf("could not list images: %v", err),
+			})
+
+			continue
+		}
+
+		repoReport := Evaluate(policy, repoName, manifests, live[normalizeRepoURI(reg.URL+"/"+repoName)], report.EvaluatedAt)
+		report.Repositories = append(report.Repositories, repoReport)
+
+		if dryRun || len(repoReport.Deleted) == 0 {
+			continue
+		}
+
+		tags := make([]string, 0)
+		digests := make([]string, 0)
+
+		for _, img := range repoReport.Deleted {
+			if img.Tag != "" {
+				tags = append(tags, img.Tag)
+			} else {
+				digests = append(digests, img.Digest)
+			}
+		}
+
+		if err := regAPI.DeleteImages(repoName, tags, digests, e.Repo, e.DOConf); err != nil {
+			repoReport.Error = fmt.Sprintf("could not delete images: %v", err)
+		}
+	}
+
+	reportBytes, err := json.Marshal(report)
+
+	if err != nil {
+		return nil, err
+	}
+
+	policy.LastRunAt = &report.EvaluatedAt
+	policy.LastReport = reportBytes
+
+	if err := e.Repo.RegistryRetentionPolicy().UpdateRegistryRetentionPolicyLastRun(policy); err != nil {
+		return nil, fmt.Errorf("could not store retention report: %v", err)
+	}
+
+	return report, nil
+}
+
+// getRepositoryNames returns the names of the repositories which a policy applies to,
+// relative to the registry URL
+func (e *Enforcer) getRepositoryNames(
+	policy *models.RegistryRetentionPolicy,
+	reg *registry.Registry,
+) ([]string, error) {
+	if policy.RepositoryName != "" {
+		return []string{policy.RepositoryName}, nil
+	}
+
+	repos, err := reg.ListRepositories(e.Repo, e.DOConf)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not list repositories: %v", err)
+	}
+
+	res := make([]string, 0, len(repos))
+
+	for _, repo := range repos {
+		res = append(res, strings.TrimPrefix(normalizeRepoURI(repo.URI), normalizeRepoURI(reg.URL)+"/"))
+	}
+
+	return res, nil
+}
+
+// getLiveImages returns the tags and digests which the live releases of a project, and
+// the revisions they would be rolled back to, are deployed with, for each image
+// repository URI
+func (e *Enforcer) getLiveImages(projectID uint) (map[string]map[string]bool, error) {
+	clusters, err := e.Repo.Cluster().ListClustersByProjectID(projectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]map[string]bool)
+
+	for _, cluster := range clusters {
+		agent, err := helm.GetAgentOutOfClusterConfig(&helm.Form{
+			Cluster:           cluster,
+			Repo:              e.Repo,
+			DigitalOceanOAuth: e.DOConf,
+			Storage:           "secret",
+		}, e.Logger)
+
+		if err != nil {
+			return nil, fmt.Errorf("could not connect to cluster %d: %v", cluster.ID, err)
+		}
+
+		releases, err := agent.ListReleases("", &types.ReleaseListFilter{
+			StatusFilter: liveStatuses,
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("could not list releases of cluster %d: %v", cluster.ID, err)
+		}
+
+		// the last good revisions are protected as well, since a rollback of a
+		// failed release redeploys them
+		lastGoodReleases, err := agent.ListLastGoodReleases("")
+
+		if err != nil {
+			return nil, fmt.Errorf("could not list last good releases of cluster %d: %v", cluster.ID, err)
+		}
+
+		for _, rel := range append(releases, lastGoodReleases...) {
+			repoURI, ref := getReleaseImage(rel)
+
+			if repoURI == "" || ref == "" {
+				continue
+			}
+
+			if _, ok := res[repoURI]; !ok {
+				res[repoURI] = make(map[string]bool)
+			}
+
+			res[repoURI][ref] = true
+		}
+	}
+
+	return res, nil
+}
+
+// getReleaseImage returns the image repository URI and the tag or digest which a
+// release is deployed with. Fields which are not set in the values of the release fall
+// back to the default values of its chart.
+func getReleaseImage(rel *release.Release) (string, string) {
+	image, _ := rel.Config["image"].(map[string]interface{})
+
+	var defaultImage map[string]interface{}
+
+	if rel.Chart != nil {
+		defaultImage, _ = rel.Chart.Values["image"].(map[string]interface{})
+	}
+
+	getField := func(field string) string {
+		if val, _ := image[field].(string); val != "" {
+			return val
+		}
+
+		val, _ := defaultImage[field].(string)
+
+		return val
+	}
+
+	repoURI := getField("repository")
+	tag := getField("tag")
+
+	if i := strings.Index(repoURI, "@"); i != -1 {
+		repoURI, tag = repoURI[:i], repoURI[i+1:]
+	}
+
+	return normalizeRepoURI(repoURI), tag
+}
+
+func normalizeRepoURI(uri string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(uri, "https://"), "http://"), "/")
+}
@@ -0,0 +1,73 @@
+package retention
+
+import (
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+func TestGetReleaseImage(t *testing.T) {
+	chartValues := map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "https://registry.example.com/web/",
+			"tag":        "latest",
+		},
+	}
+
+	tests := []struct {
+		description string
+		config      map[string]interface{}
+		expRepoURI  string
+		expRef      string
+	}{
+		{
+			description: "image from values",
+			config: map[string]interface{}{
+				"image": map[string]interface{}{
+					"repository": "registry.example.com/api",
+					"tag":        "v1.2.0",
+				},
+			},
+			expRepoURI: "registry.example.com/api",
+			expRef:     "v1.2.0",
+		},
+		{
+			description: "tag override falls back to the chart repository",
+			config: map[string]interface{}{
+				"image": map[string]interface{}{
+					"tag": "v1.2.0",
+				},
+			},
+			expRepoURI: "registry.example.com/web",
+			expRef:     "v1.2.0",
+		},
+		{
+			description: "no image in values",
+			config:      map[string]interface{}{},
+			expRepoURI:  "registry.example.com/web",
+			expRef:      "latest",
+		},
+		{
+			description: "digest in repository",
+			config: map[string]interface{}{
+				"image": map[string]interface{}{
+					"repository": "registry.example.com/api@sha256:abc",
+				},
+			},
+			expRepoURI: "registry.example.com/api",
+			expRef:     "sha256:abc",
+		},
+	}
+
+	for _, test := range tests {
+		repoURI, ref := getReleaseImage(&release.Release{
+			Config: test.config,
+			Chart:  &chart.Chart{Values: chartValues},
+		})
+
+		if repoURI != test.expRepoURI || ref != test.expRef {
+			t.Errorf("[ %s ]: expected %s:%s, got %s:%s", test.description, test.expRepoURI, test.expRef, repoURI, ref)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package retention
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+// buildCacheTag is the tag of the registry cache of Docker builds on the default branch,
+// and the prefix of the cache tags of other branches, which the CLI pushes to the image
+// repository of a release
+const buildCacheTag = "porter-cache"
+
+// Evaluate decides which tags and untagged manifests of a repository a retention policy
+// deletes, without deleting anything. Live contains the tags and digests which live
+// releases are deployed with, and is only used if the policy protects live releases.
+//
+// Tags are ranked by when their manifest was pushed, and the most recent tags are kept.
+// Live tags count towards the most recent tags, but are kept when they are older.
+// Build cache tags, images with an unknown push time and the manifests of multi-platform
+// images are never deleted, and cache tags do not count towards the most recent tags.
+func Evaluate(
+	policy *models.RegistryRetentionPolicy,
+	repoName string,
+	manifests []*registry.Manifest,
+	live map[string]bool,
+	now time.Time,
+) *types.RepositoryRetentionReport {
+	report := &types.RepositoryRetentionReport{
+		RepositoryName: repoName,
+		Kept:           make([]*types.RetentionImage, 0),
+		Deleted:        make([]*types.RetentionImage, 0),
+	}
+
+	isLive := func(tag, digest string) bool {
+		return policy.ProtectLiveReleases && (live[digest] || (tag != "" && live[tag]))
+	}
+
+	children := make(map[string]bool)
+
+	for _, manifest := range manifests {
+		for _, child := range manifest.Children {
+			children[child] = true
+		}
+	}
+
+	tagged := make([]*types.RetentionImage, 0)
+	untagged := make([]*types.RetentionImage, 0)
+
+	for _, manifest := range manifests {
+		if len(manifest.Tags) == 0 {
+			untagged = append(untagged, &types.RetentionImage{
+				Digest:   manifest.Digest,
+				PushedAt: manifest.PushedAt,
+			})
+		}
+
+		for _, tag := range manifest.Tags {
+			if isBuildCacheTag(tag) {
+				report.Kept = append(report.Kept, &types.RetentionImage{
+					Tag:      tag,
+					Digest:   manifest.Digest,
+					PushedAt: manifest.PushedAt,
+					Reason:   types.RetentionReasonBuildCache,
+				})
+
+				continue
+			}
+
+			tagged = append(tagged, &types.RetentionImage{
+				Tag:      tag,
+				Digest:   manifest.Digest,
+				PushedAt: manifest.PushedAt,
+			})
+		}
+	}
+
+	sortByPushedAt(tagged)
+	sortByPushedAt(untagged)
+
+	keep := func(img *types.RetentionImage, reason types.RetentionImageReason) {
+		img.Reason = reason
+		report.Kept = append(report.Kept, img)
+	}
+
+	remove := func(img *types.RetentionImage, reason types.RetentionImageReason) {
+		img.Reason = reason
+		report.Deleted = append(report.Deleted, img)
+	}
+
+	for i, img := range tagged {
+		switch {
+		case policy.KeepLastTags == 0:
+			keep(img, types.RetentionReasonNoTagRule)
+		case img.PushedAt == nil:
+			keep(img, types.RetentionReasonUnknownPushed)
+		case uint(i) < policy.KeepLastTags:
+			keep(img, types.RetentionReasonRecentTag)
+		case isLive(img.Tag, img.Digest):
+			keep(img, types.RetentionReasonLiveRelease)
+		default:
+			remove(img, types.RetentionReasonOldTag)
+		}
+	}
+
+	maxAge := time.Duration(policy.DeleteUntaggedAfterDays) * 24 * time.Hour
+
+	for _, img := range untagged {
+		switch {
+		case policy.DeleteUntaggedAfterDays == 0:
+			keep(img, types.RetentionReasonNoUntaggedRule)
+		case isLive("", img.Digest):
+			keep(img, types.RetentionReasonLiveRelease)
+		case children[img.Digest]:
+			keep(img, types.RetentionReasonIndexChild)
+		case img.PushedAt == nil:
+			keep(img, types.RetentionReasonUnknownPushed)
+		case now.Sub(*img.PushedAt) <= maxAge:
+			keep(img, types.RetentionReasonRecentUntagged)
+		default:
+			remove(img, types.RetentionReasonOldUntagged)
+		}
+	}
+
+	return report
+}
+
+func isBuildCacheTag(tag string) bool {
+	return tag == buildCacheTag || strings.HasPrefix(tag, buildCacheTag+"-")
+}
+
+// sortByPushedAt sorts images from the most recently pushed, with images which have an
+// unknown push time last
+func sortByPushedAt(imgs []*types.RetentionImage) {
+	sort.SliceStable(imgs, func(i, j int) bool {
+		a, b := imgs[i].PushedAt, imgs[j].PushedAt
+
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+
+		if a.Equal(*b) {
+			if imgs[i].Tag != imgs[j].Tag {
+				return imgs[i].Tag < imgs[j].Tag
+			}
+
+			return imgs[i].Digest < imgs[j].Digest
+		}
+
+		return a.After(*b)
+	})
+}
@@ -0,0 +1,222 @@
+package retention
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/registry"
+)
+
+var evaluateNow = time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func daysAgo(days int) *time.Time {
+	t := evaluateNow.Add(-time.Duration(days) * 24 * time.Hour)
+	return &t
+}
+
+type evaluateTest struct {
+	name       string
+	policy     *models.RegistryRetentionPolicy
+	manifests  []*registry.Manifest
+	live       map[string]bool
+	expKept    map[string]types.RetentionImageReason
+	expDeleted map[string]types.RetentionImageReason
+}
+
+var evaluateTests = []evaluateTest{
+	{
+		name:   "keep last tags",
+		policy: &models.RegistryRetentionPolicy{KeepLastTags: 2},
+		manifests: []*registry.Manifest{
+			{Digest: "sha256:a", Tags: []string{"a"}, PushedAt: daysAgo(4)},
+			{Digest: "sha256:b", Tags: []string{"b"}, PushedAt: daysAgo(3)},
+			{Digest: "sha256:c", Tags: []string{"c", "latest"}, PushedAt: daysAgo(1)},
+			{Digest: "sha256:d", PushedAt: daysAgo(30)},
+		},
+		expKept: map[string]types.RetentionImageReason{
+			"c":        types.RetentionReasonRecentTag,
+			"latest":   types.RetentionReasonRecentTag,
+			"sha256:d": types.RetentionReasonNoUntaggedRule,
+		},
+		expDeleted: map[string]types.RetentionImageReason{
+			"a": types.RetentionReasonOldTag,
+			"b": types.RetentionReasonOldTag,
+		},
+	},
+	{
+		name:   "live releases are protected",
+		policy: &models.RegistryRetentionPolicy{KeepLastTags: 1, ProtectLiveReleases: true},
+		manifests: []*registry.Manifest{
+			{Digest: "sha256:a", Tags: []string{"a"}, PushedAt: daysAgo(10)},
+			{Digest: "sha256:b", Tags: []string{"b"}, PushedAt: daysAgo(5)},
+			{Digest: "sha256:c", Tags: []string{"c"}, PushedAt: daysAgo(1)},
+		},
+		live: map[string]bool{"a": true, "c": true},
+		expKept: map[string]types.RetentionImageReason{
+			"a": types.RetentionReasonLiveRelease,
+			"c": types.RetentionReasonRecentTag,
+		},
+		expDeleted: map[string]types.RetentionImageReason{
+			"b": types.RetentionReasonOldTag,
+		},
+	},
+	{
+		name:   "live releases are not protected",
+		policy: &models.RegistryRetentionPolicy{KeepLastTags: 1},
+		manifests: []*registry.Manifest{
+			{Digest: "sha256:a", Tags: []string{"a"}, PushedAt: daysAgo(10)},
+			{Digest: "sha256:c", Tags: []string{"c"}, PushedAt: daysAgo(1)},
+		},
+		live: map[string]bool{"a": true},
+		expKept: map[string]types.RetentionImageReason{
+			"c": types.RetentionReasonRecentTag,
+		},
+		expDeleted: map[string]types.RetentionImageReason{
+			"a": types.RetentionReasonOldTag,
+		},
+	},
+	{
+		name:   "delete old untagged images",
+		policy: &models.RegistryRetentionPolicy{DeleteUntaggedAfterDays: 7, ProtectLiveReleases: true},
+		manifests: []*registry.Manifest{
+			{Digest: "sha256:a", Tags: []string{"a"}, PushedAt: daysAgo(100)},
+			{Digest: "sha256:old", PushedAt: daysAgo(8)},
+			{Digest: "sha256:new", PushedAt: daysAgo(6)},
+			{Digest: "sha256:pinned", PushedAt: daysAgo(50)},
+			{Digest: "sha256:unknown"},
+		},
+		live: map[string]bool{"sha256:pinned": true},
+		expKept: map[string]types.RetentionImageReason{
+			"a":              types.RetentionReasonNoTagRule,
+			"sha256:new":     types.RetentionReasonRecentUntagged,
+			"sha256:pinned":  types.RetentionReasonLiveRelease,
+			"sha256:unknown": types.RetentionReasonUnknownPushed,
+		},
+		expDeleted: map[string]types.RetentionImageReason{
+			"sha256:old": types.RetentionReasonOldUntagged,
+		},
+	},
+	{
+		name:   "multi-platform images",
+		policy: &models.RegistryRetentionPolicy{KeepLastTags: 1, DeleteUntaggedAfterDays: 1},
+		manifests: []*registry.Manifest{
+			{
+				Digest:    "sha256:index",
+				MediaType: "application/vnd.oci.image.index.v1+json",
+				Tags:      []string{"v2"},
+				PushedAt:  daysAgo(3),
+				Children:  []string{"sha256:amd64", "sha256:arm64"},
+			},
+			{Digest: "sha256:amd64", PushedAt: daysAgo(3)},
+			{Digest: "sha256:arm64", PushedAt: daysAgo(3)},
+			{Digest: "sha256:orphan", PushedAt: daysAgo(3)},
+		},
+		expKept: map[string]types.RetentionImageReason{
+			"v2":           types.RetentionReasonRecentTag,
+			"sha256:amd64": types.RetentionReasonIndexChild,
+			"sha256:arm64": types.RetentionReasonIndexChild,
+		},
+		expDeleted: map[string]types.RetentionImageReason{
+			"sha256:orphan": types.RetentionReasonOldUntagged,
+		},
+	},
+	{
+		name:   "build cache tags",
+		policy: &models.RegistryRetentionPolicy{KeepLastTags: 2},
+		manifests: []*registry.Manifest{
+			{Digest: "sha256:main-cache", Tags: []string{"porter-cache"}, PushedAt: daysAgo(30)},
+			{Digest: "sha256:branch-cache", Tags: []string{"porter-cache-feature-a"}, PushedAt: daysAgo(1)},
+			{Digest: "sha256:a", Tags: []string{"a"}, PushedAt: daysAgo(5)},
+			{Digest: "sha256:b", Tags: []string{"b"}, PushedAt: daysAgo(4)},
+			{Digest: "sha256:c", Tags: []string{"c"}, PushedAt: daysAgo(3)},
+		},
+		expKept: map[string]types.RetentionImageReason{
+			"porter-cache":           types.RetentionReasonBuildCache,
+			"porter-cache-feature-a": types.RetentionReasonBuildCache,
+			"b":                      types.RetentionReasonRecentTag,
+			"c":                      types.RetentionReasonRecentTag,
+		},
+		expDeleted: map[string]types.RetentionImageReason{
+			"a": types.RetentionReasonOldTag,
+		},
+	},
+	{
+		name:   "unknown push times",
+		policy: &models.RegistryRetentionPolicy{KeepLastTags: 1},
+		manifests: []*registry.Manifest{
+			{Digest: "sha256:a", Tags: []string{"a"}},
+			{Digest: "sha256:b", Tags: []string{"b"}, PushedAt: daysAgo(2)},
+			{Digest: "sha256:c", Tags: []string{"c"}, PushedAt: daysAgo(1)},
+		},
+		expKept: map[string]types.RetentionImageReason{
+			"a": types.RetentionReasonUnknownPushed,
+			"c": types.RetentionReasonRecentTag,
+		},
+		expDeleted: map[string]types.RetentionImageReason{
+			"b": types.RetentionReasonOldTag,
+		},
+	},
+}
+
+func TestEvaluate(t *testing.T) {
+	for _, test := range evaluateTests {
+		report := Evaluate(test.policy, "app", test.manifests, test.live, evaluateNow)
+
+		if report.RepositoryName != "app" {
+			t.Errorf("%s: expected repository app, got %s", test.name, report.RepositoryName)
+		}
+
+		if kept := getReasons(report.Kept); !reflect.DeepEqual(kept, test.expKept) {
+			t.Errorf("%s: expected kept images %v, got %v", test.name, test.expKept, kept)
+		}
+
+		expDeleted := test.expDeleted
+
+		if expDeleted == nil {
+			expDeleted = map[string]types.RetentionImageReason{}
+		}
+
+		if deleted := getReasons(report.Deleted); !reflect.DeepEqual(deleted, expDeleted) {
+			t.Errorf("%s: expected deleted images %v, got %v", test.name, expDeleted, deleted)
+		}
+	}
+}
+
+func TestEvaluateOrder(t *testing.T) {
+	policy := &models.RegistryRetentionPolicy{KeepLastTags: 1}
+
+	report := Evaluate(policy, "app", []*registry.Manifest{
+		{Digest: "sha256:a", Tags: []string{"a"}, PushedAt: daysAgo(3)},
+		{Digest: "sha256:b", Tags: []string{"b"}, PushedAt: daysAgo(1)},
+		{Digest: "sha256:c", Tags: []string{"c"}, PushedAt: daysAgo(2)},
+	}, nil, evaluateNow)
+
+	var deleted []string
+
+	for _, img := range report.Deleted {
+		deleted = append(deleted, img.Tag)
+	}
+
+	// deleted images are reported from the most recently pushed
+	if !reflect.DeepEqual(deleted, []string{"c", "a"}) {
+		t.Errorf("expected deleted tags [c a], got %v", deleted)
+	}
+}
+
+// getReasons returns the reason for each image, by its tag or by its digest if untagged
+func getReasons(imgs []*types.RetentionImage) map[string]types.RetentionImageReason {
+	res := make(map[string]types.RetentionImageReason)
+
+	for _, img := range imgs {
+		if img.Tag != "" {
+			res[img.Tag] = img.Reason
+		} else {
+			res[img.Digest] = img.Reason
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ApplyManifestRepository represents the set of queries on the ApplyManifest model
+type ApplyManifestRepository interface {
+	CreateApplyManifest(manifest *models.ApplyManifest) (*models.ApplyManifest, error)
+	ReadApplyManifest(projectID, clusterID uint, namespace, name string) (*models.ApplyManifest, error)
+	UpdateApplyManifest(manifest *models.ApplyManifest) (*models.ApplyManifest, error)
+}
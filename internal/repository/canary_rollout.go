@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// CanaryRolloutRepository represents the set of queries on the CanaryRollout model
+type CanaryRolloutRepository interface {
+	CreateCanaryRollout(rollout *models.CanaryRollout) (*models.CanaryRollout, error)
+	ReadInProgressCanaryRollout(clusterID uint, namespace, releaseName string) (*models.CanaryRollout, error)
+	ListInProgressCanaryRollouts() ([]*models.CanaryRollout, error)
+	UpdateCanaryRollout(rollout *models.CanaryRollout) (*models.CanaryRollout, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// CustomRoleRepository represents the set of queries on the CustomRole model
+type CustomRoleRepository interface {
+	CreateCustomRole(role *models.CustomRole) (*models.CustomRole, error)
+	ReadCustomRole(projectID, roleID uint) (*models.CustomRole, error)
+	ReadCustomRoleByName(projectID uint, name string) (*models.CustomRole, error)
+	ListCustomRolesByProjectID(projectID uint) ([]*models.CustomRole, error)
+	UpdateCustomRole(role *models.CustomRole) (*models.CustomRole, error)
+	DeleteCustomRole(role *models.CustomRole) (*models.CustomRole, error)
+}
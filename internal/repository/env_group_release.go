@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// EnvGroupReleaseRepository represents the set of queries on the EnvGroupRelease model
+type EnvGroupReleaseRepository interface {
+	CreateEnvGroupRelease(link *models.EnvGroupRelease) (*models.EnvGroupRelease, error)
+	ReadEnvGroupRelease(projectID, clusterID uint, namespace, envGroupName, releaseName string) (*models.EnvGroupRelease, error)
+	ListEnvGroupReleases(projectID, clusterID uint, namespace, envGroupName string) ([]*models.EnvGroupRelease, error)
+	UpdateEnvGroupRelease(link *models.EnvGroupRelease) (*models.EnvGroupRelease, error)
+	DeleteEnvGroupRelease(link *models.EnvGroupRelease) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// EnvGroupSecretRefRepository represents the set of queries on the EnvGroupSecretRef model
+type EnvGroupSecretRefRepository interface {
+	CreateEnvGroupSecretRef(ref *models.EnvGroupSecretRef) (*models.EnvGroupSecretRef, error)
+	ListEnvGroupSecretRefs(projectID, clusterID uint, namespace, envGroupName string) ([]*models.EnvGroupSecretRef, error)
+	ListAllEnvGroupSecretRefs() ([]*models.EnvGroupSecretRef, error)
+	UpdateEnvGroupSecretRef(ref *models.EnvGroupSecretRef) (*models.EnvGroupSecretRef, error)
+	DeleteEnvGroupSecretRef(ref *models.EnvGroupSecretRef) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// EnvGroupVersionRepository represents the set of queries on the EnvGroupVersion model
+type EnvGroupVersionRepository interface {
+	CreateEnvGroupVersion(version *models.EnvGroupVersion) (*models.EnvGroupVersion, error)
+	ReadEnvGroupVersion(projectID, clusterID uint, namespace, name string, version uint) (*models.EnvGroupVersion, error)
+	ReadLatestEnvGroupVersion(projectID, clusterID uint, namespace, name string) (*models.EnvGroupVersion, error)
+	ListEnvGroupVersions(projectID, clusterID uint, namespace, name string) ([]*models.EnvGroupVersion, error)
+}
@@ -0,0 +1,70 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// APITokenRepository uses gorm.DB for querying the database
+type APITokenRepository struct {
+	db *gorm.DB
+}
+
+// NewAPITokenRepository returns a APITokenRepository which uses
+// gorm.DB for querying the database
+func NewAPITokenRepository(db *gorm.DB) repository.APITokenRepository {
+	return &APITokenRepository{db}
+}
+
+// CreateAPIToken creates a new api token
+func (repo *APITokenRepository) CreateAPIToken(token *models.APIToken) (*models.APIToken, error) {
+	if err := repo.db.Create(token).Error; err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ReadAPIToken gets an api token specified by its unique id
+func (repo *APITokenRepository) ReadAPIToken(projectID uint, uid string) (*models.APIToken, error) {
+	token := &models.APIToken{}
+
+	if err := repo.db.Where("project_id = ? AND unique_id = ?", projectID, uid).First(&token).Error; err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ListAPITokensByProjectID finds all api tokens for a given project id
+func (repo *APITokenRepository) ListAPITokensByProjectID(projectID uint) ([]*models.APIToken, error) {
+	tokens := []*models.APIToken{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// UpdateAPIToken modifies an existing api token in the database
+func (repo *APITokenRepository) UpdateAPIToken(token *models.APIToken) (*models.APIToken, error) {
+	if err := repo.db.Save(token).Error; err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// UpdateAPITokenLastUsed sets the last used time of an api token, without writing the
+// other fields of the token, so that a token which is revoked concurrently stays revoked
+func (repo *APITokenRepository) UpdateAPITokenLastUsed(token *models.APIToken, lastUsed time.Time) error {
+	if err := repo.db.Model(token).UpdateColumn("last_used", lastUsed).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
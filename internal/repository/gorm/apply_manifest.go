@@ -0,0 +1,59 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ApplyManifestRepository uses gorm.DB for querying the database
+type ApplyManifestRepository struct {
+	db *gorm.DB
+}
+
+// NewApplyManifestRepository returns an ApplyManifestRepository which uses
+// gorm.DB for querying the database
+func NewApplyManifestRepository(db *gorm.DB) repository.ApplyManifestRepository {
+	return &ApplyManifestRepository{db}
+}
+
+// CreateApplyManifest creates a new apply manifest
+func (repo *ApplyManifestRepository) CreateApplyManifest(
+	manifest *models.ApplyManifest,
+) (*models.ApplyManifest, error) {
+	if err := repo.db.Create(manifest).Error; err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// ReadApplyManifest gets the apply manifest of a porter.yaml file in a namespace
+func (repo *ApplyManifestRepository) ReadApplyManifest(
+	projectID, clusterID uint,
+	namespace, name string,
+) (*models.ApplyManifest, error) {
+	manifest := &models.ApplyManifest{}
+
+	query := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND name = ?",
+		projectID, clusterID, namespace, name,
+	)
+
+	if err := query.First(&manifest).Error; err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// UpdateApplyManifest modifies an existing apply manifest in the database
+func (repo *ApplyManifestRepository) UpdateApplyManifest(
+	manifest *models.ApplyManifest,
+) (*models.ApplyManifest, error) {
+	if err := repo.db.Save(manifest).Error; err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
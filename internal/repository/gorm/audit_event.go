@@ -0,0 +1,128 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AuditEventRepository uses gorm.DB for querying the database
+type AuditEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditEventRepository returns a AuditEventRepository which uses
+// gorm.DB for querying the database
+func NewAuditEventRepository(db *gorm.DB) repository.AuditEventRepository {
+	return &AuditEventRepository{db}
+}
+
+// CreateAuditEvent creates a new audit event
+func (repo *AuditEventRepository) CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error) {
+	if err := repo.db.Create(event).Error; err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ListAuditEventsByProjectID finds all audit events for a given project id
+// with the given options
+func (repo *AuditEventRepository) ListAuditEventsByProjectID(
+	projectID uint,
+	opts *types.ListAuditEventsRequest,
+) ([]*models.AuditEvent, int64, error) {
+	limit := opts.Limit
+
+	if limit == 0 {
+		limit = types.DefaultAuditEventsLimit
+	}
+
+	events := []*models.AuditEvent{}
+
+	query := filterAuditEvents(repo.db.Where("project_id = ?", projectID), opts)
+
+	// get the count before limit and offset
+	var count int64
+
+	if err := query.Model([]*models.AuditEvent{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("created_at desc").Order("id desc").Limit(limit).Offset(opts.Skip)
+
+	if err := query.Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
+
+// ListAuditEventsBefore finds up to limit audit events for a given project id with the
+// given filters, which were created before the given event, most recent first. If
+// before is nil, the most recent events are returned. The limit and skip options are
+// ignored, so that all events can be read in batches without an offset.
+func (repo *AuditEventRepository) ListAuditEventsBefore(
+	projectID uint,
+	opts *types.ListAuditEventsRequest,
+	before *models.AuditEvent,
+	limit int,
+) ([]*models.AuditEvent, error) {
+	events := []*models.AuditEvent{}
+
+	query := filterAuditEvents(repo.db.Where("project_id = ?", projectID), opts)
+
+	if before != nil {
+		query = query.Where(
+			"created_at < ? OR (created_at = ? AND id < ?)",
+			before.CreatedAt, before.CreatedAt, before.ID,
+		)
+	}
+
+	query = query.Order("created_at desc").Order("id desc").Limit(limit)
+
+	if err := query.Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func filterAuditEvents(query *gorm.DB, opts *types.ListAuditEventsRequest) *gorm.DB {
+	if opts.UserID != 0 {
+		query = query.Where("user_id = ?", opts.UserID)
+	}
+
+	if opts.APITokenID != "" {
+		query = query.Where("api_token_id = ?", opts.APITokenID)
+	}
+
+	if opts.ClusterID != 0 {
+		query = query.Where("cluster_id = ?", opts.ClusterID)
+	}
+
+	if opts.Namespace != "" {
+		query = query.Where("namespace = ?", opts.Namespace)
+	}
+
+	if opts.ReleaseName != "" {
+		query = query.Where("release_name = ?", opts.ReleaseName)
+	}
+
+	if opts.Verb != "" {
+		query = query.Where("verb = ?", string(opts.Verb))
+	}
+
+	if opts.StartTime != 0 {
+		query = query.Where("created_at >= ?", time.Unix(opts.StartTime, 0))
+	}
+
+	if opts.EndTime != 0 {
+		query = query.Where("created_at <= ?", time.Unix(opts.EndTime, 0))
+	}
+
+	return query
+}
@@ -0,0 +1,70 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CanaryRolloutRepository uses gorm.DB for querying the database
+type CanaryRolloutRepository struct {
+	db *gorm.DB
+}
+
+// NewCanaryRolloutRepository returns a CanaryRolloutRepository which uses gorm.DB for
+// querying the database
+func NewCanaryRolloutRepository(db *gorm.DB) repository.CanaryRolloutRepository {
+	return &CanaryRolloutRepository{db}
+}
+
+// CreateCanaryRollout creates a new canary rollout
+func (repo *CanaryRolloutRepository) CreateCanaryRollout(
+	rollout *models.CanaryRollout,
+) (*models.CanaryRollout, error) {
+	if err := repo.db.Create(rollout).Error; err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
+
+// ReadInProgressCanaryRollout gets the canary rollout of a release which is in progress
+func (repo *CanaryRolloutRepository) ReadInProgressCanaryRollout(
+	clusterID uint,
+	namespace, releaseName string,
+) (*models.CanaryRollout, error) {
+	rollout := &models.CanaryRollout{}
+
+	if err := repo.db.Where(
+		"cluster_id = ? AND namespace = ? AND release_name = ? AND status = ?",
+		clusterID, namespace, releaseName, types.CanaryRolloutStatusInProgress,
+	).First(&rollout).Error; err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
+
+// ListInProgressCanaryRollouts finds the canary rollouts of all releases which are in
+// progress
+func (repo *CanaryRolloutRepository) ListInProgressCanaryRollouts() ([]*models.CanaryRollout, error) {
+	rollouts := []*models.CanaryRollout{}
+
+	if err := repo.db.Where("status = ?", types.CanaryRolloutStatusInProgress).Find(&rollouts).Error; err != nil {
+		return nil, err
+	}
+
+	return rollouts, nil
+}
+
+// UpdateCanaryRollout modifies an existing canary rollout in the database
+func (repo *CanaryRolloutRepository) UpdateCanaryRollout(
+	rollout *models.CanaryRollout,
+) (*models.CanaryRollout, error) {
+	if err := repo.db.Save(rollout).Error; err != nil {
+		return nil, err
+	}
+
+	return rollout, nil
+}
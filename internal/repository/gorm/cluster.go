@@ -217,6 +217,23 @@ func (repo *ClusterRepository) ListClustersByProjectID(
 	return clusters, nil
 }
 
+// ListAllClusters finds all clusters across every project
+func (repo *ClusterRepository) ListAllClusters() ([]*models.Cluster, error) {
+	ctxDB := repo.db.WithContext(context.Background())
+
+	clusters := []*models.Cluster{}
+
+	if err := ctxDB.Find(&clusters).Error; err != nil {
+		return nil, err
+	}
+
+	for _, cluster := range clusters {
+		repo.DecryptClusterData(cluster, repo.key)
+	}
+
+	return clusters, nil
+}
+
 // UpdateCluster modifies an existing Cluster in the database
 func (repo *ClusterRepository) UpdateCluster(
 	cluster *models.Cluster,
@@ -305,6 +305,31 @@ func TestListClustersByProjectID(t *testing.T) {
 	}
 }
 
+func TestListAllClusters(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_all_clusters.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	initCluster(tester, t)
+	defer cleanup(tester, t)
+
+	clusters, err := tester.repo.Cluster().ListAllClusters()
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(clusters) != 1 {
+		t.Fatalf("length of clusters incorrect: expected %d, got %d\n", 1, len(clusters))
+	}
+
+	if clusters[0].ID != tester.initClusters[0].ID {
+		t.Errorf("incorrect cluster: expected id %d, got %d", tester.initClusters[0].ID, clusters[0].ID)
+	}
+}
+
 func TestUpdateCluster(t *testing.T) {
 	tester := &tester{
 		dbFileName: "./porter_update_cluster.db",
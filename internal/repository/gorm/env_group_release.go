@@ -0,0 +1,83 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EnvGroupReleaseRepository uses gorm.DB for querying the database
+type EnvGroupReleaseRepository struct {
+	db *gorm.DB
+}
+
+// NewEnvGroupReleaseRepository returns an EnvGroupReleaseRepository which uses
+// gorm.DB for querying the database
+func NewEnvGroupReleaseRepository(db *gorm.DB) repository.EnvGroupReleaseRepository {
+	return &EnvGroupReleaseRepository{db}
+}
+
+// CreateEnvGroupRelease links a release to an env group
+func (repo *EnvGroupReleaseRepository) CreateEnvGroupRelease(
+	link *models.EnvGroupRelease,
+) (*models.EnvGroupRelease, error) {
+	if err := repo.db.Create(link).Error; err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// ReadEnvGroupRelease gets the link between a release and an env group
+func (repo *EnvGroupReleaseRepository) ReadEnvGroupRelease(
+	projectID, clusterID uint,
+	namespace, envGroupName, releaseName string,
+) (*models.EnvGroupRelease, error) {
+	link := &models.EnvGroupRelease{}
+
+	query := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND env_group_name = ? AND release_name = ?",
+		projectID, clusterID, namespace, envGroupName, releaseName,
+	)
+
+	if err := query.First(&link).Error; err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// ListEnvGroupReleases finds all releases linked to an env group
+func (repo *EnvGroupReleaseRepository) ListEnvGroupReleases(
+	projectID, clusterID uint,
+	namespace, envGroupName string,
+) ([]*models.EnvGroupRelease, error) {
+	links := []*models.EnvGroupRelease{}
+
+	query := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND env_group_name = ?",
+		projectID, clusterID, namespace, envGroupName,
+	).Order("release_name asc")
+
+	if err := query.Find(&links).Error; err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// UpdateEnvGroupRelease modifies an existing link between a release and an env group
+func (repo *EnvGroupReleaseRepository) UpdateEnvGroupRelease(
+	link *models.EnvGroupRelease,
+) (*models.EnvGroupRelease, error) {
+	if err := repo.db.Save(link).Error; err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// DeleteEnvGroupRelease removes the link between a release and an env group
+func (repo *EnvGroupReleaseRepository) DeleteEnvGroupRelease(link *models.EnvGroupRelease) error {
+	return repo.db.Delete(link).Error
+}
@@ -0,0 +1,75 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EnvGroupSecretRefRepository uses gorm.DB for querying the database
+type EnvGroupSecretRefRepository struct {
+	db *gorm.DB
+}
+
+// NewEnvGroupSecretRefRepository returns an EnvGroupSecretRefRepository which uses
+// gorm.DB for querying the database
+func NewEnvGroupSecretRefRepository(db *gorm.DB) repository.EnvGroupSecretRefRepository {
+	return &EnvGroupSecretRefRepository{db}
+}
+
+// CreateEnvGroupSecretRef creates a new secret reference
+func (repo *EnvGroupSecretRefRepository) CreateEnvGroupSecretRef(
+	ref *models.EnvGroupSecretRef,
+) (*models.EnvGroupSecretRef, error) {
+	if err := repo.db.Create(ref).Error; err != nil {
+		return nil, err
+	}
+
+	return ref, nil
+}
+
+// ListEnvGroupSecretRefs finds all secret references of an env group
+func (repo *EnvGroupSecretRefRepository) ListEnvGroupSecretRefs(
+	projectID, clusterID uint,
+	namespace, envGroupName string,
+) ([]*models.EnvGroupSecretRef, error) {
+	refs := []*models.EnvGroupSecretRef{}
+
+	query := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND env_group_name = ?",
+		projectID, clusterID, namespace, envGroupName,
+	).Order("key asc")
+
+	if err := query.Find(&refs).Error; err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// ListAllEnvGroupSecretRefs finds the secret references of all env groups
+func (repo *EnvGroupSecretRefRepository) ListAllEnvGroupSecretRefs() ([]*models.EnvGroupSecretRef, error) {
+	refs := []*models.EnvGroupSecretRef{}
+
+	if err := repo.db.Order("id asc").Find(&refs).Error; err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// UpdateEnvGroupSecretRef modifies an existing secret reference
+func (repo *EnvGroupSecretRefRepository) UpdateEnvGroupSecretRef(
+	ref *models.EnvGroupSecretRef,
+) (*models.EnvGroupSecretRef, error) {
+	if err := repo.db.Save(ref).Error; err != nil {
+		return nil, err
+	}
+
+	return ref, nil
+}
+
+// DeleteEnvGroupSecretRef removes a secret reference
+func (repo *EnvGroupSecretRefRepository) DeleteEnvGroupSecretRef(ref *models.EnvGroupSecretRef) error {
+	return repo.db.Delete(ref).Error
+}
@@ -0,0 +1,152 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EnvGroupVersionRepository uses gorm.DB for querying the database
+type EnvGroupVersionRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewEnvGroupVersionRepository returns an EnvGroupVersionRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// the secret values of each version.
+func NewEnvGroupVersionRepository(db *gorm.DB, key *[32]byte) repository.EnvGroupVersionRepository {
+	return &EnvGroupVersionRepository{db, key}
+}
+
+// CreateEnvGroupVersion creates a new env group version
+func (repo *EnvGroupVersionRepository) CreateEnvGroupVersion(
+	version *models.EnvGroupVersion,
+) (*models.EnvGroupVersion, error) {
+	err := repo.EncryptEnvGroupVersionData(version, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(version).Error; err != nil {
+		return nil, err
+	}
+
+	err = repo.DecryptEnvGroupVersionData(version, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// ReadEnvGroupVersion gets a single version of an env group
+func (repo *EnvGroupVersionRepository) ReadEnvGroupVersion(
+	projectID, clusterID uint,
+	namespace, name string,
+	version uint,
+) (*models.EnvGroupVersion, error) {
+	res := &models.EnvGroupVersion{}
+
+	query := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND name = ? AND version = ?",
+		projectID, clusterID, namespace, name, version,
+	)
+
+	if err := query.First(&res).Error; err != nil {
+		return nil, err
+	}
+
+	err := repo.DecryptEnvGroupVersionData(res, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ReadLatestEnvGroupVersion gets the most recent version of an env group
+func (repo *EnvGroupVersionRepository) ReadLatestEnvGroupVersion(
+	projectID, clusterID uint,
+	namespace, name string,
+) (*models.EnvGroupVersion, error) {
+	res := &models.EnvGroupVersion{}
+
+	query := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND name = ?",
+		projectID, clusterID, namespace, name,
+	).Order("version desc")
+
+	if err := query.First(&res).Error; err != nil {
+		return nil, err
+	}
+
+	err := repo.DecryptEnvGroupVersionData(res, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ListEnvGroupVersions finds all versions of an env group, most recent first. Secret
+// values are not read.
+func (repo *EnvGroupVersionRepository) ListEnvGroupVersions(
+	projectID, clusterID uint,
+	namespace, name string,
+) ([]*models.EnvGroupVersion, error) {
+	versions := []*models.EnvGroupVersion{}
+
+	query := repo.db.Omit("secret_values").Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND name = ?",
+		projectID, clusterID, namespace, name,
+	).Order("version desc")
+
+	if err := query.Find(&versions).Error; err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// EncryptEnvGroupVersionData will encrypt the secret values of the env group version
+// before writing to the DB
+func (repo *EnvGroupVersionRepository) EncryptEnvGroupVersionData(
+	version *models.EnvGroupVersion,
+	key *[32]byte,
+) error {
+	if len(version.SecretValues) > 0 {
+		cipherData, err := repository.Encrypt(version.SecretValues, key)
+
+		if err != nil {
+			return err
+		}
+
+		version.SecretValues = cipherData
+	}
+
+	return nil
+}
+
+// DecryptEnvGroupVersionData will decrypt the secret values of the env group version
+// before returning it from the DB
+func (repo *EnvGroupVersionRepository) DecryptEnvGroupVersionData(
+	version *models.EnvGroupVersion,
+	key *[32]byte,
+) error {
+	if len(version.SecretValues) > 0 {
+		plaintext, err := repository.Decrypt(version.SecretValues, key)
+
+		if err != nil {
+			return err
+		}
+
+		version.SecretValues = plaintext
+	}
+
+	return nil
+}
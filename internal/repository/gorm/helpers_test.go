@@ -76,6 +76,9 @@ func setupTestEnv(tester *tester, t *testing.T) {
 		&models.KubeSubEvent{},
 		&models.Onboarding{},
 		&models.Allowlist{},
+		&models.SchedulerLock{},
+		&models.WebhookDelivery{},
+		&models.WebhookDeliveryAttempt{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
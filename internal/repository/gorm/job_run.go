@@ -0,0 +1,95 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// JobRunRepository uses gorm.DB for querying the database
+type JobRunRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRunRepository returns a JobRunRepository which uses gorm.DB for querying the
+// database
+func NewJobRunRepository(db *gorm.DB) repository.JobRunRepository {
+	return &JobRunRepository{db}
+}
+
+// CreateJobRun creates a new job run record
+func (repo *JobRunRepository) CreateJobRun(run *models.JobRun) (*models.JobRun, error) {
+	if err := repo.db.Create(run).Error; err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// ReadJobRun gets a job run specified by its id
+func (repo *JobRunRepository) ReadJobRun(projectID, clusterID, runID uint) (*models.JobRun, error) {
+	run := &models.JobRun{}
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ? AND id = ?", projectID, clusterID, runID).First(&run).Error; err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// ReadJobRunByUID gets a job run specified by the uid of its Kubernetes job
+func (repo *JobRunRepository) ReadJobRunByUID(clusterID uint, uid string) (*models.JobRun, error) {
+	run := &models.JobRun{}
+
+	if err := repo.db.Where("cluster_id = ? AND job_uid = ?", clusterID, uid).First(&run).Error; err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// ListJobRunsByRelease finds the runs of a job release, most recent first, and returns
+// the total number of runs
+func (repo *JobRunRepository) ListJobRunsByRelease(
+	projectID, clusterID uint,
+	namespace, releaseName string,
+	opts *types.ListJobRunsRequest,
+) ([]*models.JobRun, int64, error) {
+	limit := opts.Limit
+
+	if limit == 0 {
+		limit = 50
+	}
+
+	runs := []*models.JobRun{}
+
+	query := repo.db.Where(
+		"project_id = ? AND cluster_id = ? AND namespace = ? AND release_name = ?",
+		projectID, clusterID, namespace, releaseName,
+	)
+
+	var count int64
+
+	if err := query.Model([]*models.JobRun{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// the log tail is omitted, since it is only returned for single runs
+	query = query.Omit("log_tail").Order("created_at desc").Order("id desc").Limit(limit).Offset(opts.Skip)
+
+	if err := query.Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return runs, count, nil
+}
+
+// UpdateJobRun modifies an existing job run in the database
+func (repo *JobRunRepository) UpdateJobRun(run *models.JobRun) (*models.JobRun, error) {
+	if err := repo.db.Save(run).Error; err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
@@ -40,6 +40,21 @@ func AutoMigrate(db *gorm.DB) error {
 		&models.CredentialsExchangeToken{},
 		&models.BuildConfig{},
 		&models.Allowlist{},
+		&models.APIToken{},
+		&models.CustomRole{},
+		&models.AuditEvent{},
+		&models.AutoRollbackConfig{},
+		&models.NotificationChannel{},
+		&models.WebhookDelivery{},
+		&models.WebhookDeliveryAttempt{},
+		&models.JobRun{},
+		&models.EnvGroupVersion{},
+		&models.EnvGroupRelease{},
+		&models.EnvGroupSecretRef{},
+		&models.ApplyManifest{},
+		&models.RegistryRetentionPolicy{},
+		&models.SchedulerLock{},
+		&models.CanaryRollout{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
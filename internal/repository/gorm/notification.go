@@ -104,3 +104,40 @@ func (repo JobNotificationConfigRepository) UpdateNotificationConfig(am *models.
 
 	return am, nil
 }
+
+type AutoRollbackConfigRepository struct {
+	db *gorm.DB
+}
+
+// NewAutoRollbackConfigRepository creates a new AutoRollbackConfigRepository
+func NewAutoRollbackConfigRepository(db *gorm.DB) repository.AutoRollbackConfigRepository {
+	return AutoRollbackConfigRepository{db: db}
+}
+
+// CreateAutoRollbackConfig creates a new AutoRollbackConfig
+func (repo AutoRollbackConfigRepository) CreateAutoRollbackConfig(am *models.AutoRollbackConfig) (*models.AutoRollbackConfig, error) {
+	if err := repo.db.Create(am).Error; err != nil {
+		return nil, err
+	}
+	return am, nil
+}
+
+// ReadAutoRollbackConfig reads an AutoRollbackConfig by ID
+func (repo AutoRollbackConfigRepository) ReadAutoRollbackConfig(id uint) (*models.AutoRollbackConfig, error) {
+	ret := &models.AutoRollbackConfig{}
+
+	if err := repo.db.Where("id = ?", id).First(&ret).Error; err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// UpdateAutoRollbackConfig updates a given AutoRollbackConfig
+func (repo AutoRollbackConfigRepository) UpdateAutoRollbackConfig(am *models.AutoRollbackConfig) (*models.AutoRollbackConfig, error) {
+	if err := repo.db.Save(am).Error; err != nil {
+		return nil, err
+	}
+
+	return am, nil
+}
@@ -0,0 +1,129 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// NotificationChannelRepository uses gorm.DB for querying the database
+type NotificationChannelRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewNotificationChannelRepository returns a NotificationChannelRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewNotificationChannelRepository(db *gorm.DB, key *[32]byte) repository.NotificationChannelRepository {
+	return &NotificationChannelRepository{db, key}
+}
+
+// CreateNotificationChannel creates a new notification channel
+func (repo *NotificationChannelRepository) CreateNotificationChannel(
+	channel *models.NotificationChannel,
+) (*models.NotificationChannel, error) {
+	err := repo.EncryptNotificationChannelData(channel, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(channel).Error; err != nil {
+		return nil, err
+	}
+
+	err = repo.DecryptNotificationChannelData(channel, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// ReadNotificationChannel gets a notification channel specified by its id
+func (repo *NotificationChannelRepository) ReadNotificationChannel(
+	projectID, channelID uint,
+) (*models.NotificationChannel, error) {
+	channel := &models.NotificationChannel{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, channelID).First(&channel).Error; err != nil {
+		return nil, err
+	}
+
+	err := repo.DecryptNotificationChannelData(channel, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// ListNotificationChannelsByProjectID finds all notification channels for a given
+// project id, including channels which are restricted to a single release
+func (repo *NotificationChannelRepository) ListNotificationChannelsByProjectID(
+	projectID uint,
+) ([]*models.NotificationChannel, error) {
+	channels := []*models.NotificationChannel{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Find(&channels).Error; err != nil {
+		return nil, err
+	}
+
+	for _, channel := range channels {
+		repo.DecryptNotificationChannelData(channel, repo.key)
+	}
+
+	return channels, nil
+}
+
+// DeleteNotificationChannel deletes a notification channel
+func (repo *NotificationChannelRepository) DeleteNotificationChannel(
+	channel *models.NotificationChannel,
+) error {
+	if err := repo.db.Delete(channel).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// EncryptNotificationChannelData will encrypt the notification channel config before
+// writing to the DB
+func (repo *NotificationChannelRepository) EncryptNotificationChannelData(
+	channel *models.NotificationChannel,
+	key *[32]byte,
+) error {
+	if len(channel.Config) > 0 {
+		cipherData, err := repository.Encrypt(channel.Config, key)
+
+		if err != nil {
+			return err
+		}
+
+		channel.Config = cipherData
+	}
+
+	return nil
+}
+
+// DecryptNotificationChannelData will decrypt the notification channel config before
+// returning it from the DB
+func (repo *NotificationChannelRepository) DecryptNotificationChannelData(
+	channel *models.NotificationChannel,
+	key *[32]byte,
+) error {
+	if len(channel.Config) > 0 {
+		plaintext, err := repository.Decrypt(channel.Config, key)
+
+		if err != nil {
+			return err
+		}
+
+		channel.Config = plaintext
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// RegistryRetentionPolicyRepository uses gorm.DB for querying the database
+type RegistryRetentionPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewRegistryRetentionPolicyRepository returns a RegistryRetentionPolicyRepository which
+// uses gorm.DB for querying the database
+func NewRegistryRetentionPolicyRepository(db *gorm.DB) repository.RegistryRetentionPolicyRepository {
+	return &RegistryRetentionPolicyRepository{db}
+}
+
+// CreateRegistryRetentionPolicy creates a new retention policy
+func (repo *RegistryRetentionPolicyRepository) CreateRegistryRetentionPolicy(
+	policy *models.RegistryRetentionPolicy,
+) (*models.RegistryRetentionPolicy, error) {
+	if err := repo.db.Create(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ReadRegistryRetentionPolicy gets a retention policy of a registry specified by its id
+func (repo *RegistryRetentionPolicyRepository) ReadRegistryRetentionPolicy(
+	projectID, registryID, policyID uint,
+) (*models.RegistryRetentionPolicy, error) {
+	policy := &models.RegistryRetentionPolicy{}
+
+	query := repo.db.Where("project_id = ? AND registry_id = ? AND id = ?", projectID, registryID, policyID)
+
+	if err := query.First(&policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ListRegistryRetentionPolicies finds all retention policies of a registry. The last
+// reports of the policies are not read.
+func (repo *RegistryRetentionPolicyRepository) ListRegistryRetentionPolicies(
+	projectID, registryID uint,
+) ([]*models.RegistryRetentionPolicy, error) {
+	policies := []*models.RegistryRetentionPolicy{}
+
+	query := repo.db.Omit("last_report").
+		Where("project_id = ? AND registry_id = ?", projectID, registryID).
+		Order("id asc")
+
+	if err := query.Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// ListAllRegistryRetentionPolicies finds the retention policies of all registries. The
+// last reports of the policies are not read.
+func (repo *RegistryRetentionPolicyRepository) ListAllRegistryRetentionPolicies() (
+	[]*models.RegistryRetentionPolicy,
+	error,
+) {
+	policies := []*models.RegistryRetentionPolicy{}
+
+	if err := repo.db.Omit("last_report").Order("id asc").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// UpdateRegistryRetentionPolicy modifies an existing retention policy in the database
+func (repo *RegistryRetentionPolicyRepository) UpdateRegistryRetentionPolicy(
+	policy *models.RegistryRetentionPolicy,
+) (*models.RegistryRetentionPolicy, error) {
+	if err := repo.db.Save(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// UpdateRegistryRetentionPolicyLastRun stores when a retention policy was last enforced
+// and its report, without modifying the rules of the policy
+func (repo *RegistryRetentionPolicyRepository) UpdateRegistryRetentionPolicyLastRun(
+	policy *models.RegistryRetentionPolicy,
+) error {
+	return repo.db.Model(policy).Updates(map[string]interface{}{
+		"last_run_at": policy.LastRunAt,
+		"last_report": policy.LastReport,
+	}).Error
+}
+
+// DeleteRegistryRetentionPolicy removes a retention policy
+func (repo *RegistryRetentionPolicyRepository) DeleteRegistryRetentionPolicy(
+	policy *models.RegistryRetentionPolicy,
+) error {
+	return repo.db.Delete(policy).Error
+}
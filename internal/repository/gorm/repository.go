@@ -40,6 +40,20 @@ type GormRepository struct {
 	ceToken                   repository.CredentialsExchangeTokenRepository
 	buildConfig               repository.BuildConfigRepository
 	allowlist                 repository.AllowlistRepository
+	apiToken                  repository.APITokenRepository
+	customRole                repository.CustomRoleRepository
+	auditEvent                repository.AuditEventRepository
+	autoRollbackConfig        repository.AutoRollbackConfigRepository
+	notificationChannel       repository.NotificationChannelRepository
+	webhookDelivery           repository.WebhookDeliveryRepository
+	jobRun                    repository.JobRunRepository
+	envGroupVersion           repository.EnvGroupVersionRepository
+	envGroupRelease           repository.EnvGroupReleaseRepository
+	envGroupSecretRef         repository.EnvGroupSecretRefRepository
+	applyManifest             repository.ApplyManifestRepository
+	registryRetentionPolicy   repository.RegistryRetentionPolicyRepository
+	schedulerLock             repository.SchedulerLockRepository
+	canaryRollout             repository.CanaryRolloutRepository
 }
 
 func (t *GormRepository) User() repository.UserRepository {
@@ -174,6 +188,62 @@ func (t *GormRepository) Allowlist() repository.AllowlistRepository {
 	return t.allowlist
 }
 
+func (t *GormRepository) APIToken() repository.APITokenRepository {
+	return t.apiToken
+}
+
+func (t *GormRepository) CustomRole() repository.CustomRoleRepository {
+	return t.customRole
+}
+
+func (t *GormRepository) AuditEvent() repository.AuditEventRepository {
+	return t.auditEvent
+}
+
+func (t *GormRepository) AutoRollbackConfig() repository.AutoRollbackConfigRepository {
+	return t.autoRollbackConfig
+}
+
+func (t *GormRepository) NotificationChannel() repository.NotificationChannelRepository {
+	return t.notificationChannel
+}
+
+func (t *GormRepository) WebhookDelivery() repository.WebhookDeliveryRepository {
+	return t.webhookDelivery
+}
+
+func (t *GormRepository) JobRun() repository.JobRunRepository {
+	return t.jobRun
+}
+
+func (t *GormRepository) EnvGroupVersion() repository.EnvGroupVersionRepository {
+	return t.envGroupVersion
+}
+
+func (t *GormRepository) EnvGroupRelease() repository.EnvGroupReleaseRepository {
+	return t.envGroupRelease
+}
+
+func (t *GormRepository) EnvGroupSecretRef() repository.EnvGroupSecretRefRepository {
+	return t.envGroupSecretRef
+}
+
+func (t *GormRepository) ApplyManifest() repository.ApplyManifestRepository {
+	return t.applyManifest
+}
+
+func (t *GormRepository) RegistryRetentionPolicy() repository.RegistryRetentionPolicyRepository {
+	return t.registryRetentionPolicy
+}
+
+func (t *GormRepository) SchedulerLock() repository.SchedulerLockRepository {
+	return t.schedulerLock
+}
+
+func (t *GormRepository) CanaryRollout() repository.CanaryRolloutRepository {
+	return t.canaryRollout
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.CredentialStorage) repository.Repository {
@@ -211,5 +281,19 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		ceToken:                   NewCredentialsExchangeTokenRepository(db),
 		buildConfig:               NewBuildConfigRepository(db),
 		allowlist:                 NewAllowlistRepository(db),
+		apiToken:                  NewAPITokenRepository(db),
+		customRole:                NewCustomRoleRepository(db),
+		auditEvent:                NewAuditEventRepository(db),
+		autoRollbackConfig:        NewAutoRollbackConfigRepository(db),
+		notificationChannel:       NewNotificationChannelRepository(db, key),
+		webhookDelivery:           NewWebhookDeliveryRepository(db),
+		jobRun:                    NewJobRunRepository(db),
+		envGroupVersion:           NewEnvGroupVersionRepository(db, key),
+		envGroupRelease:           NewEnvGroupReleaseRepository(db),
+		envGroupSecretRef:         NewEnvGroupSecretRefRepository(db),
+		applyManifest:             NewApplyManifestRepository(db),
+		registryRetentionPolicy:   NewRegistryRetentionPolicyRepository(db),
+		schedulerLock:             NewSchedulerLockRepository(db),
+		canaryRollout:             NewCanaryRolloutRepository(db),
 	}
 }
@@ -0,0 +1,69 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// SchedulerLockRepository uses gorm.DB for querying the database
+type SchedulerLockRepository struct {
+	db *gorm.DB
+}
+
+// NewSchedulerLockRepository returns a SchedulerLockRepository which uses gorm.DB for
+// querying the database
+func NewSchedulerLockRepository(db *gorm.DB) repository.SchedulerLockRepository {
+	return &SchedulerLockRepository{db}
+}
+
+// AcquireSchedulerLock acquires or renews the lock with the given name for ttl, and
+// returns false if another holder has a lease on the lock which has not expired
+func (repo *SchedulerLockRepository) AcquireSchedulerLock(
+	name, holder string,
+	ttl time.Duration,
+) (bool, error) {
+	now := time.Now()
+
+	// the lock is taken over with a single conditional update, so that only one
+	// holder can acquire an expired lease
+	res := repo.db.Model(&models.SchedulerLock{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]interface{}{
+			"holder":     holder,
+			"expires_at": now.Add(ttl),
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected > 0 {
+		return true, nil
+	}
+
+	err := repo.db.Create(&models.SchedulerLock{
+		Name:      name,
+		Holder:    holder,
+		ExpiresAt: now.Add(ttl),
+	}).Error
+
+	if err == nil {
+		return true, nil
+	}
+
+	// the lock was created by another holder, which violates the unique name
+	var count int64
+
+	if countErr := repo.db.Model(&models.SchedulerLock{}).Where("name = ?", name).Count(&count).Error; countErr != nil {
+		return false, countErr
+	}
+
+	if count > 0 {
+		return false, nil
+	}
+
+	return false, err
+}
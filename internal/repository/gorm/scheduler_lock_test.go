@@ -0,0 +1,42 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAcquireSchedulerLock(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_acquire_scheduler_lock.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	lockRepo := tester.repo.SchedulerLock()
+
+	steps := []struct {
+		name   string
+		holder string
+		ttl    time.Duration
+		exp    bool
+	}{
+		{"new lock", "replica-a", time.Hour, true},
+		{"lock held by another holder", "replica-b", time.Hour, false},
+		{"lock renewed by its holder", "replica-a", -time.Second, true},
+		{"expired lock taken over", "replica-b", time.Hour, true},
+		{"lock held by the new holder", "replica-a", time.Hour, false},
+	}
+
+	for _, step := range steps {
+		acquired, err := lockRepo.AcquireSchedulerLock("job", step.holder, step.ttl)
+
+		if err != nil {
+			t.Fatalf("%s: %v\n", step.name, err)
+		}
+
+		if acquired != step.exp {
+			t.Errorf("%s: expected acquired to be %t but got: %t", step.name, step.exp, acquired)
+		}
+	}
+}
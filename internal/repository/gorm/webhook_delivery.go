@@ -0,0 +1,136 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryRepository uses gorm.DB for querying the database
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository returns a WebhookDeliveryRepository which uses
+// gorm.DB for querying the database
+func NewWebhookDeliveryRepository(db *gorm.DB) repository.WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db}
+}
+
+// CreateWebhookDelivery creates a new webhook delivery
+func (repo *WebhookDeliveryRepository) CreateWebhookDelivery(
+	delivery *models.WebhookDelivery,
+) (*models.WebhookDelivery, error) {
+	if err := repo.db.Create(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// ReadWebhookDelivery gets a webhook delivery and its attempts specified by its id
+func (repo *WebhookDeliveryRepository) ReadWebhookDelivery(
+	projectID, deliveryID uint,
+) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+
+	if err := repo.db.Preload("Attempts").Where("project_id = ? AND id = ?", projectID, deliveryID).First(&delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// ListWebhookDeliveriesByChannelID finds all webhook deliveries and their attempts for
+// a notification channel, most recent first
+func (repo *WebhookDeliveryRepository) ListWebhookDeliveriesByChannelID(
+	projectID, channelID uint,
+) ([]*models.WebhookDelivery, error) {
+	deliveries := []*models.WebhookDelivery{}
+
+	query := repo.db.Preload("Attempts").
+		Where("project_id = ? AND notification_channel_id = ?", projectID, channelID).
+		Order("id desc")
+
+	if err := query.Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// ListDueWebhookDeliveries finds all pending webhook deliveries and their attempts which
+// are due to be retried at the given time
+func (repo *WebhookDeliveryRepository) ListDueWebhookDeliveries(
+	now time.Time,
+) ([]*models.WebhookDelivery, error) {
+	deliveries := []*models.WebhookDelivery{}
+
+	query := repo.db.Preload("Attempts").
+		Where("status = ? AND next_attempt_at <= ?", types.WebhookDeliveryPending, now).
+		Order("next_attempt_at asc")
+
+	if err := query.Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// UpdateWebhookDelivery modifies an existing webhook delivery in the database. Attempts
+// are not modified, and should be created with CreateWebhookDeliveryAttempt.
+func (repo *WebhookDeliveryRepository) UpdateWebhookDelivery(
+	delivery *models.WebhookDelivery,
+) (*models.WebhookDelivery, error) {
+	if err := repo.db.Omit("Attempts").Save(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// ClaimWebhookDelivery claims a webhook delivery until the given time, if it is not
+// claimed at the given time and its status and next attempt have not changed since it
+// was read. It returns false if the delivery could not be claimed.
+func (repo *WebhookDeliveryRepository) ClaimWebhookDelivery(
+	delivery *models.WebhookDelivery,
+	now, until time.Time,
+) (bool, error) {
+	query := repo.db.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ?", delivery.ID, delivery.Status).
+		Where("(claimed_until IS NULL OR claimed_until <= ?)", now)
+
+	if delivery.NextAttemptAt == nil {
+		query = query.Where("next_attempt_at IS NULL")
+	} else {
+		query = query.Where("next_attempt_at = ?", *delivery.NextAttemptAt)
+	}
+
+	res := query.Update("claimed_until", until)
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+
+	delivery.ClaimedUntil = &until
+
+	return true, nil
+}
+
+// CreateWebhookDeliveryAttempt records a new attempt for a webhook delivery
+func (repo *WebhookDeliveryRepository) CreateWebhookDeliveryAttempt(
+	attempt *models.WebhookDeliveryAttempt,
+) (*models.WebhookDeliveryAttempt, error) {
+	if err := repo.db.Create(attempt).Error; err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
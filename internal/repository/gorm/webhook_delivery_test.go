@@ -0,0 +1,89 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestClaimWebhookDelivery(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_claim_webhook_delivery.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	deliveryRepo := tester.repo.WebhookDelivery()
+	nextAttemptAt := time.Now().Add(-time.Second)
+
+	delivery, err := deliveryRepo.CreateWebhookDelivery(&models.WebhookDelivery{
+		ProjectID:     1,
+		DeliveryID:    "delivery",
+		Status:        types.WebhookDeliveryPending,
+		NextAttemptAt: &nextAttemptAt,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	read := func() *models.WebhookDelivery {
+		delivery, err := deliveryRepo.ReadWebhookDelivery(1, delivery.ID)
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		return delivery
+	}
+
+	first, second := read(), read()
+	now := time.Now()
+
+	steps := []struct {
+		name     string
+		delivery *models.WebhookDelivery
+		now      time.Time
+		exp      bool
+	}{
+		{"unclaimed delivery", first, now, true},
+		{"delivery claimed elsewhere", second, now, false},
+		{"expired claim taken over", second, now.Add(2 * time.Minute), true},
+	}
+
+	for _, step := range steps {
+		claimed, err := deliveryRepo.ClaimWebhookDelivery(step.delivery, step.now, step.now.Add(time.Minute))
+
+		if err != nil {
+			t.Fatalf("%s: %v\n", step.name, err)
+		}
+
+		if claimed != step.exp {
+			t.Errorf("%s: expected claimed to be %t but got: %t", step.name, step.exp, claimed)
+		}
+	}
+
+	// a delivery which was attempted since it was read cannot be claimed
+	stale := read()
+	attempted := read()
+	attempted.NextAttemptAt = nil
+	attempted.ClaimedUntil = nil
+	attempted.Status = types.WebhookDeliverySucceeded
+
+	if _, err := deliveryRepo.UpdateWebhookDelivery(attempted); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	claimed, err := deliveryRepo.ClaimWebhookDelivery(stale, now.Add(time.Hour), now.Add(2*time.Hour))
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if claimed {
+		t.Errorf("expected a delivery attempted since it was read to not be claimed")
+	}
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// JobRunRepository represents the set of queries on the JobRun model
+type JobRunRepository interface {
+	CreateJobRun(run *models.JobRun) (*models.JobRun, error)
+	ReadJobRun(projectID, clusterID, runID uint) (*models.JobRun, error)
+	ReadJobRunByUID(clusterID uint, uid string) (*models.JobRun, error)
+	ListJobRunsByRelease(projectID, clusterID uint, namespace, releaseName string, opts *types.ListJobRunsRequest) ([]*models.JobRun, int64, error)
+	UpdateJobRun(run *models.JobRun) (*models.JobRun, error)
+}
@@ -15,3 +15,9 @@ type JobNotificationConfigRepository interface {
 	ReadNotificationConfig(projID, clusterID uint, name, namespace string) (*models.JobNotificationConfig, error)
 	UpdateNotificationConfig(am *models.JobNotificationConfig) (*models.JobNotificationConfig, error)
 }
+
+type AutoRollbackConfigRepository interface {
+	CreateAutoRollbackConfig(am *models.AutoRollbackConfig) (*models.AutoRollbackConfig, error)
+	ReadAutoRollbackConfig(id uint) (*models.AutoRollbackConfig, error)
+	UpdateAutoRollbackConfig(am *models.AutoRollbackConfig) (*models.AutoRollbackConfig, error)
+}
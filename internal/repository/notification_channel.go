@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// NotificationChannelRepository represents the set of queries on the NotificationChannel model
+type NotificationChannelRepository interface {
+	CreateNotificationChannel(channel *models.NotificationChannel) (*models.NotificationChannel, error)
+	ReadNotificationChannel(projectID, channelID uint) (*models.NotificationChannel, error)
+	ListNotificationChannelsByProjectID(projectID uint) ([]*models.NotificationChannel, error)
+	DeleteNotificationChannel(channel *models.NotificationChannel) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// RegistryRetentionPolicyRepository represents the set of queries on the
+// RegistryRetentionPolicy model
+type RegistryRetentionPolicyRepository interface {
+	CreateRegistryRetentionPolicy(policy *models.RegistryRetentionPolicy) (*models.RegistryRetentionPolicy, error)
+	ReadRegistryRetentionPolicy(projectID, registryID, policyID uint) (*models.RegistryRetentionPolicy, error)
+	ListRegistryRetentionPolicies(projectID, registryID uint) ([]*models.RegistryRetentionPolicy, error)
+	ListAllRegistryRetentionPolicies() ([]*models.RegistryRetentionPolicy, error)
+	UpdateRegistryRetentionPolicy(policy *models.RegistryRetentionPolicy) (*models.RegistryRetentionPolicy, error)
+	UpdateRegistryRetentionPolicyLastRun(policy *models.RegistryRetentionPolicy) error
+	DeleteRegistryRetentionPolicy(policy *models.RegistryRetentionPolicy) error
+}
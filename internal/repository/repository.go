@@ -34,4 +34,18 @@ type Repository interface {
 	CredentialsExchangeToken() CredentialsExchangeTokenRepository
 	BuildConfig() BuildConfigRepository
 	Allowlist() AllowlistRepository
+	APIToken() APITokenRepository
+	CustomRole() CustomRoleRepository
+	AuditEvent() AuditEventRepository
+	AutoRollbackConfig() AutoRollbackConfigRepository
+	NotificationChannel() NotificationChannelRepository
+	WebhookDelivery() WebhookDeliveryRepository
+	JobRun() JobRunRepository
+	EnvGroupVersion() EnvGroupVersionRepository
+	EnvGroupRelease() EnvGroupReleaseRepository
+	EnvGroupSecretRef() EnvGroupSecretRefRepository
+	ApplyManifest() ApplyManifestRepository
+	RegistryRetentionPolicy() RegistryRetentionPolicyRepository
+	SchedulerLock() SchedulerLockRepository
+	CanaryRollout() CanaryRolloutRepository
 }
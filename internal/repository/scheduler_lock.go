@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"time"
+)
+
+// SchedulerLockRepository represents the set of queries on the SchedulerLock model
+type SchedulerLockRepository interface {
+	AcquireSchedulerLock(name, holder string, ttl time.Duration) (bool, error)
+}
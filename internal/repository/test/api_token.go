@@ -0,0 +1,97 @@
+package test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// APITokenRepository uses an in-memory slice for querying api tokens
+type APITokenRepository struct {
+	canQuery bool
+	tokens   []*models.APIToken
+}
+
+// NewAPITokenRepository returns a APITokenRepository which stores api
+// tokens in memory
+func NewAPITokenRepository(canQuery bool) repository.APITokenRepository {
+	return &APITokenRepository{canQuery, []*models.APIToken{}}
+}
+
+// CreateAPIToken creates a new api token
+func (repo *APITokenRepository) CreateAPIToken(token *models.APIToken) (*models.APIToken, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.tokens = append(repo.tokens, token)
+	token.ID = uint(len(repo.tokens))
+
+	return token, nil
+}
+
+// ReadAPIToken gets an api token specified by its unique id
+func (repo *APITokenRepository) ReadAPIToken(projectID uint, uid string) (*models.APIToken, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, token := range repo.tokens {
+		if token != nil && token.ProjectID == projectID && token.UniqueID == uid {
+			return token, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListAPITokensByProjectID finds all api tokens for a given project id
+func (repo *APITokenRepository) ListAPITokensByProjectID(projectID uint) ([]*models.APIToken, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.APIToken, 0)
+
+	for _, token := range repo.tokens {
+		if token != nil && token.ProjectID == projectID {
+			res = append(res, token)
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateAPIToken modifies an existing api token in memory
+func (repo *APITokenRepository) UpdateAPIToken(token *models.APIToken) (*models.APIToken, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(token.ID-1) >= len(repo.tokens) || repo.tokens[token.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.tokens[token.ID-1] = token
+
+	return token, nil
+}
+
+// UpdateAPITokenLastUsed sets the last used time of an api token in memory
+func (repo *APITokenRepository) UpdateAPITokenLastUsed(token *models.APIToken, lastUsed time.Time) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(token.ID-1) >= len(repo.tokens) || repo.tokens[token.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.tokens[token.ID-1].LastUsed = &lastUsed
+	token.LastUsed = &lastUsed
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ApplyManifestRepository uses an in-memory slice for querying apply manifests
+type ApplyManifestRepository struct {
+	canQuery  bool
+	manifests []*models.ApplyManifest
+}
+
+// NewApplyManifestRepository returns an ApplyManifestRepository which stores apply
+// manifests in memory
+func NewApplyManifestRepository(canQuery bool) repository.ApplyManifestRepository {
+	return &ApplyManifestRepository{canQuery, []*models.ApplyManifest{}}
+}
+
+// CreateApplyManifest creates a new apply manifest
+func (repo *ApplyManifestRepository) CreateApplyManifest(
+	manifest *models.ApplyManifest,
+) (*models.ApplyManifest, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.manifests = append(repo.manifests, manifest)
+	manifest.ID = uint(len(repo.manifests))
+
+	return manifest, nil
+}
+
+// ReadApplyManifest gets the apply manifest of a porter.yaml file in a namespace
+func (repo *ApplyManifestRepository) ReadApplyManifest(
+	projectID, clusterID uint,
+	namespace, name string,
+) (*models.ApplyManifest, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, manifest := range repo.manifests {
+		if manifest.ProjectID == projectID && manifest.ClusterID == clusterID &&
+			manifest.Namespace == namespace && manifest.Name == name {
+			return manifest, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// UpdateApplyManifest modifies an existing apply manifest in memory
+func (repo *ApplyManifestRepository) UpdateApplyManifest(
+	manifest *models.ApplyManifest,
+) (*models.ApplyManifest, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(manifest.ID-1) >= len(repo.manifests) || repo.manifests[manifest.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.manifests[int(manifest.ID-1)] = manifest
+
+	return manifest, nil
+}
@@ -0,0 +1,98 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// AuditEventRepository uses an in-memory slice for storing audit events
+type AuditEventRepository struct {
+	canQuery bool
+	events   []*models.AuditEvent
+}
+
+// NewAuditEventRepository returns a AuditEventRepository which stores
+// audit events in memory
+func NewAuditEventRepository(canQuery bool) repository.AuditEventRepository {
+	return &AuditEventRepository{canQuery, []*models.AuditEvent{}}
+}
+
+// CreateAuditEvent creates a new audit event
+func (repo *AuditEventRepository) CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.events = append(repo.events, event)
+	event.ID = uint(len(repo.events))
+
+	return event, nil
+}
+
+// ListAuditEventsByProjectID finds all audit events for a given project id. Only
+// the project filter and pagination options are supported in memory.
+func (repo *AuditEventRepository) ListAuditEventsByProjectID(
+	projectID uint,
+	opts *types.ListAuditEventsRequest,
+) ([]*models.AuditEvent, int64, error) {
+	if !repo.canQuery {
+		return nil, 0, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.AuditEvent, 0)
+
+	for i := len(repo.events) - 1; i >= 0; i-- {
+		if repo.events[i].ProjectID == projectID {
+			res = append(res, repo.events[i])
+		}
+	}
+
+	count := int64(len(res))
+
+	if opts.Skip >= len(res) {
+		return []*models.AuditEvent{}, count, nil
+	}
+
+	res = res[opts.Skip:]
+
+	if opts.Limit > 0 && opts.Limit < len(res) {
+		res = res[:opts.Limit]
+	}
+
+	return res, count, nil
+}
+
+// ListAuditEventsBefore finds up to limit audit events for a given project id which
+// were created before the given event. Only the project filter is supported in memory.
+func (repo *AuditEventRepository) ListAuditEventsBefore(
+	projectID uint,
+	opts *types.ListAuditEventsRequest,
+	before *models.AuditEvent,
+	limit int,
+) ([]*models.AuditEvent, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.AuditEvent, 0)
+
+	for i := len(repo.events) - 1; i >= 0 && len(res) < limit; i-- {
+		event := repo.events[i]
+
+		if event.ProjectID != projectID {
+			continue
+		}
+
+		if before != nil && !event.CreatedAt.Before(before.CreatedAt) &&
+			!(event.CreatedAt.Equal(before.CreatedAt) && event.ID < before.ID) {
+			continue
+		}
+
+		res = append(res, event)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CanaryRolloutRepository uses an in-memory slice for querying canary rollouts
+type CanaryRolloutRepository struct {
+	canQuery bool
+	rollouts []*models.CanaryRollout
+}
+
+// NewCanaryRolloutRepository returns a CanaryRolloutRepository which stores canary
+// rollouts in memory
+func NewCanaryRolloutRepository(canQuery bool) repository.CanaryRolloutRepository {
+	return &CanaryRolloutRepository{canQuery, []*models.CanaryRollout{}}
+}
+
+// CreateCanaryRollout creates a new canary rollout
+func (repo *CanaryRolloutRepository) CreateCanaryRollout(
+	rollout *models.CanaryRollout,
+) (*models.CanaryRollout, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.rollouts = append(repo.rollouts, rollout)
+	rollout.ID = uint(len(repo.rollouts))
+	rollout.UpdatedAt = time.Now()
+
+	return rollout, nil
+}
+
+// ReadInProgressCanaryRollout gets the canary rollout of a release which is in progress
+func (repo *CanaryRolloutRepository) ReadInProgressCanaryRollout(
+	clusterID uint,
+	namespace, releaseName string,
+) (*models.CanaryRollout, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, rollout := range repo.rollouts {
+		if rollout.ClusterID == clusterID && rollout.Namespace == namespace &&
+			rollout.ReleaseName == releaseName && rollout.Status == types.CanaryRolloutStatusInProgress {
+			return rollout, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListInProgressCanaryRollouts finds the canary rollouts of all releases which are in
+// progress
+func (repo *CanaryRolloutRepository) ListInProgressCanaryRollouts() ([]*models.CanaryRollout, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.CanaryRollout, 0)
+
+	for _, rollout := range repo.rollouts {
+		if rollout.Status == types.CanaryRolloutStatusInProgress {
+			res = append(res, rollout)
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateCanaryRollout modifies an existing canary rollout in memory
+func (repo *CanaryRolloutRepository) UpdateCanaryRollout(
+	rollout *models.CanaryRollout,
+) (*models.CanaryRollout, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if rollout.ID == 0 || int(rollout.ID-1) >= len(repo.rollouts) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	rollout.UpdatedAt = time.Now()
+	repo.rollouts[rollout.ID-1] = rollout
+
+	return rollout, nil
+}
@@ -144,6 +144,23 @@ func (repo *ClusterRepository) ListClustersByProjectID(
 	return res, nil
 }
 
+// ListAllClusters finds all clusters across every project
+func (repo *ClusterRepository) ListAllClusters() ([]*models.Cluster, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Cluster, 0)
+
+	for _, cluster := range repo.clusters {
+		if cluster != nil {
+			res = append(res, cluster)
+		}
+	}
+
+	return res, nil
+}
+
 // UpdateCluster modifies an existing Cluster in the database
 func (repo *ClusterRepository) UpdateCluster(
 	cluster *models.Cluster,
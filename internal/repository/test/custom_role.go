@@ -0,0 +1,112 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// CustomRoleRepository uses an in-memory slice for querying custom roles
+type CustomRoleRepository struct {
+	canQuery bool
+	roles    []*models.CustomRole
+}
+
+// NewCustomRoleRepository returns a CustomRoleRepository which stores custom
+// roles in memory
+func NewCustomRoleRepository(canQuery bool) repository.CustomRoleRepository {
+	return &CustomRoleRepository{canQuery, []*models.CustomRole{}}
+}
+
+// CreateCustomRole creates a new custom role
+func (repo *CustomRoleRepository) CreateCustomRole(role *models.CustomRole) (*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.roles = append(repo.roles, role)
+	role.ID = uint(len(repo.roles))
+
+	return role, nil
+}
+
+// ReadCustomRole gets a custom role specified by its id
+func (repo *CustomRoleRepository) ReadCustomRole(projectID, roleID uint) (*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if roleID == 0 || int(roleID-1) >= len(repo.roles) || repo.roles[roleID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	if role := repo.roles[roleID-1]; role.ProjectID == projectID {
+		return role, nil
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ReadCustomRoleByName gets a custom role specified by its name
+func (repo *CustomRoleRepository) ReadCustomRoleByName(projectID uint, name string) (*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, role := range repo.roles {
+		if role != nil && role.ProjectID == projectID && role.Name == name {
+			return role, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListCustomRolesByProjectID finds all custom roles for a given project id
+func (repo *CustomRoleRepository) ListCustomRolesByProjectID(projectID uint) ([]*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.CustomRole, 0)
+
+	for _, role := range repo.roles {
+		if role != nil && role.ProjectID == projectID {
+			res = append(res, role)
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateCustomRole modifies an existing custom role in memory
+func (repo *CustomRoleRepository) UpdateCustomRole(role *models.CustomRole) (*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if role.ID == 0 || int(role.ID-1) >= len(repo.roles) || repo.roles[role.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.roles[role.ID-1] = role
+
+	return role, nil
+}
+
+// DeleteCustomRole deletes a custom role from memory
+func (repo *CustomRoleRepository) DeleteCustomRole(role *models.CustomRole) (*models.CustomRole, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if role.ID == 0 || int(role.ID-1) >= len(repo.roles) || repo.roles[role.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.roles[role.ID-1] = nil
+
+	return role, nil
+}
@@ -0,0 +1,119 @@
+package test
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EnvGroupReleaseRepository uses an in-memory slice for querying env group links
+type EnvGroupReleaseRepository struct {
+	canQuery bool
+	links    []*models.EnvGroupRelease
+}
+
+// NewEnvGroupReleaseRepository returns an EnvGroupReleaseRepository which stores
+// links between releases and env groups in memory
+func NewEnvGroupReleaseRepository(canQuery bool) repository.EnvGroupReleaseRepository {
+	return &EnvGroupReleaseRepository{canQuery, []*models.EnvGroupRelease{}}
+}
+
+// CreateEnvGroupRelease links a release to an env group
+func (repo *EnvGroupReleaseRepository) CreateEnvGroupRelease(
+	link *models.EnvGroupRelease,
+) (*models.EnvGroupRelease, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.links = append(repo.links, link)
+	link.ID = uint(len(repo.links))
+
+	return link, nil
+}
+
+// ReadEnvGroupRelease gets the link between a release and an env group
+func (repo *EnvGroupReleaseRepository) ReadEnvGroupRelease(
+	projectID, clusterID uint,
+	namespace, envGroupName, releaseName string,
+) (*models.EnvGroupRelease, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, link := range repo.links {
+		if link != nil && repo.matches(link, projectID, clusterID, namespace, envGroupName) && link.ReleaseName == releaseName {
+			return link, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListEnvGroupReleases finds all releases linked to an env group
+func (repo *EnvGroupReleaseRepository) ListEnvGroupReleases(
+	projectID, clusterID uint,
+	namespace, envGroupName string,
+) ([]*models.EnvGroupRelease, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.EnvGroupRelease, 0)
+
+	for _, link := range repo.links {
+		if link != nil && repo.matches(link, projectID, clusterID, namespace, envGroupName) {
+			res = append(res, link)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ReleaseName < res[j].ReleaseName
+	})
+
+	return res, nil
+}
+
+// UpdateEnvGroupRelease modifies an existing link between a release and an env group
+func (repo *EnvGroupReleaseRepository) UpdateEnvGroupRelease(
+	link *models.EnvGroupRelease,
+) (*models.EnvGroupRelease, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(link.ID-1) >= len(repo.links) || repo.links[link.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.links[int(link.ID-1)] = link
+
+	return link, nil
+}
+
+// DeleteEnvGroupRelease removes the link between a release and an env group
+func (repo *EnvGroupReleaseRepository) DeleteEnvGroupRelease(link *models.EnvGroupRelease) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(link.ID-1) >= len(repo.links) || repo.links[link.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.links[int(link.ID-1)] = nil
+
+	return nil
+}
+
+func (repo *EnvGroupReleaseRepository) matches(
+	link *models.EnvGroupRelease,
+	projectID, clusterID uint,
+	namespace, envGroupName string,
+) bool {
+	return link.ProjectID == projectID && link.ClusterID == clusterID &&
+		link.Namespace == namespace && link.EnvGroupName == envGroupName
+}
@@ -0,0 +1,110 @@
+package test
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EnvGroupSecretRefRepository uses an in-memory slice for querying secret references
+type EnvGroupSecretRefRepository struct {
+	canQuery bool
+	refs     []*models.EnvGroupSecretRef
+}
+
+// NewEnvGroupSecretRefRepository returns an EnvGroupSecretRefRepository which stores
+// secret references in memory
+func NewEnvGroupSecretRefRepository(canQuery bool) repository.EnvGroupSecretRefRepository {
+	return &EnvGroupSecretRefRepository{canQuery, []*models.EnvGroupSecretRef{}}
+}
+
+// CreateEnvGroupSecretRef creates a new secret reference
+func (repo *EnvGroupSecretRefRepository) CreateEnvGroupSecretRef(
+	ref *models.EnvGroupSecretRef,
+) (*models.EnvGroupSecretRef, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.refs = append(repo.refs, ref)
+	ref.ID = uint(len(repo.refs))
+
+	return ref, nil
+}
+
+// ListEnvGroupSecretRefs finds all secret references of an env group
+func (repo *EnvGroupSecretRefRepository) ListEnvGroupSecretRefs(
+	projectID, clusterID uint,
+	namespace, envGroupName string,
+) ([]*models.EnvGroupSecretRef, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.EnvGroupSecretRef, 0)
+
+	for _, ref := range repo.refs {
+		if ref != nil && ref.ProjectID == projectID && ref.ClusterID == clusterID &&
+			ref.Namespace == namespace && ref.EnvGroupName == envGroupName {
+			res = append(res, ref)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Key < res[j].Key
+	})
+
+	return res, nil
+}
+
+// ListAllEnvGroupSecretRefs finds the secret references of all env groups
+func (repo *EnvGroupSecretRefRepository) ListAllEnvGroupSecretRefs() ([]*models.EnvGroupSecretRef, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.EnvGroupSecretRef, 0)
+
+	for _, ref := range repo.refs {
+		if ref != nil {
+			res = append(res, ref)
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateEnvGroupSecretRef modifies an existing secret reference
+func (repo *EnvGroupSecretRefRepository) UpdateEnvGroupSecretRef(
+	ref *models.EnvGroupSecretRef,
+) (*models.EnvGroupSecretRef, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(ref.ID-1) >= len(repo.refs) || repo.refs[ref.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.refs[int(ref.ID-1)] = ref
+
+	return ref, nil
+}
+
+// DeleteEnvGroupSecretRef removes a secret reference
+func (repo *EnvGroupSecretRefRepository) DeleteEnvGroupSecretRef(ref *models.EnvGroupSecretRef) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(ref.ID-1) >= len(repo.refs) || repo.refs[ref.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.refs[int(ref.ID-1)] = nil
+
+	return nil
+}
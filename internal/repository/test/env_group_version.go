@@ -0,0 +1,106 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// EnvGroupVersionRepository uses an in-memory slice for querying env group versions
+type EnvGroupVersionRepository struct {
+	canQuery bool
+	versions []*models.EnvGroupVersion
+}
+
+// NewEnvGroupVersionRepository returns an EnvGroupVersionRepository which stores env
+// group versions in memory
+func NewEnvGroupVersionRepository(canQuery bool) repository.EnvGroupVersionRepository {
+	return &EnvGroupVersionRepository{canQuery, []*models.EnvGroupVersion{}}
+}
+
+// CreateEnvGroupVersion creates a new env group version
+func (repo *EnvGroupVersionRepository) CreateEnvGroupVersion(
+	version *models.EnvGroupVersion,
+) (*models.EnvGroupVersion, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.versions = append(repo.versions, version)
+	version.ID = uint(len(repo.versions))
+
+	return version, nil
+}
+
+// ReadEnvGroupVersion gets a single version of an env group
+func (repo *EnvGroupVersionRepository) ReadEnvGroupVersion(
+	projectID, clusterID uint,
+	namespace, name string,
+	version uint,
+) (*models.EnvGroupVersion, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, v := range repo.versions {
+		if repo.matches(v, projectID, clusterID, namespace, name) && v.Version == version {
+			return v, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ReadLatestEnvGroupVersion gets the most recent version of an env group
+func (repo *EnvGroupVersionRepository) ReadLatestEnvGroupVersion(
+	projectID, clusterID uint,
+	namespace, name string,
+) (*models.EnvGroupVersion, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	var res *models.EnvGroupVersion
+
+	for _, v := range repo.versions {
+		if repo.matches(v, projectID, clusterID, namespace, name) && (res == nil || v.Version > res.Version) {
+			res = v
+		}
+	}
+
+	if res == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return res, nil
+}
+
+// ListEnvGroupVersions finds all versions of an env group, most recent first
+func (repo *EnvGroupVersionRepository) ListEnvGroupVersions(
+	projectID, clusterID uint,
+	namespace, name string,
+) ([]*models.EnvGroupVersion, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.EnvGroupVersion, 0)
+
+	for i := len(repo.versions) - 1; i >= 0; i-- {
+		if v := repo.versions[i]; repo.matches(v, projectID, clusterID, namespace, name) {
+			res = append(res, v)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *EnvGroupVersionRepository) matches(
+	v *models.EnvGroupVersion,
+	projectID, clusterID uint,
+	namespace, name string,
+) bool {
+	return v.ProjectID == projectID && v.ClusterID == clusterID && v.Namespace == namespace && v.Name == name
+}
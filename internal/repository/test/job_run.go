@@ -0,0 +1,119 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// JobRunRepository uses an in-memory slice for querying job runs
+type JobRunRepository struct {
+	canQuery bool
+	runs     []*models.JobRun
+}
+
+// NewJobRunRepository returns a JobRunRepository which stores job runs in memory
+func NewJobRunRepository(canQuery bool) repository.JobRunRepository {
+	return &JobRunRepository{canQuery, []*models.JobRun{}}
+}
+
+// CreateJobRun creates a new job run record
+func (repo *JobRunRepository) CreateJobRun(run *models.JobRun) (*models.JobRun, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.runs = append(repo.runs, run)
+	run.ID = uint(len(repo.runs))
+
+	return run, nil
+}
+
+// ReadJobRun gets a job run specified by its id
+func (repo *JobRunRepository) ReadJobRun(projectID, clusterID, runID uint) (*models.JobRun, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if runID == 0 || int(runID-1) >= len(repo.runs) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	run := repo.runs[runID-1]
+
+	if run.ProjectID != projectID || run.ClusterID != clusterID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return run, nil
+}
+
+// ReadJobRunByUID gets a job run specified by the uid of its Kubernetes job
+func (repo *JobRunRepository) ReadJobRunByUID(clusterID uint, uid string) (*models.JobRun, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, run := range repo.runs {
+		if run.ClusterID == clusterID && run.JobUID == uid {
+			return run, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListJobRunsByRelease finds the runs of a job release, most recent first, and returns
+// the total number of runs
+func (repo *JobRunRepository) ListJobRunsByRelease(
+	projectID, clusterID uint,
+	namespace, releaseName string,
+	opts *types.ListJobRunsRequest,
+) ([]*models.JobRun, int64, error) {
+	if !repo.canQuery {
+		return nil, 0, errors.New("Cannot read from database")
+	}
+
+	limit := opts.Limit
+
+	if limit == 0 {
+		limit = 50
+	}
+
+	matches := make([]*models.JobRun, 0)
+
+	for i := len(repo.runs) - 1; i >= 0; i-- {
+		run := repo.runs[i]
+
+		if run.ProjectID == projectID && run.ClusterID == clusterID &&
+			run.Namespace == namespace && run.ReleaseName == releaseName {
+			matches = append(matches, run)
+		}
+	}
+
+	res := make([]*models.JobRun, 0)
+
+	for i := opts.Skip; i < len(matches) && len(res) < limit; i++ {
+		res = append(res, matches[i])
+	}
+
+	return res, int64(len(matches)), nil
+}
+
+// UpdateJobRun modifies an existing job run
+func (repo *JobRunRepository) UpdateJobRun(run *models.JobRun) (*models.JobRun, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if run.ID == 0 || int(run.ID-1) >= len(repo.runs) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.runs[run.ID-1] = run
+
+	return run, nil
+}
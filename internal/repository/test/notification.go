@@ -1,8 +1,11 @@
 package test
 
 import (
+	"errors"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
 type NotificationConfigRepository struct{}
@@ -40,3 +43,49 @@ func (n *JobNotificationConfigRepository) ReadNotificationConfig(projID, cluster
 func (n *JobNotificationConfigRepository) UpdateNotificationConfig(am *models.JobNotificationConfig) (*models.JobNotificationConfig, error) {
 	panic("not implemented") // TODO: Implement
 }
+
+type AutoRollbackConfigRepository struct {
+	canQuery bool
+	configs  []*models.AutoRollbackConfig
+}
+
+func NewAutoRollbackConfigRepository(canQuery bool) repository.AutoRollbackConfigRepository {
+	return &AutoRollbackConfigRepository{canQuery, []*models.AutoRollbackConfig{}}
+}
+
+func (repo *AutoRollbackConfigRepository) CreateAutoRollbackConfig(am *models.AutoRollbackConfig) (*models.AutoRollbackConfig, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.configs = append(repo.configs, am)
+	am.ID = uint(len(repo.configs))
+
+	return am, nil
+}
+
+func (repo *AutoRollbackConfigRepository) ReadAutoRollbackConfig(id uint) (*models.AutoRollbackConfig, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(repo.configs) || repo.configs[id-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.configs[id-1], nil
+}
+
+func (repo *AutoRollbackConfigRepository) UpdateAutoRollbackConfig(am *models.AutoRollbackConfig) (*models.AutoRollbackConfig, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(am.ID-1) >= len(repo.configs) || repo.configs[am.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.configs[am.ID-1] = am
+
+	return am, nil
+}
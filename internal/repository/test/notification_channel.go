@@ -0,0 +1,92 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// NotificationChannelRepository uses an in-memory slice for querying notification channels
+type NotificationChannelRepository struct {
+	canQuery bool
+	channels []*models.NotificationChannel
+}
+
+// NewNotificationChannelRepository returns a NotificationChannelRepository which stores
+// notification channels in memory
+func NewNotificationChannelRepository(canQuery bool) repository.NotificationChannelRepository {
+	return &NotificationChannelRepository{canQuery, []*models.NotificationChannel{}}
+}
+
+// CreateNotificationChannel creates a new notification channel
+func (repo *NotificationChannelRepository) CreateNotificationChannel(
+	channel *models.NotificationChannel,
+) (*models.NotificationChannel, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.channels = append(repo.channels, channel)
+	channel.ID = uint(len(repo.channels))
+
+	return channel, nil
+}
+
+// ReadNotificationChannel gets a notification channel specified by its id
+func (repo *NotificationChannelRepository) ReadNotificationChannel(
+	projectID, channelID uint,
+) (*models.NotificationChannel, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if channelID == 0 || int(channelID-1) >= len(repo.channels) || repo.channels[channelID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	channel := repo.channels[channelID-1]
+
+	if channel.ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return channel, nil
+}
+
+// ListNotificationChannelsByProjectID finds all notification channels for a given project id
+func (repo *NotificationChannelRepository) ListNotificationChannelsByProjectID(
+	projectID uint,
+) ([]*models.NotificationChannel, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.NotificationChannel, 0)
+
+	for _, channel := range repo.channels {
+		if channel != nil && channel.ProjectID == projectID {
+			res = append(res, channel)
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteNotificationChannel deletes a notification channel
+func (repo *NotificationChannelRepository) DeleteNotificationChannel(
+	channel *models.NotificationChannel,
+) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if channel.ID == 0 || int(channel.ID-1) >= len(repo.channels) || repo.channels[channel.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.channels[channel.ID-1] = nil
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// RegistryRetentionPolicyRepository uses an in-memory slice for querying retention
+// policies
+type RegistryRetentionPolicyRepository struct {
+	canQuery bool
+	policies []*models.RegistryRetentionPolicy
+}
+
+// NewRegistryRetentionPolicyRepository returns a RegistryRetentionPolicyRepository
+// which stores retention policies in memory
+func NewRegistryRetentionPolicyRepository(canQuery bool) repository.RegistryRetentionPolicyRepository {
+	return &RegistryRetentionPolicyRepository{canQuery, []*models.RegistryRetentionPolicy{}}
+}
+
+// CreateRegistryRetentionPolicy creates a new retention policy
+func (repo *RegistryRetentionPolicyRepository) CreateRegistryRetentionPolicy(
+	policy *models.RegistryRetentionPolicy,
+) (*models.RegistryRetentionPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.policies = append(repo.policies, policy)
+	policy.ID = uint(len(repo.policies))
+
+	return policy, nil
+}
+
+// ReadRegistryRetentionPolicy gets a retention policy of a registry specified by its id
+func (repo *RegistryRetentionPolicyRepository) ReadRegistryRetentionPolicy(
+	projectID, registryID, policyID uint,
+) (*models.RegistryRetentionPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if policyID == 0 || int(policyID-1) >= len(repo.policies) || repo.policies[policyID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	policy := repo.policies[policyID-1]
+
+	if policy.ProjectID != projectID || policy.RegistryID != registryID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return policy, nil
+}
+
+// ListRegistryRetentionPolicies finds all retention policies of a registry
+func (repo *RegistryRetentionPolicyRepository) ListRegistryRetentionPolicies(
+	projectID, registryID uint,
+) ([]*models.RegistryRetentionPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.RegistryRetentionPolicy, 0)
+
+	for _, policy := range repo.policies {
+		if policy != nil && policy.ProjectID == projectID && policy.RegistryID == registryID {
+			res = append(res, policy)
+		}
+	}
+
+	return res, nil
+}
+
+// ListAllRegistryRetentionPolicies finds the retention policies of all registries
+func (repo *RegistryRetentionPolicyRepository) ListAllRegistryRetentionPolicies() (
+	[]*models.RegistryRetentionPolicy,
+	error,
+) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.RegistryRetentionPolicy, 0)
+
+	for _, policy := range repo.policies {
+		if policy != nil {
+			res = append(res, policy)
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateRegistryRetentionPolicy modifies an existing retention policy
+func (repo *RegistryRetentionPolicyRepository) UpdateRegistryRetentionPolicy(
+	policy *models.RegistryRetentionPolicy,
+) (*models.RegistryRetentionPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if policy.ID == 0 || int(policy.ID-1) >= len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.policies[int(policy.ID-1)] = policy
+
+	return policy, nil
+}
+
+// UpdateRegistryRetentionPolicyLastRun stores when a retention policy was last enforced
+// and its report
+func (repo *RegistryRetentionPolicyRepository) UpdateRegistryRetentionPolicyLastRun(
+	policy *models.RegistryRetentionPolicy,
+) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if policy.ID == 0 || int(policy.ID-1) >= len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	stored := repo.policies[policy.ID-1]
+	stored.LastRunAt = policy.LastRunAt
+	stored.LastReport = policy.LastReport
+
+	return nil
+}
+
+// DeleteRegistryRetentionPolicy removes a retention policy
+func (repo *RegistryRetentionPolicyRepository) DeleteRegistryRetentionPolicy(
+	policy *models.RegistryRetentionPolicy,
+) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if policy.ID == 0 || int(policy.ID-1) >= len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.policies[int(policy.ID-1)] = nil
+
+	return nil
+}
@@ -38,6 +38,20 @@ type TestRepository struct {
 	ceToken                   repository.CredentialsExchangeTokenRepository
 	buildConfig               repository.BuildConfigRepository
 	allowlist                 repository.AllowlistRepository
+	apiToken                  repository.APITokenRepository
+	customRole                repository.CustomRoleRepository
+	auditEvent                repository.AuditEventRepository
+	autoRollbackConfig        repository.AutoRollbackConfigRepository
+	notificationChannel       repository.NotificationChannelRepository
+	webhookDelivery           repository.WebhookDeliveryRepository
+	jobRun                    repository.JobRunRepository
+	envGroupVersion           repository.EnvGroupVersionRepository
+	envGroupRelease           repository.EnvGroupReleaseRepository
+	envGroupSecretRef         repository.EnvGroupSecretRefRepository
+	applyManifest             repository.ApplyManifestRepository
+	registryRetentionPolicy   repository.RegistryRetentionPolicyRepository
+	schedulerLock             repository.SchedulerLockRepository
+	canaryRollout             repository.CanaryRolloutRepository
 }
 
 func (t *TestRepository) User() repository.UserRepository {
@@ -172,6 +186,62 @@ func (t *TestRepository) Allowlist() repository.AllowlistRepository {
 	return t.allowlist
 }
 
+func (t *TestRepository) APIToken() repository.APITokenRepository {
+	return t.apiToken
+}
+
+func (t *TestRepository) CustomRole() repository.CustomRoleRepository {
+	return t.customRole
+}
+
+func (t *TestRepository) AuditEvent() repository.AuditEventRepository {
+	return t.auditEvent
+}
+
+func (t *TestRepository) AutoRollbackConfig() repository.AutoRollbackConfigRepository {
+	return t.autoRollbackConfig
+}
+
+func (t *TestRepository) NotificationChannel() repository.NotificationChannelRepository {
+	return t.notificationChannel
+}
+
+func (t *TestRepository) WebhookDelivery() repository.WebhookDeliveryRepository {
+	return t.webhookDelivery
+}
+
+func (t *TestRepository) JobRun() repository.JobRunRepository {
+	return t.jobRun
+}
+
+func (t *TestRepository) EnvGroupVersion() repository.EnvGroupVersionRepository {
+	return t.envGroupVersion
+}
+
+func (t *TestRepository) EnvGroupRelease() repository.EnvGroupReleaseRepository {
+	return t.envGroupRelease
+}
+
+func (t *TestRepository) EnvGroupSecretRef() repository.EnvGroupSecretRefRepository {
+	return t.envGroupSecretRef
+}
+
+func (t *TestRepository) ApplyManifest() repository.ApplyManifestRepository {
+	return t.applyManifest
+}
+
+func (t *TestRepository) RegistryRetentionPolicy() repository.RegistryRetentionPolicyRepository {
+	return t.registryRetentionPolicy
+}
+
+func (t *TestRepository) SchedulerLock() repository.SchedulerLockRepository {
+	return t.schedulerLock
+}
+
+func (t *TestRepository) CanaryRollout() repository.CanaryRolloutRepository {
+	return t.canaryRollout
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(canQuery bool, failingMethods ...string) repository.Repository {
@@ -209,5 +279,19 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		ceToken:                   NewCredentialsExchangeTokenRepository(canQuery),
 		buildConfig:               NewBuildConfigRepository(canQuery),
 		allowlist:                 NewAllowlistRepository(canQuery),
+		apiToken:                  NewAPITokenRepository(canQuery),
+		customRole:                NewCustomRoleRepository(canQuery),
+		auditEvent:                NewAuditEventRepository(canQuery),
+		autoRollbackConfig:        NewAutoRollbackConfigRepository(canQuery),
+		notificationChannel:       NewNotificationChannelRepository(canQuery),
+		webhookDelivery:           NewWebhookDeliveryRepository(canQuery),
+		jobRun:                    NewJobRunRepository(canQuery),
+		envGroupVersion:           NewEnvGroupVersionRepository(canQuery),
+		envGroupRelease:           NewEnvGroupReleaseRepository(canQuery),
+		envGroupSecretRef:         NewEnvGroupSecretRefRepository(canQuery),
+		applyManifest:             NewApplyManifestRepository(canQuery),
+		registryRetentionPolicy:   NewRegistryRetentionPolicyRepository(canQuery),
+		schedulerLock:             NewSchedulerLockRepository(canQuery),
+		canaryRollout:             NewCanaryRolloutRepository(canQuery),
 	}
 }
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// SchedulerLockRepository uses an in-memory map for querying scheduler locks
+type SchedulerLockRepository struct {
+	canQuery bool
+	mu       sync.Mutex
+	locks    map[string]*models.SchedulerLock
+}
+
+// NewSchedulerLockRepository returns a SchedulerLockRepository which stores scheduler
+// locks in memory
+func NewSchedulerLockRepository(canQuery bool) repository.SchedulerLockRepository {
+	return &SchedulerLockRepository{canQuery: canQuery, locks: make(map[string]*models.SchedulerLock)}
+}
+
+// AcquireSchedulerLock acquires or renews the lock with the given name for ttl, and
+// returns false if another holder has a lease on the lock which has not expired
+func (repo *SchedulerLockRepository) AcquireSchedulerLock(
+	name, holder string,
+	ttl time.Duration,
+) (bool, error) {
+	if !repo.canQuery {
+		return false, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := time.Now()
+
+	if lock, ok := repo.locks[name]; ok && lock.Holder != holder && !lock.ExpiresAt.Before(now) {
+		return false, nil
+	}
+
+	repo.locks[name] = &models.SchedulerLock{
+		Name:      name,
+		Holder:    holder,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	return true, nil
+}
@@ -1,24 +1,65 @@
 package test
 
 import (
+	"errors"
+
 	ints "github.com/porter-dev/porter/internal/models/integrations"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
-type SlackIntegrationRepository struct{}
+// SlackIntegrationRepository uses an in-memory slice for querying slack integrations
+type SlackIntegrationRepository struct {
+	canQuery  bool
+	slackInts []*ints.SlackIntegration
+}
 
+// NewSlackIntegrationRepository returns a SlackIntegrationRepository which stores
+// slack integrations in memory
 func NewSlackIntegrationRepository(canQuery bool) repository.SlackIntegrationRepository {
-	return &SlackIntegrationRepository{}
+	return &SlackIntegrationRepository{canQuery, []*ints.SlackIntegration{}}
 }
 
+// CreateSlackIntegration creates a new slack integration
 func (s *SlackIntegrationRepository) CreateSlackIntegration(slackInt *ints.SlackIntegration) (*ints.SlackIntegration, error) {
-	panic("not implemented") // TODO: Implement
+	if !s.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	s.slackInts = append(s.slackInts, slackInt)
+	slackInt.ID = uint(len(s.slackInts))
+
+	return slackInt, nil
 }
 
+// ListSlackIntegrationsByProjectID finds all slack integrations for a given project id
 func (s *SlackIntegrationRepository) ListSlackIntegrationsByProjectID(projectID uint) ([]*ints.SlackIntegration, error) {
-	panic("not implemented") // TODO: Implement
+	if !s.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*ints.SlackIntegration, 0)
+
+	for _, slackInt := range s.slackInts {
+		if slackInt != nil && slackInt.ProjectID == projectID {
+			res = append(res, slackInt)
+		}
+	}
+
+	return res, nil
 }
 
+// DeleteSlackIntegration deletes a slack integration by ID
 func (s *SlackIntegrationRepository) DeleteSlackIntegration(integrationID uint) error {
-	panic("not implemented") // TODO: Implement
+	if !s.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if integrationID == 0 || int(integrationID-1) >= len(s.slackInts) || s.slackInts[integrationID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	s.slackInts[integrationID-1] = nil
+
+	return nil
 }
@@ -0,0 +1,215 @@
+package test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryRepository uses an in-memory slice for querying webhook deliveries.
+// Deliveries may be retried while they are read, so access is guarded by a mutex.
+type WebhookDeliveryRepository struct {
+	canQuery   bool
+	mu         sync.Mutex
+	deliveries []*models.WebhookDelivery
+	attempts   uint
+}
+
+// NewWebhookDeliveryRepository returns a WebhookDeliveryRepository which stores
+// webhook deliveries in memory
+func NewWebhookDeliveryRepository(canQuery bool) repository.WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{canQuery: canQuery}
+}
+
+// CreateWebhookDelivery creates a new webhook delivery
+func (repo *WebhookDeliveryRepository) CreateWebhookDelivery(
+	delivery *models.WebhookDelivery,
+) (*models.WebhookDelivery, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	stored := *delivery
+	repo.deliveries = append(repo.deliveries, &stored)
+	stored.ID = uint(len(repo.deliveries))
+	delivery.ID = stored.ID
+
+	return delivery, nil
+}
+
+// ReadWebhookDelivery gets a webhook delivery and its attempts specified by its id
+func (repo *WebhookDeliveryRepository) ReadWebhookDelivery(
+	projectID, deliveryID uint,
+) (*models.WebhookDelivery, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if deliveryID == 0 || int(deliveryID-1) >= len(repo.deliveries) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	delivery := repo.deliveries[deliveryID-1]
+
+	if delivery.ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return copyDelivery(delivery), nil
+}
+
+// ListWebhookDeliveriesByChannelID finds all webhook deliveries and their attempts for
+// a notification channel, most recent first
+func (repo *WebhookDeliveryRepository) ListWebhookDeliveriesByChannelID(
+	projectID, channelID uint,
+) ([]*models.WebhookDelivery, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	res := make([]*models.WebhookDelivery, 0)
+
+	for i := len(repo.deliveries) - 1; i >= 0; i-- {
+		delivery := repo.deliveries[i]
+
+		if delivery.ProjectID == projectID && delivery.NotificationChannelID == channelID {
+			res = append(res, copyDelivery(delivery))
+		}
+	}
+
+	return res, nil
+}
+
+// ListDueWebhookDeliveries finds all pending webhook deliveries and their attempts which
+// are due to be retried at the given time
+func (repo *WebhookDeliveryRepository) ListDueWebhookDeliveries(
+	now time.Time,
+) ([]*models.WebhookDelivery, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	res := make([]*models.WebhookDelivery, 0)
+
+	for _, delivery := range repo.deliveries {
+		if delivery.Status == types.WebhookDeliveryPending && delivery.NextAttemptAt != nil && !delivery.NextAttemptAt.After(now) {
+			res = append(res, copyDelivery(delivery))
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateWebhookDelivery modifies an existing webhook delivery. Attempts are not modified.
+func (repo *WebhookDeliveryRepository) UpdateWebhookDelivery(
+	delivery *models.WebhookDelivery,
+) (*models.WebhookDelivery, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if delivery.ID == 0 || int(delivery.ID-1) >= len(repo.deliveries) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	stored := *delivery
+	stored.Attempts = repo.deliveries[delivery.ID-1].Attempts
+	repo.deliveries[delivery.ID-1] = &stored
+
+	return delivery, nil
+}
+
+// ClaimWebhookDelivery claims a webhook delivery until the given time, if it is not
+// claimed at the given time and its status and next attempt have not changed since it
+// was read. It returns false if the delivery could not be claimed.
+func (repo *WebhookDeliveryRepository) ClaimWebhookDelivery(
+	delivery *models.WebhookDelivery,
+	now, until time.Time,
+) (bool, error) {
+	if !repo.canQuery {
+		return false, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if delivery.ID == 0 || int(delivery.ID-1) >= len(repo.deliveries) {
+		return false, nil
+	}
+
+	stored := repo.deliveries[delivery.ID-1]
+
+	if stored.Status != delivery.Status || !equalTimes(stored.NextAttemptAt, delivery.NextAttemptAt) {
+		return false, nil
+	}
+
+	if stored.ClaimedUntil != nil && stored.ClaimedUntil.After(now) {
+		return false, nil
+	}
+
+	stored.ClaimedUntil = &until
+	delivery.ClaimedUntil = &until
+
+	return true, nil
+}
+
+// CreateWebhookDeliveryAttempt records a new attempt for a webhook delivery
+func (repo *WebhookDeliveryRepository) CreateWebhookDeliveryAttempt(
+	attempt *models.WebhookDeliveryAttempt,
+) (*models.WebhookDeliveryAttempt, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	id := attempt.WebhookDeliveryID
+
+	if id == 0 || int(id-1) >= len(repo.deliveries) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.attempts++
+	attempt.ID = repo.attempts
+
+	delivery := repo.deliveries[id-1]
+	delivery.Attempts = append(delivery.Attempts, *attempt)
+
+	return attempt, nil
+}
+
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+func copyDelivery(delivery *models.WebhookDelivery) *models.WebhookDelivery {
+	res := *delivery
+	res.Attempts = append([]models.WebhookDeliveryAttempt{}, delivery.Attempts...)
+
+	return &res
+}
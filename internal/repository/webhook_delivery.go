@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// WebhookDeliveryRepository represents the set of queries on the WebhookDelivery model
+type WebhookDeliveryRepository interface {
+	CreateWebhookDelivery(delivery *models.WebhookDelivery) (*models.WebhookDelivery, error)
+	ReadWebhookDelivery(projectID, deliveryID uint) (*models.WebhookDelivery, error)
+	ListWebhookDeliveriesByChannelID(projectID, channelID uint) ([]*models.WebhookDelivery, error)
+	ListDueWebhookDeliveries(now time.Time) ([]*models.WebhookDelivery, error)
+	UpdateWebhookDelivery(delivery *models.WebhookDelivery) (*models.WebhookDelivery, error)
+	ClaimWebhookDelivery(delivery *models.WebhookDelivery, now, until time.Time) (bool, error)
+	CreateWebhookDeliveryAttempt(attempt *models.WebhookDeliveryAttempt) (*models.WebhookDeliveryAttempt, error)
+}
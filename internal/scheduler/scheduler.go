@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/porter-dev/porter/internal/logger"
+	"github.com/porter-dev/porter/internal/random"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// Run calls fn on every interval, on only one replica of the server at a time. Replicas
+// race for a lock with the given name, and the replica holding it keeps its lease for
+// two intervals, renewing it on every run, so another replica only takes over once the
+// holder has stopped. If interval is not positive, the job is disabled and Run returns
+// immediately; otherwise it never returns.
+func Run(repo repository.Repository, l *logger.Logger, name string, interval time.Duration, fn func()) {
+	if interval <= 0 {
+		l.Info().Str("job", name).Msg("background job is disabled")
+		return
+	}
+
+	holder, err := getHolderID()
+
+	if err != nil {
+		l.Error().Err(err).Str("job", name).Msg("could not start background job")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := runOnce(repo, name, holder, 2*interval, fn); err != nil {
+			l.Error().Err(err).Str("job", name).Msg("could not acquire lock for background job")
+		}
+	}
+}
+
+// runOnce calls fn if the lock with the given name can be acquired by holder, and
+// returns whether fn was called
+func runOnce(repo repository.Repository, name, holder string, ttl time.Duration, fn func()) (bool, error) {
+	acquired, err := repo.SchedulerLock().AcquireSchedulerLock(name, holder, ttl)
+
+	if err != nil || !acquired {
+		return false, err
+	}
+
+	fn()
+
+	return true, nil
+}
+
+// getHolderID returns an identifier for this replica, which is unique even if replicas
+// share a hostname
+func getHolderID() (string, error) {
+	hostname, err := os.Hostname()
+
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	suffix, err := random.StringWithCharset(8, "")
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), suffix), nil
+}
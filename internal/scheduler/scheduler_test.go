@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+func TestRunOnce(t *testing.T) {
+	repo := test.NewRepository(true)
+	calls := 0
+	fn := func() { calls++ }
+
+	if ran, err := runOnce(repo, "job", "replica-a", time.Hour, fn); err != nil || !ran {
+		t.Fatalf("expected replica-a to run the job, got ran=%v err=%v", ran, err)
+	}
+
+	// the lease of replica-a has not expired
+	if ran, err := runOnce(repo, "job", "replica-b", time.Hour, fn); err != nil || ran {
+		t.Fatalf("expected replica-b not to run the job, got ran=%v err=%v", ran, err)
+	}
+
+	// replica-a renews its own lease
+	if ran, err := runOnce(repo, "job", "replica-a", -time.Second, fn); err != nil || !ran {
+		t.Fatalf("expected replica-a to run the job again, got ran=%v err=%v", ran, err)
+	}
+
+	// the lease of replica-a has expired, so replica-b takes over
+	if ran, err := runOnce(repo, "job", "replica-b", time.Hour, fn); err != nil || !ran {
+		t.Fatalf("expected replica-b to take over the job, got ran=%v err=%v", ran, err)
+	}
+
+	// other jobs have their own locks
+	if ran, err := runOnce(repo, "other", "replica-a", time.Hour, fn); err != nil || !ran {
+		t.Fatalf("expected replica-a to run another job, got ran=%v err=%v", ran, err)
+	}
+
+	if calls != 4 {
+		t.Errorf("expected 4 calls, got %d", calls)
+	}
+}
+
+func TestRunOnceError(t *testing.T) {
+	repo := test.NewRepository(false)
+
+	ran, err := runOnce(repo, "job", "replica-a", time.Hour, func() {
+		t.Error("expected job not to run")
+	})
+
+	if err == nil || ran {
+		t.Errorf("expected an error, got ran=%v err=%v", ran, err)
+	}
+}